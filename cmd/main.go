@@ -1,18 +1,21 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/labstack/echo/v4"
 	_ "github.com/muhrizqiardi/spendtracker/docs"
 	"github.com/muhrizqiardi/spendtracker/internal/database/setup"
 	"github.com/muhrizqiardi/spendtracker/internal/handler"
+	"github.com/muhrizqiardi/spendtracker/internal/llm"
+	"github.com/muhrizqiardi/spendtracker/internal/mailer"
 	"github.com/muhrizqiardi/spendtracker/internal/middleware"
 	"github.com/muhrizqiardi/spendtracker/internal/repository"
 	"github.com/muhrizqiardi/spendtracker/internal/route"
+	"github.com/muhrizqiardi/spendtracker/internal/scheduler"
 	"github.com/muhrizqiardi/spendtracker/internal/service"
 	"github.com/muhrizqiardi/spendtracker/internal/util"
-	"github.com/sashabaranov/go-openai"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"go.uber.org/zap"
 )
@@ -34,29 +37,98 @@ func main() {
 	cfg := util.LoadConfig()
 	db, err := setup.SetupMigrateAndSeedMySQL(cfg, lg)
 
-	oac := openai.NewClient(cfg.OpenAIAPIKey)
+	llmOpts := llm.Options{
+		BaseURL:   cfg.LLMBaseURL,
+		Model:     cfg.LLMModel,
+		MaxTokens: cfg.LLMMaxTokens,
+		Timeout:   cfg.LLMTimeout,
+	}
+	var lp llm.Provider
+	switch cfg.LLMProvider {
+	case "ollama":
+		lp = llm.NewOllamaProvider(llmOpts)
+	case "offline":
+		lp = llm.NewOfflineProvider()
+	default:
+		lp = llm.NewOpenAIProvider(cfg.OpenAIAPIKey, llmOpts)
+	}
+
+	var m mailer.Mailer
+	switch cfg.Mailer {
+	case "smtp":
+		m = mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	case "file":
+		m = mailer.NewFileMailer(cfg.MailDir)
+	default:
+		m = mailer.NewLogMailer(lg)
+	}
 
 	userRepo := repository.NewUserRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	apiTokenRepo := repository.NewAPITokenRepository(db)
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
 	accountRepo := repository.NewAccountRepository(db)
 	categoryRepo := repository.NewCategoryRepository(db)
 	expenseRepo := repository.NewExpenseRepository(db)
-	openaiRepo := repository.NewOpenAIRepository(oac)
+	incomeRepo := repository.NewIncomeRepository(db)
+	transactionRepo := repository.NewTransactionRepository(db)
+	transferRepo := repository.NewTransferRepository(db)
+	budgetRepo := repository.NewBudgetRepository(db)
+	recurringRepo := repository.NewRecurringRepository(db)
+	importMappingRepo := repository.NewImportMappingRepository(db)
+	importRepo := repository.NewImportRepository(db)
+	backupRepo := repository.NewBackupRepository(db)
+	journalRepo := repository.NewJournalRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	currencyRepo := repository.NewCurrencyRepository(db)
+	adviceRepo := repository.NewAdviceRepository(db)
 
-	userService := service.NewUserService(userRepo)
-	authService := service.NewAuthService(userService, cfg.Secret)
-	accountService := service.NewAccountService(accountRepo)
+	verificationService := service.NewVerificationService(userRepo, userTokenRepo, sessionRepo, m, cfg.AppURL)
+	userService := service.NewUserService(userRepo, sessionRepo, verificationService)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepo, userService)
+	authService := service.NewAuthService(userService, twoFactorService, sessionRepo, cfg.Secret)
+	apiTokenService := service.NewAPITokenService(apiTokenRepo)
+	currencyService := service.NewCurrencyService(currencyRepo)
+	accountService := service.NewAccountService(accountRepo, currencyService)
 	categoryService := service.NewCategoryService(categoryRepo)
-	expenseService := service.NewExpenseService(expenseRepo, accountService)
-	adviceService := service.NewAdviceService(expenseService, openaiRepo)
+	expenseService := service.NewExpenseService(expenseRepo, accountService, categoryService)
+	incomeService := service.NewIncomeService(incomeRepo, accountService)
+	transactionService := service.NewTransactionService(transactionRepo)
+	transferService := service.NewTransferService(transferRepo, accountService)
+	budgetService := service.NewBudgetService(budgetRepo, expenseRepo, accountService, categoryService, currencyService)
+	recurringService := service.NewRecurringService(recurringRepo, accountService, categoryService, lg)
+	importService := service.NewImportService(importMappingRepo, importRepo, accountService, categoryService)
+	backupService := service.NewBackupService(backupRepo)
+	exportService := service.NewExportService(journalRepo)
+	reportService := service.NewReportService(reportRepo, currencyService)
+	adviceService := service.NewAdviceService(adviceRepo, expenseService, categoryService, reportService, budgetService, recurringService, currencyService, lp)
 
 	authHandler := handler.NewAuthHandler(authService)
 	userHandler := handler.NewUserHandler(userService)
 	accountHandler := handler.NewAccountHandler(accountService)
 	categoryHandler := handler.NewCategoryHandler(categoryService)
 	expenseHandler := handler.NewExpenseHandler(expenseService)
+	incomeHandler := handler.NewIncomeHandler(incomeService)
+	transactionHandler := handler.NewTransactionHandler(transactionService)
+	transferHandler := handler.NewTransferHandler(transferService)
+	budgetHandler := handler.NewBudgetHandler(budgetService)
+	recurringHandler := handler.NewRecurringHandler(recurringService)
+	importHandler := handler.NewImportHandler(importService)
+	backupHandler := handler.NewBackupHandler(backupService)
+	exportHandler := handler.NewExportHandler(exportService)
+	reportHandler := handler.NewReportHandler(reportService)
+	currencyHandler := handler.NewCurrencyHandler(currencyService)
 	adviceHandler := handler.NewAdviceHandler(adviceService)
+	apiTokenHandler := handler.NewAPITokenHandler(apiTokenService)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService)
+	verificationHandler := handler.NewVerificationHandler(verificationService)
+
+	authMiddleware := middleware.NewAuthMiddleware(userService, authService, apiTokenService, cfg.Secret)
 
-	authMiddleware := middleware.NewAuthMiddleware(userService, cfg.Secret)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.NewScheduler(recurringService, lg, cfg.SchedulerInterval).Start(ctx)
 
 	e := echo.New()
 
@@ -68,7 +140,20 @@ func main() {
 		accountHandler,
 		categoryHandler,
 		expenseHandler,
+		incomeHandler,
+		transactionHandler,
+		transferHandler,
+		budgetHandler,
+		recurringHandler,
+		importHandler,
+		backupHandler,
+		exportHandler,
+		reportHandler,
+		currencyHandler,
 		adviceHandler,
+		apiTokenHandler,
+		twoFactorHandler,
+		verificationHandler,
 	).Define()
 
 	r.GET("/docs/*", echoSwagger.WrapHandler)
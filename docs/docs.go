@@ -61,14 +61,14 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Amount of items per page",
+                        "description": "Amount of items per page, 10 by default",
                         "name": "itemPerPage",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Page number",
-                        "name": "page",
+                        "description": "Cursor of the page to get, from the pagination of another page",
+                        "name": "cursor",
                         "in": "query"
                     }
                 ],
@@ -117,6 +117,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
+                "description": "The currency can only change while the account has no transactions or recurring templates, since their amounts are kept in the account's currency.",
                 "tags": [
                     "account"
                 ],
@@ -177,6 +178,42 @@ const docTemplate = `{
                 }
             }
         },
+        "/accounts/{accountID}/balance": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Get balance of an account as of a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD, inclusive) or RFC 3339 timestamp; defaults to now",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_AccountBalanceResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/accounts/{accountID}/expenses": {
             "post": {
                 "security": [
@@ -216,90 +253,152 @@ const docTemplate = `{
                 }
             }
         },
-        "/advice": {
-            "get": {
+        "/accounts/{accountID}/imports/csv": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "tags": [
-                    "advice"
+                "description": "Credits are skipped. If any row cannot be read nothing is imported and the rows are returned with their errors. Set dryRun to preview without importing.",
+                "consumes": [
+                    "multipart/form-data"
                 ],
-                "summary": "Get advice",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-response_CommonAccountResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth": {
-            "post": {
                 "tags": [
-                    "auth"
+                    "import"
                 ],
-                "summary": "Log in to account",
+                "summary": "Import a CSV bank statement as expenses",
                 "parameters": [
                     {
-                        "description": "log in DTO",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LogInDTO"
-                        }
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Import mapping ID",
+                        "name": "mappingId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category for the imported expenses",
+                        "name": "categoryId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview without importing",
+                        "name": "dryRun",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.LogInResponse"
+                            "$ref": "#/definitions/util.BaseResponse-response_ImportResultResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_ImportResultResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_ImportResultResponse"
                         }
                     }
                 }
             }
         },
-        "/categories": {
-            "get": {
+        "/accounts/{accountID}/imports/ofx": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
+                "description": "Transactions whose FITID was imported into the account before are skipped as duplicates. If any entry cannot be read nothing is imported and the entries are returned with their errors. Set dryRun to preview without importing.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "tags": [
-                    "category"
+                    "import"
                 ],
-                "summary": "Get many categories",
+                "summary": "Import an OFX or QFX bank statement as expenses and incomes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Amount of items per page",
-                        "name": "itemPerPage",
-                        "in": "query",
+                        "description": "Account ID",
+                        "name": "accountID",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
+                        "type": "file",
+                        "description": "OFX or QFX statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category for the imported expenses",
+                        "name": "categoryId",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone for times without a UTC offset; defaults to UTC",
+                        "name": "timezone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview without importing",
+                        "name": "dryRun",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonCategoryResponse"
+                            "$ref": "#/definitions/util.BaseResponse-response_ImportResultResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_ImportResultResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_ImportResultResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/accounts/{accountID}/incomes": {
             "post": {
                 "security": [
                     {
@@ -307,17 +406,24 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "category"
+                    "income"
                 ],
-                "summary": "Create category",
+                "summary": "Create income",
                 "parameters": [
                     {
-                        "description": "Create category DTO",
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create income DTO",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCategoryDTO"
+                            "$ref": "#/definitions/dto.CreateIncomeDTO"
                         }
                     }
                 ],
@@ -325,102 +431,135 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-response_CommonCategoryResponse"
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonIncomeResponse"
                         }
                     }
                 }
             }
         },
-        "/categories/{categoryID}": {
+        "/advice": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
+                "description": "Advice on this month's spending against the months before it, budgets, recurring charges and the largest expenses, in the reporting currency or, without one, in each currency spent in. Advice is reused while none of that changes within the month.",
                 "tags": [
-                    "category"
+                    "advice"
+                ],
+                "summary": "Get advice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "How many months before the current one to compare with, 1 to 12, 3 by default",
+                        "name": "months",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "What to focus on: saving, subscriptions or overspending",
+                        "name": "focus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone or UTC offset months are in, UTC by default",
+                        "name": "timezone",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get one category by ID",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-response_CommonCategoryResponse"
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonAdviceResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/advice/history": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
                 "tags": [
-                    "category"
+                    "advice"
+                ],
+                "summary": "Get advice given before",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page, 10 by default",
+                        "name": "itemPerPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to get, from the pagination of another page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Delete one category by ID",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-any"
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonAdviceResponse"
                         }
                     }
                 }
             }
         },
-        "/expenses": {
+        "/advice/stream": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
+                "description": "Streams advice as Server-Sent Events: \"token\" events carry the advice piece by piece as the model writes it, then a \"done\" event carries the token usage, or an \"error\" event tells that it failed.",
+                "produces": [
+                    "text/event-stream"
+                ],
                 "tags": [
-                    "expense"
+                    "advice"
                 ],
-                "summary": "Get many expenses",
+                "summary": "Stream advice",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Account ID",
-                        "name": "accountId",
+                        "type": "integer",
+                        "description": "How many months before the current one to compare with, 1 to 12, 3 by default",
+                        "name": "months",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Category ID",
-                        "name": "categoryId",
+                        "description": "What to focus on: saving, subscriptions or overspending",
+                        "name": "focus",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Amount of items per page",
-                        "name": "itemPerPage",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
+                        "description": "IANA timezone or UTC offset months are in, UTC by default",
+                        "name": "timezone",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonExpenseResponse"
+                            "$ref": "#/definitions/response.AdviceDoneEvent"
                         }
                     }
                 }
             }
         },
-        "/expenses/{expenseID}": {
+        "/api-tokens": {
             "get": {
                 "security": [
                     {
@@ -428,64 +567,50 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "expense"
-                ],
-                "summary": "Get one expense by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Expense ID",
-                        "name": "expenseID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Get the API tokens",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-response_CommonExpenseResponse"
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonAPITokenResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
                 "tags": [
-                    "expense"
+                    "auth"
                 ],
-                "summary": "Update expense",
+                "summary": "Create an API token; it is only shown in this response",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Expense ID",
-                        "name": "expenseID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update expense DTO",
+                        "description": "Create API token DTO",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateExpenseDTO"
+                            "$ref": "#/definitions/dto.CreateAPITokenDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-response_CommonExpenseResponse"
+                            "$ref": "#/definitions/util.BaseResponse-response_CreateAPITokenResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api-tokens/{tokenID}": {
             "delete": {
                 "security": [
                     {
@@ -493,14 +618,14 @@ const docTemplate = `{
                     }
                 ],
                 "tags": [
-                    "expense"
+                    "auth"
                 ],
-                "summary": "Delete one expense by ID",
+                "summary": "Revoke one API token by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Expense ID",
-                        "name": "expenseID",
+                        "description": "API token ID",
+                        "name": "tokenID",
                         "in": "path",
                         "required": true
                     }
@@ -515,54 +640,101 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/auth": {
             "post": {
                 "tags": [
-                    "user"
+                    "auth"
                 ],
-                "summary": "Register user",
+                "summary": "Log in to account",
                 "parameters": [
                     {
-                        "description": "Create user DTO",
+                        "description": "log in DTO",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterUserDTO"
+                            "$ref": "#/definitions/dto.LogInDTO"
                         }
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "Two-factor code required; see /auth/totp",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_TwoFactorRequiredResponse"
+                        }
+                    },
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-response_CommonUserResponse"
+                            "$ref": "#/definitions/util.BaseResponse-response_LogInResponse"
                         }
                     }
                 }
             }
         },
-        "/users/{userID}": {
-            "put": {
-                "tags": [
-                    "user"
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
-                "summary": "Update user",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out of the current session",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password-reset": {
+            "post": {
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Email a password reset link, if an account has the address",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
-                    },
+                        "description": "Request password reset DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequestPasswordResetDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password-reset/confirm": {
+            "post": {
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Choose a new password with the token from the emailed link",
+                "parameters": [
                     {
-                        "description": "Update user DTO",
+                        "description": "Reset password DTO",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserDTO"
+                            "$ref": "#/definitions/dto.ResetPasswordDTO"
                         }
                     }
                 ],
@@ -570,380 +742,4232 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/util.BaseResponse-response_CommonUserResponse"
+                            "$ref": "#/definitions/util.BaseResponse-any"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CreateAccountDTO": {
-            "type": "object",
-            "required": [
-                "currencyId",
-                "initialAmount",
-                "name"
-            ],
-            "properties": {
-                "currencyId": {
-                    "type": "integer"
-                },
-                "initialAmount": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+        },
+        "/auth/refresh": {
+            "post": {
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Trade a refresh token for new tokens",
+                "parameters": [
+                    {
+                        "description": "refresh token DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_LogInResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.CreateCategoryDTO": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
+        "/auth/totp": {
+            "post": {
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Finish logging in with a TOTP or recovery code",
+                "parameters": [
+                    {
+                        "description": "two-factor log in DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LogInTwoFactorDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_LogInResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "post": {
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Verify an email address with the token from the emailed link",
+                "parameters": [
+                    {
+                        "description": "Verify email DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifyEmailDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email/resend": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Send another email verification link",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/backup": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "backup"
+                ],
+                "summary": "Download a backup of everything the current user has recorded",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BackupDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/backup/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Everything in the backup is added in one transaction with new IDs; categories the user already has are matched by name, and expenses without a category are put in Uncategorized. Backups from a newer version are refused.",
+                "tags": [
+                    "backup"
+                ],
+                "summary": "Restore a backup into the current user's data",
+                "parameters": [
+                    {
+                        "description": "Backup as downloaded",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BackupDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_RestoreResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "budget"
+                ],
+                "summary": "Get many budgets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page",
+                        "name": "itemPerPage",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonBudgetResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "budget"
+                ],
+                "summary": "Create a monthly budget for a category",
+                "parameters": [
+                    {
+                        "description": "Create budget DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateBudgetDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonBudgetResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets/{budgetID}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "budget"
+                ],
+                "summary": "Get one budget by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget ID",
+                        "name": "budgetID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonBudgetResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "budget"
+                ],
+                "summary": "Update budget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget ID",
+                        "name": "budgetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update budget DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateBudgetDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonBudgetResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "budget"
+                ],
+                "summary": "Delete one budget by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget ID",
+                        "name": "budgetID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets/{budgetID}/status": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "budget"
+                ],
+                "summary": "Get spending against a budget over a period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget ID",
+                        "name": "budgetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the period (YYYY-MM-DD or RFC 3339); defaults to the current month together with to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (YYYY-MM-DD, inclusive, or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_BudgetStatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "category"
+                ],
+                "summary": "Get many categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page, 10 by default",
+                        "name": "itemPerPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to get, from the pagination of another page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonCategoryResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "category"
+                ],
+                "summary": "Create category",
+                "parameters": [
+                    {
+                        "description": "Create category DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCategoryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonCategoryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{categoryID}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "category"
+                ],
+                "summary": "Get one category by ID",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonCategoryResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "category"
+                ],
+                "summary": "Delete one category by ID",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "currency"
+                ],
+                "summary": "Get many exchange rates, newest first",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base currency",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote currency",
+                        "name": "quote",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include rates on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include rates on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page",
+                        "name": "itemPerPage",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonExchangeRateResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/imports": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Accepts the ECB's XML and CSV reference rate files, or a CSV with date, base, quote and rate columns. Rates already stored for the same day and currencies are replaced.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "currency"
+                ],
+                "summary": "Load exchange rates from a file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Rate file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency the rates are quoted against, for files that do not say; defaults to EUR",
+                        "name": "base",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_ImportExchangeRatesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "expense"
+                ],
+                "summary": "Get many expenses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only include expenses of these accounts; repeat or separate IDs with commas",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include expenses in these categories; repeat or separate IDs with commas",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include transactions that occurred at or after this date (YYYY-MM-DD) or time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include transactions that occurred before this time, or on or before this date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include expenses of at least this amount in their account's currency",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include expenses of at most this amount in their account's currency",
+                        "name": "maxAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include expenses with any of these tags; repeat or separate tags with commas",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include expenses whose name or description contains every word of this",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by: occurredAt (default), amount, name, description, createdAt or updatedAt",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: desc (default) or asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page, 10 by default",
+                        "name": "itemPerPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to get, from the pagination of another page; only valid with the same sort",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonExpenseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{expenseID}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "expense"
+                ],
+                "summary": "Get one expense by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expense ID",
+                        "name": "expenseID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonExpenseResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "expense"
+                ],
+                "summary": "Update expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expense ID",
+                        "name": "expenseID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update expense DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateExpenseDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonExpenseResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "expense"
+                ],
+                "summary": "Delete one expense by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expense ID",
+                        "name": "expenseID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/beancount": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Accounts are under Assets, categories under Expenses, and amounts are in each account's currency.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Download the current user's records as a beancount file",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/ledger": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "The journal is readable by both ledger and hledger. Accounts are under Assets, categories under Expenses, and amounts are in each account's currency.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Download the current user's records as a ledger journal",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/import-mappings": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Get the saved import mappings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonImportMappingResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Save how a bank lays out its CSV statements",
+                "parameters": [
+                    {
+                        "description": "Create import mapping DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateImportMappingDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonImportMappingResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/import-mappings/{mappingID}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Delete one import mapping by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import mapping ID",
+                        "name": "mappingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/incomes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "income"
+                ],
+                "summary": "Get many incomes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include transactions that occurred at or after this date (YYYY-MM-DD) or time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include transactions that occurred before this time, or on or before this date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page",
+                        "name": "itemPerPage",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonIncomeResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/incomes/{incomeID}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "income"
+                ],
+                "summary": "Get one income by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Income ID",
+                        "name": "incomeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonIncomeResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "income"
+                ],
+                "summary": "Update income",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Income ID",
+                        "name": "incomeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update income DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateIncomeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonIncomeResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "income"
+                ],
+                "summary": "Delete one income by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Income ID",
+                        "name": "incomeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-templates": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Get many recurring templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page",
+                        "name": "itemPerPage",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonRecurringTemplateResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Create a recurring expense or income",
+                "parameters": [
+                    {
+                        "description": "Create recurring template DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateRecurringTemplateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonRecurringTemplateResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-templates/{templateID}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Get one recurring template by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recurring template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonRecurringTemplateResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Update what a recurring template records; its schedule cannot be changed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recurring template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update recurring template DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateRecurringTemplateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonRecurringTemplateResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Delete one recurring template by ID; recorded transactions are kept",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recurring template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-templates/{templateID}/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "recurring"
+                ],
+                "summary": "Preview the next occurrences of a recurring template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recurring template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of occurrences, at most 100; defaults to 5",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_UpcomingOccurrencesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reporting-currency": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "currency"
+                ],
+                "summary": "Get the currency that totals across accounts are reported in",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_ReportingCurrencyResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Reports and budgets convert every amount into this currency at the rate valid on the day it was spent. An empty currency clears it.",
+                "tags": [
+                    "currency"
+                ],
+                "summary": "Set the currency that totals across accounts are reported in",
+                "parameters": [
+                    {
+                        "description": "ISO 4217 currency code",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetReportingCurrencyDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_ReportingCurrencyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/expenses": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Returns the count, total, average and largest expense over the range, for each period in it and for each category and account. Periods are calendar days, ISO weeks, months or years in the given timezone.",
+                "tags": [
+                    "report"
+                ],
+                "summary": "Summarize spending by period, category and account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range (YYYY-MM-DD or RFC 3339); defaults to the current year together with to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range (YYYY-MM-DD, inclusive, or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period length: day, week, month (default) or year",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone or UTC offset that dates and periods are in; defaults to UTC",
+                        "name": "timezone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_ExpenseReportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/totp": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Start setting up two-factor authentication",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_TOTPEnrollmentResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Turn off two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Disable TOTP DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisableTOTPDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/totp/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Turn on two-factor authentication; recovery codes are only shown in this response",
+                "parameters": [
+                    {
+                        "description": "Confirm TOTP DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConfirmTOTPDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_RecoveryCodesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Get many transactions (expenses and incomes) with signed amounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include transactions that occurred at or after this date (YYYY-MM-DD) or time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include transactions that occurred before this time, or on or before this date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page",
+                        "name": "itemPerPage",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonTransactionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "Get many transfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID, matches either side of the transfer",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amount of items per page",
+                        "name": "itemPerPage",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-array_response_CommonTransferResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "Transfer money between two accounts",
+                "parameters": [
+                    {
+                        "description": "Create transfer DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransferDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonTransferResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{transferID}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "Get one transfer by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transferID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonTransferResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "Delete one transfer by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "transferID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "tags": [
+                    "user"
+                ],
+                "summary": "Register user",
+                "parameters": [
+                    {
+                        "description": "Create user DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterUserDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonUserResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userID}": {
+            "put": {
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update user DTO",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/util.BaseResponse-response_CommonUserResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.BackupAccountDTO": {
+            "type": "object",
+            "required": [
+                "currency",
+                "id"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "initialAmount": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BackupAdviceDTO": {
+            "type": "object",
+            "properties": {
+                "completionTokens": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "focus": {
+                    "type": "string"
+                },
+                "modelName": {
+                    "type": "string"
+                },
+                "months": {
+                    "type": "integer"
+                },
+                "promptTokens": {
+                    "type": "integer"
+                },
+                "promptVersion": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "totalTokens": {
+                    "type": "integer"
+                },
+                "usageEstimated": {
+                    "type": "boolean"
+                },
+                "windowFrom": {
+                    "type": "string"
+                },
+                "windowTo": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BackupBudgetDTO": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "currency"
+            ],
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "monthlyLimit": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.BackupCategoryDTO": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BackupDTO": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupAccountDTO"
+                    }
+                },
+                "advice": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupAdviceDTO"
+                    }
+                },
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupBudgetDTO"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupCategoryDTO"
+                    }
+                },
+                "exchangeRates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupExchangeRateDTO"
+                    }
+                },
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupExpenseDTO"
+                    }
+                },
+                "exportedAt": {
+                    "type": "string"
+                },
+                "importMappings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupImportMappingDTO"
+                    }
+                },
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupIncomeDTO"
+                    }
+                },
+                "recurringOccurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupRecurringOccurrenceDTO"
+                    }
+                },
+                "recurringTemplates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupRecurringTemplateDTO"
+                    }
+                },
+                "reportingCurrency": {
+                    "type": "string"
+                },
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackupTransferDTO"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BackupExchangeRateDTO": {
+            "type": "object",
+            "required": [
+                "baseCurrency",
+                "quoteCurrency"
+            ],
+            "properties": {
+                "baseCurrency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "quoteCurrency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.BackupExpenseDTO": {
+            "type": "object",
+            "required": [
+                "accountId"
+            ],
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "externalId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BackupImportMappingDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "amountColumn": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dateColumn": {
+                    "type": "integer"
+                },
+                "dateFormat": {
+                    "type": "string"
+                },
+                "decimalSeparator": {
+                    "type": "string"
+                },
+                "delimiter": {
+                    "type": "string"
+                },
+                "descriptionColumn": {
+                    "type": "integer"
+                },
+                "hasHeader": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "signConvention": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BackupIncomeDTO": {
+            "type": "object",
+            "required": [
+                "accountId"
+            ],
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "externalId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BackupRecurringOccurrenceDTO": {
+            "type": "object",
+            "required": [
+                "sequence",
+                "templateId"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "templateId": {
+                    "type": "integer"
+                },
+                "transactionId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BackupRecurringTemplateDTO": {
+            "type": "object",
+            "required": [
+                "accountId",
+                "frequency",
+                "id",
+                "type"
+            ],
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "materialized": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nextAt": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "expense",
+                        "income"
+                    ]
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BackupTransferDTO": {
+            "type": "object",
+            "required": [
+                "fromAccountId",
+                "toAccountId"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchangeRate": {
+                    "type": "number"
+                },
+                "fee": {
+                    "type": "number"
+                },
+                "fromAccountId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "receivedAmount": {
+                    "type": "number"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "toAccountId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ConfirmTOTPDTO": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAPITokenDTO": {
+            "type": "object",
+            "required": [
+                "expiresAt",
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateAccountDTO": {
+            "type": "object",
+            "required": [
+                "currencyId",
+                "initialAmount",
+                "name"
+            ],
+            "properties": {
+                "currencyId": {
+                    "type": "integer"
+                },
+                "initialAmount": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateBudgetDTO": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "monthlyLimit"
+            ],
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "monthlyLimit": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.CreateCategoryDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateExpenseDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "categoryId",
+                "name"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateImportMappingDTO": {
+            "type": "object",
+            "required": [
+                "amountColumn",
+                "dateColumn",
+                "dateFormat",
+                "descriptionColumn",
+                "name",
+                "signConvention"
+            ],
+            "properties": {
+                "amountColumn": {
+                    "type": "integer"
+                },
+                "dateColumn": {
+                    "type": "integer"
+                },
+                "dateFormat": {
+                    "type": "string"
+                },
+                "decimalSeparator": {
+                    "type": "string",
+                    "enum": [
+                        ".",
+                        ","
+                    ]
+                },
+                "delimiter": {
+                    "type": "string"
+                },
+                "descriptionColumn": {
+                    "type": "integer"
+                },
+                "hasHeader": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "signConvention": {
+                    "type": "string",
+                    "enum": [
+                        "negative",
+                        "positive",
+                        "absolute"
+                    ]
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateIncomeDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "name"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateRecurringTemplateDTO": {
+            "type": "object",
+            "required": [
+                "accountId",
+                "amount",
+                "frequency",
+                "name",
+                "startAt",
+                "type"
+            ],
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "description": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly"
+                    ]
+                },
+                "interval": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "expense",
+                        "income"
+                    ]
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateTransferDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "fromAccountId",
+                "toAccountId"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchangeRate": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "fee": {
+                    "type": "number"
+                },
+                "fromAccountId": {
+                    "type": "integer"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "toAccountId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DisableTOTPDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Code is a TOTP code or a recovery code.",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LogInDTO": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "dto.LogInTwoFactorDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "twoFactorToken"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Code is a TOTP code or a recovery code.",
+                    "type": "string"
+                },
+                "twoFactorToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RefreshTokenDTO": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegisterUserDTO": {
+            "type": "object",
+            "required": [
+                "fullName",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "dto.RequestPasswordResetDTO": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResetPasswordDTO": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SetReportingCurrencyDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency is an ISO 4217 code; empty clears the reporting currency.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateAccountDTO": {
+            "type": "object",
+            "required": [
+                "currencyId",
+                "initialAmount",
+                "name"
+            ],
+            "properties": {
+                "currencyId": {
+                    "type": "integer"
+                },
+                "initialAmount": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateBudgetDTO": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "monthlyLimit"
+            ],
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "monthlyLimit": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.UpdateExpenseDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "categoryId",
+                "name"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateIncomeDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "name"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateRecurringTemplateDTO": {
+            "type": "object",
+            "required": [
+                "accountId",
+                "amount",
+                "name"
+            ],
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateUserDTO": {
+            "type": "object",
+            "required": [
+                "email",
+                "fullName",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "dto.VerifyEmailDTO": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AccountBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AccountExpenseStatsResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "accountName": {
+                    "type": "string"
+                },
+                "average": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "largest": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AdviceDoneEvent": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "usage": {
+                    "$ref": "#/definitions/response.AdviceUsageResponse"
+                }
+            }
+        },
+        "response.AdviceUsageResponse": {
+            "type": "object",
+            "properties": {
+                "completionTokens": {
+                    "type": "integer"
+                },
+                "estimated": {
+                    "type": "boolean"
+                },
+                "promptTokens": {
+                    "type": "integer"
+                },
+                "totalTokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.BudgetStatusResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "budgetId": {
+                    "type": "integer"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "string"
+                },
+                "projected": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "string"
+                },
+                "spent": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CategoryExpenseStatsResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "categoryName": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "largest": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CommonAPITokenResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.CommonAccountResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string"
+                },
+                "currencyId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "initialAmount": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonAdviceResponse": {
+            "type": "object",
+            "properties": {
+                "advice": {
+                    "type": "string"
+                },
+                "cached": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "focus": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "months": {
+                    "type": "integer"
+                },
+                "promptVersion": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/response.AdviceUsageResponse"
+                }
+            }
+        },
+        "response.CommonBudgetResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "monthlyLimit": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonExchangeRateResponse": {
+            "type": "object",
+            "properties": {
+                "baseCurrency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "quoteCurrency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CommonExpenseResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonImportMappingResponse": {
+            "type": "object",
+            "properties": {
+                "amountColumn": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dateColumn": {
+                    "type": "integer"
+                },
+                "dateFormat": {
+                    "type": "string"
+                },
+                "decimalSeparator": {
+                    "type": "string"
+                },
+                "delimiter": {
+                    "type": "string"
+                },
+                "descriptionColumn": {
+                    "type": "integer"
+                },
+                "hasHeader": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "signConvention": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonIncomeResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonRecurringTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "materialized": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nextAt": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "until": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonTransferResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchangeRate": {
+                    "type": "number"
+                },
+                "fee": {
+                    "type": "string"
+                },
+                "fromAccountId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "receivedAmount": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "toAccountId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CommonUserResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreateAPITokenResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ExpenseReportResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AccountExpenseStatsResponse"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CategoryExpenseStatsResponse"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "groupBy": {
+                    "type": "string"
+                },
+                "largestExpense": {
+                    "$ref": "#/definitions/response.CommonExpenseResponse"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PeriodExpenseStatsResponse"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/response.ExpenseStatsResponse"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ExpenseStatsResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "largest": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ImportExchangeRatesResponse": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ImportResultResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ImportRowResponse"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ImportRowResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duplicate": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "externalId": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "type": "boolean"
+                },
+                "transactionId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.LogInResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.PeriodExpenseStatsResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "largest": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.ReportingCurrencyResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency is empty when the user has not picked one.",
+                    "type": "string"
+                }
+            }
+        },
+        "response.RestoreResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "integer"
+                },
+                "advice": {
+                    "type": "integer"
+                },
+                "budgets": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "integer"
+                },
+                "exchangeRates": {
+                    "type": "integer"
+                },
+                "expenses": {
+                    "type": "integer"
+                },
+                "importMappings": {
+                    "type": "integer"
+                },
+                "incomes": {
+                    "type": "integer"
+                },
+                "recurringTemplates": {
+                    "type": "integer"
+                },
+                "transfers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.TOTPEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.TwoFactorRequiredResponse": {
+            "type": "object",
+            "properties": {
+                "twoFactorToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.UpcomingOccurrencesResponse": {
+            "type": "object",
+            "properties": {
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "templateId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "util.BaseResponse-any": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonAPITokenResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonAPITokenResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonAccountResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonAccountResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonAdviceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonAdviceResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonBudgetResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonBudgetResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonCategoryResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonExchangeRateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonExchangeRateResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonExpenseResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonExpenseResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonImportMappingResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonImportMappingResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonIncomeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonIncomeResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonRecurringTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonRecurringTemplateResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonTransactionResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-array_response_CommonTransferResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommonTransferResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-response_AccountBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.AccountBalanceResponse"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-response_BudgetStatusResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.BudgetStatusResponse"
+                },
+                "message": {
                     "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CreateExpenseDTO": {
+        "util.BaseResponse-response_CommonAccountResponse": {
             "type": "object",
-            "required": [
-                "amount",
-                "categoryId",
-                "name"
-            ],
             "properties": {
-                "amount": {
-                    "type": "integer"
-                },
-                "categoryId": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/response.CommonAccountResponse"
                 },
-                "description": {
+                "message": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.LogInDTO": {
+        "util.BaseResponse-response_CommonAdviceResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "data": {
+                    "$ref": "#/definitions/response.CommonAdviceResponse"
+                },
+                "message": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.RegisterUserDTO": {
+        "util.BaseResponse-response_CommonBudgetResponse": {
             "type": "object",
-            "required": [
-                "fullName",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/response.CommonBudgetResponse"
                 },
-                "fullName": {
+                "message": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdateAccountDTO": {
+        "util.BaseResponse-response_CommonCategoryResponse": {
             "type": "object",
-            "required": [
-                "currencyId",
-                "initialAmount",
-                "name"
-            ],
             "properties": {
-                "currencyId": {
-                    "type": "integer"
-                },
-                "initialAmount": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/response.CommonCategoryResponse"
                 },
-                "name": {
+                "message": {
                     "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdateExpenseDTO": {
+        "util.BaseResponse-response_CommonExpenseResponse": {
             "type": "object",
-            "required": [
-                "amount",
-                "categoryId",
-                "name"
-            ],
             "properties": {
-                "amount": {
-                    "type": "integer"
-                },
-                "categoryId": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/response.CommonExpenseResponse"
                 },
-                "description": {
+                "message": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdateUserDTO": {
+        "util.BaseResponse-response_CommonImportMappingResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "fullName",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/response.CommonImportMappingResponse"
                 },
-                "fullName": {
+                "message": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "response.CommonAccountResponse": {
+        "util.BaseResponse-response_CommonIncomeResponse": {
             "type": "object",
             "properties": {
-                "currencyId": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "initialAmount": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/response.CommonIncomeResponse"
                 },
-                "name": {
+                "message": {
                     "type": "string"
                 },
-                "userId": {
-                    "type": "integer"
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "response.CommonCategoryResponse": {
+        "util.BaseResponse-response_CommonRecurringTemplateResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/response.CommonRecurringTemplateResponse"
                 },
-                "name": {
+                "message": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
                 },
-                "userId": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "response.CommonExpenseResponse": {
+        "util.BaseResponse-response_CommonTransferResponse": {
             "type": "object",
             "properties": {
-                "accountId": {
-                    "type": "integer"
-                },
-                "amount": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/response.CommonTransferResponse"
                 },
-                "createdAt": {
+                "message": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
                 },
-                "id": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-response_CommonUserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.CommonUserResponse"
                 },
-                "name": {
+                "message": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
                 },
-                "userId": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "response.CommonUserResponse": {
+        "util.BaseResponse-response_CreateAPITokenResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/response.CreateAPITokenResponse"
                 },
-                "email": {
+                "message": {
                     "type": "string"
                 },
-                "fullName": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
                 },
-                "id": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "util.BaseResponse-response_ExpenseReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/response.ExpenseReportResponse"
                 },
-                "password": {
+                "message": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "response.LogInResponse": {
+        "util.BaseResponse-response_ImportExchangeRatesResponse": {
             "type": "object",
             "properties": {
-                "token": {
+                "data": {
+                    "$ref": "#/definitions/response.ImportExchangeRatesResponse"
+                },
+                "message": {
                     "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "util.BaseResponse-any": {
+        "util.BaseResponse-response_ImportResultResponse": {
             "type": "object",
             "properties": {
-                "data": {},
+                "data": {
+                    "$ref": "#/definitions/response.ImportResultResponse"
+                },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "util.BaseResponse-array_response_CommonAccountResponse": {
+        "util.BaseResponse-response_LogInResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.CommonAccountResponse"
-                    }
+                    "$ref": "#/definitions/response.LogInResponse"
                 },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "util.BaseResponse-array_response_CommonCategoryResponse": {
+        "util.BaseResponse-response_RecoveryCodesResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.CommonCategoryResponse"
-                    }
+                    "$ref": "#/definitions/response.RecoveryCodesResponse"
                 },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "util.BaseResponse-array_response_CommonExpenseResponse": {
+        "util.BaseResponse-response_ReportingCurrencyResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.CommonExpenseResponse"
-                    }
+                    "$ref": "#/definitions/response.ReportingCurrencyResponse"
                 },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "util.BaseResponse-response_CommonAccountResponse": {
+        "util.BaseResponse-response_RestoreResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/response.CommonAccountResponse"
+                    "$ref": "#/definitions/response.RestoreResponse"
                 },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "util.BaseResponse-response_CommonCategoryResponse": {
+        "util.BaseResponse-response_TOTPEnrollmentResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/response.CommonCategoryResponse"
+                    "$ref": "#/definitions/response.TOTPEnrollmentResponse"
                 },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "util.BaseResponse-response_CommonExpenseResponse": {
+        "util.BaseResponse-response_TwoFactorRequiredResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/response.CommonExpenseResponse"
+                    "$ref": "#/definitions/response.TwoFactorRequiredResponse"
                 },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "util.BaseResponse-response_CommonUserResponse": {
+        "util.BaseResponse-response_UpcomingOccurrencesResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/response.CommonUserResponse"
+                    "$ref": "#/definitions/response.UpcomingOccurrencesResponse"
                 },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/util.Pagination"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
+        },
+        "util.Pagination": {
+            "type": "object",
+            "properties": {
+                "nextCursor": {
+                    "type": "string"
+                },
+                "prevCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
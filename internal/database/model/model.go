@@ -1,12 +1,31 @@
 package model
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
+// Currency is an ISO 4217 currency. MinorUnit is the number of decimal
+// places its amounts have, such as 2 for USD and 0 for JPY; every amount is
+// stored as an integer of these minor units, such as cents.
 type Currency struct {
 	gorm.Model
-	Code string
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	MinorUnit int    `json:"minorUnit"`
+}
+
+// ExchangeRate says that on Date, one unit of BaseCurrency was worth Rate
+// units of QuoteCurrency. Currencies are ISO 4217 codes. Rates are kept per
+// user, who loads them from the source they trust.
+type ExchangeRate struct {
+	gorm.Model
+	UserID        uint      `gorm:"uniqueIndex:idx_exchange_rate" json:"userId"`
+	Date          time.Time `gorm:"uniqueIndex:idx_exchange_rate" json:"date"`
+	BaseCurrency  string    `gorm:"size:16;uniqueIndex:idx_exchange_rate" json:"baseCurrency"`
+	QuoteCurrency string    `gorm:"size:16;uniqueIndex:idx_exchange_rate" json:"quoteCurrency"`
+	Rate          float64   `json:"rate"`
 }
 
 type User struct {
@@ -14,27 +33,432 @@ type User struct {
 	Email    string `gorm:"unique" json:"email"`
 	FullName string `json:"fullName"`
 	Password string `json:"password"`
+	// EmailVerifiedAt is when the user proved they own Email. Until then
+	// they can read but not change anything but their profile.
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt"`
+	// ReportingCurrencyID is the currency that totals across accounts are
+	// converted to, if set.
+	ReportingCurrencyID *uint     `json:"reportingCurrencyId"`
+	ReportingCurrency   *Currency `json:"reportingCurrency,omitempty"`
+	// TOTPSecret is set when two-factor enrollment starts and TOTPEnabledAt
+	// once it is confirmed. TOTPLastStep is the time step of the last code
+	// used, so that no code is accepted twice.
+	TOTPSecret    string     `gorm:"column:totp_secret" json:"-"`
+	TOTPEnabledAt *time.Time `gorm:"column:totp_enabled_at" json:"totpEnabledAt"`
+	TOTPLastStep  int64      `gorm:"column:totp_last_step" json:"-"`
+}
+
+// UserToken is a single-use token mailed to a user to prove they own their
+// email address, for Purpose. Email is the address it was mailed to, which
+// it is only good for. Only its hash is kept.
+type UserToken struct {
+	gorm.Model
+	UserID    uint       `gorm:"index" json:"userId"`
+	Purpose   string     `gorm:"size:32" json:"purpose"`
+	Email     string     `json:"email"`
+	TokenHash string     `gorm:"size:64;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+}
+
+// RecoveryCode lets a user with two-factor authentication log in once
+// without their authenticator. Only its hash is kept.
+type RecoveryCode struct {
+	gorm.Model
+	UserID   uint       `gorm:"index" json:"userId"`
+	CodeHash string     `gorm:"size:64;index" json:"-"`
+	UsedAt   *time.Time `json:"usedAt"`
+}
+
+// TwoFactorChallenge is a log in by a user with two-factor authentication
+// whose password was right, waiting for a code. Its token, of which only the
+// hash is kept, can be redeemed once; Attempts counts the codes tried
+// against it.
+type TwoFactorChallenge struct {
+	gorm.Model
+	UserID    uint       `gorm:"index" json:"userId"`
+	TokenHash string     `gorm:"size:64;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	Attempts  int        `json:"attempts"`
+	UsedAt    *time.Time `json:"usedAt"`
+}
+
+// TOTPEnrollment is what an authenticator app is set up from.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// Session is one log in. The access tokens issued for it carry its ID, so
+// revoking it logs out whoever holds them. RevokedAt is set on log out, on a
+// password change and when one of its refresh tokens is used twice.
+type Session struct {
+	gorm.Model
+	UserID    uint       `gorm:"index" json:"userId"`
+	RevokedAt *time.Time `json:"revokedAt"`
+}
+
+// RefreshToken is kept hashed and can be used once: refreshing marks it used
+// and issues the next one in the same session.
+type RefreshToken struct {
+	gorm.Model
+	SessionID uint       `gorm:"index" json:"sessionId"`
+	Session   *Session   `json:"session,omitempty"`
+	TokenHash string     `gorm:"size:64;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+}
+
+// AuthTokens are what logging in or refreshing hands out: a short-lived
+// access token and the refresh token that replaces it. For users with
+// two-factor authentication, logging in with a password only hands out
+// TwoFactorToken, which is traded for the others along with a code.
+type AuthTokens struct {
+	AccessToken          string    `json:"accessToken"`
+	AccessTokenExpiresAt time.Time `json:"accessTokenExpiresAt"`
+	RefreshToken         string    `json:"refreshToken"`
+	TwoFactorToken       string    `json:"twoFactorToken,omitempty"`
+}
+
+// APIToken is a personal access token for scripts and integrations. Only
+// its hash is kept; the token itself is shown once, when it is created.
+// Scopes is a space separated list of resource:read or resource:write, where
+// resource * stands for every resource and write implies read.
+type APIToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"index" json:"userId"`
+	Name      string    `json:"name"`
+	TokenHash string    `gorm:"size:64;uniqueIndex" json:"-"`
+	Scopes    string    `json:"scopes"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
 
 type Account struct {
 	gorm.Model
-	UserID        uint   `json:"userId"`
-	CurrencyID    uint   `json:"currencyId"`
-	Name          string `json:"name"`
-	InitialAmount int    `json:"initialAmount"`
+	UserID        uint      `json:"userId"`
+	CurrencyID    uint      `json:"currencyId"`
+	Currency      *Currency `json:"currency,omitempty"`
+	Name          string    `json:"name"`
+	InitialAmount int       `json:"initialAmount"`
 }
 
+// Category names are unique per user.
 type Category struct {
 	gorm.Model
-	UserID uint   `gorm:"unique:users_categories" json:"userId"`
-	Name   string `gorm:"unique:users_categories" json:"name"`
+	UserID uint   `gorm:"uniqueIndex:users_categories" json:"userId"`
+	Name   string `gorm:"size:191;uniqueIndex:users_categories" json:"name"`
 }
 
+// Tag labels expenses across categories, such as a trip. Tag names are
+// unique per user.
+type Tag struct {
+	gorm.Model
+	UserID uint   `gorm:"uniqueIndex:users_tags" json:"userId"`
+	Name   string `gorm:"size:64;uniqueIndex:users_tags" json:"name"`
+}
+
+// ExternalID identifies an imported transaction at the bank, such as an OFX
+// FITID, so that importing the same statement again adds nothing. It is nil
+// for transactions entered by hand.
 type Expense struct {
 	gorm.Model
-	UserID      uint   `json:"userId"`
-	AccountID   uint   `json:"accountId"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Amount      int    `json:"amount"`
+	UserID      uint      `json:"userId"`
+	AccountID   uint      `gorm:"uniqueIndex:idx_expense_external_id" json:"accountId"`
+	Account     *Account  `json:"account,omitempty"`
+	CategoryID  uint      `gorm:"index" json:"categoryId"`
+	Category    *Category `json:"category,omitempty"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Amount      int       `json:"amount"`
+	OccurredAt  time.Time `gorm:"index" json:"occurredAt"`
+	Timezone    string    `json:"timezone"`
+	ExternalID  *string   `gorm:"size:255;uniqueIndex:idx_expense_external_id" json:"externalId,omitempty"`
+	Tags        []Tag     `gorm:"many2many:expense_tags" json:"tags,omitempty"`
+}
+
+type Income struct {
+	gorm.Model
+	UserID      uint      `json:"userId"`
+	AccountID   uint      `gorm:"uniqueIndex:idx_income_external_id" json:"accountId"`
+	Account     *Account  `json:"account,omitempty"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Amount      int       `json:"amount"`
+	OccurredAt  time.Time `gorm:"index" json:"occurredAt"`
+	Timezone    string    `json:"timezone"`
+	ExternalID  *string   `gorm:"size:255;uniqueIndex:idx_income_external_id" json:"externalId,omitempty"`
+}
+
+// Transfer moves money between two accounts of the same user. Amount and Fee
+// are debited from the source account in its currency; ReceivedAmount is
+// credited to the destination account, converted with ExchangeRate.
+type Transfer struct {
+	gorm.Model
+	UserID         uint      `json:"userId"`
+	FromAccountID  uint      `json:"fromAccountId"`
+	FromAccount    *Account  `json:"fromAccount,omitempty"`
+	ToAccountID    uint      `json:"toAccountId"`
+	ToAccount      *Account  `json:"toAccount,omitempty"`
+	Description    string    `json:"description"`
+	Amount         int       `json:"amount"`
+	Fee            int       `json:"fee"`
+	ExchangeRate   float64   `json:"exchangeRate"`
+	ReceivedAmount int       `json:"receivedAmount"`
+	OccurredAt     time.Time `gorm:"index" json:"occurredAt"`
+	Timezone       string    `json:"timezone"`
+}
+
+// Budget caps how much may be spent in a category each calendar month,
+// optionally counting only expenses paid from one account. MonthlyLimit is
+// in Currency, which spending is converted to.
+type Budget struct {
+	gorm.Model
+	UserID       uint      `gorm:"index" json:"userId"`
+	CategoryID   uint      `json:"categoryId"`
+	Category     *Category `json:"category,omitempty"`
+	AccountID    *uint     `json:"accountId"`
+	Account      *Account  `json:"account,omitempty"`
+	CurrencyID   uint      `json:"currencyId"`
+	Currency     *Currency `json:"currency,omitempty"`
+	MonthlyLimit int       `json:"monthlyLimit"`
+}
+
+// BudgetStatus compares a budget against actual spending over a period.
+// Limit is the monthly limit prorated to the period and Projected
+// extrapolates Spent to the end of the period. Amounts are in Currency.
+type BudgetStatus struct {
+	Budget    Budget
+	From      time.Time
+	To        time.Time
+	Currency  Currency
+	Limit     int
+	Spent     int
+	Remaining int
+	Projected int
+}
+
+// ExpenseStats aggregates a set of expenses. Largest is the biggest single
+// amount among them.
+type ExpenseStats struct {
+	Count   int
+	Total   int
+	Average float64
+	Largest int
+}
+
+// ExpenseStatsRow is ExpenseStats for the expenses in one currency on one
+// UTC date (YYYY-MM-DD), so that they can be converted at that day's rate
+// before being added up. Key and Name identify what the row was grouped
+// by, such as a category, when there is one. MinorUnit is the currency's.
+type ExpenseStatsRow struct {
+	Key       uint
+	Name      string
+	Currency  string
+	MinorUnit int
+	Date      string
+	ExpenseStats
+}
+
+// PeriodExpenseStats is ExpenseStats for expenses occurring in [From, To).
+type PeriodExpenseStats struct {
+	From time.Time
+	To   time.Time
+	ExpenseStats
+}
+
+type CategoryExpenseStats struct {
+	CategoryID   uint
+	CategoryName string
+	ExpenseStats
+}
+
+type AccountExpenseStats struct {
+	AccountID   uint
+	AccountName string
+	ExpenseStats
+}
+
+// ExpenseReport summarizes a user's spending between From and To, split
+// into periods of GroupBy in Timezone, and by category and account. Amounts
+// are in Currency. LargestExpense is nil when there were no expenses.
+type ExpenseReport struct {
+	From           time.Time
+	To             time.Time
+	Timezone       string
+	GroupBy        string
+	Currency       Currency
+	Summary        ExpenseStats
+	LargestExpense *Expense
+	Periods        []PeriodExpenseStats
+	Categories     []CategoryExpenseStats
+	Accounts       []AccountExpenseStats
+}
+
+// RecurringTemplate is an expense or income that repeats on a schedule.
+// NextAt is the next occurrence still to be recorded, or nil once the
+// schedule has ended; Materialized counts the occurrences recorded so far.
+type RecurringTemplate struct {
+	gorm.Model
+	UserID       uint       `gorm:"index" json:"userId"`
+	Type         string     `json:"type"`
+	AccountID    uint       `json:"accountId"`
+	Account      *Account   `json:"account,omitempty"`
+	CategoryID   uint       `json:"categoryId"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Amount       int        `json:"amount"`
+	Frequency    string     `json:"frequency"`
+	Interval     int        `gorm:"column:repeat_interval" json:"interval"`
+	StartAt      time.Time  `json:"startAt"`
+	Until        *time.Time `json:"until"`
+	Count        int        `gorm:"column:repeat_count" json:"count"`
+	Timezone     string     `json:"timezone"`
+	Materialized int        `json:"materialized"`
+	NextAt       *time.Time `gorm:"index" json:"nextAt"`
+}
+
+// Advice is advice generated for a user from their spending in the Months
+// before the month starting at WindowFrom, up to WindowTo, by ModelName
+// with version PromptVersion of the prompt. InputHash identifies all of
+// that along with the data the advice was based on, so that advice is only
+// generated once for the same input. Cached is set when advice is reused.
+type Advice struct {
+	gorm.Model
+	UserID           uint      `gorm:"index:idx_advice_input" json:"userId"`
+	InputHash        string    `gorm:"size:64;index:idx_advice_input" json:"-"`
+	Months           int       `json:"months"`
+	Focus            string    `gorm:"size:32" json:"focus"`
+	Timezone         string    `json:"timezone"`
+	WindowFrom       time.Time `json:"windowFrom"`
+	WindowTo         time.Time `json:"windowTo"`
+	ModelName        string    `json:"modelName"`
+	PromptVersion    int       `json:"promptVersion"`
+	Text             string    `gorm:"type:text" json:"text"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	TotalTokens      int       `json:"totalTokens"`
+	UsageEstimated   bool      `json:"usageEstimated"`
+	Cached           bool      `gorm:"-" json:"cached"`
+}
+
+// RecurringOccurrence links one occurrence of a template to the transaction
+// recorded for it. The unique index on (TemplateID, Sequence) guarantees an
+// occurrence is never recorded twice.
+type RecurringOccurrence struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	TemplateID    uint      `gorm:"uniqueIndex:idx_recurring_occurrence" json:"templateId"`
+	Sequence      int       `gorm:"uniqueIndex:idx_recurring_occurrence" json:"sequence"`
+	OccurredAt    time.Time `json:"occurredAt"`
+	TransactionID uint      `json:"transactionId"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// Backup is what a user has recorded, as exported for moving to another
+// instance and restored from there. ReportingCurrency is nil when the user
+// has none.
+type Backup struct {
+	ReportingCurrency    *Currency
+	Accounts             []Account
+	Categories           []Category
+	Expenses             []Expense
+	Incomes              []Income
+	Transfers            []Transfer
+	Budgets              []Budget
+	RecurringTemplates   []RecurringTemplate
+	RecurringOccurrences []RecurringOccurrence
+	ImportMappings       []ImportMapping
+	ExchangeRates        []ExchangeRate
+	Advice               []Advice
+}
+
+// Journal is everything a user has recorded, for rendering into
+// plain-text accounting formats.
+type Journal struct {
+	Accounts   []Account
+	Categories []Category
+	Expenses   []Expense
+	Incomes    []Income
+	Transfers  []Transfer
+}
+
+// ImportMapping describes how one bank lays out its CSV statements so they
+// can be imported as expenses. Columns are numbered from 1 and DateFormat
+// uses YYYY, MM, DD style tokens.
+type ImportMapping struct {
+	gorm.Model
+	UserID            uint   `gorm:"index" json:"userId"`
+	Name              string `json:"name"`
+	Delimiter         string `json:"delimiter"`
+	HasHeader         bool   `json:"hasHeader"`
+	DateColumn        int    `json:"dateColumn"`
+	DescriptionColumn int    `json:"descriptionColumn"`
+	AmountColumn      int    `json:"amountColumn"`
+	SignConvention    string `json:"signConvention"`
+	DecimalSeparator  string `json:"decimalSeparator"`
+	DateFormat        string `json:"dateFormat"`
+	Timezone          string `json:"timezone"`
+}
+
+// ImportRow is one statement entry read for import, becoming an expense or
+// an income by Type. Skipped rows are not imported: credits in a CSV
+// statement of expenses, or Duplicate transactions imported before. A row
+// with an Error keeps the whole statement from being imported.
+type ImportRow struct {
+	Line          int
+	Type          string
+	ExternalID    string
+	OccurredAt    time.Time
+	Timezone      string
+	Name          string
+	Description   string
+	Amount        int
+	Skipped       bool
+	Duplicate     bool
+	Error         string
+	TransactionID uint
+}
+
+// ImportResult lists the rows read from a statement. Their amounts are in
+// Currency, the account's.
+type ImportResult struct {
+	DryRun   bool
+	Currency *Currency
+	Created  int
+	Skipped  int
+	Failed   int
+	Rows     []ImportRow
+}
+
+const (
+	TransactionTypeExpense = "expense"
+	TransactionTypeIncome  = "income"
+)
+
+// Transaction is a read-only view over expenses and incomes. Amount is
+// signed: expenses are negative and incomes are positive. Currency and
+// MinorUnit are those of the account.
+type Transaction struct {
+	Type        string    `json:"type"`
+	ID          uint      `json:"id"`
+	UserID      uint      `json:"userId"`
+	AccountID   uint      `json:"accountId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Amount      int       `json:"amount"`
+	Currency    string    `json:"currency"`
+	MinorUnit   int       `json:"minorUnit"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	Timezone    string    `json:"timezone"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Page is one page of a listing. Its cursors point at the pages either
+// side of it and are empty where there is none; Total counts the items on
+// every page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+	Total      int64
 }
@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"encoding/csv"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
@@ -14,9 +15,15 @@ import (
 //go:embed seed_currencies.csv
 var currenciesCSV string
 
+// Seed adds the ISO 4217 currencies that are missing and brings the names
+// and minor units of the others up to date, so it can run on every start.
+// Currencies are referenced by their alphabetic code, which is how backups
+// refer to them across instances. Those without a minor unit, such as gold,
+// are given none.
 func Seed(db *gorm.DB, lg util.Logger) error {
 	r := csv.NewReader(strings.NewReader(currenciesCSV))
-	for {
+	seen := map[string]bool{}
+	for header := true; ; header = false {
 		record, err := r.Read()
 		if err == io.EOF {
 			break
@@ -25,15 +32,38 @@ func Seed(db *gorm.DB, lg util.Logger) error {
 			lg.Error("Parsing currencies CSV failed", err)
 			return err
 		}
+		code := record[2]
+		if header || code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		name := record[1]
+		minorUnit, err := strconv.Atoi(record[4])
+		if err != nil {
+			minorUnit = 0
+		}
 
 		currency := model.Currency{
-			Model: gorm.Model{},
-			Code:  record[1],
+			Model:     gorm.Model{},
+			Code:      code,
+			Name:      name,
+			MinorUnit: minorUnit,
 		}
-		if err := db.Save(&currency).Error; err != nil {
+		if err := db.Where("code = ?", code).FirstOrCreate(&currency).Error; err != nil {
 			lg.Error("Inserting currency failed", err)
 			return err
 		}
+		if currency.Name == name && currency.MinorUnit == minorUnit {
+			continue
+		}
+		if err := db.Model(&currency).Updates(map[string]interface{}{
+			"name":       name,
+			"minor_unit": minorUnit,
+		}).Error; err != nil {
+			lg.Error("Updating currency failed", err)
+			return err
+		}
 	}
 
 	return nil
@@ -2,9 +2,11 @@ package setup
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/database/seed"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
 	"github.com/muhrizqiardi/spendtracker/internal/util"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -27,18 +29,172 @@ func SetupMigrateAndSeedMySQL(cfg util.Config, lg util.Logger) (*gorm.DB, error)
 
 	if err := db.AutoMigrate(
 		&model.User{},
+		&model.Session{},
+		&model.RefreshToken{},
+		&model.APIToken{},
+		&model.RecoveryCode{},
+		&model.TwoFactorChallenge{},
+		&model.UserToken{},
 		&model.Account{},
 		&model.Category{},
 		&model.Currency{},
+		&model.ExchangeRate{},
+		&model.Tag{},
 		&model.Expense{},
+		&model.Income{},
+		&model.Transfer{},
+		&model.Budget{},
+		&model.RecurringTemplate{},
+		&model.RecurringOccurrence{},
+		&model.ImportMapping{},
+		&model.Advice{},
+		&appliedMigration{},
 	); err != nil {
 		lg.Error("Failed to migrate", err)
 		return nil, err
 	}
 
+	if err := backfillOccurredAt(db); err != nil {
+		lg.Error("Failed to backfill occurred at", err)
+		return nil, err
+	}
+
+	if err := dropCategoryColumnUniques(db); err != nil {
+		lg.Error("Failed to drop category column unique indexes", err)
+		return nil, err
+	}
+
 	if err := seed.Seed(db, lg); err != nil {
 		return nil, err
 	}
 
+	if err := runOnce(db, "amounts_in_minor_units", scaleAmountsToMinorUnits); err != nil {
+		lg.Error("Failed to convert amounts to minor units", err)
+		return nil, err
+	}
+
+	if err := runOnce(db, "verify_existing_users", verifyExistingUsers); err != nil {
+		lg.Error("Failed to mark existing users verified", err)
+		return nil, err
+	}
+
 	return db, nil
 }
+
+// backfillOccurredAt dates transactions recorded before occurred_at existed
+// by their creation time.
+func backfillOccurredAt(db *gorm.DB) error {
+	for _, m := range []interface{}{&model.Expense{}, &model.Income{}, &model.Transfer{}} {
+		if err := db.
+			Model(m).
+			Where("occurred_at IS NULL").
+			Updates(map[string]interface{}{
+				"occurred_at": gorm.Expr("created_at"),
+				"timezone":    "UTC",
+			}).
+			Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dropCategoryColumnUniques removes the unique indexes categories used to
+// have on user_id and on name separately, which allowed each user only one
+// category. Uniqueness is now on the pair.
+func dropCategoryColumnUniques(db *gorm.DB) error {
+	for _, name := range []string{"user_id", "name"} {
+		if !db.Migrator().HasIndex(&model.Category{}, name) {
+			continue
+		}
+		if err := db.Migrator().DropIndex(&model.Category{}, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appliedMigration records a data migration that has run, for those that
+// cannot tell from the data whether they still need to.
+type appliedMigration struct {
+	Name      string `gorm:"primaryKey;size:191"`
+	AppliedAt time.Time
+}
+
+// runOnce runs migrate in a transaction unless a migration by name has run
+// before, and records that it has.
+func runOnce(db *gorm.DB, name string, migrate func(*gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&appliedMigration{}).Where("name = ?", name).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := migrate(tx); err != nil {
+			return err
+		}
+
+		return tx.Create(&appliedMigration{Name: name, AppliedAt: time.Now().UTC()}).Error
+	})
+}
+
+// scaleAmountsToMinorUnits converts amounts recorded in whole units into
+// minor units of their currency, which is what they are in since currencies
+// have minor units. Budgets, whose limit used to be in whatever currency
+// their expenses were reported in, are first given a currency: their
+// account's, else the user's reporting currency, else that of the user's
+// first account.
+func scaleAmountsToMinorUnits(db *gorm.DB) error {
+	for _, update := range []string{
+		"UPDATE budgets SET currency_id = (SELECT currency_id FROM accounts WHERE accounts.id = budgets.account_id) " +
+			"WHERE currency_id = 0 AND account_id IS NOT NULL",
+		"UPDATE budgets SET currency_id = (SELECT reporting_currency_id FROM users WHERE users.id = budgets.user_id) " +
+			"WHERE currency_id = 0 AND EXISTS (SELECT 1 FROM users WHERE users.id = budgets.user_id AND reporting_currency_id IS NOT NULL)",
+		"UPDATE budgets SET currency_id = (SELECT currency_id FROM accounts WHERE accounts.user_id = budgets.user_id ORDER BY id LIMIT 1) " +
+			"WHERE currency_id = 0 AND EXISTS (SELECT 1 FROM accounts WHERE accounts.user_id = budgets.user_id)",
+	} {
+		if err := db.Exec(update).Error; err != nil {
+			return err
+		}
+	}
+
+	var currencies []model.Currency
+	if err := db.
+		Where("minor_unit > 0").
+		Where("id IN (SELECT currency_id FROM accounts) OR id IN (SELECT currency_id FROM budgets)").
+		Find(&currencies).
+		Error; err != nil {
+		return err
+	}
+
+	inAccounts := "IN (SELECT id FROM accounts WHERE currency_id = ?)"
+	for _, c := range currencies {
+		factor := money.Scale(1, c.MinorUnit)
+		for _, update := range []string{
+			"UPDATE accounts SET initial_amount = initial_amount * ? WHERE currency_id = ?",
+			"UPDATE budgets SET monthly_limit = monthly_limit * ? WHERE currency_id = ?",
+			"UPDATE expenses SET amount = amount * ? WHERE account_id " + inAccounts,
+			"UPDATE incomes SET amount = amount * ? WHERE account_id " + inAccounts,
+			"UPDATE recurring_templates SET amount = amount * ? WHERE account_id " + inAccounts,
+			"UPDATE transfers SET amount = amount * ? WHERE from_account_id " + inAccounts,
+			"UPDATE transfers SET fee = fee * ? WHERE from_account_id " + inAccounts,
+			"UPDATE transfers SET received_amount = received_amount * ? WHERE to_account_id " + inAccounts,
+		} {
+			if err := db.Exec(update, factor, c.ID).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyExistingUsers counts users who registered before email verification
+// existed as verified, rather than locking them out of their data.
+func verifyExistingUsers(db *gorm.DB) error {
+	return db.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL").Error
+}
@@ -1,13 +1,15 @@
 package dto
 
+import "github.com/muhrizqiardi/spendtracker/internal/money"
+
 type CreateAccountDTO struct {
-	CurrencyID    uint   `json:"currencyId" validate:"required"`
-	Name          string `json:"name" validate:"required"`
-	InitialAmount int    `json:"initialAmount" validate:"required"`
+	CurrencyID    uint          `json:"currencyId" validate:"required"`
+	Name          string        `json:"name" validate:"required"`
+	InitialAmount money.Decimal `json:"initialAmount" validate:"required"`
 }
 
 type UpdateAccountDTO struct {
-	CurrencyID    uint   `json:"currencyId" validate:"required"`
-	Name          string `json:"name" validate:"required"`
-	InitialAmount int    `json:"initialAmount" validate:"required"`
+	CurrencyID    uint          `json:"currencyId" validate:"required"`
+	Name          string        `json:"name" validate:"required"`
+	InitialAmount money.Decimal `json:"initialAmount" validate:"required"`
 }
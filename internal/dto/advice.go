@@ -0,0 +1,10 @@
+package dto
+
+// AdviceDTO picks what advice is about. Months is how many months before
+// the current one spending is compared with, 3 by default; Focus is
+// saving, subscriptions, overspending or empty for no focus in particular.
+type AdviceDTO struct {
+	Months   int
+	Focus    string
+	Timezone string
+}
@@ -1,6 +1,34 @@
 package dto
 
+import "time"
+
 type LogInDTO struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
 }
+
+type RefreshTokenDTO struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+type CreateAPITokenDTO struct {
+	Name      string    `json:"name" validate:"required,max=100"`
+	Scopes    []string  `json:"scopes" validate:"required,min=1"`
+	ExpiresAt time.Time `json:"expiresAt" validate:"required"`
+}
+
+type LogInTwoFactorDTO struct {
+	TwoFactorToken string `json:"twoFactorToken" validate:"required"`
+	// Code is a TOTP code or a recovery code.
+	Code string `json:"code" validate:"required"`
+}
+
+type ConfirmTOTPDTO struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type DisableTOTPDTO struct {
+	Password string `json:"password" validate:"required"`
+	// Code is a TOTP code or a recovery code.
+	Code string `json:"code" validate:"required"`
+}
@@ -0,0 +1,166 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+)
+
+// BackupDTO is the document exported as a backup and accepted back by
+// restore. Version is bumped whenever its shape changes; IDs are only used
+// to link records within the document. Accounts and budgets refer to their
+// currency by its ISO 4217 code, and amounts are decimals in that currency.
+// Version 1 wrote amounts as JSON numbers of whole units, which read the
+// same way; versions before 3 only held accounts, categories and expenses,
+// and versions before 5 left out deleted accounts and categories.
+type BackupDTO struct {
+	Version              int                            `json:"version" validate:"required"`
+	ExportedAt           time.Time                      `json:"exportedAt"`
+	ReportingCurrency    string                         `json:"reportingCurrency,omitempty"`
+	Accounts             []BackupAccountDTO             `json:"accounts" validate:"dive"`
+	Categories           []BackupCategoryDTO            `json:"categories" validate:"dive"`
+	Expenses             []BackupExpenseDTO             `json:"expenses" validate:"dive"`
+	Incomes              []BackupIncomeDTO              `json:"incomes" validate:"dive"`
+	Transfers            []BackupTransferDTO            `json:"transfers" validate:"dive"`
+	Budgets              []BackupBudgetDTO              `json:"budgets" validate:"dive"`
+	RecurringTemplates   []BackupRecurringTemplateDTO   `json:"recurringTemplates" validate:"dive"`
+	RecurringOccurrences []BackupRecurringOccurrenceDTO `json:"recurringOccurrences" validate:"dive"`
+	ImportMappings       []BackupImportMappingDTO       `json:"importMappings" validate:"dive"`
+	ExchangeRates        []BackupExchangeRateDTO        `json:"exchangeRates" validate:"dive"`
+	Advice               []BackupAdviceDTO              `json:"advice" validate:"dive"`
+}
+
+type BackupAccountDTO struct {
+	ID            uint          `json:"id" validate:"required"`
+	Currency      string        `json:"currency" validate:"required"`
+	Name          string        `json:"name"`
+	InitialAmount money.Decimal `json:"initialAmount"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	DeletedAt     *time.Time    `json:"deletedAt,omitempty"`
+}
+
+type BackupCategoryDTO struct {
+	ID        uint      `json:"id" validate:"required"`
+	Name      string     `json:"name" validate:"required"`
+	CreatedAt time.Time  `json:"createdAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// BackupExpenseDTO has no CategoryID when it was recorded before expenses
+// had categories.
+type BackupExpenseDTO struct {
+	ID          uint          `json:"id"`
+	AccountID   uint          `json:"accountId" validate:"required"`
+	CategoryID  uint          `json:"categoryId"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Amount      money.Decimal `json:"amount"`
+	OccurredAt  time.Time     `json:"occurredAt"`
+	Timezone    string        `json:"timezone"`
+	ExternalID  *string       `json:"externalId,omitempty"`
+	Tags        []string      `json:"tags,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt"`
+}
+
+type BackupIncomeDTO struct {
+	ID          uint          `json:"id"`
+	AccountID   uint          `json:"accountId" validate:"required"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Amount      money.Decimal `json:"amount"`
+	OccurredAt  time.Time     `json:"occurredAt"`
+	Timezone    string        `json:"timezone"`
+	ExternalID  *string       `json:"externalId,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt"`
+}
+
+// BackupTransferDTO has Amount and Fee in the currency of the account it is
+// from and ReceivedAmount in that of the account it is to.
+type BackupTransferDTO struct {
+	ID             uint          `json:"id"`
+	FromAccountID  uint          `json:"fromAccountId" validate:"required"`
+	ToAccountID    uint          `json:"toAccountId" validate:"required"`
+	Description    string        `json:"description"`
+	Amount         money.Decimal `json:"amount"`
+	Fee            money.Decimal `json:"fee"`
+	ExchangeRate   float64       `json:"exchangeRate"`
+	ReceivedAmount money.Decimal `json:"receivedAmount"`
+	OccurredAt     time.Time     `json:"occurredAt"`
+	Timezone       string        `json:"timezone"`
+	CreatedAt      time.Time     `json:"createdAt"`
+}
+
+type BackupBudgetDTO struct {
+	ID           uint          `json:"id"`
+	CategoryID   uint          `json:"categoryId" validate:"required"`
+	AccountID    *uint         `json:"accountId,omitempty"`
+	Currency     string        `json:"currency" validate:"required"`
+	MonthlyLimit money.Decimal `json:"monthlyLimit"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
+
+type BackupRecurringTemplateDTO struct {
+	ID           uint          `json:"id" validate:"required"`
+	Type         string        `json:"type" validate:"required,oneof=expense income"`
+	AccountID    uint          `json:"accountId" validate:"required"`
+	CategoryID   uint          `json:"categoryId"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	Amount       money.Decimal `json:"amount"`
+	Frequency    string        `json:"frequency" validate:"required,oneof=daily weekly monthly yearly"`
+	Interval     int           `json:"interval" validate:"gte=0"`
+	StartAt      time.Time     `json:"startAt"`
+	Until        *time.Time    `json:"until,omitempty"`
+	Count        int           `json:"count" validate:"gte=0"`
+	Timezone     string        `json:"timezone"`
+	Materialized int           `json:"materialized" validate:"gte=0"`
+	NextAt       *time.Time    `json:"nextAt,omitempty"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
+
+// BackupRecurringOccurrenceDTO links an occurrence of a template to the
+// expense or income, by the template's type, recorded for it.
+type BackupRecurringOccurrenceDTO struct {
+	TemplateID    uint      `json:"templateId" validate:"required"`
+	Sequence      int       `json:"sequence" validate:"required"`
+	OccurredAt    time.Time `json:"occurredAt"`
+	TransactionID uint      `json:"transactionId"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type BackupImportMappingDTO struct {
+	Name              string    `json:"name" validate:"required"`
+	Delimiter         string    `json:"delimiter"`
+	HasHeader         bool      `json:"hasHeader"`
+	DateColumn        int       `json:"dateColumn"`
+	DescriptionColumn int       `json:"descriptionColumn"`
+	AmountColumn      int       `json:"amountColumn"`
+	SignConvention    string    `json:"signConvention"`
+	DecimalSeparator  string    `json:"decimalSeparator"`
+	DateFormat        string    `json:"dateFormat"`
+	Timezone          string    `json:"timezone"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+type BackupExchangeRateDTO struct {
+	Date          time.Time `json:"date"`
+	BaseCurrency  string    `json:"baseCurrency" validate:"required"`
+	QuoteCurrency string    `json:"quoteCurrency" validate:"required"`
+	Rate          float64   `json:"rate" validate:"gt=0"`
+}
+
+type BackupAdviceDTO struct {
+	Months           int       `json:"months"`
+	Focus            string    `json:"focus"`
+	Timezone         string    `json:"timezone"`
+	WindowFrom       time.Time `json:"windowFrom"`
+	WindowTo         time.Time `json:"windowTo"`
+	ModelName        string    `json:"modelName"`
+	PromptVersion    int       `json:"promptVersion"`
+	Text             string    `json:"text"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	TotalTokens      int       `json:"totalTokens"`
+	UsageEstimated   bool      `json:"usageEstimated"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/muhrizqiardi/spendtracker/internal/money"
+
+type CreateBudgetDTO struct {
+	CategoryID   uint          `json:"categoryId" validate:"required"`
+	AccountID    *uint         `json:"accountId"`
+	Currency     string        `json:"currency" validate:"omitempty,len=3,alpha"`
+	MonthlyLimit money.Decimal `json:"monthlyLimit" validate:"required"`
+}
+
+type UpdateBudgetDTO struct {
+	CategoryID   uint          `json:"categoryId" validate:"required"`
+	AccountID    *uint         `json:"accountId"`
+	Currency     string        `json:"currency" validate:"omitempty,len=3,alpha"`
+	MonthlyLimit money.Decimal `json:"monthlyLimit" validate:"required"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type SetReportingCurrencyDTO struct {
+	// Currency is an ISO 4217 code; empty clears the reporting currency.
+	Currency string `json:"currency" validate:"omitempty,len=3,alpha"`
+}
@@ -1,15 +1,42 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
 type CreateExpenseDTO struct {
-	CategoryID  int    `json:"categoryId" validate:"required"`
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description"`
-	Amount      int    `json:"amount" validate:"required"`
+	CategoryID  int           `json:"categoryId" validate:"required"`
+	Name        string        `json:"name" validate:"required"`
+	Description string        `json:"description"`
+	Amount      money.Decimal `json:"amount" validate:"required"`
+	OccurredAt  *time.Time    `json:"occurredAt"`
+	Timezone    string        `json:"timezone"`
+	Tags        []string      `json:"tags"`
 }
 
 type UpdateExpenseDTO struct {
-	CategoryID  int    `json:"categoryId" validate:"required"`
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description"`
-	Amount      int    `json:"amount" validate:"required"`
+	CategoryID  int           `json:"categoryId" validate:"required"`
+	Name        string        `json:"name" validate:"required"`
+	Description string        `json:"description"`
+	Amount      money.Decimal `json:"amount" validate:"required"`
+	OccurredAt  *time.Time    `json:"occurredAt"`
+	Timezone    string        `json:"timezone"`
+	Tags        []string      `json:"tags"`
+}
+
+// ExpenseFilterDTO narrows down and orders a listing of expenses. Empty
+// fields do not filter. Amounts are decimals in each expense's currency.
+type ExpenseFilterDTO struct {
+	Period      util.Period
+	AccountIDs  []uint
+	CategoryIDs []uint
+	MinAmount   money.Decimal
+	MaxAmount   money.Decimal
+	Tags        []string
+	Search      string
+	SortBy      string
+	Order       string
 }
@@ -0,0 +1,26 @@
+package dto
+
+type CreateImportMappingDTO struct {
+	Name              string `json:"name" validate:"required"`
+	Delimiter         string `json:"delimiter" validate:"omitempty,len=1"`
+	HasHeader         bool   `json:"hasHeader"`
+	DateColumn        int    `json:"dateColumn" validate:"required,gt=0"`
+	DescriptionColumn int    `json:"descriptionColumn" validate:"required,gt=0"`
+	AmountColumn      int    `json:"amountColumn" validate:"required,gt=0"`
+	SignConvention    string `json:"signConvention" validate:"required,oneof=negative positive absolute"`
+	DecimalSeparator  string `json:"decimalSeparator" validate:"omitempty,oneof=. 0x2C"`
+	DateFormat        string `json:"dateFormat" validate:"required"`
+	Timezone          string `json:"timezone"`
+}
+
+type ImportCSVDTO struct {
+	MappingID  int  `form:"mappingId" validate:"required"`
+	CategoryID int  `form:"categoryId" validate:"required"`
+	DryRun     bool `form:"dryRun"`
+}
+
+type ImportOFXDTO struct {
+	CategoryID int    `form:"categoryId" validate:"required"`
+	Timezone   string `form:"timezone"`
+	DryRun     bool   `form:"dryRun"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+)
+
+type CreateIncomeDTO struct {
+	Name        string        `json:"name" validate:"required"`
+	Description string        `json:"description"`
+	Amount      money.Decimal `json:"amount" validate:"required"`
+	OccurredAt  *time.Time    `json:"occurredAt"`
+	Timezone    string        `json:"timezone"`
+}
+
+type UpdateIncomeDTO struct {
+	Name        string        `json:"name" validate:"required"`
+	Description string        `json:"description"`
+	Amount      money.Decimal `json:"amount" validate:"required"`
+	OccurredAt  *time.Time    `json:"occurredAt"`
+	Timezone    string        `json:"timezone"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+)
+
+type CreateRecurringTemplateDTO struct {
+	Type        string        `json:"type" validate:"required,oneof=expense income"`
+	AccountID   uint          `json:"accountId" validate:"required"`
+	CategoryID  uint          `json:"categoryId" validate:"required_if=Type expense"`
+	Name        string        `json:"name" validate:"required"`
+	Description string        `json:"description"`
+	Amount      money.Decimal `json:"amount" validate:"required"`
+	Frequency   string        `json:"frequency" validate:"required,oneof=daily weekly monthly yearly"`
+	Interval    int           `json:"interval" validate:"gte=0"`
+	StartAt     time.Time     `json:"startAt" validate:"required"`
+	Until       *time.Time    `json:"until"`
+	Count       int           `json:"count" validate:"gte=0"`
+	Timezone    string        `json:"timezone"`
+}
+
+type UpdateRecurringTemplateDTO struct {
+	AccountID   uint          `json:"accountId" validate:"required"`
+	CategoryID  uint          `json:"categoryId"`
+	Name        string        `json:"name" validate:"required"`
+	Description string        `json:"description"`
+	Amount      money.Decimal `json:"amount" validate:"required"`
+}
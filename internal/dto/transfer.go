@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+)
+
+type CreateTransferDTO struct {
+	FromAccountID uint          `json:"fromAccountId" validate:"required"`
+	ToAccountID   uint          `json:"toAccountId" validate:"required"`
+	Description   string        `json:"description"`
+	Amount        money.Decimal `json:"amount" validate:"required"`
+	Fee           money.Decimal `json:"fee"`
+	ExchangeRate  float64       `json:"exchangeRate" validate:"gte=0"`
+	OccurredAt    *time.Time    `json:"occurredAt"`
+	Timezone      string        `json:"timezone"`
+}
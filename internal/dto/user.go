@@ -11,3 +11,16 @@ type UpdateUserDTO struct {
 	FullName string `json:"fullName" validate:"required"`
 	Password string `json:"password" validate:"required,min=8"`
 }
+
+type VerifyEmailDTO struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type RequestPasswordResetDTO struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordDTO struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
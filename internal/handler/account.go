@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
@@ -16,6 +18,7 @@ type AccountHandler interface {
 	Create(c echo.Context) error
 	GetOneByID(c echo.Context) error
 	GetMany(c echo.Context) error
+	GetBalance(c echo.Context) error
 	UpdateOneByID(c echo.Context) error
 	DeleteOneByID(c echo.Context) error
 }
@@ -49,6 +52,12 @@ func (ah *accountHandler) Create(c echo.Context) error {
 	account, err := ah.as.Create(int(user.ID), payload)
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrUnknownCurrency) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
@@ -65,7 +74,8 @@ func (ah *accountHandler) Create(c echo.Context) error {
 				UserID:        account.UserID,
 				CurrencyID:    account.CurrencyID,
 				Name:          account.Name,
-				InitialAmount: account.InitialAmount,
+				InitialAmount: formatAmount(account.InitialAmount, account.Currency),
+				Balance:       formatAmount(account.InitialAmount, account.Currency),
 			},
 		),
 	)
@@ -87,42 +97,57 @@ func (ah *accountHandler) GetOneByID(c echo.Context) error {
 		)
 	}
 
-	account, err := ah.as.GetOneByID(accountID)
+	user := c.Get("user").(model.User)
+	account, err := ah.as.GetOneByID(int(user.ID), accountID)
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 		)
 	}
 
-	user := c.Get("user").(model.User)
-	if user.ID != account.UserID {
-		c.Logger().Error("Not Allowed")
+	balances, err := ah.as.GetBalances([]model.Account{account}, time.Now())
+	if err != nil {
+		c.Logger().Error(err)
 		return c.JSON(
-			http.StatusForbidden,
-			util.CreateBaseResponse[any](false, "Forbidden", nil),
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 		)
 	}
 
 	return c.JSON(
 		http.StatusOK,
-		util.CreateBaseResponse[model.Account](true, "Account found", account),
+		util.CreateBaseResponse[response.CommonAccountResponse](
+			true,
+			"Account found",
+			response.CommonAccountResponse{
+				ID:            account.ID,
+				UserID:        account.UserID,
+				CurrencyID:    account.CurrencyID,
+				Name:          account.Name,
+				InitialAmount: formatAmount(account.InitialAmount, account.Currency),
+				Balance:       formatAmount(balances[account.ID], account.Currency),
+			},
+		),
 	)
 }
 
 //	@Router		/accounts/ [get]
 //	@Summary	Get many
 //	@Tags		account
-//	@Param		itemPerPage	query	string	false	"Amount of items per page"
-//	@Param		page		query	string	false	"Page number"
+//	@Param		itemPerPage	query	string	false	"Amount of items per page, 10 by default"
+//	@Param		cursor		query	string	false	"Cursor of the page to get, from the pagination of another page"
 //	@Security	Bearer
 //	@Success	200	{object}	util.BaseResponse[[]response.CommonAccountResponse]
 func (ah *accountHandler) GetMany(c echo.Context) error {
-	itemPerPage := 10
-	page := 1
-	var err error
-	itemPerPage, err = strconv.Atoi(c.QueryParam("itemPerPage"))
+	itemPerPage, err := parseItemPerPage(c.QueryParam("itemPerPage"))
 	if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
@@ -130,7 +155,58 @@ func (ah *accountHandler) GetMany(c echo.Context) error {
 			util.CreateBaseResponse[any](false, "Bad Request", nil),
 		)
 	}
-	page, err = strconv.Atoi(c.QueryParam("page"))
+
+	user := c.Get("user").(model.User)
+	accounts, err := ah.as.GetMany(int(user.ID), itemPerPage, c.QueryParam("cursor"))
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidCursor) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	balances, err := ah.as.GetBalances(accounts.Items, time.Now())
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	responses := make([]response.CommonAccountResponse, 0, len(accounts.Items))
+	for _, a := range accounts.Items {
+		responses = append(responses, response.CommonAccountResponse{
+			ID:            a.ID,
+			UserID:        a.UserID,
+			CurrencyID:    a.CurrencyID,
+			Name:          a.Name,
+			InitialAmount: formatAmount(a.InitialAmount, a.Currency),
+			Balance:       formatAmount(balances[a.ID], a.Currency),
+		})
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreatePaginatedResponse("Account(s) found", responses, pagination(accounts)),
+	)
+}
+
+//	@Router		/accounts/{accountID}/balance [get]
+//	@Summary	Get balance of an account as of a date
+//	@Tags		account
+//	@Param		accountID	path	string	true	"Account ID"
+//	@Param		date		query	string	false	"Date (YYYY-MM-DD, inclusive) or RFC 3339 timestamp; defaults to now"
+//	@Security	Bearer
+//	@Success	200	{object}	util.BaseResponse[response.AccountBalanceResponse]
+func (ah *accountHandler) GetBalance(c echo.Context) error {
+	accountID, err := strconv.Atoi(c.Param("accountID"))
 	if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
@@ -139,8 +215,39 @@ func (ah *accountHandler) GetMany(c echo.Context) error {
 		)
 	}
 
+	at := time.Now()
+	if c.QueryParam("date") != "" {
+		date, isDate, err := util.ParseDateOrTime(c.QueryParam("date"))
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, "Bad Request", nil),
+			)
+		}
+		at = date
+		if isDate {
+			at = date.AddDate(0, 0, 1)
+		}
+	}
+
 	user := c.Get("user").(model.User)
-	account, err := ah.as.GetMany(int(user.ID), itemPerPage, page)
+	account, err := ah.as.GetOneByID(int(user.ID), accountID)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	balances, err := ah.as.GetBalances([]model.Account{account}, at)
 	if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
@@ -151,18 +258,36 @@ func (ah *accountHandler) GetMany(c echo.Context) error {
 
 	return c.JSON(
 		http.StatusOK,
-		util.CreateBaseResponse[[]model.Account](true, "Account(s) found", account),
+		util.CreateBaseResponse[response.AccountBalanceResponse](
+			true,
+			"Balance found",
+			response.AccountBalanceResponse{
+				AccountID: account.ID,
+				At:        at,
+				Balance:   formatAmount(balances[account.ID], account.Currency),
+			},
+		),
 	)
 }
 
 //	@Router		/accounts/{accountID} [put]
 //	@Summary	Update account
+//	@Description	The currency can only change while the account has no transactions or recurring templates, since their amounts are kept in the account's currency.
 //	@Tags		account
 //	@Param		accountID	path	string					true	"Account ID"
 //	@Param		payload		body	dto.UpdateAccountDTO	true	"Update account DTO"
 //	@Security	Bearer
 //	@Success	200	{object}	util.BaseResponse[response.CommonAccountResponse]
 func (ah *accountHandler) UpdateOneByID(c echo.Context) error {
+	accountID, err := strconv.Atoi(c.Param("accountID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
 	var payload dto.UpdateAccountDTO
 	if err := c.Bind(&payload); err != nil {
 		c.Logger().Error(err)
@@ -174,7 +299,34 @@ func (ah *accountHandler) UpdateOneByID(c echo.Context) error {
 
 	user := c.Get("user").(model.User)
 
-	account, err := ah.as.UpdateOneByID(int(user.ID), payload)
+	account, err := ah.as.UpdateOneByID(int(user.ID), accountID, payload)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrUnknownCurrency) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrAccountCurrencyLocked) {
+			return c.JSON(
+				http.StatusConflict,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	balances, err := ah.as.GetBalances([]model.Account{account}, time.Now())
 	if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
@@ -184,8 +336,19 @@ func (ah *accountHandler) UpdateOneByID(c echo.Context) error {
 	}
 
 	return c.JSON(
-		http.StatusCreated,
-		util.CreateBaseResponse[model.Account](true, "Account updated", account),
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonAccountResponse](
+			true,
+			"Account updated",
+			response.CommonAccountResponse{
+				ID:            account.ID,
+				UserID:        account.UserID,
+				CurrencyID:    account.CurrencyID,
+				Name:          account.Name,
+				InitialAmount: formatAmount(account.InitialAmount, account.Currency),
+				Balance:       formatAmount(balances[account.ID], account.Currency),
+			},
+		),
 	)
 }
 
@@ -205,13 +368,23 @@ func (ah *accountHandler) DeleteOneByID(c echo.Context) error {
 		)
 	}
 
-	if err := ah.as.DeleteOneByID(accountID); err != nil {
+	user := c.Get("user").(model.User)
+	if err := ah.as.DeleteOneByID(int(user.ID), accountID); err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 		)
 	}
 
-	return nil
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](true, "Account deleted", nil),
+	)
 }
@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
 	"github.com/muhrizqiardi/spendtracker/internal/response"
 	"github.com/muhrizqiardi/spendtracker/internal/service"
 	"github.com/muhrizqiardi/spendtracker/internal/util"
@@ -12,6 +17,8 @@ import (
 
 type AdviceHandler interface {
 	GetAdvice(c echo.Context) error
+	StreamAdvice(c echo.Context) error
+	GetHistory(c echo.Context) error
 }
 
 type adviceHandler struct {
@@ -24,28 +31,222 @@ func NewAdviceHandler(ads service.AdviceService) *adviceHandler {
 
 //	@Router		/advice [get]
 //	@Summary	Get advice
+//	@Description	Advice on this month's spending against the months before it, budgets, recurring charges and the largest expenses, in the reporting currency or, without one, in each currency spent in. Advice is reused while none of that changes within the month.
 //	@Tags		advice
+//	@Param		months		query	int		false	"How many months before the current one to compare with, 1 to 12, 3 by default"
+//	@Param		focus		query	string	false	"What to focus on: saving, subscriptions or overspending"
+//	@Param		timezone	query	string	false	"IANA timezone or UTC offset months are in, UTC by default"
 //	@Security	Bearer
-//	@Success	200	{object}	util.BaseResponse[response.CommonAccountResponse]
+//	@Success	200	{object}	util.BaseResponse[response.CommonAdviceResponse]
 func (adh *adviceHandler) GetAdvice(c echo.Context) error {
 	user := c.Get("user").(model.User)
+	payload, err := parseAdviceQuery(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	advice, err := adh.ads.GetAdvice(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		return adviceError(c, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonAdviceResponse](
+			true, "Getting Advice Success",
+			adviceResponse(advice),
+		),
+	)
+}
+
+//	@Router			/advice/stream [get]
+//	@Summary		Stream advice
+//	@Description	Streams advice as Server-Sent Events: "token" events carry the advice piece by piece as the model writes it, then a "done" event carries the token usage, or an "error" event tells that it failed.
+//	@Tags			advice
+//	@Param			months		query	int		false	"How many months before the current one to compare with, 1 to 12, 3 by default"
+//	@Param			focus		query	string	false	"What to focus on: saving, subscriptions or overspending"
+//	@Param			timezone	query	string	false	"IANA timezone or UTC offset months are in, UTC by default"
+//	@Produce		text/event-stream
+//	@Security		Bearer
+//	@Success		200	{object}	response.AdviceDoneEvent
+func (adh *adviceHandler) StreamAdvice(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	ctx := c.Request().Context()
+	payload, err := parseAdviceQuery(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	// The stream only starts with the first token, so that failing to
+	// build the prompt can still be answered with a status code.
+	w := c.Response()
+	started := false
+	advice, err := adh.ads.StreamAdvice(ctx, int(user.ID), payload, func(token string) error {
+		if !started {
+			startEventStream(w)
+			started = true
+		}
+		return writeEvent(w, "token", response.AdviceTokenEvent{Token: token})
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			// The client went away, so there is no one left to tell.
+			return nil
+		}
+		c.Logger().Error(err)
+		if !started {
+			return adviceError(c, err)
+		}
+		return writeEvent(w, "error", response.AdviceErrorEvent{Message: "Internal Server Error"})
+	}
+	if !started {
+		startEventStream(w)
+	}
+
+	return writeEvent(w, "done", response.AdviceDoneEvent{
+		ID:     advice.ID,
+		Cached: advice.Cached,
+		Usage:  adviceUsageResponse(advice),
+	})
+}
+
+//	@Router		/advice/history [get]
+//	@Summary	Get advice given before
+//	@Tags		advice
+//	@Param		itemPerPage	query	string	false	"Amount of items per page, 10 by default"
+//	@Param		cursor		query	string	false	"Cursor of the page to get, from the pagination of another page"
+//	@Security	Bearer
+//	@Success	200	{object}	util.BaseResponse[[]response.CommonAdviceResponse]
+func (adh *adviceHandler) GetHistory(c echo.Context) error {
+	itemPerPage, err := parseItemPerPage(c.QueryParam("itemPerPage"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
 
-	res, err := adh.ads.GetAdvice(int(user.ID))
+	user := c.Get("user").(model.User)
+	history, err := adh.ads.GetHistory(int(user.ID), itemPerPage, c.QueryParam("cursor"))
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidCursor) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 		)
 	}
 
+	responses := make([]response.CommonAdviceResponse, 0, len(history.Items))
+	for _, a := range history.Items {
+		responses = append(responses, adviceResponse(a))
+	}
 	return c.JSON(
 		http.StatusOK,
-		util.CreateBaseResponse[response.GetAdviceResponse](
-			true, "Getting Advice Success",
-			response.GetAdviceResponse{
-				Advice: res,
-			},
-		),
+		util.CreatePaginatedResponse("Advice found", responses, pagination(history)),
+	)
+}
+
+func adviceResponse(a model.Advice) response.CommonAdviceResponse {
+	return response.CommonAdviceResponse{
+		ID:            a.ID,
+		Advice:        a.Text,
+		Months:        a.Months,
+		Focus:         a.Focus,
+		Timezone:      a.Timezone,
+		From:          util.InTimezone(a.WindowFrom, a.Timezone),
+		To:            util.InTimezone(a.WindowTo, a.Timezone),
+		Model:         a.ModelName,
+		PromptVersion: a.PromptVersion,
+		Usage:         adviceUsageResponse(a),
+		Cached:        a.Cached,
+		CreatedAt:     a.CreatedAt,
+	}
+}
+
+func adviceUsageResponse(a model.Advice) response.AdviceUsageResponse {
+	return response.AdviceUsageResponse{
+		PromptTokens:     a.PromptTokens,
+		CompletionTokens: a.CompletionTokens,
+		TotalTokens:      a.TotalTokens,
+		Estimated:        a.UsageEstimated,
+	}
+}
+
+// parseAdviceQuery reads what advice is about from the query.
+func parseAdviceQuery(c echo.Context) (dto.AdviceDTO, error) {
+	payload := dto.AdviceDTO{
+		Focus:    c.QueryParam("focus"),
+		Timezone: c.QueryParam("timezone"),
+	}
+	if months := c.QueryParam("months"); months != "" {
+		var err error
+		if payload.Months, err = strconv.Atoi(months); err != nil {
+			return dto.AdviceDTO{}, err
+		}
+	}
+
+	return payload, nil
+}
+
+// adviceError answers with the status err calls for.
+func adviceError(c echo.Context, err error) error {
+	if errors.Is(err, service.ErrInvalidAdviceMonths) ||
+		errors.Is(err, service.ErrInvalidAdviceFocus) ||
+		errors.Is(err, service.ErrInvalidTimezone) {
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, err.Error(), nil),
+		)
+	}
+	if errors.Is(err, service.ErrExchangeRateNotFound) {
+		return c.JSON(
+			http.StatusUnprocessableEntity,
+			util.CreateBaseResponse[any](false, err.Error(), nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusInternalServerError,
+		util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 	)
 }
+
+func startEventStream(w *echo.Response) {
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+}
+
+// writeEvent sends data as the Server-Sent Event event, flushing it to the
+// client right away.
+func writeEvent(w *echo.Response, event string, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+		return err
+	}
+	w.Flush()
+
+	return nil
+}
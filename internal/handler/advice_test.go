@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/llm"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestAdviceHandler_GetAdvice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mads := mock_service.NewMockAdviceService(ctrl)
+	adh := NewAdviceHandler(mads)
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		exp  int
+	}{
+		{"should return 400 on an invalid window", service.ErrInvalidAdviceMonths, http.StatusBadRequest},
+		{"should return 422 without an exchange rate", service.ErrExchangeRateNotFound, http.StatusUnprocessableEntity},
+		{"should return 500 when the model fails", llm.ErrEmptyCompletion, http.StatusInternalServerError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mads.EXPECT().GetAdvice(gomock.Eq(1), gomock.Eq(dto.AdviceDTO{Months: 24})).Return(model.Advice{}, tc.err)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/advice?months=24", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", model.User{Model: gorm.Model{ID: 1}})
+
+			adh.GetAdvice(c)
+
+			if rec.Code != tc.exp {
+				t.Errorf("exp %v; got %v", tc.exp, rec.Code)
+			}
+		})
+	}
+}
+
+func TestAdviceHandler_GetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mads := mock_service.NewMockAdviceService(ctrl)
+	adh := NewAdviceHandler(mads)
+
+	t.Run("should return a page of advice", func(t *testing.T) {
+		mads.EXPECT().GetHistory(gomock.Eq(1), gomock.Eq(2), gomock.Eq("")).Return(model.Page[model.Advice]{
+			Items:      []model.Advice{{Model: gorm.Model{ID: 9}, Text: "Spend less.", ModelName: "model"}},
+			NextCursor: "next",
+			Total:      3,
+		}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/advice/history?itemPerPage=2", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", model.User{Model: gorm.Model{ID: 1}})
+
+		adh.GetHistory(c)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("exp %v; got %v", http.StatusOK, rec.Code)
+		}
+		for _, exp := range []string{`"advice":"Spend less."`, `"model":"model"`, `"nextCursor":"next"`} {
+			if !strings.Contains(rec.Body.String(), exp) {
+				t.Errorf("exp %s in body; got %s", exp, rec.Body.String())
+			}
+		}
+	})
+	t.Run("should return error on an invalid cursor", func(t *testing.T) {
+		mads.EXPECT().GetHistory(gomock.Eq(1), gomock.Any(), gomock.Eq("bad")).Return(model.Page[model.Advice]{}, service.ErrInvalidCursor)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/advice/history?cursor=bad", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", model.User{Model: gorm.Model{ID: 1}})
+
+		adh.GetHistory(c)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("exp %v; got %v", http.StatusBadRequest, rec.Code)
+		}
+	})
+}
+
+func TestAdviceHandler_StreamAdvice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mads := mock_service.NewMockAdviceService(ctrl)
+	adh := NewAdviceHandler(mads)
+
+	newContext := func(ctx context.Context, target string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, target, nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", model.User{Model: gorm.Model{ID: 1}})
+
+		return c, rec
+	}
+
+	t.Run("should send each token as an event, then the usage", func(t *testing.T) {
+		mads.EXPECT().StreamAdvice(gomock.Any(), gomock.Eq(1), gomock.Eq(dto.AdviceDTO{Months: 6, Focus: "saving"}), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int, payload dto.AdviceDTO, onToken func(string) error) (model.Advice, error) {
+			onToken("Spend\nless")
+			onToken(".")
+			return model.Advice{Model: gorm.Model{ID: 9}, PromptTokens: 10, CompletionTokens: 2, TotalTokens: 12}, nil
+		})
+		c, rec := newContext(context.Background(), "/advice/stream?months=6&focus=saving")
+
+		if err := adh.StreamAdvice(c); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+
+		if got := rec.Header().Get(echo.HeaderContentType); got != "text/event-stream" {
+			t.Error("exp text/event-stream; got", got)
+		}
+		exp := "event: token\ndata: {\"token\":\"Spend\\nless\"}\n\n" +
+			"event: token\ndata: {\"token\":\".\"}\n\n" +
+			"event: done\ndata: {\"id\":9,\"cached\":false,\"usage\":{\"promptTokens\":10,\"completionTokens\":2,\"totalTokens\":12,\"estimated\":false}}\n\n"
+		if got := rec.Body.String(); got != exp {
+			t.Errorf("exp %q; got %q", exp, got)
+		}
+	})
+	t.Run("should return error when months is not a number", func(t *testing.T) {
+		c, rec := newContext(context.Background(), "/advice/stream?months=six")
+
+		adh.StreamAdvice(c)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("exp %v; got %v", http.StatusBadRequest, rec.Code)
+		}
+	})
+	t.Run("should answer with a status when nothing was streamed yet", func(t *testing.T) {
+		mads.EXPECT().StreamAdvice(gomock.Any(), gomock.Eq(1), gomock.Any(), gomock.Any()).Return(model.Advice{}, service.ErrInvalidAdviceFocus)
+		c, rec := newContext(context.Background(), "/advice/stream?focus=luck")
+
+		adh.StreamAdvice(c)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("exp %v; got %v", http.StatusBadRequest, rec.Code)
+		}
+		if got := rec.Header().Get(echo.HeaderContentType); got == "text/event-stream" {
+			t.Error("exp a JSON response; got", got)
+		}
+	})
+	t.Run("should end with an error event when the model fails midway", func(t *testing.T) {
+		mads.EXPECT().StreamAdvice(gomock.Any(), gomock.Eq(1), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int, payload dto.AdviceDTO, onToken func(string) error) (model.Advice, error) {
+			onToken("Spend ")
+			return model.Advice{}, errors.New("")
+		})
+		c, rec := newContext(context.Background(), "/advice/stream")
+
+		adh.StreamAdvice(c)
+
+		if got := rec.Body.String(); !strings.HasSuffix(got, "event: error\ndata: {\"message\":\"Internal Server Error\"}\n\n") {
+			t.Error("exp an error event last; got", got)
+		}
+	})
+	t.Run("should stop quietly when the client goes away", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		mads.EXPECT().StreamAdvice(gomock.Any(), gomock.Eq(1), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID int, payload dto.AdviceDTO, onToken func(string) error) (model.Advice, error) {
+			onToken("Spend ")
+			cancel()
+			<-ctx.Done()
+			return model.Advice{}, ctx.Err()
+		})
+		c, rec := newContext(ctx, "/advice/stream")
+
+		if err := adh.StreamAdvice(c); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got := rec.Body.String(); strings.Contains(got, "event: error") || strings.Contains(got, "event: done") {
+			t.Error("exp no more events after the client went away; got", got)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+)
+
+// formatAmount writes an amount in minor units of currency as a decimal.
+func formatAmount(amount int, currency *model.Currency) string {
+	if currency == nil {
+		return money.Format(amount, 0)
+	}
+
+	return money.Format(amount, currency.MinorUnit)
+}
+
+// accountCurrency returns the currency of an account loaded with it.
+func accountCurrency(account *model.Account) *model.Currency {
+	if account == nil {
+		return nil
+	}
+
+	return account.Currency
+}
+
+// currencyCode returns the ISO 4217 code of a loaded currency.
+func currencyCode(currency *model.Currency) string {
+	if currency == nil {
+		return ""
+	}
+
+	return currency.Code
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type APITokenHandler interface {
+	Create(c echo.Context) error
+	GetMany(c echo.Context) error
+	DeleteOneByID(c echo.Context) error
+}
+
+type apiTokenHandler struct {
+	ats service.APITokenService
+}
+
+func NewAPITokenHandler(ats service.APITokenService) *apiTokenHandler {
+	return &apiTokenHandler{ats}
+}
+
+// @Router		/api-tokens [post]
+// @Summary	Create an API token; it is only shown in this response
+// @Tags		auth
+// @Param		payload	body	dto.CreateAPITokenDTO	true	"Create API token DTO"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.CreateAPITokenResponse]
+func (ath *apiTokenHandler) Create(c echo.Context) error {
+	var payload dto.CreateAPITokenDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	apiToken, token, err := ath.ats.Create(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrInvalidScope) || errors.Is(err, service.ErrExpiryNotInFuture) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.CreateAPITokenResponse](
+			true, "API token created",
+			response.CreateAPITokenResponse{
+				CommonAPITokenResponse: apiTokenResponse(apiToken),
+				Token:                  token,
+			},
+		),
+	)
+}
+
+// @Router		/api-tokens [get]
+// @Summary	Get the API tokens
+// @Tags		auth
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[[]response.CommonAPITokenResponse]
+func (ath *apiTokenHandler) GetMany(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	apiTokens, err := ath.ats.GetMany(int(user.ID))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	responses := make([]response.CommonAPITokenResponse, 0, len(apiTokens))
+	for _, t := range apiTokens {
+		responses = append(responses, apiTokenResponse(t))
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[[]response.CommonAPITokenResponse](
+			true, "API tokens found", responses,
+		),
+	)
+}
+
+// @Router		/api-tokens/{tokenID} [delete]
+// @Summary	Revoke one API token by ID
+// @Tags		auth
+// @Param		tokenID	path	string	true	"API token ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[any]
+func (ath *apiTokenHandler) DeleteOneByID(c echo.Context) error {
+	tokenID, err := strconv.Atoi(c.Param("tokenID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	if err := ath.ats.DeleteOneByID(int(user.ID), tokenID); err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](
+			true, "API token revoked", nil,
+		),
+	)
+}
+
+func apiTokenResponse(t model.APIToken) response.CommonAPITokenResponse {
+	return response.CommonAPITokenResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Scopes:    strings.Fields(t.Scopes),
+		ExpiresAt: t.ExpiresAt,
+		CreatedAt: t.CreatedAt,
+	}
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
 	"github.com/muhrizqiardi/spendtracker/internal/response"
 	"github.com/muhrizqiardi/spendtracker/internal/service"
@@ -12,6 +15,9 @@ import (
 
 type AuthHandler interface {
 	LogIn(c echo.Context) error
+	LogInTwoFactor(c echo.Context) error
+	Refresh(c echo.Context) error
+	LogOut(c echo.Context) error
 }
 
 type authHandler struct {
@@ -28,7 +34,8 @@ func NewAuthHandler(as service.AuthService) *authHandler {
 //	@Summary	Log in to account
 //	@Tags		auth
 //	@Param		payload	body		dto.LogInDTO	true	"log in DTO"
-//	@Success	200		{object}	response.LogInResponse
+//	@Success	201		{object}	util.BaseResponse[response.LogInResponse]
+//	@Success	200		{object}	util.BaseResponse[response.TwoFactorRequiredResponse]	"Two-factor code required; see /auth/totp"
 func (ah *authHandler) LogIn(c echo.Context) error {
 	var payload dto.LogInDTO
 	if err := c.Bind(&payload); err != nil {
@@ -39,24 +46,174 @@ func (ah *authHandler) LogIn(c echo.Context) error {
 		)
 	}
 
-	token, err := ah.as.LogIn(payload)
+	tokens, err := ah.as.LogIn(payload)
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrTooManyTwoFactorAttempts) {
+			return c.JSON(
+				http.StatusTooManyRequests,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 		)
 	}
 
+	if tokens.TwoFactorToken != "" {
+		return c.JSON(
+			http.StatusOK,
+			util.CreateBaseResponse[response.TwoFactorRequiredResponse](
+				true,
+				"Two-factor code required",
+				response.TwoFactorRequiredResponse{TwoFactorToken: tokens.TwoFactorToken},
+			),
+		)
+	}
+
 	// return c.JSON(200, util.CreateBaseResponse[response.log]()(response.LogInResponse{Token: token}))
 	return c.JSON(
 		http.StatusCreated,
 		util.CreateBaseResponse[response.LogInResponse](
 			true,
 			"Log in success",
-			response.LogInResponse{
-				Token: token,
-			},
+			logInResponse(tokens),
 		),
 	)
 }
+
+// LogInTwoFactor
+//
+//	@Router		/auth/totp [post]
+//	@Summary	Finish logging in with a TOTP or recovery code
+//	@Tags		auth
+//	@Param		payload	body		dto.LogInTwoFactorDTO	true	"two-factor log in DTO"
+//	@Success	201		{object}	util.BaseResponse[response.LogInResponse]
+func (ah *authHandler) LogInTwoFactor(c echo.Context) error {
+	var payload dto.LogInTwoFactorDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	tokens, err := ah.as.LogInTwoFactor(payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrInvalidTwoFactorToken) || errors.Is(err, service.ErrInvalidTOTPCode) {
+			return c.JSON(
+				http.StatusUnauthorized,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrTooManyTwoFactorAttempts) {
+			return c.JSON(
+				http.StatusTooManyRequests,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.LogInResponse](
+			true,
+			"Log in success",
+			logInResponse(tokens),
+		),
+	)
+}
+
+// Refresh
+//
+//	@Router		/auth/refresh [post]
+//	@Summary	Trade a refresh token for new tokens
+//	@Tags		auth
+//	@Param		payload	body		dto.RefreshTokenDTO	true	"refresh token DTO"
+//	@Success	201		{object}	util.BaseResponse[response.LogInResponse]
+func (ah *authHandler) Refresh(c echo.Context) error {
+	var payload dto.RefreshTokenDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	tokens, err := ah.as.Refresh(payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) || errors.Is(err, service.ErrSessionRevoked) {
+			return c.JSON(
+				http.StatusUnauthorized,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.LogInResponse](
+			true,
+			"Tokens refreshed",
+			logInResponse(tokens),
+		),
+	)
+}
+
+// LogOut
+//
+//	@Router		/auth/logout [post]
+//	@Summary	Log out of the current session
+//	@Tags		auth
+//	@Security	Bearer
+//	@Success	200	{object}	util.BaseResponse[any]
+func (ah *authHandler) LogOut(c echo.Context) error {
+	session := c.Get("session").(model.Session)
+	if err := ah.as.LogOut(session.ID); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](true, "Logged out", nil),
+	)
+}
+
+func logInResponse(tokens model.AuthTokens) response.LogInResponse {
+	return response.LogInResponse{
+		Token:        tokens.AccessToken,
+		ExpiresAt:    tokens.AccessTokenExpiresAt,
+		RefreshToken: tokens.RefreshToken,
+	}
+}
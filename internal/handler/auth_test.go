@@ -10,12 +10,15 @@ import (
 	"testing"
 
 	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
 	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
 	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
 	"github.com/muhrizqiardi/spendtracker/internal/util"
 	"github.com/muhrizqiardi/spendtracker/tests/testutil"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func TestAuthHandler_LogIn(t *testing.T) {
@@ -43,8 +46,8 @@ func TestAuthHandler_LogIn(t *testing.T) {
 		mas.EXPECT().LogIn(gomock.Eq(dto.LogInDTO{
 			Email:    "test@example.com",
 			Password: "topsecret",
-		})).DoAndReturn(func(payload dto.LogInDTO) (string, error) {
-			return "", errors.New("")
+		})).DoAndReturn(func(payload dto.LogInDTO) (model.AuthTokens, error) {
+			return model.AuthTokens{}, errors.New("")
 		})
 
 		e := echo.New()
@@ -68,8 +71,8 @@ func TestAuthHandler_LogIn(t *testing.T) {
 		mas.EXPECT().LogIn(gomock.Eq(dto.LogInDTO{
 			Email:    "test@example.com",
 			Password: "topsecret",
-		})).DoAndReturn(func(payload dto.LogInDTO) (string, error) {
-			return "mocktoken", nil
+		})).DoAndReturn(func(payload dto.LogInDTO) (model.AuthTokens, error) {
+			return model.AuthTokens{AccessToken: "mocktoken", RefreshToken: "mockrefreshtoken"}, nil
 		})
 
 		e := echo.New()
@@ -86,9 +89,136 @@ func TestAuthHandler_LogIn(t *testing.T) {
 			t.Error("exp nil; got error:", err)
 		}
 		exp := response.LogInResponse{
-			Token: "mocktoken",
+			Token:        "mocktoken",
+			RefreshToken: "mockrefreshtoken",
 		}
 		got := resBody.Data
 		testutil.CompareAndAssert(t, exp, got)
 	})
 }
+
+func TestAuthHandler_LogIn_TwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mas := mock_service.NewMockAuthService(ctrl)
+	ah := NewAuthHandler(mas)
+
+	t.Run("should only return a two-factor token when a code is needed", func(t *testing.T) {
+		mas.EXPECT().LogIn(gomock.Any()).Return(model.AuthTokens{TwoFactorToken: "mockchallenge"}, nil)
+
+		e := echo.New()
+		r := httptest.NewRequest(http.MethodPost, "/auth", strings.NewReader(`{"email": "test@example.com","password":"topsecret"}`))
+		r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w := httptest.NewRecorder()
+		c := e.NewContext(r, w)
+
+		ah.LogIn(c)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("exp %v; got %v", http.StatusOK, w.Code)
+		}
+		var resBody util.BaseResponse[response.TwoFactorRequiredResponse]
+		if err := json.Unmarshal([]byte(w.Body.String()), &resBody); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, response.TwoFactorRequiredResponse{TwoFactorToken: "mockchallenge"}, resBody.Data)
+	})
+	t.Run("should return 401 for a wrong code", func(t *testing.T) {
+		mas.EXPECT().LogInTwoFactor(gomock.Eq(dto.LogInTwoFactorDTO{TwoFactorToken: "mockchallenge", Code: "000000"})).Return(model.AuthTokens{}, service.ErrInvalidTOTPCode)
+
+		e := echo.New()
+		r := httptest.NewRequest(http.MethodPost, "/auth/totp", strings.NewReader(`{"twoFactorToken":"mockchallenge","code":"000000"}`))
+		r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w := httptest.NewRecorder()
+		c := e.NewContext(r, w)
+
+		ah.LogInTwoFactor(c)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("exp %v; got %v", http.StatusUnauthorized, w.Code)
+		}
+	})
+	t.Run("should return 429 once too many wrong codes were tried", func(t *testing.T) {
+		mas.EXPECT().LogInTwoFactor(gomock.Any()).Return(model.AuthTokens{}, service.ErrTooManyTwoFactorAttempts)
+
+		e := echo.New()
+		r := httptest.NewRequest(http.MethodPost, "/auth/totp", strings.NewReader(`{"twoFactorToken":"mockchallenge","code":"000000"}`))
+		r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w := httptest.NewRecorder()
+		c := e.NewContext(r, w)
+
+		ah.LogInTwoFactor(c)
+
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("exp %v; got %v", http.StatusTooManyRequests, w.Code)
+		}
+	})
+}
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mas := mock_service.NewMockAuthService(ctrl)
+	ah := NewAuthHandler(mas)
+
+	t.Run("should return 401 when the refresh token was already used", func(t *testing.T) {
+		mas.EXPECT().Refresh(gomock.Eq(dto.RefreshTokenDTO{RefreshToken: "used"})).Return(model.AuthTokens{}, service.ErrRefreshTokenReused)
+
+		e := echo.New()
+		r := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{"refreshToken":"used"}`))
+		r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w := httptest.NewRecorder()
+		c := e.NewContext(r, w)
+
+		ah.Refresh(c)
+
+		exp := http.StatusUnauthorized
+		got := w.Code
+		if exp != got {
+			t.Errorf("exp %v; got %v", exp, got)
+		}
+	})
+	t.Run("should return new tokens", func(t *testing.T) {
+		mas.EXPECT().Refresh(gomock.Eq(dto.RefreshTokenDTO{RefreshToken: "fresh"})).Return(model.AuthTokens{AccessToken: "mocktoken", RefreshToken: "next"}, nil)
+
+		e := echo.New()
+		r := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{"refreshToken":"fresh"}`))
+		r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w := httptest.NewRecorder()
+		c := e.NewContext(r, w)
+
+		ah.Refresh(c)
+
+		var resBody util.BaseResponse[response.LogInResponse]
+		if err := json.Unmarshal([]byte(w.Body.String()), &resBody); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		exp := response.LogInResponse{
+			Token:        "mocktoken",
+			RefreshToken: "next",
+		}
+		testutil.CompareAndAssert(t, exp, resBody.Data)
+	})
+}
+
+func TestAuthHandler_LogOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mas := mock_service.NewMockAuthService(ctrl)
+	ah := NewAuthHandler(mas)
+
+	t.Run("should revoke the current session", func(t *testing.T) {
+		mas.EXPECT().LogOut(gomock.Eq(uint(7))).Return(nil)
+
+		e := echo.New()
+		r := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+		w := httptest.NewRecorder()
+		c := e.NewContext(r, w)
+		c.Set("session", model.Session{Model: gorm.Model{ID: 7}})
+
+		ah.LogOut(c)
+
+		exp := http.StatusOK
+		got := w.Code
+		if exp != got {
+			t.Errorf("exp %v; got %v", exp, got)
+		}
+	})
+}
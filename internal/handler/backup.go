@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type BackupHandler interface {
+	Export(c echo.Context) error
+	Restore(c echo.Context) error
+}
+
+type backupHandler struct {
+	bs service.BackupService
+}
+
+func NewBackupHandler(bs service.BackupService) *backupHandler {
+	return &backupHandler{bs}
+}
+
+// @Router		/backup [get]
+// @Summary	Download a backup of everything the current user has recorded
+// @Tags		backup
+// @Produce	json
+// @Security	Bearer
+// @Success	200	{object}	dto.BackupDTO
+func (bh *backupHandler) Export(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	backup, err := bh.bs.Export(int(user.ID))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=\"spendtracker-backup-%s.json\"", backup.ExportedAt.Format(util.DateLayout)),
+	)
+	return c.JSON(http.StatusOK, backup)
+}
+
+// @Router		/backup/restore [post]
+// @Summary	Restore a backup into the current user's data
+// @Description	Everything in the backup is added in one transaction with new IDs; categories the user already has are matched by name, and expenses without a category are put in Uncategorized. Backups from a newer version are refused.
+// @Tags		backup
+// @Param		payload	body	dto.BackupDTO	true	"Backup as downloaded"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.RestoreResponse]
+func (bh *backupHandler) Restore(c echo.Context) error {
+	var payload dto.BackupDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	restored, err := bh.bs.Restore(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrInvalidBackup) || errors.Is(err, service.ErrUnsupportedBackupVersion) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.RestoreResponse](
+			true, "Backup restored",
+			response.RestoreResponse{
+				Accounts:           len(restored.Accounts),
+				Categories:         len(restored.Categories),
+				Expenses:           len(restored.Expenses),
+				Incomes:            len(restored.Incomes),
+				Transfers:          len(restored.Transfers),
+				Budgets:            len(restored.Budgets),
+				RecurringTemplates: len(restored.RecurringTemplates),
+				ImportMappings:     len(restored.ImportMappings),
+				ExchangeRates:      len(restored.ExchangeRates),
+				Advice:             len(restored.Advice),
+			},
+		),
+	)
+}
@@ -0,0 +1,399 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type BudgetHandler interface {
+	Create(c echo.Context) error
+	GetOneByID(c echo.Context) error
+	GetMany(c echo.Context) error
+	GetStatus(c echo.Context) error
+	UpdateOneByID(c echo.Context) error
+	DeleteOneByID(c echo.Context) error
+}
+
+type budgetHandler struct {
+	bs service.BudgetService
+}
+
+func NewBudgetHandler(bs service.BudgetService) *budgetHandler {
+	return &budgetHandler{bs}
+}
+
+// @Router		/budgets [post]
+// @Summary	Create a monthly budget for a category
+// @Tags		budget
+// @Param		payload	body	dto.CreateBudgetDTO	true	"Create budget DTO"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.CommonBudgetResponse]
+func (bh *budgetHandler) Create(c echo.Context) error {
+	var payload dto.CreateBudgetDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	budget, err := bh.bs.Create(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrInvalidAmount) ||
+			errors.Is(err, service.ErrAmountNotPositive) ||
+			errors.Is(err, service.ErrUnknownCurrency) ||
+			errors.Is(err, service.ErrBudgetCurrencyRequired) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrCategoryNotBelongedToUser) || errors.Is(err, service.ErrAccountNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.CommonBudgetResponse](
+			true, "Budget created",
+			response.CommonBudgetResponse{
+				ID:           budget.ID,
+				UserID:       budget.UserID,
+				CategoryID:   budget.CategoryID,
+				AccountID:    budget.AccountID,
+				Currency:     currencyCode(budget.Currency),
+				MonthlyLimit: formatAmount(budget.MonthlyLimit, budget.Currency),
+				CreatedAt:    budget.CreatedAt,
+				UpdatedAt:    budget.UpdatedAt,
+			},
+		),
+	)
+}
+
+// @Router		/budgets/{budgetID} [get]
+// @Summary	Get one budget by ID
+// @Tags		budget
+// @Param		budgetID	path	string	true	"Budget ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.CommonBudgetResponse]
+func (bh *budgetHandler) GetOneByID(c echo.Context) error {
+	budgetID, err := strconv.Atoi(c.Param("budgetID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	budget, err := bh.bs.GetOneByID(int(user.ID), budgetID)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonBudgetResponse](
+			true, "Budget found",
+			response.CommonBudgetResponse{
+				ID:           budget.ID,
+				UserID:       budget.UserID,
+				CategoryID:   budget.CategoryID,
+				AccountID:    budget.AccountID,
+				Currency:     currencyCode(budget.Currency),
+				MonthlyLimit: formatAmount(budget.MonthlyLimit, budget.Currency),
+				CreatedAt:    budget.CreatedAt,
+				UpdatedAt:    budget.UpdatedAt,
+			},
+		),
+	)
+}
+
+// @Router		/budgets [get]
+// @Summary	Get many budgets
+// @Tags		budget
+// @Param		itemPerPage	query	string	true	"Amount of items per page"
+// @Param		page		query	string	true	"Page number"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[[]response.CommonBudgetResponse]
+func (bh *budgetHandler) GetMany(c echo.Context) error {
+	itemPerPage, err := strconv.Atoi(c.QueryParam("itemPerPage"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	budgets, err := bh.bs.GetManyBelongedToUser(int(user.ID), itemPerPage, page)
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	responses := make([]response.CommonBudgetResponse, 0, len(budgets))
+	for _, b := range budgets {
+		responses = append(responses, response.CommonBudgetResponse{
+			ID:           b.ID,
+			UserID:       b.UserID,
+			CategoryID:   b.CategoryID,
+			AccountID:    b.AccountID,
+			Currency:     currencyCode(b.Currency),
+			MonthlyLimit: formatAmount(b.MonthlyLimit, b.Currency),
+			CreatedAt:    b.CreatedAt,
+			UpdatedAt:    b.UpdatedAt,
+		})
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[[]response.CommonBudgetResponse](
+			true, "Budgets found", responses,
+		),
+	)
+}
+
+// @Router		/budgets/{budgetID}/status [get]
+// @Summary	Get spending against a budget over a period
+// @Tags		budget
+// @Param		budgetID	path	string	true	"Budget ID"
+// @Param		from		query	string	false	"Start of the period (YYYY-MM-DD or RFC 3339); defaults to the current month together with to"
+// @Param		to			query	string	false	"End of the period (YYYY-MM-DD, inclusive, or RFC 3339)"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.BudgetStatusResponse]
+func (bh *budgetHandler) GetStatus(c echo.Context) error {
+	budgetID, err := strconv.Atoi(c.Param("budgetID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	period, err := util.ParsePeriod(c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	status, err := bh.bs.GetStatus(int(user.ID), budgetID, period)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidBudgetPeriod) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrReportingCurrencyRequired) || errors.Is(err, service.ErrExchangeRateNotFound) {
+			return c.JSON(
+				http.StatusUnprocessableEntity,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.BudgetStatusResponse](
+			true, "Budget status found",
+			response.BudgetStatusResponse{
+				BudgetID:   status.Budget.ID,
+				CategoryID: status.Budget.CategoryID,
+				AccountID:  status.Budget.AccountID,
+				From:       status.From,
+				To:         status.To,
+				Currency:   status.Currency.Code,
+				Limit:      formatAmount(status.Limit, &status.Currency),
+				Spent:      formatAmount(status.Spent, &status.Currency),
+				Remaining:  formatAmount(status.Remaining, &status.Currency),
+				Projected:  formatAmount(status.Projected, &status.Currency),
+			},
+		),
+	)
+}
+
+// @Router		/budgets/{budgetID} [put]
+// @Summary	Update budget
+// @Tags		budget
+// @Param		budgetID	path	string				true	"Budget ID"
+// @Param		payload		body	dto.UpdateBudgetDTO	true	"Update budget DTO"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.CommonBudgetResponse]
+func (bh *budgetHandler) UpdateOneByID(c echo.Context) error {
+	budgetID, err := strconv.Atoi(c.Param("budgetID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var payload dto.UpdateBudgetDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	budget, err := bh.bs.UpdateOneByID(int(user.ID), budgetID, payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrInvalidAmount) ||
+			errors.Is(err, service.ErrAmountNotPositive) ||
+			errors.Is(err, service.ErrUnknownCurrency) ||
+			errors.Is(err, service.ErrBudgetCurrencyRequired) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrCategoryNotBelongedToUser) || errors.Is(err, service.ErrAccountNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonBudgetResponse](
+			true, "Budget updated",
+			response.CommonBudgetResponse{
+				ID:           budget.ID,
+				UserID:       budget.UserID,
+				CategoryID:   budget.CategoryID,
+				AccountID:    budget.AccountID,
+				Currency:     currencyCode(budget.Currency),
+				MonthlyLimit: formatAmount(budget.MonthlyLimit, budget.Currency),
+				CreatedAt:    budget.CreatedAt,
+				UpdatedAt:    budget.UpdatedAt,
+			},
+		),
+	)
+}
+
+// @Router		/budgets/{budgetID} [delete]
+// @Summary	Delete one budget by ID
+// @Tags		budget
+// @Param		budgetID	path	string	true	"Budget ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[any]
+func (bh *budgetHandler) DeleteOneByID(c echo.Context) error {
+	budgetID, err := strconv.Atoi(c.Param("budgetID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	if err := bh.bs.DeleteOneByID(int(user.ID), budgetID); err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](
+			true, "Budget deleted", nil,
+		),
+	)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -87,24 +88,22 @@ func (ch *categoryHandler) GetOneByID(c echo.Context) error {
 		)
 	}
 
-	category, err := ch.cs.GetOneByID(categoryID)
+	user := c.Get("user").(model.User)
+	category, err := ch.cs.GetOneByID(int(user.ID), categoryID)
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 		)
 	}
 
-	user := c.Get("user").(model.User)
-	if user.ID != category.ID {
-		c.Logger().Error("Not Allowed")
-		return c.JSON(
-			http.StatusForbidden,
-			util.CreateBaseResponse[any](false, "Forbidden", nil),
-		)
-	}
-
 	return c.JSON(
 		http.StatusOK,
 		util.CreateBaseResponse[response.CommonCategoryResponse](
@@ -124,23 +123,12 @@ func (ch *categoryHandler) GetOneByID(c echo.Context) error {
 //	@Router		/categories [get]
 //	@Summary	Get many categories
 //	@Tags		category
-//	@Param		itemPerPage	query	string	true	"Amount of items per page"
-//	@Param		page		query	string	true	"Page number"
+//	@Param		itemPerPage	query	string	false	"Amount of items per page, 10 by default"
+//	@Param		cursor		query	string	false	"Cursor of the page to get, from the pagination of another page"
 //	@Security	Bearer
 //	@Success	200	{object}	util.BaseResponse[[]response.CommonCategoryResponse]
 func (ch *categoryHandler) GetMany(c echo.Context) error {
-	itemPerPage := 10
-	page := 1
-	var err error
-	itemPerPage, err = strconv.Atoi(c.QueryParam("itemPerPage"))
-	if err != nil {
-		c.Logger().Error(err)
-		return c.JSON(
-			http.StatusBadRequest,
-			util.CreateBaseResponse[any](false, "Bad Request", nil),
-		)
-	}
-	page, err = strconv.Atoi(c.QueryParam("page"))
+	itemPerPage, err := parseItemPerPage(c.QueryParam("itemPerPage"))
 	if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
@@ -150,17 +138,23 @@ func (ch *categoryHandler) GetMany(c echo.Context) error {
 	}
 
 	user := c.Get("user").(model.User)
-	categories, err := ch.cs.GetMany(int(user.ID), itemPerPage, page)
+	categories, err := ch.cs.GetMany(int(user.ID), itemPerPage, c.QueryParam("cursor"))
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidCursor) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 		)
 	}
 
-	responses := make([]response.CommonCategoryResponse, 0, len(categories))
-	for _, e := range categories {
+	responses := make([]response.CommonCategoryResponse, 0, len(categories.Items))
+	for _, e := range categories.Items {
 		responses = append(responses, response.CommonCategoryResponse{
 			ID:        e.ID,
 			UserID:    e.UserID,
@@ -171,10 +165,7 @@ func (ch *categoryHandler) GetMany(c echo.Context) error {
 	}
 	return c.JSON(
 		http.StatusOK,
-		util.CreateBaseResponse[[]response.CommonCategoryResponse](
-			true, "Categories found",
-			responses,
-		),
+		util.CreatePaginatedResponse("Categories found", responses, pagination(categories)),
 	)
 }
 
@@ -193,26 +184,15 @@ func (ch *categoryHandler) DeleteOneByID(c echo.Context) error {
 		)
 	}
 
-	category, err := ch.cs.GetOneByID(categoryID)
-	if err != nil {
-		c.Logger().Error(err)
-		return c.JSON(
-			http.StatusInternalServerError,
-			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
-		)
-	}
-
 	user := c.Get("user").(model.User)
-	if user.ID != category.ID {
-		c.Logger().Error("Not Allowed")
-		return c.JSON(
-			http.StatusForbidden,
-			util.CreateBaseResponse[any](false, "Forbidden", nil),
-		)
-	}
-
-	if err := ch.cs.DeleteOneByID(categoryID); err != nil {
+	if err := ch.cs.DeleteOneByID(int(user.ID), categoryID); err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/importer"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type CurrencyHandler interface {
+	GetReportingCurrency(c echo.Context) error
+	SetReportingCurrency(c echo.Context) error
+	ImportExchangeRates(c echo.Context) error
+	GetExchangeRates(c echo.Context) error
+}
+
+type currencyHandler struct {
+	cs service.CurrencyService
+}
+
+func NewCurrencyHandler(cs service.CurrencyService) *currencyHandler {
+	return &currencyHandler{cs}
+}
+
+// @Router		/reporting-currency [get]
+// @Summary	Get the currency that totals across accounts are reported in
+// @Tags		currency
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.ReportingCurrencyResponse]
+func (ch *currencyHandler) GetReportingCurrency(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	currency, err := ch.cs.GetReportingCurrency(int(user.ID))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.ReportingCurrencyResponse](
+			true, "Reporting currency found", reportingCurrencyResponse(currency),
+		),
+	)
+}
+
+// @Router		/reporting-currency [put]
+// @Summary	Set the currency that totals across accounts are reported in
+// @Description	Reports and budgets convert every amount into this currency at the rate valid on the day it was spent. An empty currency clears it.
+// @Tags		currency
+// @Param		payload	body	dto.SetReportingCurrencyDTO	true	"ISO 4217 currency code"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.ReportingCurrencyResponse]
+func (ch *currencyHandler) SetReportingCurrency(c echo.Context) error {
+	var payload dto.SetReportingCurrencyDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	currency, err := ch.cs.SetReportingCurrency(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrUnknownCurrency) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.ReportingCurrencyResponse](
+			true, "Reporting currency updated", reportingCurrencyResponse(currency),
+		),
+	)
+}
+
+// @Router		/exchange-rates/imports [post]
+// @Summary	Load exchange rates from a file
+// @Description	Accepts the ECB's XML and CSV reference rate files, or a CSV with date, base, quote and rate columns. Rates already stored for the same day and currencies are replaced.
+// @Tags		currency
+// @Accept		multipart/form-data
+// @Param		file	formData	file	true	"Rate file"
+// @Param		base	formData	string	false	"Currency the rates are quoted against, for files that do not say; defaults to EUR"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.ImportExchangeRatesResponse]
+func (ch *currencyHandler) ImportExchangeRates(c echo.Context) error {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	file, err := fh.Open()
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	defer file.Close()
+
+	user := c.Get("user").(model.User)
+	imported, err := ch.cs.ImportExchangeRates(int(user.ID), file, c.FormValue("base"))
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, importer.ErrMalformedExchangeRates) || errors.Is(err, service.ErrTooManyExchangeRates) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.ImportExchangeRatesResponse](
+			true, "Exchange rates imported",
+			response.ImportExchangeRatesResponse{Imported: imported},
+		),
+	)
+}
+
+// @Router		/exchange-rates [get]
+// @Summary	Get many exchange rates, newest first
+// @Tags		currency
+// @Param		base		query	string	false	"Base currency"
+// @Param		quote		query	string	false	"Quote currency"
+// @Param		from		query	string	false	"Only include rates on or after this date (YYYY-MM-DD)"
+// @Param		to			query	string	false	"Only include rates on or before this date (YYYY-MM-DD)"
+// @Param		itemPerPage	query	string	true	"Amount of items per page"
+// @Param		page		query	string	true	"Page number"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[[]response.CommonExchangeRateResponse]
+func (ch *currencyHandler) GetExchangeRates(c echo.Context) error {
+	itemPerPage, err := strconv.Atoi(c.QueryParam("itemPerPage"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	period, err := util.ParsePeriod(c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	rates, err := ch.cs.GetExchangeRates(int(user.ID), c.QueryParam("base"), c.QueryParam("quote"), period, itemPerPage, page)
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	responses := make([]response.CommonExchangeRateResponse, 0, len(rates))
+	for _, r := range rates {
+		responses = append(responses, response.CommonExchangeRateResponse{
+			Date:          r.Date.UTC().Format(util.DateLayout),
+			BaseCurrency:  r.BaseCurrency,
+			QuoteCurrency: r.QuoteCurrency,
+			Rate:          r.Rate,
+			UpdatedAt:     r.UpdatedAt,
+		})
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[[]response.CommonExchangeRateResponse](
+			true, "Exchange rates found", responses,
+		),
+	)
+}
+
+func reportingCurrencyResponse(currency *model.Currency) response.ReportingCurrencyResponse {
+	if currency == nil {
+		return response.ReportingCurrencyResponse{}
+	}
+
+	return response.ReportingCurrencyResponse{Currency: currency.Code}
+}
@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
 	"github.com/muhrizqiardi/spendtracker/internal/response"
 	"github.com/muhrizqiardi/spendtracker/internal/service"
 	"github.com/muhrizqiardi/spendtracker/internal/util"
@@ -58,6 +61,24 @@ func (eh *expenseHandler) Create(c echo.Context) error {
 	expense, err := eh.es.Create(int(user.ID), accountID, payload)
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidTimezone) || errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrAmountNotPositive) || errors.Is(err, service.ErrInvalidTag) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		if errors.Is(err, service.ErrCategoryNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
@@ -72,9 +93,13 @@ func (eh *expenseHandler) Create(c echo.Context) error {
 				ID:          int(expense.ID),
 				UserID:      expense.UserID,
 				AccountID:   expense.AccountID,
+				CategoryID:  expense.CategoryID,
 				Name:        expense.Name,
 				Description: expense.Description,
-				Amount:      expense.Amount,
+				Amount:      formatAmount(expense.Amount, accountCurrency(expense.Account)),
+				OccurredAt:  util.InTimezone(expense.OccurredAt, expense.Timezone),
+				Timezone:    expense.Timezone,
+				Tags:        tagNames(expense.Tags),
 				CreatedAt:   expense.CreatedAt,
 				UpdatedAt:   expense.UpdatedAt,
 			},
@@ -98,9 +123,16 @@ func (eh *expenseHandler) GetOneByID(c echo.Context) error {
 		)
 	}
 
-	expense, err := eh.es.GetOneByID(expenseID)
+	user := c.Get("user").(model.User)
+	expense, err := eh.es.GetOneByID(int(user.ID), expenseID)
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
@@ -115,9 +147,13 @@ func (eh *expenseHandler) GetOneByID(c echo.Context) error {
 				ID:          int(expense.ID),
 				UserID:      expense.UserID,
 				AccountID:   expense.AccountID,
+				CategoryID:  expense.CategoryID,
 				Name:        expense.Name,
 				Description: expense.Description,
-				Amount:      expense.Amount,
+				Amount:      formatAmount(expense.Amount, accountCurrency(expense.Account)),
+				OccurredAt:  util.InTimezone(expense.OccurredAt, expense.Timezone),
+				Timezone:    expense.Timezone,
+				Tags:        tagNames(expense.Tags),
 				CreatedAt:   expense.CreatedAt,
 				UpdatedAt:   expense.UpdatedAt,
 			},
@@ -128,18 +164,23 @@ func (eh *expenseHandler) GetOneByID(c echo.Context) error {
 // @Router		/expenses [get]
 // @Summary	Get many expenses
 // @Tags		expense
-// @Param		accountId	query	string	false	"Account ID"
-// @Param		categoryId	query	string	false	"Category ID"
-// @Param		itemPerPage	query	string	true	"Amount of items per page"
-// @Param		page		query	string	true	"Page number"
+// @Param		accountId	query	string	false	"Only include expenses of these accounts; repeat or separate IDs with commas"
+// @Param		categoryId	query	string	false	"Only include expenses in these categories; repeat or separate IDs with commas"
+// @Param		from		query	string	false	"Only include transactions that occurred at or after this date (YYYY-MM-DD) or time (RFC 3339)"
+// @Param		to			query	string	false	"Only include transactions that occurred before this time, or on or before this date"
+// @Param		minAmount	query	string	false	"Only include expenses of at least this amount in their account's currency"
+// @Param		maxAmount	query	string	false	"Only include expenses of at most this amount in their account's currency"
+// @Param		tag			query	string	false	"Only include expenses with any of these tags; repeat or separate tags with commas"
+// @Param		q			query	string	false	"Only include expenses whose name or description contains every word of this"
+// @Param		sortBy		query	string	false	"Field to sort by: occurredAt (default), amount, name, description, createdAt or updatedAt"
+// @Param		order		query	string	false	"Sort order: desc (default) or asc"
+// @Param		itemPerPage	query	string	false	"Amount of items per page, 10 by default"
+// @Param		cursor		query	string	false	"Cursor of the page to get, from the pagination of another page; only valid with the same sort"
 // @Security	Bearer
 // @Success	200	{object}	util.BaseResponse[[]response.CommonExpenseResponse]
 func (eh *expenseHandler) GetMany(c echo.Context) error {
 	user := c.Get("user").(model.User)
-	itemPerPage := 10
-	page := 1
-	var err error
-	itemPerPage, err = strconv.Atoi(c.QueryParam("itemPerPage"))
+	itemPerPage, err := parseItemPerPage(c.QueryParam("itemPerPage"))
 	if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
@@ -147,7 +188,8 @@ func (eh *expenseHandler) GetMany(c echo.Context) error {
 			util.CreateBaseResponse[any](false, "Bad Request", nil),
 		)
 	}
-	page, err = strconv.Atoi(c.QueryParam("page"))
+
+	period, err := util.ParsePeriod(c.QueryParam("from"), c.QueryParam("to"))
 	if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
@@ -155,157 +197,72 @@ func (eh *expenseHandler) GetMany(c echo.Context) error {
 			util.CreateBaseResponse[any](false, "Bad Request", nil),
 		)
 	}
-
-	if c.QueryParam("categoryId") != "" && c.QueryParam("accountId") != "" {
-		categoryID, err := strconv.Atoi(c.QueryParam("categoryId"))
-		if err != nil {
-			c.Logger().Error(err)
-			return c.JSON(
-				http.StatusBadRequest,
-				util.CreateBaseResponse[any](false, "Bad Request", nil),
-			)
-		}
-		accountID, err := strconv.Atoi(c.QueryParam("accountId"))
-		if err != nil {
-			c.Logger().Error(err)
-			return c.JSON(
-				http.StatusBadRequest,
-				util.CreateBaseResponse[any](false, "Bad Request", nil),
-			)
-		}
-
-		expenses, err := eh.es.GetManyBelongedToCategoryAccount(int(user.ID), categoryID, accountID, itemPerPage, page)
-		if err != nil {
-			c.Logger().Error(err)
-			return c.JSON(
-				http.StatusInternalServerError,
-				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
-			)
-		}
-
-		responses := make([]response.CommonExpenseResponse, 0, len(expenses))
-		for _, e := range expenses {
-			responses = append(responses, response.CommonExpenseResponse{
-				ID:          int(e.ID),
-				UserID:      e.UserID,
-				AccountID:   e.AccountID,
-				Name:        e.Name,
-				Description: e.Description,
-				Amount:      e.Amount,
-				CreatedAt:   e.CreatedAt,
-				UpdatedAt:   e.UpdatedAt,
-			})
-		}
+	accountIDs, err := parseIDs(c.QueryParams()["accountId"])
+	if err != nil {
+		c.Logger().Error(err)
 		return c.JSON(
-			http.StatusOK,
-			util.CreateBaseResponse[[]response.CommonExpenseResponse](
-				true, "Expenses found", responses,
-			),
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
 		)
-	} else if c.QueryParam("accountId") == "" {
-		categoryID, err := strconv.Atoi(c.QueryParam("categoryId"))
-		if err != nil {
-			c.Logger().Error(err)
-			return c.JSON(
-				http.StatusBadRequest,
-				util.CreateBaseResponse[any](false, "Bad Request", nil),
-			)
-		}
-		expenses, err := eh.es.GetManyBelongedToCategory(int(user.ID), categoryID, itemPerPage, page)
-		if err != nil {
-			c.Logger().Error(err)
-			return c.JSON(
-				http.StatusInternalServerError,
-				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
-			)
-		}
-
-		responses := make([]response.CommonExpenseResponse, 0, len(expenses))
-		for _, e := range expenses {
-			responses = append(responses, response.CommonExpenseResponse{
-				ID:          int(e.ID),
-				UserID:      e.UserID,
-				AccountID:   e.AccountID,
-				Name:        e.Name,
-				Description: e.Description,
-				Amount:      e.Amount,
-				CreatedAt:   e.CreatedAt,
-				UpdatedAt:   e.UpdatedAt,
-			})
-		}
+	}
+	categoryIDs, err := parseIDs(c.QueryParams()["categoryId"])
+	if err != nil {
+		c.Logger().Error(err)
 		return c.JSON(
-			http.StatusOK,
-			util.CreateBaseResponse[[]response.CommonExpenseResponse](
-				true, "Expenses found", responses,
-			),
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
 		)
-	} else if c.QueryParam("categoryId") == "" {
-		accountID, err := strconv.Atoi(c.QueryParam("accountId"))
-		if err != nil {
-			c.Logger().Error(err)
+	}
+
+	expenses, err := eh.es.GetManyBelongedToUser(int(user.ID), dto.ExpenseFilterDTO{
+		Period:      period,
+		AccountIDs:  accountIDs,
+		CategoryIDs: categoryIDs,
+		MinAmount:   money.Decimal(c.QueryParam("minAmount")),
+		MaxAmount:   money.Decimal(c.QueryParam("maxAmount")),
+		Tags:        parseTags(c.QueryParams()["tag"]),
+		Search:      c.QueryParam("q"),
+		SortBy:      c.QueryParam("sortBy"),
+		Order:       c.QueryParam("order"),
+	}, itemPerPage, c.QueryParam("cursor"))
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidExpenseSort) ||
+			errors.Is(err, service.ErrInvalidAmount) ||
+			errors.Is(err, service.ErrInvalidTag) ||
+			errors.Is(err, service.ErrInvalidCursor) {
 			return c.JSON(
 				http.StatusBadRequest,
-				util.CreateBaseResponse[any](false, "Bad Request", nil),
-			)
-		}
-		expenses, err := eh.es.GetManyBelongedToAccount(int(user.ID), accountID, itemPerPage, page)
-		if err != nil {
-			c.Logger().Error(err)
-			return c.JSON(
-				http.StatusInternalServerError,
-				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+				util.CreateBaseResponse[any](false, err.Error(), nil),
 			)
 		}
-
-		responses := make([]response.CommonExpenseResponse, 0, len(expenses))
-		for _, e := range expenses {
-			responses = append(responses, response.CommonExpenseResponse{
-				ID:          int(e.ID),
-				UserID:      e.UserID,
-				AccountID:   e.AccountID,
-				Name:        e.Name,
-				Description: e.Description,
-				Amount:      e.Amount,
-				CreatedAt:   e.CreatedAt,
-				UpdatedAt:   e.UpdatedAt,
-			})
-		}
 		return c.JSON(
-			http.StatusOK,
-			util.CreateBaseResponse[[]response.CommonExpenseResponse](
-				true, "Expenses found", responses,
-			),
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
 		)
-	} else {
-		expenses, err := eh.es.GetManyBelongedToUser(int(user.ID), itemPerPage, page)
-		if err != nil {
-			c.Logger().Error(err)
-			return c.JSON(
-				http.StatusInternalServerError,
-				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
-			)
-		}
+	}
 
-		responses := make([]response.CommonExpenseResponse, 0, len(expenses))
-		for _, e := range expenses {
-			responses = append(responses, response.CommonExpenseResponse{
-				ID:          int(e.ID),
-				UserID:      e.UserID,
-				AccountID:   e.AccountID,
-				Name:        e.Name,
-				Description: e.Description,
-				Amount:      e.Amount,
-				CreatedAt:   e.CreatedAt,
-				UpdatedAt:   e.UpdatedAt,
-			})
-		}
-		return c.JSON(
-			http.StatusOK,
-			util.CreateBaseResponse[[]response.CommonExpenseResponse](
-				true, "Expenses found", responses,
-			),
-		)
+	responses := make([]response.CommonExpenseResponse, 0, len(expenses.Items))
+	for _, e := range expenses.Items {
+		responses = append(responses, response.CommonExpenseResponse{
+			ID:          int(e.ID),
+			UserID:      e.UserID,
+			AccountID:   e.AccountID,
+			CategoryID:  e.CategoryID,
+			Name:        e.Name,
+			Description: e.Description,
+			Amount:      formatAmount(e.Amount, accountCurrency(e.Account)),
+			OccurredAt:  util.InTimezone(e.OccurredAt, e.Timezone),
+			Timezone:    e.Timezone,
+			Tags:        tagNames(e.Tags),
+			CreatedAt:   e.CreatedAt,
+			UpdatedAt:   e.UpdatedAt,
+		})
 	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreatePaginatedResponse("Expenses found", responses, pagination(expenses)),
+	)
 }
 
 // @Router		/expenses/{expenseID} [put]
@@ -335,17 +292,27 @@ func (eh *expenseHandler) UpdateOneByID(c echo.Context) error {
 	}
 
 	user := c.Get("user").(model.User)
-	if expense, err := eh.es.GetOneByID(expenseID); expense.UserID != user.ID || err != nil {
-		c.Logger().Error(err)
-		return c.JSON(
-			http.StatusForbidden,
-			util.CreateBaseResponse[any](false, "Forbidden", nil),
-		)
-	}
-
-	expense, err := eh.es.UpdateOneByID(expenseID, payload)
+	expense, err := eh.es.UpdateOneByID(int(user.ID), expenseID, payload)
 	if err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		if errors.Is(err, service.ErrInvalidTimezone) || errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrAmountNotPositive) || errors.Is(err, service.ErrInvalidTag) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrCategoryNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
@@ -360,9 +327,13 @@ func (eh *expenseHandler) UpdateOneByID(c echo.Context) error {
 				ID:          int(expense.ID),
 				UserID:      expense.UserID,
 				AccountID:   expense.AccountID,
+				CategoryID:  expense.CategoryID,
 				Name:        expense.Name,
 				Description: expense.Description,
-				Amount:      expense.Amount,
+				Amount:      formatAmount(expense.Amount, accountCurrency(expense.Account)),
+				OccurredAt:  util.InTimezone(expense.OccurredAt, expense.Timezone),
+				Timezone:    expense.Timezone,
+				Tags:        tagNames(expense.Tags),
 				CreatedAt:   expense.CreatedAt,
 				UpdatedAt:   expense.UpdatedAt,
 			},
@@ -386,8 +357,15 @@ func (eh *expenseHandler) DeleteOneByID(c echo.Context) error {
 		)
 	}
 
-	if err := eh.es.DeleteOneByID(expenseID); err != nil {
+	user := c.Get("user").(model.User)
+	if err := eh.es.DeleteOneByID(int(user.ID), expenseID); err != nil {
 		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
 		return c.JSON(
 			http.StatusInternalServerError,
 			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
@@ -401,3 +379,43 @@ func (eh *expenseHandler) DeleteOneByID(c echo.Context) error {
 		),
 	)
 }
+
+// parseIDs reads IDs given as repeated query parameters, each of which may
+// also hold several separated by commas.
+func parseIDs(values []string) ([]uint, error) {
+	var ids []uint
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			if field == "" {
+				continue
+			}
+			id, err := strconv.ParseUint(field, 10, 0)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, uint(id))
+		}
+	}
+
+	return ids, nil
+}
+
+// parseTags reads tags given as repeated query parameters, each of which
+// may also hold several separated by commas.
+func parseTags(values []string) []string {
+	var tags []string
+	for _, value := range values {
+		tags = append(tags, strings.Split(value, ",")...)
+	}
+
+	return tags
+}
+
+func tagNames(tags []model.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type ExportHandler interface {
+	Ledger(c echo.Context) error
+	Beancount(c echo.Context) error
+}
+
+type exportHandler struct {
+	es service.ExportService
+}
+
+func NewExportHandler(es service.ExportService) *exportHandler {
+	return &exportHandler{es}
+}
+
+// @Router		/export/ledger [get]
+// @Summary	Download the current user's records as a ledger journal
+// @Description	The journal is readable by both ledger and hledger. Accounts are under Assets, categories under Expenses, and amounts are in each account's currency.
+// @Tags		export
+// @Produce	plain
+// @Security	Bearer
+// @Success	200	{string}	string
+func (eh *exportHandler) Ledger(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	out, err := eh.es.Ledger(int(user.ID))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return attachment(c, "spendtracker.ledger", out)
+}
+
+// @Router		/export/beancount [get]
+// @Summary	Download the current user's records as a beancount file
+// @Description	Accounts are under Assets, categories under Expenses, and amounts are in each account's currency.
+// @Tags		export
+// @Produce	plain
+// @Security	Bearer
+// @Success	200	{string}	string
+func (eh *exportHandler) Beancount(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	out, err := eh.es.Beancount(int(user.ID))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return attachment(c, "spendtracker.beancount", out)
+}
+
+func attachment(c echo.Context, filename string, out []byte) error {
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", filename),
+	)
+	return c.Blob(http.StatusOK, "text/plain; charset=utf-8", out)
+}
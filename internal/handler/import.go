@@ -0,0 +1,388 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/importer"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type ImportHandler interface {
+	CreateMapping(c echo.Context) error
+	GetMappings(c echo.Context) error
+	DeleteMapping(c echo.Context) error
+	ImportCSV(c echo.Context) error
+	ImportOFX(c echo.Context) error
+}
+
+type importHandler struct {
+	is service.ImportService
+}
+
+func NewImportHandler(is service.ImportService) *importHandler {
+	return &importHandler{is}
+}
+
+// @Router		/import-mappings [post]
+// @Summary	Save how a bank lays out its CSV statements
+// @Tags		import
+// @Param		payload	body	dto.CreateImportMappingDTO	true	"Create import mapping DTO"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.CommonImportMappingResponse]
+func (ih *importHandler) CreateMapping(c echo.Context) error {
+	var payload dto.CreateImportMappingDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	mapping, err := ih.is.CreateMapping(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, importer.ErrInvalidDateFormat) || errors.Is(err, service.ErrInvalidTimezone) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.CommonImportMappingResponse](
+			true, "Import mapping created", importMappingResponse(mapping),
+		),
+	)
+}
+
+// @Router		/import-mappings [get]
+// @Summary	Get the saved import mappings
+// @Tags		import
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[[]response.CommonImportMappingResponse]
+func (ih *importHandler) GetMappings(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	mappings, err := ih.is.GetMappings(int(user.ID))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	responses := make([]response.CommonImportMappingResponse, 0, len(mappings))
+	for _, m := range mappings {
+		responses = append(responses, importMappingResponse(m))
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[[]response.CommonImportMappingResponse](
+			true, "Import mappings found", responses,
+		),
+	)
+}
+
+// @Router		/import-mappings/{mappingID} [delete]
+// @Summary	Delete one import mapping by ID
+// @Tags		import
+// @Param		mappingID	path	string	true	"Import mapping ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[any]
+func (ih *importHandler) DeleteMapping(c echo.Context) error {
+	mappingID, err := strconv.Atoi(c.Param("mappingID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	if err := ih.is.DeleteMapping(int(user.ID), mappingID); err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](
+			true, "Import mapping deleted", nil,
+		),
+	)
+}
+
+// @Router		/accounts/{accountID}/imports/csv [post]
+// @Summary	Import a CSV bank statement as expenses
+// @Description	Credits are skipped. If any row cannot be read nothing is imported and the rows are returned with their errors. Set dryRun to preview without importing.
+// @Tags		import
+// @Accept		multipart/form-data
+// @Param		accountID	path		string	true	"Account ID"
+// @Param		file		formData	file	true	"CSV statement"
+// @Param		mappingId	formData	int		true	"Import mapping ID"
+// @Param		categoryId	formData	int		true	"Category for the imported expenses"
+// @Param		dryRun		formData	bool	false	"Preview without importing"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.ImportResultResponse]
+// @Success	200	{object}	util.BaseResponse[response.ImportResultResponse]
+// @Failure	422	{object}	util.BaseResponse[response.ImportResultResponse]
+func (ih *importHandler) ImportCSV(c echo.Context) error {
+	accountID, err := strconv.Atoi(c.Param("accountID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var payload dto.ImportCSVDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	file, err := fh.Open()
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	defer file.Close()
+
+	user := c.Get("user").(model.User)
+	result, err := ih.is.ImportCSV(int(user.ID), accountID, payload, file)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, importer.ErrMalformedStatement) || errors.Is(err, service.ErrImportTooLarge) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrImportHasInvalidRows) {
+			return c.JSON(
+				http.StatusUnprocessableEntity,
+				util.CreateBaseResponse[response.ImportResultResponse](false, err.Error(), importResultResponse(result)),
+			)
+		}
+		if errors.Is(err, service.ErrCategoryNotBelongedToUser) || errors.Is(err, service.ErrImportMappingNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	if result.DryRun {
+		return c.JSON(
+			http.StatusOK,
+			util.CreateBaseResponse[response.ImportResultResponse](
+				true, "Statement previewed", importResultResponse(result),
+			),
+		)
+	}
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.ImportResultResponse](
+			true, "Statement imported", importResultResponse(result),
+		),
+	)
+}
+
+// @Router		/accounts/{accountID}/imports/ofx [post]
+// @Summary	Import an OFX or QFX bank statement as expenses and incomes
+// @Description	Transactions whose FITID was imported into the account before are skipped as duplicates. If any entry cannot be read nothing is imported and the entries are returned with their errors. Set dryRun to preview without importing.
+// @Tags		import
+// @Accept		multipart/form-data
+// @Param		accountID	path		string	true	"Account ID"
+// @Param		file		formData	file	true	"OFX or QFX statement"
+// @Param		categoryId	formData	int		true	"Category for the imported expenses"
+// @Param		timezone	formData	string	false	"Timezone for times without a UTC offset; defaults to UTC"
+// @Param		dryRun		formData	bool	false	"Preview without importing"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.ImportResultResponse]
+// @Success	200	{object}	util.BaseResponse[response.ImportResultResponse]
+// @Failure	422	{object}	util.BaseResponse[response.ImportResultResponse]
+func (ih *importHandler) ImportOFX(c echo.Context) error {
+	accountID, err := strconv.Atoi(c.Param("accountID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var payload dto.ImportOFXDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	file, err := fh.Open()
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	defer file.Close()
+
+	user := c.Get("user").(model.User)
+	result, err := ih.is.ImportOFX(int(user.ID), accountID, payload, file)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrInvalidTimezone) || errors.Is(err, importer.ErrMalformedStatement) || errors.Is(err, service.ErrImportTooLarge) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrImportHasInvalidRows) {
+			return c.JSON(
+				http.StatusUnprocessableEntity,
+				util.CreateBaseResponse[response.ImportResultResponse](false, err.Error(), importResultResponse(result)),
+			)
+		}
+		if errors.Is(err, service.ErrCategoryNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	if result.DryRun {
+		return c.JSON(
+			http.StatusOK,
+			util.CreateBaseResponse[response.ImportResultResponse](
+				true, "Statement previewed", importResultResponse(result),
+			),
+		)
+	}
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.ImportResultResponse](
+			true, "Statement imported", importResultResponse(result),
+		),
+	)
+}
+
+func importMappingResponse(m model.ImportMapping) response.CommonImportMappingResponse {
+	return response.CommonImportMappingResponse{
+		ID:                m.ID,
+		UserID:            m.UserID,
+		Name:              m.Name,
+		Delimiter:         m.Delimiter,
+		HasHeader:         m.HasHeader,
+		DateColumn:        m.DateColumn,
+		DescriptionColumn: m.DescriptionColumn,
+		AmountColumn:      m.AmountColumn,
+		SignConvention:    m.SignConvention,
+		DecimalSeparator:  m.DecimalSeparator,
+		DateFormat:        m.DateFormat,
+		Timezone:          m.Timezone,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+}
+
+func importResultResponse(result model.ImportResult) response.ImportResultResponse {
+	rows := make([]response.ImportRowResponse, 0, len(result.Rows))
+	for _, r := range result.Rows {
+		rows = append(rows, response.ImportRowResponse{
+			Line:          r.Line,
+			Type:          r.Type,
+			ExternalID:    r.ExternalID,
+			OccurredAt:    util.InTimezone(r.OccurredAt, r.Timezone),
+			Name:          r.Name,
+			Description:   r.Description,
+			Amount:        formatAmount(r.Amount, result.Currency),
+			Skipped:       r.Skipped,
+			Duplicate:     r.Duplicate,
+			Error:         r.Error,
+			TransactionID: r.TransactionID,
+		})
+	}
+
+	return response.ImportResultResponse{
+		DryRun:  result.DryRun,
+		Created: result.Created,
+		Skipped: result.Skipped,
+		Failed:  result.Failed,
+		Rows:    rows,
+	}
+}
@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type IncomeHandler interface {
+	Create(c echo.Context) error
+	GetOneByID(c echo.Context) error
+	GetMany(c echo.Context) error
+	UpdateOneByID(c echo.Context) error
+	DeleteOneByID(c echo.Context) error
+}
+
+type incomeHandler struct {
+	is service.IncomeService
+}
+
+func NewIncomeHandler(is service.IncomeService) *incomeHandler {
+	return &incomeHandler{is}
+}
+
+// @Router		/accounts/{accountID}/incomes [post]
+// @Summary	Create income
+// @Tags		income
+// @Param		accountID	path	string				true	"Account ID"
+// @Param		payload		body	dto.CreateIncomeDTO	true	"Create income DTO"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.CommonIncomeResponse]
+func (ih *incomeHandler) Create(c echo.Context) error {
+	accountID, err := strconv.Atoi(c.Param("accountID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var payload dto.CreateIncomeDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	income, err := ih.is.Create(int(user.ID), accountID, payload)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		if errors.Is(err, service.ErrInvalidTimezone) || errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrAmountNotPositive) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.CommonIncomeResponse](
+			true, "Income created",
+			response.CommonIncomeResponse{
+				ID:          int(income.ID),
+				UserID:      income.UserID,
+				AccountID:   income.AccountID,
+				Name:        income.Name,
+				Description: income.Description,
+				Amount:      formatAmount(income.Amount, accountCurrency(income.Account)),
+				OccurredAt:  util.InTimezone(income.OccurredAt, income.Timezone),
+				Timezone:    income.Timezone,
+				CreatedAt:   income.CreatedAt,
+				UpdatedAt:   income.UpdatedAt,
+			},
+		),
+	)
+}
+
+// @Router		/incomes/{incomeID} [get]
+// @Summary	Get one income by ID
+// @Tags		income
+// @Param		incomeID	path	string	true	"Income ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.CommonIncomeResponse]
+func (ih *incomeHandler) GetOneByID(c echo.Context) error {
+	incomeID, err := strconv.Atoi(c.Param("incomeID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	income, err := ih.is.GetOneByID(int(user.ID), incomeID)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonIncomeResponse](
+			true, "Income found",
+			response.CommonIncomeResponse{
+				ID:          int(income.ID),
+				UserID:      income.UserID,
+				AccountID:   income.AccountID,
+				Name:        income.Name,
+				Description: income.Description,
+				Amount:      formatAmount(income.Amount, accountCurrency(income.Account)),
+				OccurredAt:  util.InTimezone(income.OccurredAt, income.Timezone),
+				Timezone:    income.Timezone,
+				CreatedAt:   income.CreatedAt,
+				UpdatedAt:   income.UpdatedAt,
+			},
+		),
+	)
+}
+
+// @Router		/incomes [get]
+// @Summary	Get many incomes
+// @Tags		income
+// @Param		accountId	query	string	false	"Account ID"
+// @Param		from		query	string	false	"Only include transactions that occurred at or after this date (YYYY-MM-DD) or time (RFC 3339)"
+// @Param		to			query	string	false	"Only include transactions that occurred before this time, or on or before this date"
+// @Param		itemPerPage	query	string	true	"Amount of items per page"
+// @Param		page		query	string	true	"Page number"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[[]response.CommonIncomeResponse]
+func (ih *incomeHandler) GetMany(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	itemPerPage, err := strconv.Atoi(c.QueryParam("itemPerPage"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	period, err := util.ParsePeriod(c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var incomes []model.Income
+	if c.QueryParam("accountId") != "" {
+		accountID, err := strconv.Atoi(c.QueryParam("accountId"))
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, "Bad Request", nil),
+			)
+		}
+		incomes, err = ih.is.GetManyBelongedToAccount(int(user.ID), accountID, period, itemPerPage, page)
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusInternalServerError,
+				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+			)
+		}
+	} else {
+		incomes, err = ih.is.GetManyBelongedToUser(int(user.ID), period, itemPerPage, page)
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusInternalServerError,
+				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+			)
+		}
+	}
+
+	responses := make([]response.CommonIncomeResponse, 0, len(incomes))
+	for _, i := range incomes {
+		responses = append(responses, response.CommonIncomeResponse{
+			ID:          int(i.ID),
+			UserID:      i.UserID,
+			AccountID:   i.AccountID,
+			Name:        i.Name,
+			Description: i.Description,
+			Amount:      formatAmount(i.Amount, accountCurrency(i.Account)),
+			OccurredAt:  util.InTimezone(i.OccurredAt, i.Timezone),
+			Timezone:    i.Timezone,
+			CreatedAt:   i.CreatedAt,
+			UpdatedAt:   i.UpdatedAt,
+		})
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[[]response.CommonIncomeResponse](
+			true, "Incomes found", responses,
+		),
+	)
+}
+
+// @Router		/incomes/{incomeID} [put]
+// @Summary	Update income
+// @Tags		income
+// @Param		incomeID	path	string				true	"Income ID"
+// @Param		payload		body	dto.UpdateIncomeDTO	true	"Update income DTO"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.CommonIncomeResponse]
+func (ih *incomeHandler) UpdateOneByID(c echo.Context) error {
+	incomeID, err := strconv.Atoi(c.Param("incomeID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var payload dto.UpdateIncomeDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	income, err := ih.is.UpdateOneByID(int(user.ID), incomeID, payload)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		if errors.Is(err, service.ErrInvalidTimezone) || errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrAmountNotPositive) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonIncomeResponse](
+			true, "Income updated",
+			response.CommonIncomeResponse{
+				ID:          int(income.ID),
+				UserID:      income.UserID,
+				AccountID:   income.AccountID,
+				Name:        income.Name,
+				Description: income.Description,
+				Amount:      formatAmount(income.Amount, accountCurrency(income.Account)),
+				OccurredAt:  util.InTimezone(income.OccurredAt, income.Timezone),
+				Timezone:    income.Timezone,
+				CreatedAt:   income.CreatedAt,
+				UpdatedAt:   income.UpdatedAt,
+			},
+		),
+	)
+}
+
+// @Router		/incomes/{incomeID} [delete]
+// @Summary	Delete one income by ID
+// @Tags		income
+// @Param		incomeID	path	string	true	"Income ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[any]
+func (ih *incomeHandler) DeleteOneByID(c echo.Context) error {
+	incomeID, err := strconv.Atoi(c.Param("incomeID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	if err := ih.is.DeleteOneByID(int(user.ID), incomeID); err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](
+			true, "Income deleted", nil,
+		),
+	)
+}
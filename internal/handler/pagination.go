@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+// defaultItemPerPage is the page size of cursor-paginated listings when
+// none is asked for.
+const defaultItemPerPage = 10
+
+var errInvalidItemPerPage = errors.New("item per page must be a positive number")
+
+// parseItemPerPage reads the page size of a cursor-paginated listing.
+func parseItemPerPage(value string) (int, error) {
+	if value == "" {
+		return defaultItemPerPage, nil
+	}
+	itemPerPage, err := strconv.Atoi(value)
+	if err != nil || itemPerPage < 1 {
+		return 0, errInvalidItemPerPage
+	}
+
+	return itemPerPage, nil
+}
+
+func pagination[T any](page model.Page[T]) util.Pagination {
+	return util.Pagination{
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Total:      page.Total,
+	}
+}
@@ -0,0 +1,365 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type RecurringHandler interface {
+	Create(c echo.Context) error
+	GetOneByID(c echo.Context) error
+	GetMany(c echo.Context) error
+	GetUpcoming(c echo.Context) error
+	UpdateOneByID(c echo.Context) error
+	DeleteOneByID(c echo.Context) error
+}
+
+type recurringHandler struct {
+	rs service.RecurringService
+}
+
+func NewRecurringHandler(rs service.RecurringService) *recurringHandler {
+	return &recurringHandler{rs}
+}
+
+// @Router		/recurring-templates [post]
+// @Summary	Create a recurring expense or income
+// @Tags		recurring
+// @Param		payload	body	dto.CreateRecurringTemplateDTO	true	"Create recurring template DTO"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.CommonRecurringTemplateResponse]
+func (rh *recurringHandler) Create(c echo.Context) error {
+	var payload dto.CreateRecurringTemplateDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	template, err := rh.rs.Create(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrInvalidTimezone) || errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrAmountNotPositive) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrAccountNotBelongedToUser) || errors.Is(err, service.ErrCategoryNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.CommonRecurringTemplateResponse](
+			true, "Recurring template created", recurringTemplateResponse(template),
+		),
+	)
+}
+
+// @Router		/recurring-templates/{templateID} [get]
+// @Summary	Get one recurring template by ID
+// @Tags		recurring
+// @Param		templateID	path	string	true	"Recurring template ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.CommonRecurringTemplateResponse]
+func (rh *recurringHandler) GetOneByID(c echo.Context) error {
+	templateID, err := strconv.Atoi(c.Param("templateID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	template, err := rh.rs.GetOneByID(int(user.ID), templateID)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonRecurringTemplateResponse](
+			true, "Recurring template found", recurringTemplateResponse(template),
+		),
+	)
+}
+
+// @Router		/recurring-templates [get]
+// @Summary	Get many recurring templates
+// @Tags		recurring
+// @Param		itemPerPage	query	string	true	"Amount of items per page"
+// @Param		page		query	string	true	"Page number"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[[]response.CommonRecurringTemplateResponse]
+func (rh *recurringHandler) GetMany(c echo.Context) error {
+	itemPerPage, err := strconv.Atoi(c.QueryParam("itemPerPage"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	templates, err := rh.rs.GetManyBelongedToUser(int(user.ID), itemPerPage, page)
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	responses := make([]response.CommonRecurringTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		responses = append(responses, recurringTemplateResponse(t))
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[[]response.CommonRecurringTemplateResponse](
+			true, "Recurring templates found", responses,
+		),
+	)
+}
+
+// @Router		/recurring-templates/{templateID}/upcoming [get]
+// @Summary	Preview the next occurrences of a recurring template
+// @Tags		recurring
+// @Param		templateID	path	string	true	"Recurring template ID"
+// @Param		count		query	string	false	"Number of occurrences, at most 100; defaults to 5"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.UpcomingOccurrencesResponse]
+func (rh *recurringHandler) GetUpcoming(c echo.Context) error {
+	templateID, err := strconv.Atoi(c.Param("templateID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	count := 5
+	if c.QueryParam("count") != "" {
+		count, err = strconv.Atoi(c.QueryParam("count"))
+		if err != nil || count < 1 {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, "Bad Request", nil),
+			)
+		}
+	}
+
+	user := c.Get("user").(model.User)
+	template, err := rh.rs.GetOneByID(int(user.ID), templateID)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+	occurrences, err := rh.rs.GetUpcoming(int(user.ID), templateID, count)
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	localized := make([]time.Time, 0, len(occurrences))
+	for _, o := range occurrences {
+		localized = append(localized, util.InTimezone(o, template.Timezone))
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.UpcomingOccurrencesResponse](
+			true, "Upcoming occurrences found",
+			response.UpcomingOccurrencesResponse{
+				TemplateID:  template.ID,
+				Occurrences: localized,
+			},
+		),
+	)
+}
+
+// @Router		/recurring-templates/{templateID} [put]
+// @Summary	Update what a recurring template records; its schedule cannot be changed
+// @Tags		recurring
+// @Param		templateID	path	string							true	"Recurring template ID"
+// @Param		payload		body	dto.UpdateRecurringTemplateDTO	true	"Update recurring template DTO"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.CommonRecurringTemplateResponse]
+func (rh *recurringHandler) UpdateOneByID(c echo.Context) error {
+	templateID, err := strconv.Atoi(c.Param("templateID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var payload dto.UpdateRecurringTemplateDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	template, err := rh.rs.UpdateOneByID(int(user.ID), templateID, payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrInvalidAmount) || errors.Is(err, service.ErrAmountNotPositive) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrAccountNotBelongedToUser) || errors.Is(err, service.ErrCategoryNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonRecurringTemplateResponse](
+			true, "Recurring template updated", recurringTemplateResponse(template),
+		),
+	)
+}
+
+// @Router		/recurring-templates/{templateID} [delete]
+// @Summary	Delete one recurring template by ID; recorded transactions are kept
+// @Tags		recurring
+// @Param		templateID	path	string	true	"Recurring template ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[any]
+func (rh *recurringHandler) DeleteOneByID(c echo.Context) error {
+	templateID, err := strconv.Atoi(c.Param("templateID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	if err := rh.rs.DeleteOneByID(int(user.ID), templateID); err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](
+			true, "Recurring template deleted", nil,
+		),
+	)
+}
+
+func recurringTemplateResponse(t model.RecurringTemplate) response.CommonRecurringTemplateResponse {
+	res := response.CommonRecurringTemplateResponse{
+		ID:           t.ID,
+		UserID:       t.UserID,
+		Type:         t.Type,
+		AccountID:    t.AccountID,
+		CategoryID:   t.CategoryID,
+		Name:         t.Name,
+		Description:  t.Description,
+		Amount:       formatAmount(t.Amount, accountCurrency(t.Account)),
+		Frequency:    t.Frequency,
+		Interval:     t.Interval,
+		StartAt:      util.InTimezone(t.StartAt, t.Timezone),
+		Until:        t.Until,
+		Count:        t.Count,
+		Timezone:     t.Timezone,
+		Materialized: t.Materialized,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+	if t.NextAt != nil {
+		nextAt := util.InTimezone(*t.NextAt, t.Timezone)
+		res.NextAt = &nextAt
+	}
+
+	return res
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type ReportHandler interface {
+	GetExpenseReport(c echo.Context) error
+}
+
+type reportHandler struct {
+	rs service.ReportService
+}
+
+func NewReportHandler(rs service.ReportService) *reportHandler {
+	return &reportHandler{rs}
+}
+
+// @Router		/reports/expenses [get]
+// @Summary	Summarize spending by period, category and account
+// @Description	Returns the count, total, average and largest expense over the range, for each period in it and for each category and account. Periods are calendar days, ISO weeks, months or years in the given timezone.
+// @Tags		report
+// @Param		from		query	string	false	"Start of the range (YYYY-MM-DD or RFC 3339); defaults to the current year together with to"
+// @Param		to			query	string	false	"End of the range (YYYY-MM-DD, inclusive, or RFC 3339)"
+// @Param		groupBy		query	string	false	"Period length: day, week, month (default) or year"
+// @Param		timezone	query	string	false	"IANA timezone or UTC offset that dates and periods are in; defaults to UTC"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.ExpenseReportResponse]
+func (rh *reportHandler) GetExpenseReport(c echo.Context) error {
+	timezone := c.QueryParam("timezone")
+	loc, err := util.LoadTimezone(timezone)
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, service.ErrInvalidTimezone.Error(), nil),
+		)
+	}
+	period, err := util.ParsePeriodIn(c.QueryParam("from"), c.QueryParam("to"), loc)
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	report, err := rh.rs.GetExpenseReport(int(user.ID), c.QueryParam("groupBy"), period, timezone)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrInvalidReportPeriod) ||
+			errors.Is(err, service.ErrInvalidGroupBy) ||
+			errors.Is(err, service.ErrReportTooLarge) ||
+			errors.Is(err, service.ErrInvalidTimezone) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrReportingCurrencyRequired) || errors.Is(err, service.ErrExchangeRateNotFound) {
+			return c.JSON(
+				http.StatusUnprocessableEntity,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.ExpenseReportResponse](
+			true, "Expense report found",
+			expenseReportResponse(report, loc),
+		),
+	)
+}
+
+func expenseReportResponse(report model.ExpenseReport, loc *time.Location) response.ExpenseReportResponse {
+	res := response.ExpenseReportResponse{
+		From:       report.From.In(loc),
+		To:         report.To.In(loc),
+		Timezone:   report.Timezone,
+		GroupBy:    report.GroupBy,
+		Currency:   report.Currency.Code,
+		Summary:    expenseStatsResponse(report.Summary, report.Currency),
+		Periods:    make([]response.PeriodExpenseStatsResponse, 0, len(report.Periods)),
+		Categories: make([]response.CategoryExpenseStatsResponse, 0, len(report.Categories)),
+		Accounts:   make([]response.AccountExpenseStatsResponse, 0, len(report.Accounts)),
+	}
+	if e := report.LargestExpense; e != nil {
+		res.LargestExpense = &response.CommonExpenseResponse{
+			ID:          int(e.ID),
+			UserID:      e.UserID,
+			AccountID:   e.AccountID,
+			CategoryID:  e.CategoryID,
+			Name:        e.Name,
+			Description: e.Description,
+			Amount:      formatAmount(e.Amount, accountCurrency(e.Account)),
+			OccurredAt:  util.InTimezone(e.OccurredAt, e.Timezone),
+			Timezone:    e.Timezone,
+			CreatedAt:   e.CreatedAt,
+			UpdatedAt:   e.UpdatedAt,
+		}
+	}
+	for _, p := range report.Periods {
+		res.Periods = append(res.Periods, response.PeriodExpenseStatsResponse{
+			From:                 p.From.In(loc),
+			To:                   p.To.In(loc),
+			ExpenseStatsResponse: expenseStatsResponse(p.ExpenseStats, report.Currency),
+		})
+	}
+	for _, c := range report.Categories {
+		res.Categories = append(res.Categories, response.CategoryExpenseStatsResponse{
+			CategoryID:           c.CategoryID,
+			CategoryName:         c.CategoryName,
+			ExpenseStatsResponse: expenseStatsResponse(c.ExpenseStats, report.Currency),
+		})
+	}
+	for _, a := range report.Accounts {
+		res.Accounts = append(res.Accounts, response.AccountExpenseStatsResponse{
+			AccountID:            a.AccountID,
+			AccountName:          a.AccountName,
+			ExpenseStatsResponse: expenseStatsResponse(a.ExpenseStats, report.Currency),
+		})
+	}
+
+	return res
+}
+
+func expenseStatsResponse(s model.ExpenseStats, currency model.Currency) response.ExpenseStatsResponse {
+	return response.ExpenseStatsResponse{
+		Count:   s.Count,
+		Total:   formatAmount(s.Total, &currency),
+		Average: money.FormatFloat(s.Average, currency.MinorUnit),
+		Largest: formatAmount(s.Largest, &currency),
+	}
+}
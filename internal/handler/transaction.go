@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type TransactionHandler interface {
+	GetMany(c echo.Context) error
+}
+
+type transactionHandler struct {
+	ts service.TransactionService
+}
+
+func NewTransactionHandler(ts service.TransactionService) *transactionHandler {
+	return &transactionHandler{ts}
+}
+
+// @Router		/transactions [get]
+// @Summary	Get many transactions (expenses and incomes) with signed amounts
+// @Tags		transaction
+// @Param		accountId	query	string	false	"Account ID"
+// @Param		from		query	string	false	"Only include transactions that occurred at or after this date (YYYY-MM-DD) or time (RFC 3339)"
+// @Param		to			query	string	false	"Only include transactions that occurred before this time, or on or before this date"
+// @Param		itemPerPage	query	string	true	"Amount of items per page"
+// @Param		page		query	string	true	"Page number"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[[]response.CommonTransactionResponse]
+func (th *transactionHandler) GetMany(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	itemPerPage, err := strconv.Atoi(c.QueryParam("itemPerPage"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	period, err := util.ParsePeriod(c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var transactions []model.Transaction
+	if c.QueryParam("accountId") != "" {
+		accountID, err := strconv.Atoi(c.QueryParam("accountId"))
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, "Bad Request", nil),
+			)
+		}
+		transactions, err = th.ts.GetManyBelongedToAccount(int(user.ID), accountID, period, itemPerPage, page)
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusInternalServerError,
+				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+			)
+		}
+	} else {
+		transactions, err = th.ts.GetManyBelongedToUser(int(user.ID), period, itemPerPage, page)
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusInternalServerError,
+				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+			)
+		}
+	}
+
+	responses := make([]response.CommonTransactionResponse, 0, len(transactions))
+	for _, t := range transactions {
+		responses = append(responses, response.CommonTransactionResponse{
+			Type:        t.Type,
+			ID:          int(t.ID),
+			UserID:      t.UserID,
+			AccountID:   t.AccountID,
+			Name:        t.Name,
+			Description: t.Description,
+			Amount:      money.Format(t.Amount, t.MinorUnit),
+			OccurredAt:  util.InTimezone(t.OccurredAt, t.Timezone),
+			Timezone:    t.Timezone,
+			CreatedAt:   t.CreatedAt,
+			UpdatedAt:   t.UpdatedAt,
+		})
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[[]response.CommonTransactionResponse](
+			true, "Transactions found", responses,
+		),
+	)
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type TransferHandler interface {
+	Create(c echo.Context) error
+	GetOneByID(c echo.Context) error
+	GetMany(c echo.Context) error
+	DeleteOneByID(c echo.Context) error
+}
+
+type transferHandler struct {
+	ts service.TransferService
+}
+
+func NewTransferHandler(ts service.TransferService) *transferHandler {
+	return &transferHandler{ts}
+}
+
+// @Router		/transfers [post]
+// @Summary	Transfer money between two accounts
+// @Tags		transfer
+// @Param		payload	body	dto.CreateTransferDTO	true	"Create transfer DTO"
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.CommonTransferResponse]
+func (th *transferHandler) Create(c echo.Context) error {
+	var payload dto.CreateTransferDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	transfer, err := th.ts.Create(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) ||
+			errors.Is(err, service.ErrSameAccountTransfer) ||
+			errors.Is(err, service.ErrExchangeRateRequired) ||
+			errors.Is(err, service.ErrExchangeRateMismatch) ||
+			errors.Is(err, service.ErrInvalidAmount) ||
+			errors.Is(err, service.ErrAmountNotPositive) ||
+			errors.Is(err, service.ErrNegativeFee) ||
+			errors.Is(err, service.ErrInvalidTimezone) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrAccountNotBelongedToUser) {
+			return c.JSON(
+				http.StatusForbidden,
+				util.CreateBaseResponse[any](false, "Forbidden", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.CommonTransferResponse](
+			true, "Transfer created",
+			response.CommonTransferResponse{
+				ID:             transfer.ID,
+				UserID:         transfer.UserID,
+				FromAccountID:  transfer.FromAccountID,
+				ToAccountID:    transfer.ToAccountID,
+				Description:    transfer.Description,
+				Amount:         formatAmount(transfer.Amount, accountCurrency(transfer.FromAccount)),
+				Fee:            formatAmount(transfer.Fee, accountCurrency(transfer.FromAccount)),
+				ExchangeRate:   transfer.ExchangeRate,
+				ReceivedAmount: formatAmount(transfer.ReceivedAmount, accountCurrency(transfer.ToAccount)),
+				OccurredAt:     util.InTimezone(transfer.OccurredAt, transfer.Timezone),
+				Timezone:       transfer.Timezone,
+				CreatedAt:      transfer.CreatedAt,
+				UpdatedAt:      transfer.UpdatedAt,
+			},
+		),
+	)
+}
+
+// @Router		/transfers/{transferID} [get]
+// @Summary	Get one transfer by ID
+// @Tags		transfer
+// @Param		transferID	path	string	true	"Transfer ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.CommonTransferResponse]
+func (th *transferHandler) GetOneByID(c echo.Context) error {
+	transferID, err := strconv.Atoi(c.Param("transferID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	transfer, err := th.ts.GetOneByID(int(user.ID), transferID)
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonTransferResponse](
+			true, "Transfer found",
+			response.CommonTransferResponse{
+				ID:             transfer.ID,
+				UserID:         transfer.UserID,
+				FromAccountID:  transfer.FromAccountID,
+				ToAccountID:    transfer.ToAccountID,
+				Description:    transfer.Description,
+				Amount:         formatAmount(transfer.Amount, accountCurrency(transfer.FromAccount)),
+				Fee:            formatAmount(transfer.Fee, accountCurrency(transfer.FromAccount)),
+				ExchangeRate:   transfer.ExchangeRate,
+				ReceivedAmount: formatAmount(transfer.ReceivedAmount, accountCurrency(transfer.ToAccount)),
+				OccurredAt:     util.InTimezone(transfer.OccurredAt, transfer.Timezone),
+				Timezone:       transfer.Timezone,
+				CreatedAt:      transfer.CreatedAt,
+				UpdatedAt:      transfer.UpdatedAt,
+			},
+		),
+	)
+}
+
+// @Router		/transfers [get]
+// @Summary	Get many transfers
+// @Tags		transfer
+// @Param		accountId	query	string	false	"Account ID, matches either side of the transfer"
+// @Param		itemPerPage	query	string	true	"Amount of items per page"
+// @Param		page		query	string	true	"Page number"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[[]response.CommonTransferResponse]
+func (th *transferHandler) GetMany(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	itemPerPage, err := strconv.Atoi(c.QueryParam("itemPerPage"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	var transfers []model.Transfer
+	if c.QueryParam("accountId") != "" {
+		accountID, err := strconv.Atoi(c.QueryParam("accountId"))
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, "Bad Request", nil),
+			)
+		}
+		transfers, err = th.ts.GetManyBelongedToAccount(int(user.ID), accountID, itemPerPage, page)
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusInternalServerError,
+				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+			)
+		}
+	} else {
+		transfers, err = th.ts.GetManyBelongedToUser(int(user.ID), itemPerPage, page)
+		if err != nil {
+			c.Logger().Error(err)
+			return c.JSON(
+				http.StatusInternalServerError,
+				util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+			)
+		}
+	}
+
+	responses := make([]response.CommonTransferResponse, 0, len(transfers))
+	for _, t := range transfers {
+		responses = append(responses, response.CommonTransferResponse{
+			ID:             t.ID,
+			UserID:         t.UserID,
+			FromAccountID:  t.FromAccountID,
+			ToAccountID:    t.ToAccountID,
+			Description:    t.Description,
+			Amount:         formatAmount(t.Amount, accountCurrency(t.FromAccount)),
+			Fee:            formatAmount(t.Fee, accountCurrency(t.FromAccount)),
+			ExchangeRate:   t.ExchangeRate,
+			ReceivedAmount: formatAmount(t.ReceivedAmount, accountCurrency(t.ToAccount)),
+			OccurredAt:     util.InTimezone(t.OccurredAt, t.Timezone),
+			Timezone:       t.Timezone,
+			CreatedAt:      t.CreatedAt,
+			UpdatedAt:      t.UpdatedAt,
+		})
+	}
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[[]response.CommonTransferResponse](
+			true, "Transfers found", responses,
+		),
+	)
+}
+
+// @Router		/transfers/{transferID} [delete]
+// @Summary	Delete one transfer by ID
+// @Tags		transfer
+// @Param		transferID	path	string	true	"Transfer ID"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[any]
+func (th *transferHandler) DeleteOneByID(c echo.Context) error {
+	transferID, err := strconv.Atoi(c.Param("transferID"))
+	if err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	if err := th.ts.DeleteOneByID(int(user.ID), transferID); err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				util.CreateBaseResponse[any](false, "Not Found", nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](
+			true, "Transfer deleted", nil,
+		),
+	)
+}
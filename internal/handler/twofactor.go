@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/response"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type TwoFactorHandler interface {
+	Enroll(c echo.Context) error
+	Confirm(c echo.Context) error
+	Disable(c echo.Context) error
+}
+
+type twoFactorHandler struct {
+	tfs service.TwoFactorService
+}
+
+func NewTwoFactorHandler(tfs service.TwoFactorService) *twoFactorHandler {
+	return &twoFactorHandler{tfs}
+}
+
+// @Router		/totp [post]
+// @Summary	Start setting up two-factor authentication
+// @Tags		auth
+// @Security	Bearer
+// @Success	201	{object}	util.BaseResponse[response.TOTPEnrollmentResponse]
+func (tfh *twoFactorHandler) Enroll(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	enrollment, err := tfh.tfs.Enroll(int(user.ID))
+	if err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrTOTPAlreadyEnabled) {
+			return c.JSON(
+				http.StatusConflict,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusCreated,
+		util.CreateBaseResponse[response.TOTPEnrollmentResponse](
+			true, "Add the secret to an authenticator app and confirm with a code",
+			response.TOTPEnrollmentResponse{
+				Secret: enrollment.Secret,
+				URI:    enrollment.URI,
+			},
+		),
+	)
+}
+
+// @Router		/totp/confirm [post]
+// @Summary	Turn on two-factor authentication; recovery codes are only shown in this response
+// @Tags		auth
+// @Param		payload	body	dto.ConfirmTOTPDTO	true	"Confirm TOTP DTO"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[response.RecoveryCodesResponse]
+func (tfh *twoFactorHandler) Confirm(c echo.Context) error {
+	var payload dto.ConfirmTOTPDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	codes, err := tfh.tfs.Confirm(int(user.ID), payload)
+	if err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrInvalidTOTPCode) || errors.Is(err, service.ErrTOTPNotEnrolled) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrTOTPAlreadyEnabled) {
+			return c.JSON(
+				http.StatusConflict,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[response.RecoveryCodesResponse](
+			true, "Two-factor authentication enabled",
+			response.RecoveryCodesResponse{RecoveryCodes: codes},
+		),
+	)
+}
+
+// @Router		/totp [delete]
+// @Summary	Turn off two-factor authentication
+// @Tags		auth
+// @Param		payload	body	dto.DisableTOTPDTO	true	"Disable TOTP DTO"
+// @Security	Bearer
+// @Success	200	{object}	util.BaseResponse[any]
+func (tfh *twoFactorHandler) Disable(c echo.Context) error {
+	var payload dto.DisableTOTPDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	user := c.Get("user").(model.User)
+	if err := tfh.tfs.Disable(int(user.ID), payload); err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrTOTPNotEnabled) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		if errors.Is(err, service.ErrWrongPassword) || errors.Is(err, service.ErrInvalidTOTPCode) {
+			return c.JSON(
+				http.StatusUnauthorized,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](
+			true, "Two-factor authentication disabled", nil,
+		),
+	)
+}
@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -42,8 +42,12 @@ func (uh *userHandler) Register(c echo.Context) error {
 		)
 	}
 
+	message := "User registered; check your email to verify your address"
 	user, err := uh.us.Register(payload)
-	if err != nil {
+	if errors.Is(err, service.ErrVerificationEmailNotSent) {
+		c.Logger().Error(err)
+		message = "User registered. " + service.ErrVerificationEmailNotSent.Error()
+	} else if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
 			http.StatusInternalServerError,
@@ -53,7 +57,7 @@ func (uh *userHandler) Register(c echo.Context) error {
 
 	return c.JSON(
 		http.StatusCreated,
-		util.CreateBaseResponse[response.CommonUserResponse](true, "User registered",
+		util.CreateBaseResponse[response.CommonUserResponse](true, message,
 			response.CommonUserResponse{
 				ID:        int(user.ID),
 				Email:     user.Email,
@@ -108,7 +112,6 @@ func (uh *userHandler) UpdateOneByID(c echo.Context) error {
 
 	var payload dto.UpdateUserDTO
 	if err := c.Bind(&payload); err != nil {
-		fmt.Println("bebek")
 		c.Logger().Error(err)
 		return c.JSON(
 			http.StatusBadRequest,
@@ -116,8 +119,20 @@ func (uh *userHandler) UpdateOneByID(c echo.Context) error {
 		)
 	}
 
+	if user := c.Get("user").(model.User); int(user.ID) != userID {
+		c.Logger().Error("Not Allowed")
+		return c.JSON(
+			http.StatusNotFound,
+			util.CreateBaseResponse[any](false, "Not Found", nil),
+		)
+	}
+
+	message := "User updated"
 	user, err := uh.us.UpdateOneByID(userID, payload)
-	if err != nil {
+	if errors.Is(err, service.ErrVerificationEmailNotSent) {
+		c.Logger().Error(err)
+		message = "User updated. " + service.ErrVerificationEmailNotSent.Error()
+	} else if err != nil {
 		c.Logger().Error(err)
 		return c.JSON(
 			http.StatusInternalServerError,
@@ -126,8 +141,8 @@ func (uh *userHandler) UpdateOneByID(c echo.Context) error {
 	}
 
 	return c.JSON(
-		http.StatusCreated,
-		util.CreateBaseResponse[response.CommonUserResponse](true, "User updated",
+		http.StatusOK,
+		util.CreateBaseResponse[response.CommonUserResponse](true, message,
 			response.CommonUserResponse{
 				ID:        int(user.ID),
 				Email:     user.Email,
@@ -243,6 +243,25 @@ func Test_UpdateOneByID(t *testing.T) {
 			t.Errorf("exp %d; got %d", exp, got)
 		}
 	})
+	t.Run("should return error 404 when updating another user", func(t *testing.T) {
+		e := echo.New()
+		validBodyJSON := `{"email":"test@example.com","fullName":"Fulan","password":"topsecret"}`
+		r := httptest.NewRequest(http.MethodPut, "/users/2", strings.NewReader(validBodyJSON))
+		r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w := httptest.NewRecorder()
+		c := e.NewContext(r, w)
+		c.SetParamNames("userID")
+		c.SetParamValues("2")
+		c.Set("user", model.User{Model: gorm.Model{ID: 1}})
+
+		uh.UpdateOneByID(c)
+
+		exp := http.StatusNotFound
+		got := w.Code
+		if exp != got {
+			t.Errorf("exp %d; got %d", exp, got)
+		}
+	})
 	t.Run("should return error 500 when service layer return error", func(t *testing.T) {
 		mus.EXPECT().UpdateOneByID(gomock.Eq(1), gomock.Eq(dto.UpdateUserDTO{
 			Email:    "test@example.com",
@@ -260,6 +279,7 @@ func Test_UpdateOneByID(t *testing.T) {
 		c := e.NewContext(r, w)
 		c.SetParamNames("userID")
 		c.SetParamValues("1")
+		c.Set("user", model.User{Model: gorm.Model{ID: 1}})
 
 		uh.UpdateOneByID(c)
 
@@ -293,6 +313,7 @@ func Test_UpdateOneByID(t *testing.T) {
 		c := e.NewContext(r, w)
 		c.SetParamNames("userID")
 		c.SetParamValues("1")
+		c.Set("user", model.User{Model: gorm.Model{ID: 1}})
 
 		uh.UpdateOneByID(c)
 
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type VerificationHandler interface {
+	SendEmailVerification(c echo.Context) error
+	VerifyEmail(c echo.Context) error
+	RequestPasswordReset(c echo.Context) error
+	ResetPassword(c echo.Context) error
+}
+
+type verificationHandler struct {
+	vs service.VerificationService
+}
+
+func NewVerificationHandler(vs service.VerificationService) *verificationHandler {
+	return &verificationHandler{vs}
+}
+
+// @Router		/auth/verify-email/resend [post]
+// @Summary	Send another email verification link
+// @Tags		auth
+// @Security	Bearer
+// @Success	202	{object}	util.BaseResponse[any]
+func (vh *verificationHandler) SendEmailVerification(c echo.Context) error {
+	user := c.Get("user").(model.User)
+	if err := vh.vs.SendEmailVerification(int(user.ID)); err != nil {
+		c.Logger().Error(err)
+		if errors.Is(err, service.ErrEmailAlreadyVerified) {
+			return c.JSON(
+				http.StatusConflict,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusAccepted,
+		util.CreateBaseResponse[any](true, "Verification email sent", nil),
+	)
+}
+
+// @Router		/auth/verify-email [post]
+// @Summary	Verify an email address with the token from the emailed link
+// @Tags		auth
+// @Param		payload	body	dto.VerifyEmailDTO	true	"Verify email DTO"
+// @Success	200	{object}	util.BaseResponse[any]
+func (vh *verificationHandler) VerifyEmail(c echo.Context) error {
+	var payload dto.VerifyEmailDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	if err := vh.vs.VerifyEmail(payload); err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrInvalidUserToken) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](true, "Email verified", nil),
+	)
+}
+
+// @Router		/auth/password-reset [post]
+// @Summary	Email a password reset link, if an account has the address
+// @Tags		auth
+// @Param		payload	body	dto.RequestPasswordResetDTO	true	"Request password reset DTO"
+// @Success	202	{object}	util.BaseResponse[any]
+func (vh *verificationHandler) RequestPasswordReset(c echo.Context) error {
+	var payload dto.RequestPasswordResetDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	if err := vh.vs.RequestPasswordReset(payload); err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusAccepted,
+		util.CreateBaseResponse[any](true, "If an account has this address, a reset link is on its way", nil),
+	)
+}
+
+// @Router		/auth/password-reset/confirm [post]
+// @Summary	Choose a new password with the token from the emailed link
+// @Tags		auth
+// @Param		payload	body	dto.ResetPasswordDTO	true	"Reset password DTO"
+// @Success	200	{object}	util.BaseResponse[any]
+func (vh *verificationHandler) ResetPassword(c echo.Context) error {
+	var payload dto.ResetPasswordDTO
+	if err := c.Bind(&payload); err != nil {
+		c.Logger().Error(err)
+		return c.JSON(
+			http.StatusBadRequest,
+			util.CreateBaseResponse[any](false, "Bad Request", nil),
+		)
+	}
+
+	if err := vh.vs.ResetPassword(payload); err != nil {
+		c.Logger().Error(err)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) || errors.Is(err, service.ErrInvalidUserToken) {
+			return c.JSON(
+				http.StatusBadRequest,
+				util.CreateBaseResponse[any](false, err.Error(), nil),
+			)
+		}
+		return c.JSON(
+			http.StatusInternalServerError,
+			util.CreateBaseResponse[any](false, "Internal Server Error", nil),
+		)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		util.CreateBaseResponse[any](true, "Password reset; log in with the new password", nil),
+	)
+}
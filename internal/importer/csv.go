@@ -0,0 +1,193 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+)
+
+// Sign conventions tell which amounts in a statement are expenses.
+const (
+	SignNegative = "negative"
+	SignPositive = "positive"
+	SignAbsolute = "absolute"
+)
+
+var (
+	ErrMalformedStatement = errors.New("Statement could not be read")
+	ErrInvalidDateFormat  = errors.New("Date format needs a year (YYYY or YY), a month (MMM, MM or M) and a day (DD or D)")
+)
+
+var dateTokens = strings.NewReplacer(
+	"YYYY", "2006",
+	"YY", "06",
+	"MMM", "Jan",
+	"MM", "01",
+	"M", "1",
+	"DD", "02",
+	"D", "2",
+	"HH", "15",
+	"mm", "04",
+	"ss", "05",
+)
+
+// DateLayout turns a format such as "DD/MM/YYYY" into a Go time layout.
+func DateLayout(format string) (string, error) {
+	if !strings.Contains(format, "YY") || !strings.Contains(format, "M") || !strings.Contains(format, "D") {
+		return "", ErrInvalidDateFormat
+	}
+
+	return dateTokens.Replace(format), nil
+}
+
+// ParseCSV reads a bank statement laid out as described by mapping. Dates
+// without a time of day are midnight in loc, and amounts are read into
+// minor units of a currency with minorUnit decimal places. A row that
+// cannot be read is returned with its Error set rather than failing the
+// whole statement; only a statement that is not valid CSV at all returns an
+// error.
+func ParseCSV(r io.Reader, mapping model.ImportMapping, loc *time.Location, minorUnit int) ([]model.ImportRow, error) {
+	layout, err := DateLayout(mapping.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	if mapping.Delimiter != "" {
+		cr.Comma = []rune(mapping.Delimiter)[0]
+	}
+
+	var rows []model.ImportRow
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedStatement, err)
+		}
+		if first && mapping.HasHeader {
+			continue
+		}
+
+		line, _ := cr.FieldPos(0)
+		row := model.ImportRow{Line: line, Type: model.TransactionTypeExpense, Timezone: loc.String()}
+		if err := parseRecord(&row, record, mapping, layout, loc, minorUnit); err != nil {
+			row.Error = err.Error()
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseRecord(row *model.ImportRow, record []string, mapping model.ImportMapping, layout string, loc *time.Location, minorUnit int) error {
+	field := func(column int) (string, error) {
+		if column < 1 || column > len(record) {
+			return "", fmt.Errorf("column %d is missing", column)
+		}
+		return strings.TrimSpace(strings.TrimPrefix(record[column-1], "\ufeff")), nil
+	}
+
+	date, err := field(mapping.DateColumn)
+	if err != nil {
+		return err
+	}
+	row.OccurredAt, err = time.ParseInLocation(layout, date, loc)
+	if err != nil {
+		return fmt.Errorf("date %q does not match %s", date, mapping.DateFormat)
+	}
+	row.OccurredAt = row.OccurredAt.UTC()
+
+	row.Name, err = field(mapping.DescriptionColumn)
+	if err != nil {
+		return err
+	}
+	if row.Name == "" {
+		return errors.New("description is empty")
+	}
+
+	value, err := field(mapping.AmountColumn)
+	if err != nil {
+		return err
+	}
+	amount, err := parseAmount(value, mapping.DecimalSeparator, minorUnit)
+	if err != nil {
+		return amountError("amount", value, minorUnit, err)
+	}
+	switch mapping.SignConvention {
+	case SignNegative:
+		row.Skipped = amount > 0
+	case SignPositive:
+		row.Skipped = amount < 0
+	}
+	row.Amount = abs(amount)
+	if row.Amount == 0 && !row.Skipped {
+		return errors.New("amount is zero")
+	}
+
+	return nil
+}
+
+// parseAmount reads amounts as banks print them: with thousands separators,
+// currency symbols, a trailing minus sign or parentheses for negatives. They
+// are read exactly, into minor units.
+func parseAmount(value, decimalSeparator string, minorUnit int) (int, error) {
+	value = strings.TrimFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsLetter(r) || unicode.IsSymbol(r)
+	})
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+	if strings.HasSuffix(value, "-") {
+		negative = !negative
+		value = strings.TrimSuffix(value, "-")
+	}
+
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+	value = strings.NewReplacer(thousandsSeparator, "", " ", "", "\u00a0", "", "'", "").Replace(value)
+	if decimalSeparator == "," {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+
+	amount, err := money.Parse(value, minorUnit)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// amountError describes why the value of field could not be read.
+func amountError(field, value string, minorUnit int, err error) error {
+	if errors.Is(err, money.ErrTooPrecise) {
+		return fmt.Errorf("%s %q has more than %d decimal places", field, value, minorUnit)
+	}
+
+	return fmt.Errorf("%s %q is not a number", field, value)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+)
+
+func TestParseCSV(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+
+	t.Run("should read a European statement with a header", func(t *testing.T) {
+		statement := "\ufeffDatum;Omschrijving;Bedrag\n" +
+			"14-10-2023;Supermarkt;-1.234,56\n" +
+			"15-10-2023;Salaris;2.500,00\n"
+		got, err := ParseCSV(strings.NewReader(statement), model.ImportMapping{
+			Delimiter:         ";",
+			HasHeader:         true,
+			DateColumn:        1,
+			DescriptionColumn: 2,
+			AmountColumn:      3,
+			SignConvention:    SignNegative,
+			DecimalSeparator:  ",",
+			DateFormat:        "DD-MM-YYYY",
+		}, jakarta, 2)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		if got[0].Line != 2 || got[0].Name != "Supermarkt" || got[0].Amount != 123456 || got[0].Skipped || got[0].Error != "" {
+			t.Error("exp expense of 123456 on line 2; got", got[0])
+		}
+		if exp := time.Date(2023, time.October, 13, 17, 0, 0, 0, time.UTC); !got[0].OccurredAt.Equal(exp) {
+			t.Error("exp", exp, "; got", got[0].OccurredAt)
+		}
+		if !got[1].Skipped {
+			t.Error("exp credit to be skipped; got", got[1])
+		}
+	})
+	t.Run("should follow the sign convention", func(t *testing.T) {
+		statement := "2023-10-14,Coffee,25000\n2023-10-14,Refund,(10000)\n2023-10-14,Fee,500-\n"
+		mapping := model.ImportMapping{
+			DateColumn:        1,
+			DescriptionColumn: 2,
+			AmountColumn:      3,
+			DateFormat:        "YYYY-MM-DD",
+		}
+
+		mapping.SignConvention = SignPositive
+		got, err := ParseCSV(strings.NewReader(statement), mapping, time.UTC, 0)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got[0].Skipped || !got[1].Skipped || !got[2].Skipped {
+			t.Error("exp only Coffee as expense; got", got)
+		}
+
+		mapping.SignConvention = SignAbsolute
+		got, err = ParseCSV(strings.NewReader(statement), mapping, time.UTC, 0)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		for _, row := range got {
+			if row.Skipped {
+				t.Error("exp every row as expense; got", row)
+			}
+		}
+		if got[2].Amount != 500 {
+			t.Error("exp 500; got", got[2].Amount)
+		}
+	})
+	t.Run("should report rows that cannot be read", func(t *testing.T) {
+		statement := "10/14/2023,Coffee,$4.50\n14/10/2023,Lunch,12.00\n10/15/2023,,3.00\n10/16/2023,Tea\n10/17/2023,Cake,abc\n10/18/2023,Gum,0.125\n"
+		got, err := ParseCSV(strings.NewReader(statement), model.ImportMapping{
+			DateColumn:        1,
+			DescriptionColumn: 2,
+			AmountColumn:      3,
+			SignConvention:    SignAbsolute,
+			DateFormat:        "MM/DD/YYYY",
+		}, time.UTC, 2)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 6 {
+			t.Fatal("exp 6; got", len(got))
+		}
+		if got[0].Error != "" || got[0].Amount != 450 {
+			t.Error("exp Coffee of 450; got", got[0])
+		}
+		for _, row := range got[1:] {
+			if row.Error == "" {
+				t.Error("exp error; got", row)
+			}
+		}
+	})
+	t.Run("should return error for a statement that is not CSV", func(t *testing.T) {
+		if _, err := ParseCSV(strings.NewReader("2023-10-14,\"Coffee,25000\n"), model.ImportMapping{
+			DateColumn:        1,
+			DescriptionColumn: 2,
+			AmountColumn:      3,
+			DateFormat:        "YYYY-MM-DD",
+		}, time.UTC, 0); !errors.Is(err, ErrMalformedStatement) {
+			t.Error("exp ErrMalformedStatement; got", err)
+		}
+	})
+}
+
+func TestDateLayout(t *testing.T) {
+	t.Run("should translate tokens to a Go layout", func(t *testing.T) {
+		got, err := DateLayout("DD MMM YYYY HH:mm")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != "02 Jan 2006 15:04" {
+			t.Error("exp 02 Jan 2006 15:04; got", got)
+		}
+	})
+	t.Run("should reject a format without a year", func(t *testing.T) {
+		if _, err := DateLayout("DD/MM"); !errors.Is(err, ErrInvalidDateFormat) {
+			t.Error("exp ErrInvalidDateFormat; got", err)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+)
+
+var ofxTimeLayouts = map[int]string{
+	8:  "20060102",
+	12: "200601021504",
+	14: "20060102150405",
+}
+
+// ParseOFX reads the STMTTRN entries of an OFX 1.x (SGML) or 2.x (XML)
+// statement, which includes QFX. Debits become expenses and credits
+// incomes, identified by their FITID. Times without a UTC offset are read in
+// loc and amounts into minor units as in ParseCSV. Like ParseCSV, an entry
+// that cannot be read is returned with its Error set.
+func ParseOFX(r io.Reader, loc *time.Location, minorUnit int) ([]model.ImportRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	start := strings.Index(s, "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("%w: no <OFX> element", ErrMalformedStatement)
+	}
+
+	// OFX 1.x leaves leaf elements such as <TRNAMT> unclosed, so both
+	// versions are read the same way: a leaf's value is the text up to the
+	// next tag, and only aggregates such as <STMTTRN> are matched with their
+	// closing tags.
+	var (
+		rows    []model.ImportRow
+		entry   map[string]string
+		txLine  int
+		line    = strings.Count(s[:start], "\n") + 1
+		pos     = start
+		advance = func(to int) {
+			line += strings.Count(s[pos:to], "\n")
+			pos = to
+		}
+	)
+	for {
+		open := strings.IndexByte(s[pos:], '<')
+		if open < 0 {
+			break
+		}
+		advance(pos + open)
+		end := strings.IndexByte(s[pos:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated tag on line %d", ErrMalformedStatement, line)
+		}
+		tag := strings.ToUpper(strings.TrimSpace(s[pos+1 : pos+end]))
+		advance(pos + end + 1)
+
+		next := strings.IndexByte(s[pos:], '<')
+		if next < 0 {
+			next = len(s) - pos
+		}
+		value := html.UnescapeString(strings.TrimSpace(s[pos : pos+next]))
+
+		switch {
+		case tag == "STMTTRN":
+			entry = map[string]string{}
+			txLine = line
+		case tag == "/STMTTRN" && entry != nil:
+			row := model.ImportRow{Line: txLine, Timezone: loc.String()}
+			if err := parseOFXEntry(&row, entry, loc, minorUnit); err != nil {
+				row.Error = err.Error()
+			}
+			rows = append(rows, row)
+			entry = nil
+		case entry != nil && !strings.HasPrefix(tag, "/") && value != "":
+			if _, ok := entry[tag]; !ok {
+				entry[tag] = value
+			}
+		}
+	}
+	if entry != nil {
+		return nil, fmt.Errorf("%w: <STMTTRN> on line %d is not closed", ErrMalformedStatement, txLine)
+	}
+
+	return rows, nil
+}
+
+func parseOFXEntry(row *model.ImportRow, entry map[string]string, loc *time.Location, minorUnit int) error {
+	row.ExternalID = entry["FITID"]
+	if row.ExternalID == "" {
+		return errors.New("FITID is missing")
+	}
+
+	var err error
+	row.OccurredAt, err = parseOFXTime(entry["DTPOSTED"], loc)
+	if err != nil {
+		return fmt.Errorf("DTPOSTED %q is not a valid date", entry["DTPOSTED"])
+	}
+
+	row.Name, row.Description = entry["NAME"], entry["MEMO"]
+	if row.Name == "" {
+		row.Name, row.Description = row.Description, ""
+	}
+	if row.Name == "" {
+		row.Name = entry["TRNTYPE"]
+	}
+	if row.Name == "" {
+		return errors.New("NAME is missing")
+	}
+
+	decimalSeparator := "."
+	if value := entry["TRNAMT"]; strings.Contains(value, ",") && !strings.Contains(value, ".") {
+		decimalSeparator = ","
+	}
+	amount, err := parseAmount(entry["TRNAMT"], decimalSeparator, minorUnit)
+	if err != nil {
+		return amountError("TRNAMT", entry["TRNAMT"], minorUnit, err)
+	}
+	row.Type = model.TransactionTypeIncome
+	if amount < 0 {
+		row.Type = model.TransactionTypeExpense
+	}
+	row.Amount = abs(amount)
+	if row.Amount == 0 {
+		return errors.New("TRNAMT is zero")
+	}
+
+	return nil
+}
+
+// parseOFXTime reads OFX datetimes such as 20231014, 20231014120000 or
+// 20231014120000.000[-5:EST]. The bracketed offset is in hours.
+func parseOFXTime(value string, loc *time.Location) (time.Time, error) {
+	if i := strings.IndexByte(value, '['); i >= 0 {
+		zone := strings.TrimSuffix(value[i+1:], "]")
+		value = value[:i]
+		offset := zone
+		if j := strings.IndexByte(zone, ':'); j >= 0 {
+			offset = zone[:j]
+		}
+		hours, err := strconv.ParseFloat(offset, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		loc = time.FixedZone(zone, int(hours*3600))
+	}
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		value = value[:i]
+	}
+
+	layout, ok := ofxTimeLayouts[len(value)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unexpected length %d", len(value))
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.UTC(), nil
+}
@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+)
+
+func TestParseOFX(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+
+	t.Run("should read an OFX 1.x statement", func(t *testing.T) {
+		statement := `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20231014120000.000[-5:EST]
+<TRNAMT>-12.50
+<FITID>2023101401
+<NAME>Tom &amp; Jerry's
+<MEMO>Lunch
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20231015
+<TRNAMT>2500.00
+<FITID>2023101502
+<MEMO>Payroll
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+		got, err := ParseOFX(strings.NewReader(statement), jakarta, 2)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		if got[0].Error != "" || got[0].Type != model.TransactionTypeExpense || got[0].Amount != 1250 || got[0].ExternalID != "2023101401" {
+			t.Error("exp expense of 1250 as 2023101401; got", got[0])
+		}
+		if got[0].Name != "Tom & Jerry's" || got[0].Description != "Lunch" || got[0].Line != 11 {
+			t.Error("exp Tom & Jerry's, Lunch on line 11; got", got[0].Name, got[0].Description, got[0].Line)
+		}
+		if exp := time.Date(2023, time.October, 14, 17, 0, 0, 0, time.UTC); !got[0].OccurredAt.Equal(exp) {
+			t.Error("exp", exp, "; got", got[0].OccurredAt)
+		}
+		if got[1].Type != model.TransactionTypeIncome || got[1].Name != "Payroll" {
+			t.Error("exp income named Payroll; got", got[1])
+		}
+		if exp := time.Date(2023, time.October, 14, 17, 0, 0, 0, time.UTC); !got[1].OccurredAt.Equal(exp) {
+			t.Error("exp midnight in Jakarta,", exp, "; got", got[1].OccurredAt)
+		}
+	})
+	t.Run("should read an OFX 2.x statement", func(t *testing.T) {
+		statement := `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><BANKTRANLIST>
+    <STMTTRN>
+      <TRNTYPE>DEBIT</TRNTYPE>
+      <DTPOSTED>20231014093000</DTPOSTED>
+      <TRNAMT>-45000</TRNAMT>
+      <FITID>CC-1</FITID>
+      <PAYEE><NAME>Bookstore</NAME></PAYEE>
+    </STMTTRN>
+    <STMTTRN>
+      <TRNTYPE>FEE</TRNTYPE>
+      <DTPOSTED>yesterday</DTPOSTED>
+      <TRNAMT>-5000</TRNAMT>
+      <FITID>CC-2</FITID>
+    </STMTTRN>
+  </BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>`
+		got, err := ParseOFX(strings.NewReader(statement), time.UTC, 0)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		if got[0].Error != "" || got[0].Name != "Bookstore" || got[0].Amount != 45000 {
+			t.Error("exp Bookstore of 45000; got", got[0])
+		}
+		if got[1].Error == "" {
+			t.Error("exp error for invalid DTPOSTED; got", got[1])
+		}
+	})
+	t.Run("should report an entry without FITID", func(t *testing.T) {
+		got, err := ParseOFX(strings.NewReader("<OFX><STMTTRN><DTPOSTED>20231014<TRNAMT>-1<NAME>X</STMTTRN></OFX>"), time.UTC, 0)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 1 || got[0].Error == "" {
+			t.Error("exp 1 row with error; got", got)
+		}
+	})
+	t.Run("should return error for a file that is not OFX", func(t *testing.T) {
+		if _, err := ParseOFX(strings.NewReader("Date,Description,Amount\n"), time.UTC, 0); !errors.Is(err, ErrMalformedStatement) {
+			t.Error("exp ErrMalformedStatement; got", err)
+		}
+		if _, err := ParseOFX(strings.NewReader("<OFX><STMTTRN><FITID>1"), time.UTC, 0); !errors.Is(err, ErrMalformedStatement) {
+			t.Error("exp ErrMalformedStatement; got", err)
+		}
+	})
+}
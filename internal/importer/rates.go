@@ -0,0 +1,252 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+)
+
+var ErrMalformedExchangeRates = errors.New("Exchange rates could not be read")
+
+// Layouts of the dates in rate files: ISO dates, and the "13 October 2023"
+// of the ECB's daily CSV.
+var rateDateLayouts = []string{"2006-01-02", "2 January 2006"}
+
+// ParseExchangeRates reads rates in any of the layouts the ECB publishes, or
+// a CSV with one rate per row:
+//
+//   - ECB XML (eurofxref-daily.xml, eurofxref-hist.xml): a Cube per day
+//     holding a Cube per currency, all quoted against base.
+//   - ECB CSV (eurofxref.csv, eurofxref-hist.csv): a Date column followed by
+//     a column per currency, all quoted against base. "N/A" is skipped.
+//   - A CSV with date, base, quote and rate columns, in any order.
+//
+// Dates are midnight UTC. When a file has the same rate more than once, the
+// last one wins.
+func ParseExchangeRates(r io.Reader, base string) ([]model.ExchangeRate, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(512)
+	head = bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\ufeff")), " \t\r\n")
+
+	var rates []model.ExchangeRate
+	var err error
+	if bytes.HasPrefix(head, []byte("<")) {
+		rates, err = parseECBXML(br, base)
+	} else {
+		rates, err = parseRatesCSV(br, base)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return dedupeRates(rates), nil
+}
+
+func parseECBXML(r io.Reader, base string) ([]model.ExchangeRate, error) {
+	if _, ok := currencyCode(base); !ok {
+		return nil, fmt.Errorf("%w: invalid base currency %q", ErrMalformedExchangeRates, base)
+	}
+
+	var rates []model.ExchangeRate
+	var date time.Time
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedExchangeRates, err)
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "Cube" {
+			continue
+		}
+
+		attrs := map[string]string{}
+		for _, a := range element.Attr {
+			attrs[a.Name.Local] = a.Value
+		}
+		if value, ok := attrs["time"]; ok {
+			if date, err = parseRateDate(value); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := attrs["currency"]; !ok {
+			continue
+		}
+		if date.IsZero() {
+			return nil, fmt.Errorf("%w: rate for %s has no date", ErrMalformedExchangeRates, attrs["currency"])
+		}
+		rate, err := newRate(date, base, attrs["currency"], attrs["rate"])
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+func parseRatesCSV(r io.Reader, base string) ([]model.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedExchangeRates, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	_, hasBase := columns["base"]
+	_, hasQuote := columns["quote"]
+	_, hasRate := columns["rate"]
+	dateColumn, hasDate := columns["date"]
+	if !hasDate {
+		return nil, fmt.Errorf("%w: no date column", ErrMalformedExchangeRates)
+	}
+	long := hasBase && hasQuote && hasRate
+	if !long {
+		code, ok := currencyCode(base)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid base currency %q", ErrMalformedExchangeRates, base)
+		}
+		base = code
+	}
+
+	var rates []model.ExchangeRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedExchangeRates, err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		date, err := parseRateDate(field(record, dateColumn))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if long {
+			rate, err := newRate(date, field(record, columns["base"]), field(record, columns["quote"]), field(record, columns["rate"]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			rates = append(rates, rate)
+			continue
+		}
+		for i, name := range header {
+			value := field(record, i)
+			if i == dateColumn || strings.TrimSpace(name) == "" || value == "" || strings.EqualFold(value, "N/A") {
+				continue
+			}
+			rate, err := newRate(date, base, name, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			rates = append(rates, rate)
+		}
+	}
+
+	return rates, nil
+}
+
+func newRate(date time.Time, base, quote, value string) (model.ExchangeRate, error) {
+	baseCode, ok := currencyCode(base)
+	if !ok {
+		return model.ExchangeRate{}, fmt.Errorf("%w: invalid currency %q", ErrMalformedExchangeRates, base)
+	}
+	quoteCode, ok := currencyCode(quote)
+	if !ok {
+		return model.ExchangeRate{}, fmt.Errorf("%w: invalid currency %q", ErrMalformedExchangeRates, quote)
+	}
+	rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || rate <= 0 {
+		return model.ExchangeRate{}, fmt.Errorf("%w: invalid rate %q for %s/%s", ErrMalformedExchangeRates, value, baseCode, quoteCode)
+	}
+
+	return model.ExchangeRate{
+		Date:          date,
+		BaseCurrency:  baseCode,
+		QuoteCurrency: quoteCode,
+		Rate:          rate,
+	}, nil
+}
+
+func parseRateDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range rateDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: invalid date %q", ErrMalformedExchangeRates, value)
+}
+
+// currencyCode normalizes an ISO 4217 code, which is three letters.
+func currencyCode(value string) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if len(code) != 3 {
+		return "", false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", false
+		}
+	}
+
+	return code, true
+}
+
+func dedupeRates(rates []model.ExchangeRate) []model.ExchangeRate {
+	type key struct {
+		date        time.Time
+		base, quote string
+	}
+	index := make(map[key]int, len(rates))
+	deduped := rates[:0]
+	for _, rate := range rates {
+		k := key{rate.Date, rate.BaseCurrency, rate.QuoteCurrency}
+		if i, ok := index[k]; ok {
+			deduped[i] = rate
+			continue
+		}
+		index[k] = len(deduped)
+		deduped = append(deduped, rate)
+	}
+
+	return deduped
+}
+
+// field returns column i of record, or "" when the record is short.
+func field(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/tests/testutil"
+)
+
+const ecbDailyXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time="2023-10-13">
+			<Cube currency="USD" rate="1.0533"/>
+			<Cube currency="IDR" rate="16544.35"/>
+		</Cube>
+		<Cube time="2023-10-12">
+			<Cube currency="USD" rate="1.0584"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestParseExchangeRates(t *testing.T) {
+	october := func(d int) time.Time { return time.Date(2023, time.October, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("should read ECB XML against the base", func(t *testing.T) {
+		got, err := ParseExchangeRates(strings.NewReader(ecbDailyXML), "EUR")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []model.ExchangeRate{
+			{Date: october(13), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1.0533},
+			{Date: october(13), BaseCurrency: "EUR", QuoteCurrency: "IDR", Rate: 16544.35},
+			{Date: october(12), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1.0584},
+		}, got)
+	})
+	t.Run("should read the ECB's daily CSV, skipping missing rates", func(t *testing.T) {
+		csv := "\ufeffDate, USD, JPY, CYP, \n13 October 2023, 1.0533, 157.62, N/A, \n"
+		got, err := ParseExchangeRates(strings.NewReader(csv), "EUR")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []model.ExchangeRate{
+			{Date: october(13), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1.0533},
+			{Date: october(13), BaseCurrency: "EUR", QuoteCurrency: "JPY", Rate: 157.62},
+		}, got)
+	})
+	t.Run("should read a CSV with one rate per row, keeping the last duplicate", func(t *testing.T) {
+		csv := "rate,quote,base,date\n15600,idr,usd,2023-10-13\n15650,IDR,USD,2023-10-13\n0.95,EUR,USD,2023-10-13\n"
+		got, err := ParseExchangeRates(strings.NewReader(csv), "EUR")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []model.ExchangeRate{
+			{Date: october(13), BaseCurrency: "USD", QuoteCurrency: "IDR", Rate: 15650},
+			{Date: october(13), BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: 0.95},
+		}, got)
+	})
+	t.Run("should return error on an invalid rate", func(t *testing.T) {
+		csv := "date,base,quote,rate\n2023-10-13,USD,IDR,-1\n"
+		if _, err := ParseExchangeRates(strings.NewReader(csv), "EUR"); !errors.Is(err, ErrMalformedExchangeRates) {
+			t.Error("exp ErrMalformedExchangeRates; got", err)
+		}
+	})
+	t.Run("should return error on an invalid currency", func(t *testing.T) {
+		csv := "date,base,quote,rate\n2023-10-13,US Dollar,IDR,15000\n"
+		if _, err := ParseExchangeRates(strings.NewReader(csv), "EUR"); !errors.Is(err, ErrMalformedExchangeRates) {
+			t.Error("exp ErrMalformedExchangeRates; got", err)
+		}
+	})
+	t.Run("should return error without a date column", func(t *testing.T) {
+		if _, err := ParseExchangeRates(strings.NewReader("day,USD\n2023-10-13,1.05\n"), "EUR"); !errors.Is(err, ErrMalformedExchangeRates) {
+			t.Error("exp ErrMalformedExchangeRates; got", err)
+		}
+	})
+}
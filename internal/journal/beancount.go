@@ -0,0 +1,43 @@
+package journal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+)
+
+var beancountString = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// WriteBeancount writes j as a beancount file. Every account is opened on
+// the earliest date in the journal, and asset accounts are restricted to
+// their currency.
+func WriteBeancount(w io.Writer, j model.Journal) error {
+	b := build(j)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "; Exported from spendtracker.")
+	if len(b.accounts) > 0 {
+		fmt.Fprintln(bw)
+	}
+	for _, name := range b.accountNames() {
+		fmt.Fprintf(bw, "%s open %s", b.start, name)
+		if commodity := b.accounts[name]; commodity != "" {
+			fmt.Fprintf(bw, " %s", commodity)
+		}
+		fmt.Fprintln(bw)
+	}
+	for _, e := range b.entries {
+		fmt.Fprintf(bw, "\n%s * \"%s\"\n", e.date, beancountString.Replace(oneLine(e.narration)))
+		if description := oneLine(e.description); description != "" {
+			fmt.Fprintf(bw, "  description: \"%s\"\n", beancountString.Replace(description))
+		}
+		for _, p := range e.postings {
+			fmt.Fprintf(bw, "  %s  %s\n", p.account, p.formatAmount())
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,267 @@
+// Package journal renders a user's records as plain-text accounting
+// journals. Accounts become asset accounts, categories expense accounts and
+// currency codes commodities. Every posting carries an explicit amount, and
+// transfers between currencies are priced at what was paid, so entries
+// balance exactly.
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+// Accounts for postings that have no account or category of their own.
+const (
+	openingBalances       = "Equity:Opening-Balances"
+	uncategorizedExpenses = "Expenses:Uncategorized"
+	uncategorizedIncome   = "Income:Uncategorized"
+	transferFees          = "Expenses:Fees"
+	unknownAsset          = "Assets:Unknown"
+	noCurrency            = "XXX"
+)
+
+// posting amounts are in minor units of their commodity, which has
+// minorUnit decimal places.
+type posting struct {
+	account   string
+	amount    int
+	commodity string
+	minorUnit int
+	// price is the total paid for amount, in priceCommodity, when money
+	// changed currency on the way.
+	price          int
+	priceCommodity string
+	priceMinorUnit int
+}
+
+// formatAmount writes the posting's amount, and price if any, as decimals
+// followed by their commodities.
+func (p posting) formatAmount() string {
+	s := money.Format(p.amount, p.minorUnit) + " " + p.commodity
+	if p.priceCommodity != "" {
+		s += " @@ " + money.Format(p.price, p.priceMinorUnit) + " " + p.priceCommodity
+	}
+
+	return s
+}
+
+type entry struct {
+	at          time.Time
+	date        string
+	narration   string
+	description string
+	postings    []posting
+}
+
+type book struct {
+	start string
+	// accounts maps every account used to the commodity it is restricted
+	// to, if any.
+	accounts map[string]string
+	entries  []entry
+}
+
+type asset struct {
+	name      string
+	commodity string
+	minorUnit int
+}
+
+func build(j model.Journal) book {
+	b := book{accounts: map[string]string{}}
+	taken := reserved()
+
+	assets := make(map[uint]asset, len(j.Accounts))
+	for _, a := range j.Accounts {
+		commodity, minorUnit := noCurrency, 0
+		if a.Currency != nil {
+			commodity, minorUnit = commodityOf(a.Currency.Code), a.Currency.MinorUnit
+		}
+		assets[a.ID] = asset{accountName("Assets", a.Name, a.ID, taken), commodity, minorUnit}
+		b.accounts[assets[a.ID].name] = commodity
+		b.start = earliest(b.start, a.CreatedAt.UTC().Format(util.DateLayout))
+	}
+	assetOf := func(id uint) asset {
+		if a, ok := assets[id]; ok {
+			return a
+		}
+		return asset{unknownAsset, noCurrency, 0}
+	}
+	categories := make(map[uint]string, len(j.Categories))
+	for _, c := range j.Categories {
+		categories[c.ID] = accountName("Expenses", c.Name, c.ID, taken)
+		b.accounts[categories[c.ID]] = ""
+	}
+
+	add := func(at time.Time, timezone, narration, description string, postings ...posting) {
+		date := util.InTimezone(at, timezone).Format(util.DateLayout)
+		b.start = earliest(b.start, date)
+		for _, p := range postings {
+			if _, ok := b.accounts[p.account]; !ok {
+				b.accounts[p.account] = ""
+			}
+		}
+		b.entries = append(b.entries, entry{at, date, narration, description, postings})
+	}
+
+	for _, e := range j.Expenses {
+		from := assetOf(e.AccountID)
+		category, ok := categories[e.CategoryID]
+		if !ok {
+			category = uncategorizedExpenses
+		}
+		add(e.OccurredAt, e.Timezone, e.Name, e.Description,
+			posting{account: category, amount: e.Amount, commodity: from.commodity, minorUnit: from.minorUnit},
+			posting{account: from.name, amount: -e.Amount, commodity: from.commodity, minorUnit: from.minorUnit},
+		)
+	}
+	for _, i := range j.Incomes {
+		to := assetOf(i.AccountID)
+		add(i.OccurredAt, i.Timezone, i.Name, i.Description,
+			posting{account: to.name, amount: i.Amount, commodity: to.commodity, minorUnit: to.minorUnit},
+			posting{account: uncategorizedIncome, amount: -i.Amount, commodity: to.commodity, minorUnit: to.minorUnit},
+		)
+	}
+	for _, t := range j.Transfers {
+		from, to := assetOf(t.FromAccountID), assetOf(t.ToAccountID)
+		received := posting{account: to.name, amount: t.ReceivedAmount, commodity: to.commodity, minorUnit: to.minorUnit}
+		if to.commodity != from.commodity || t.ReceivedAmount != t.Amount {
+			received.price, received.priceCommodity, received.priceMinorUnit = t.Amount, from.commodity, from.minorUnit
+		}
+		postings := []posting{received}
+		if t.Fee != 0 {
+			postings = append(postings, posting{account: transferFees, amount: t.Fee, commodity: from.commodity, minorUnit: from.minorUnit})
+		}
+		postings = append(postings, posting{account: from.name, amount: -(t.Amount + t.Fee), commodity: from.commodity, minorUnit: from.minorUnit})
+		narration := t.Description
+		if narration == "" {
+			narration = "Transfer"
+		}
+		add(t.OccurredAt, t.Timezone, narration, "", postings...)
+	}
+
+	sort.SliceStable(b.entries, func(i, k int) bool {
+		return b.entries[i].at.Before(b.entries[k].at)
+	})
+
+	// Opening balances go first, on the earliest date in the journal, so
+	// no transaction predates them.
+	var openings []entry
+	for _, a := range j.Accounts {
+		if a.InitialAmount == 0 {
+			continue
+		}
+		account := assets[a.ID]
+		b.accounts[openingBalances] = ""
+		openings = append(openings, entry{
+			date:      b.start,
+			narration: "Opening balance",
+			postings: []posting{
+				{account: account.name, amount: a.InitialAmount, commodity: account.commodity, minorUnit: account.minorUnit},
+				{account: openingBalances, amount: -a.InitialAmount, commodity: account.commodity, minorUnit: account.minorUnit},
+			},
+		})
+	}
+	b.entries = append(openings, b.entries...)
+
+	return b
+}
+
+// reserved returns the names accountName must not hand out, so that a
+// category called "Fees" is not merged into the account for transfer fees.
+func reserved() map[string]bool {
+	taken := map[string]bool{}
+	for _, name := range []string{openingBalances, uncategorizedExpenses, uncategorizedIncome, transferFees, unknownAsset} {
+		taken[strings.ToLower(name)] = true
+	}
+
+	return taken
+}
+
+func (b book) accountNames() []string {
+	names := make([]string, 0, len(b.accounts))
+	for name := range b.accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// accountName makes name a valid account name component for both ledger
+// and beancount, which want it to start with a capital letter or digit and
+// hold only letters, digits and dashes. Names that end up the same are told
+// apart by ID, ignoring case so that they stay apart after a round trip
+// through tools that fold it.
+func accountName(root, name string, id uint, taken map[string]bool) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			dash = true
+			continue
+		}
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		dash = false
+		b.WriteRune(r)
+	}
+
+	component := b.String()
+	first, size := utf8.DecodeRuneInString(component)
+	switch {
+	case component == "":
+		component = "Unnamed"
+	case unicode.IsUpper(unicode.ToUpper(first)):
+		component = string(unicode.ToUpper(first)) + component[size:]
+	case !unicode.IsDigit(first):
+		component = "X-" + component
+	}
+
+	full := root + ":" + component
+	if taken[strings.ToLower(full)] {
+		full = fmt.Sprintf("%s-%d", full, id)
+	}
+	taken[strings.ToLower(full)] = true
+
+	return full
+}
+
+// commodityOf keeps the capital letters of a currency code, which is all
+// an ISO 4217 code has.
+func commodityOf(code string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if r >= 'A' && r <= 'Z' && b.Len() < 24 {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() < 2 {
+		return noCurrency
+	}
+
+	return b.String()
+}
+
+func earliest(a, b string) string {
+	if a == "" || b < a {
+		return b
+	}
+
+	return a
+}
+
+// oneLine collapses the whitespace in s, including newlines, to single
+// spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,163 @@
+package journal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+func testJournal() model.Journal {
+	created := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	idr := &model.Currency{Code: "IDR", MinorUnit: 2}
+	usd := &model.Currency{Code: "USD", MinorUnit: 2}
+
+	return model.Journal{
+		Accounts: []model.Account{
+			{Model: gorm.Model{ID: 1, CreatedAt: created}, Name: "bank jago", InitialAmount: 100000000, Currency: idr},
+			{Model: gorm.Model{ID: 2, CreatedAt: created}, Name: "Wise (USD)", Currency: usd},
+			{Model: gorm.Model{ID: 3, CreatedAt: created}, Name: "Bank Jago", Currency: idr},
+		},
+		Categories: []model.Category{
+			{Model: gorm.Model{ID: 1}, Name: "food & drinks"},
+		},
+		Expenses: []model.Expense{
+			{
+				AccountID:   1,
+				CategoryID:  1,
+				Name:        `Dinner at "Warung"`,
+				Description: "with\nfriends",
+				Amount:      12000050,
+				// Still Saturday evening in Jakarta.
+				OccurredAt: time.Date(2023, time.October, 14, 16, 30, 0, 0, time.UTC),
+				Timezone:   "Asia/Jakarta",
+			},
+			{
+				AccountID:  1,
+				Name:       "Parking",
+				Amount:     500000,
+				OccurredAt: time.Date(2023, time.October, 14, 18, 0, 0, 0, time.UTC),
+				Timezone:   "Asia/Jakarta",
+			},
+		},
+		Incomes: []model.Income{
+			{
+				AccountID:  1,
+				Name:       "Salary",
+				Amount:     500000000,
+				OccurredAt: time.Date(2023, time.October, 1, 2, 0, 0, 0, time.UTC),
+				Timezone:   "UTC",
+			},
+		},
+		Transfers: []model.Transfer{
+			{
+				FromAccountID:  1,
+				ToAccountID:    2,
+				Amount:         150000000,
+				Fee:            1000000,
+				ReceivedAmount: 9605,
+				OccurredAt:     time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC),
+				Timezone:       "UTC",
+			},
+		},
+	}
+}
+
+func TestWriteLedger(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteLedger(&buf, testJournal()); err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+	out := buf.String()
+
+	for _, exp := range []string{
+		"account Assets:Bank-jago\n",
+		"account Assets:Bank-Jago-3\n",
+		"account Assets:Wise-USD\n",
+		"account Expenses:Food-drinks\n",
+		"2023-10-01 * Opening balance\n    Assets:Bank-jago  1000000.00 IDR\n    Equity:Opening-Balances  -1000000.00 IDR\n",
+		"2023-10-14 * Dinner at \"Warung\"\n    ; with friends\n    Expenses:Food-drinks  120000.50 IDR\n    Assets:Bank-jago  -120000.50 IDR\n",
+		"2023-10-15 * Parking\n    Expenses:Uncategorized  5000.00 IDR\n",
+		"2023-10-01 * Salary\n    Assets:Bank-jago  5000000.00 IDR\n    Income:Uncategorized  -5000000.00 IDR\n",
+		"2023-10-20 * Transfer\n    Assets:Wise-USD  96.05 USD @@ 1500000.00 IDR\n    Expenses:Fees  10000.00 IDR\n    Assets:Bank-jago  -1510000.00 IDR\n",
+	} {
+		if !strings.Contains(out, exp) {
+			t.Errorf("exp output to contain %q; got:\n%s", exp, out)
+		}
+	}
+	t.Run("should put the opening balance before every transaction", func(t *testing.T) {
+		if strings.Index(out, "Opening balance") > strings.Index(out, "Salary") {
+			t.Error("exp opening balance first; got:\n", out)
+		}
+	})
+}
+
+func TestWriteBeancount(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBeancount(&buf, testJournal()); err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+	out := buf.String()
+
+	for _, exp := range []string{
+		"2023-10-01 open Assets:Bank-jago IDR\n",
+		"2023-10-01 open Assets:Wise-USD USD\n",
+		"2023-10-01 open Equity:Opening-Balances\n",
+		"2023-10-01 open Expenses:Fees\n",
+		"2023-10-01 open Expenses:Uncategorized\n",
+		"2023-10-01 open Income:Uncategorized\n",
+		"2023-10-14 * \"Dinner at \\\"Warung\\\"\"\n  description: \"with friends\"\n  Expenses:Food-drinks  120000.50 IDR\n",
+		"2023-10-20 * \"Transfer\"\n  Assets:Wise-USD  96.05 USD @@ 1500000.00 IDR\n",
+	} {
+		if !strings.Contains(out, exp) {
+			t.Errorf("exp output to contain %q; got:\n%s", exp, out)
+		}
+	}
+}
+
+func TestWriteLedger_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteLedger(&buf, model.Journal{}); err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+	if buf.String() != "; Exported from spendtracker.\n" {
+		t.Error("exp header only; got", buf.String())
+	}
+}
+
+func TestAccountName(t *testing.T) {
+	taken := reserved()
+	for _, tc := range []struct {
+		name string
+		id   uint
+		exp  string
+	}{
+		{"groceries", 1, "Expenses:Groceries"},
+		{"  ", 2, "Expenses:Unnamed"},
+		{"2023 trip", 3, "Expenses:2023-trip"},
+		{"_misc", 4, "Expenses:Misc"},
+		{"Groceries!", 5, "Expenses:Groceries-5"},
+		{"日本", 6, "Expenses:X-日本"},
+		{"fees", 7, "Expenses:Fees-7"},
+	} {
+		if got := accountName("Expenses", tc.name, tc.id, taken); got != tc.exp {
+			t.Errorf("exp %s for %q; got %s", tc.exp, tc.name, got)
+		}
+	}
+}
+
+func TestCommodityOf(t *testing.T) {
+	for code, exp := range map[string]string{
+		"IDR": "IDR",
+		"usd": "USD",
+		"":    "XXX",
+		"1":   "XXX",
+	} {
+		if got := commodityOf(code); got != exp {
+			t.Errorf("exp %s for %q; got %s", exp, code, got)
+		}
+	}
+}
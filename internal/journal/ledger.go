@@ -0,0 +1,34 @@
+package journal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+)
+
+// WriteLedger writes j as a journal that both ledger and hledger read.
+func WriteLedger(w io.Writer, j model.Journal) error {
+	b := build(j)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "; Exported from spendtracker.")
+	if len(b.accounts) > 0 {
+		fmt.Fprintln(bw)
+	}
+	for _, name := range b.accountNames() {
+		fmt.Fprintf(bw, "account %s\n", name)
+	}
+	for _, e := range b.entries {
+		fmt.Fprintf(bw, "\n%s * %s\n", e.date, oneLine(e.narration))
+		if description := oneLine(e.description); description != "" {
+			fmt.Fprintf(bw, "    ; %s\n", description)
+		}
+		for _, p := range e.postings {
+			fmt.Fprintf(bw, "    %s  %s\n", p.account, p.formatAmount())
+		}
+	}
+
+	return bw.Flush()
+}
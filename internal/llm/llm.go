@@ -0,0 +1,65 @@
+// Package llm completes chat prompts with a large language model, through
+// an OpenAI-compatible API, a local Ollama server or, offline, a
+// deterministic stand-in.
+package llm
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrEmptyCompletion = errors.New("model returned an empty completion")
+
+const (
+	DefaultMaxTokens = 56
+	DefaultTimeout   = 30 * time.Second
+)
+
+type Provider interface {
+	// Model names the model answers come from.
+	Model() string
+	// Complete answers message, a user's chat message, following prompt,
+	// the system prompt.
+	Complete(ctx context.Context, prompt, message string) (string, error)
+	// Stream is Complete, handing each piece of the answer to onToken as
+	// soon as it arrives. It stops at the first error onToken returns, or
+	// when ctx is done.
+	Stream(ctx context.Context, prompt, message string, onToken func(token string) error) (Usage, error)
+}
+
+// Usage is how many tokens a completion took. Estimated is set when the
+// provider does not report it, and the count of streamed chunks stands in
+// for CompletionTokens.
+type Usage struct {
+	PromptTokens     int  `json:"promptTokens"`
+	CompletionTokens int  `json:"completionTokens"`
+	TotalTokens      int  `json:"totalTokens"`
+	Estimated        bool `json:"estimated"`
+}
+
+// Options configure a provider. Zero values fall back to the provider's
+// defaults.
+type Options struct {
+	BaseURL   string
+	Model     string
+	MaxTokens int
+	Timeout   time.Duration
+}
+
+func (o Options) withDefaults(baseURL, model string) Options {
+	if o.BaseURL == "" {
+		o.BaseURL = baseURL
+	}
+	if o.Model == "" {
+		o.Model = model
+	}
+	if o.MaxTokens <= 0 {
+		o.MaxTokens = DefaultMaxTokens
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+
+	return o
+}
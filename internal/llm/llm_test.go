@@ -0,0 +1,234 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOpenAIProvider_Complete(t *testing.T) {
+	t.Run("should send the configured model and limit and join the streamed deltas", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/chat/completions" {
+				t.Error("exp path /v1/chat/completions; got", r.URL.Path)
+			}
+			var req struct {
+				Model     string `json:"model"`
+				MaxTokens int    `json:"max_tokens"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Model != "local-model" || req.MaxTokens != 100 {
+				t.Error("exp local-model limited to 100 tokens; got", req)
+			}
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, delta := range []string{"Spend ", "less."} {
+				fmt.Fprintf(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":%q}}]}\n\n", delta)
+			}
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		}))
+		defer srv.Close()
+
+		got, err := NewOpenAIProvider("key", Options{
+			BaseURL:   srv.URL + "/v1/",
+			Model:     "local-model",
+			MaxTokens: 100,
+		}).Complete(context.Background(), "prompt", "message")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != "Spend less." {
+			t.Errorf("exp %q; got %q", "Spend less.", got)
+		}
+	})
+	t.Run("should give up after the timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer srv.Close()
+
+		if _, err := NewOpenAIProvider("key", Options{
+			BaseURL: srv.URL,
+			Timeout: 20 * time.Millisecond,
+		}).Complete(context.Background(), "prompt", "message"); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+}
+
+func TestOpenAIProvider_Stream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, delta := range []string{"Spend ", "less", "."} {
+			fmt.Fprintf(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":%q}}]}\n\n", delta)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+	oap := NewOpenAIProvider("key", Options{BaseURL: srv.URL})
+
+	t.Run("should hand out each delta and estimate usage", func(t *testing.T) {
+		var tokens []string
+		usage, err := oap.Stream(context.Background(), "prompt", "message", func(token string) error {
+			tokens = append(tokens, token)
+			return nil
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(tokens) != 3 || tokens[1] != "less" {
+			t.Error("exp 3 tokens; got", tokens)
+		}
+		if usage != (Usage{CompletionTokens: 3, TotalTokens: 3, Estimated: true}) {
+			t.Error("exp an estimate of 3 tokens; got", usage)
+		}
+	})
+	t.Run("should stop at the first error from onToken", func(t *testing.T) {
+		stop := errors.New("client went away")
+		calls := 0
+		if _, err := oap.Stream(context.Background(), "prompt", "message", func(token string) error {
+			calls++
+			return stop
+		}); !errors.Is(err, stop) || calls != 1 {
+			t.Error("exp to stop after 1 token; got", calls, err)
+		}
+	})
+}
+
+func TestOllamaProvider_Complete(t *testing.T) {
+	t.Run("should send the configured model and limit and return the message", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/chat" {
+				t.Error("exp path /api/chat; got", r.URL.Path)
+			}
+			var req ollamaChatRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Model != "mistral" || req.Options.NumPredict != 100 || req.Stream {
+				t.Error("exp mistral limited to 100 tokens without streaming; got", req)
+			}
+			if len(req.Messages) != 2 || req.Messages[0].Content != "prompt" || req.Messages[1].Content != "message" {
+				t.Error("exp the prompt and message; got", req.Messages)
+			}
+
+			fmt.Fprint(w, `{"message":{"role":"assistant","content":"Spend less."},"done":true}`)
+		}))
+		defer srv.Close()
+
+		got, err := NewOllamaProvider(Options{
+			BaseURL:   srv.URL,
+			Model:     "mistral",
+			MaxTokens: 100,
+		}).Complete(context.Background(), "prompt", "message")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != "Spend less." {
+			t.Errorf("exp %q; got %q", "Spend less.", got)
+		}
+	})
+	t.Run("should return the server's error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"model 'mistral' not found"}`)
+		}))
+		defer srv.Close()
+
+		if _, err := NewOllamaProvider(Options{BaseURL: srv.URL}).Complete(context.Background(), "prompt", "message"); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+}
+
+func TestOllamaProvider_Stream(t *testing.T) {
+	t.Run("should hand out each message and report usage", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req ollamaChatRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if !req.Stream {
+				t.Error("exp a streaming request")
+			}
+
+			fmt.Fprintln(w, `{"message":{"role":"assistant","content":"Spend "},"done":false}`)
+			fmt.Fprintln(w, `{"message":{"role":"assistant","content":"less."},"done":false}`)
+			fmt.Fprintln(w, `{"message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":12,"eval_count":2}`)
+		}))
+		defer srv.Close()
+
+		var got string
+		usage, err := NewOllamaProvider(Options{BaseURL: srv.URL}).Stream(context.Background(), "prompt", "message", func(token string) error {
+			got += token
+			return nil
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != "Spend less." {
+			t.Errorf("exp %q; got %q", "Spend less.", got)
+		}
+		if usage != (Usage{PromptTokens: 12, CompletionTokens: 2, TotalTokens: 14}) {
+			t.Error("exp the reported usage; got", usage)
+		}
+	})
+	t.Run("should fail when the stream ends early", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"message":{"role":"assistant","content":"Spend "},"done":false}`)
+		}))
+		defer srv.Close()
+
+		if _, err := NewOllamaProvider(Options{BaseURL: srv.URL}).Stream(context.Background(), "prompt", "message", func(string) error {
+			return nil
+		}); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+}
+
+func TestOfflineProvider_Complete(t *testing.T) {
+	op := NewOfflineProvider()
+
+	t.Run("should answer the same input the same way", func(t *testing.T) {
+		first, err := op.Complete(context.Background(), "prompt", "message")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		second, _ := op.Complete(context.Background(), "prompt", "message")
+		if first == "" || first != second {
+			t.Errorf("exp the same advice twice; got %q and %q", first, second)
+		}
+	})
+	t.Run("should respect a cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err := op.Complete(ctx, "prompt", "message"); !errors.Is(err, context.Canceled) {
+			t.Error("exp context.Canceled; got", err)
+		}
+	})
+}
+
+func TestOfflineProvider_Stream(t *testing.T) {
+	t.Run("should stream what Complete returns", func(t *testing.T) {
+		op := NewOfflineProvider()
+		exp, _ := op.Complete(context.Background(), "prompt", "message")
+
+		var got string
+		usage, err := op.Stream(context.Background(), "prompt", "message", func(token string) error {
+			got += token
+			return nil
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != exp {
+			t.Errorf("exp %q; got %q", exp, got)
+		}
+		if usage.CompletionTokens == 0 || usage.PromptTokens != 2 || !usage.Estimated {
+			t.Error("exp estimated usage; got", usage)
+		}
+	})
+}
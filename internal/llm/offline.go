@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+)
+
+var offlineAdvice = []string{
+	"Look over your largest expenses first; trimming one of them often saves more than cutting many small ones.",
+	"Set a monthly budget for your top spending category and check it weekly so you can adjust before the month ends.",
+	"Review your recurring charges and cancel the subscriptions you no longer use.",
+	"Move a fixed amount into savings as soon as you get paid, before the rest goes to spending.",
+}
+
+type offlineProvider struct{}
+
+// NewOfflineProvider answers without any model, picking one of a few
+// canned pieces of advice by the prompt and message, so the same input
+// always gets the same answer. It is meant for development and tests.
+func NewOfflineProvider() *offlineProvider {
+	return &offlineProvider{}
+}
+
+func (op *offlineProvider) Model() string {
+	return "offline"
+}
+
+func (op *offlineProvider) Complete(ctx context.Context, prompt, message string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(prompt))
+	h.Write([]byte{0})
+	h.Write([]byte(message))
+
+	return offlineAdvice[h.Sum32()%uint32(len(offlineAdvice))], nil
+}
+
+// Stream hands the advice out word by word, and counts words for tokens.
+func (op *offlineProvider) Stream(ctx context.Context, prompt, message string, onToken func(token string) error) (Usage, error) {
+	advice, err := op.Complete(ctx, prompt, message)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	words := strings.SplitAfter(advice, " ")
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return Usage{}, err
+		}
+		if err := onToken(word); err != nil {
+			return Usage{}, err
+		}
+	}
+
+	promptTokens := len(strings.Fields(prompt)) + len(strings.Fields(message))
+	return Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: len(words),
+		TotalTokens:      promptTokens + len(words),
+		Estimated:        true,
+	}, nil
+}
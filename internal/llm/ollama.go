@@ -0,0 +1,156 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	ollamaBaseURL = "http://localhost:11434"
+	ollamaModel   = "llama3"
+)
+
+type ollamaProvider struct {
+	c    *http.Client
+	opts Options
+}
+
+// NewOllamaProvider completes prompts through the chat API of an Ollama
+// server at opts.BaseURL, which defaults to one running locally.
+func NewOllamaProvider(opts Options) *ollamaProvider {
+	opts = opts.withDefaults(ollamaBaseURL, ollamaModel)
+
+	return &ollamaProvider{&http.Client{Timeout: opts.Timeout}, opts}
+}
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Options  struct {
+		NumPredict int `json:"num_predict"`
+	} `json:"options"`
+}
+
+type ollamaChatResponse struct {
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	Error           string        `json:"error"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+}
+
+func (op *ollamaProvider) Model() string {
+	return op.opts.Model
+}
+
+// chat posts a chat request, which streams newline delimited responses
+// when stream is set, and returns the response for the caller to read.
+func (op *ollamaProvider) chat(ctx context.Context, prompt, message string, stream bool) (*http.Response, error) {
+	chat := ollamaChatRequest{
+		Model: op.opts.Model,
+		Messages: []ollamaMessage{
+			{Role: "system", Content: prompt},
+			{Role: "user", Content: message},
+		},
+		Stream: stream,
+	}
+	chat.Options.NumPredict = op.opts.MaxTokens
+	body, err := json.Marshal(chat)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(op.opts.BaseURL, "/")+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := op.c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		var resp ollamaChatResponse
+		json.NewDecoder(res.Body).Decode(&resp)
+		return nil, fmt.Errorf("ollama: %s: %s", res.Status, resp.Error)
+	}
+
+	return res, nil
+}
+
+func (op *ollamaProvider) Complete(ctx context.Context, prompt, message string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, op.opts.Timeout)
+	defer cancel()
+
+	res, err := op.chat(ctx, prompt, message, false)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var resp ollamaChatResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil && err != io.EOF {
+		return "", err
+	}
+	if resp.Message.Content == "" {
+		return "", ErrEmptyCompletion
+	}
+
+	return resp.Message.Content, nil
+}
+
+func (op *ollamaProvider) Stream(ctx context.Context, prompt, message string, onToken func(token string) error) (Usage, error) {
+	ctx, cancel := context.WithTimeout(ctx, op.opts.Timeout)
+	defer cancel()
+
+	res, err := op.chat(ctx, prompt, message, true)
+	if err != nil {
+		return Usage{}, err
+	}
+	defer res.Body.Close()
+
+	dec := json.NewDecoder(res.Body)
+	streamed := false
+	for {
+		var resp ollamaChatResponse
+		if err := dec.Decode(&resp); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return Usage{}, err
+		}
+		if resp.Error != "" {
+			return Usage{}, fmt.Errorf("ollama: %s", resp.Error)
+		}
+
+		if resp.Message.Content != "" {
+			streamed = true
+			if err := onToken(resp.Message.Content); err != nil {
+				return Usage{}, err
+			}
+		}
+		if resp.Done {
+			if !streamed {
+				return Usage{}, ErrEmptyCompletion
+			}
+			return Usage{
+				PromptTokens:     resp.PromptEvalCount,
+				CompletionTokens: resp.EvalCount,
+				TotalTokens:      resp.PromptEvalCount + resp.EvalCount,
+			}, nil
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+type openAIProvider struct {
+	c    *openai.Client
+	opts Options
+}
+
+// NewOpenAIProvider completes prompts through the OpenAI chat completions
+// API at opts.BaseURL, which may be any OpenAI-compatible server, and
+// defaults to OpenAI's own.
+func NewOpenAIProvider(apiKey string, opts Options) *openAIProvider {
+	opts = opts.withDefaults(openai.DefaultConfig("").BaseURL, openai.GPT3Dot5Turbo)
+
+	cfg := openai.DefaultConfig(apiKey)
+	cfg.BaseURL = strings.TrimSuffix(opts.BaseURL, "/")
+	cfg.HTTPClient = &http.Client{Timeout: opts.Timeout}
+
+	return &openAIProvider{openai.NewClientWithConfig(cfg), opts}
+}
+
+func (oap *openAIProvider) Model() string {
+	return oap.opts.Model
+}
+
+func (oap *openAIProvider) Complete(ctx context.Context, prompt, message string) (string, error) {
+	var resp strings.Builder
+	if _, err := oap.Stream(ctx, prompt, message, func(token string) error {
+		resp.WriteString(token)
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	return resp.String(), nil
+}
+
+// Stream reports estimated usage, as streamed completions come without it.
+func (oap *openAIProvider) Stream(ctx context.Context, prompt, message string, onToken func(token string) error) (Usage, error) {
+	ctx, cancel := context.WithTimeout(ctx, oap.opts.Timeout)
+	defer cancel()
+
+	stream, err := oap.c.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Stream:    true,
+		Model:     oap.opts.Model,
+		MaxTokens: oap.opts.MaxTokens,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: prompt,
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: message,
+			},
+		},
+	})
+	if err != nil {
+		return Usage{}, err
+	}
+	defer stream.Close()
+
+	usage := Usage{Estimated: true}
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Usage{}, err
+		}
+
+		if len(response.Choices) == 0 || response.Choices[0].Delta.Content == "" {
+			continue
+		}
+		usage.CompletionTokens++
+		if err := onToken(response.Choices[0].Delta.Content); err != nil {
+			return Usage{}, err
+		}
+	}
+	if usage.CompletionTokens == 0 {
+		return Usage{}, ErrEmptyCompletion
+	}
+	usage.TotalTokens = usage.CompletionTokens
+
+	return usage, nil
+}
@@ -0,0 +1,63 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+const devFrom = "Spendtracker <noreply@localhost>"
+
+type fileMailer struct {
+	dir string
+}
+
+// NewFileMailer writes each message into dir as an .eml file, which mail
+// clients can open, instead of sending it. It is meant for development.
+func NewFileMailer(dir string) *fileMailer {
+	return &fileMailer{dir}
+}
+
+func (fm *fileMailer) Send(msg Message) error {
+	now := time.Now()
+	b, err := format(devFrom, msg, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(fm.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), sanitize(msg.To))
+	return os.WriteFile(filepath.Join(fm.dir, name), b, 0o600)
+}
+
+// sanitize keeps an address usable in a file name.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+type logMailer struct {
+	lg util.Logger
+}
+
+// NewLogMailer logs each message instead of sending it. It is meant for
+// development.
+func NewLogMailer(lg util.Logger) *logMailer {
+	return &logMailer{lg}
+}
+
+func (lm *logMailer) Send(msg Message) error {
+	lm.lg.Log("Mail to", msg.To, "|", msg.Subject, "|", msg.Body)
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	t.Run("should render headers and a CRLF body", func(t *testing.T) {
+		got, err := format("from@example.com", Message{
+			To:      "to@example.com",
+			Subject: "Verify your email",
+			Body:    "Hello\nthere",
+		}, time.Date(2023, time.October, 16, 8, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		for _, exp := range []string{
+			"From: from@example.com\r\n",
+			"To: to@example.com\r\n",
+			"Subject: Verify your email\r\n",
+			"Date: Mon, 16 Oct 2023 08:00:00 +0000\r\n",
+			"\r\n\r\nHello\r\nthere",
+		} {
+			if !strings.Contains(string(got), exp) {
+				t.Errorf("exp %q in message; got %q", exp, got)
+			}
+		}
+	})
+	t.Run("should refuse headers with line breaks", func(t *testing.T) {
+		if _, err := format("from@example.com", Message{
+			To:      "to@example.com\r\nBcc: someone@example.com",
+			Subject: "Hi",
+		}, time.Now()); !errors.Is(err, ErrInvalidHeader) {
+			t.Error("exp ErrInvalidHeader; got", err)
+		}
+	})
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	t.Run("should write the message into the directory", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := NewFileMailer(dir).Send(Message{
+			To:      "to@example.com",
+			Subject: "Hi",
+			Body:    "Hello",
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+
+		files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+		if len(files) != 1 {
+			t.Fatal("exp 1 file; got", len(files))
+		}
+		b, _ := os.ReadFile(files[0])
+		if !strings.Contains(string(b), "To: to@example.com") {
+			t.Error("exp the message in the file; got", string(b))
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"net"
+	"net/smtp"
+	"time"
+)
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer sends through an SMTP server, authenticating with username
+// and password if a username is given. The connection is upgraded with
+// STARTTLS when the server offers it.
+func NewSMTPMailer(host, port, username, password, from string) *smtpMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpMailer{net.JoinHostPort(host, port), auth, from}
+}
+
+func (sm *smtpMailer) Send(msg Message) error {
+	b, err := format(sm.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(sm.addr, sm.auth, sm.from, []string{msg.To}, b)
+}
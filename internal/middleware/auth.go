@@ -1,26 +1,31 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/service"
 )
 
 type AuthMiddleware interface {
 	Authenticate(next echo.HandlerFunc) echo.HandlerFunc
+	RequireVerifiedEmail(next echo.HandlerFunc) echo.HandlerFunc
 }
 
 type authMiddleware struct {
 	us     service.UserService
+	as     service.AuthService
+	ats    service.APITokenService
 	secret string
 }
 
-func NewAuthMiddleware(us service.UserService, secret string) *authMiddleware {
-	return &authMiddleware{us, secret}
+func NewAuthMiddleware(us service.UserService, as service.AuthService, ats service.APITokenService, secret string) *authMiddleware {
+	return &authMiddleware{us, as, ats, secret}
 }
 
 func (am *authMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
@@ -30,6 +35,9 @@ func (am *authMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 			ctx.Logger().Error("invalid Authorization header")
 			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 		}
+		if strings.HasPrefix(authorizationHeader[1], service.APITokenPrefix) {
+			return am.authenticateAPIToken(ctx, next, authorizationHeader[1])
+		}
 
 		token, err := jwt.Parse(authorizationHeader[1], func(t *jwt.Token) (interface{}, error) {
 			return []byte(am.secret), nil
@@ -59,6 +67,23 @@ func (am *authMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 		}
 
+		// Access tokens without a session predate sessions and can't be
+		// revoked, so they aren't accepted.
+		claimsSid, ok := claims["sid"].(float64)
+		if !ok {
+			ctx.Logger().Error("access token has no session")
+			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+		}
+		session, err := am.as.GetActiveSession(uint(claimsSid))
+		if err != nil {
+			ctx.Logger().Error(err.Error())
+			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+		}
+		if int(session.UserID) != userIDInt {
+			ctx.Logger().Error("access token session belongs to another user")
+			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+		}
+
 		user, err := am.us.GetOneByID(userIDInt)
 		if err != nil {
 			ctx.Logger().Error(err.Error())
@@ -66,6 +91,97 @@ func (am *authMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 		}
 
 		ctx.Set("user", user)
+		ctx.Set("session", session)
+		return next(ctx)
+	}
+}
+
+// RequireVerifiedEmail only lets users who haven't verified their email
+// address read. It goes after Authenticate.
+func (am *authMiddleware) RequireVerifiedEmail(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		method := ctx.Request().Method
+		if method == http.MethodGet || method == http.MethodHead {
+			return next(ctx)
+		}
+
+		user, ok := ctx.Get("user").(model.User)
+		if !ok || user.EmailVerifiedAt == nil {
+			return echo.NewHTTPError(http.StatusForbidden, "Verify your email address first")
+		}
+
 		return next(ctx)
 	}
 }
+
+// authenticateAPIToken lets the request through if the API token's scopes
+// cover the resource of the route it is for.
+func (am *authMiddleware) authenticateAPIToken(ctx echo.Context, next echo.HandlerFunc, token string) error {
+	method := ctx.Request().Method
+	write := method != http.MethodGet && method != http.MethodHead
+	apiToken, err := am.ats.Authenticate(token, resourceOf(ctx.Path()), write)
+	if err != nil {
+		ctx.Logger().Error(err.Error())
+		if errors.Is(err, service.ErrAPITokenScope) {
+			return echo.NewHTTPError(http.StatusForbidden, "Forbidden")
+		}
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	user, err := am.us.GetOneByID(int(apiToken.UserID))
+	if err != nil {
+		ctx.Logger().Error(err.Error())
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	ctx.Set("user", user)
+	ctx.Set("apiToken", apiToken)
+	return next(ctx)
+}
+
+// routeResources maps the routes API tokens can reach to the resource their
+// scopes have to cover. Routes missing from it, like the ones for users,
+// sessions and API tokens themselves, are left to logged in users.
+var routeResources = map[string]string{
+	"/accounts":                                 "accounts",
+	"/accounts/:accountID":                      "accounts",
+	"/accounts/:accountID/balance":              "accounts",
+	"/categories":                               "categories",
+	"/categories/:categoryID":                   "categories",
+	"/accounts/:accountID/expenses":             "expenses",
+	"/expenses":                                 "expenses",
+	"/expenses/:expenseID":                      "expenses",
+	"/accounts/:accountID/incomes":              "incomes",
+	"/incomes":                                  "incomes",
+	"/incomes/:incomeID":                        "incomes",
+	"/transactions":                             "transactions",
+	"/transfers":                                "transfers",
+	"/transfers/:transferID":                    "transfers",
+	"/budgets":                                  "budgets",
+	"/budgets/:budgetID":                        "budgets",
+	"/budgets/:budgetID/status":                 "budgets",
+	"/recurring-templates":                      "recurring-templates",
+	"/recurring-templates/:templateID":          "recurring-templates",
+	"/recurring-templates/:templateID/upcoming": "recurring-templates",
+	"/import-mappings":                          "import-mappings",
+	"/import-mappings/:mappingID":               "import-mappings",
+	"/accounts/:accountID/imports/csv":          "imports",
+	"/accounts/:accountID/imports/ofx":          "imports",
+	"/backup":                                   "backup",
+	"/backup/restore":                           "backup",
+	"/export/ledger":                            "export",
+	"/export/beancount":                         "export",
+	"/reports/expenses":                         "reports",
+	"/reporting-currency":                       "reporting-currency",
+	"/exchange-rates":                           "exchange-rates",
+	"/exchange-rates/imports":                   "exchange-rates",
+	"/advice":                                   "advice",
+	"/advice/stream":                            "advice",
+	"/advice/history":                           "advice",
+}
+
+// resourceOf returns the resource of the route at path, or an empty string
+// if API tokens can't reach it.
+func resourceOf(path string) string {
+	return routeResources[path]
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/labstack/echo/v4"
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/service"
 	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
 	"github.com/muhrizqiardi/spendtracker/tests/testutil"
 	"go.uber.org/mock/gomock"
@@ -23,7 +25,9 @@ var mockSecret string = "DO_NOT_USE_THIS"
 func TestAuthMiddleware_Authenticate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mus := mock_service.NewMockUserService(ctrl)
-	am := NewAuthMiddleware(mus, mockSecret)
+	mas := mock_service.NewMockAuthService(ctrl)
+	mats := mock_service.NewMockAPITokenService(ctrl)
+	am := NewAuthMiddleware(mus, mas, mats, mockSecret)
 
 	t.Run("should return error if `Authorization` header is invalid", func(t *testing.T) {
 		r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
@@ -83,7 +87,80 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 		}
 	})
 
+	t.Run("should return error if token has no session", func(t *testing.T) {
+		claims := jwt.RegisteredClaims{
+			Subject:   "42",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
+		}
+		ss, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(mockSecret))
+
+		r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		r.Header.Set("Authorization", "Bearer "+ss)
+		w := httptest.NewRecorder()
+		e := echo.New()
+		c := e.NewContext(r, w)
+
+		got := am.Authenticate(func(c echo.Context) error {
+			return nil
+		})(c)
+
+		if got == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+
+	t.Run("should return error if the token's session was revoked", func(t *testing.T) {
+		mas.EXPECT().GetActiveSession(gomock.Eq(uint(7))).Return(model.Session{}, service.ErrSessionRevoked)
+
+		claims := jwt.MapClaims{
+			"sub": "42",
+			"sid": 7,
+			"exp": jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
+		}
+		ss, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(mockSecret))
+
+		r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		r.Header.Set("Authorization", "Bearer "+ss)
+		w := httptest.NewRecorder()
+		e := echo.New()
+		c := e.NewContext(r, w)
+
+		got := am.Authenticate(func(c echo.Context) error {
+			return nil
+		})(c)
+
+		if got == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+
+	t.Run("should return error if the token's session belongs to another user", func(t *testing.T) {
+		mas.EXPECT().GetActiveSession(gomock.Eq(uint(7))).Return(model.Session{UserID: 1}, nil)
+
+		claims := jwt.MapClaims{
+			"sub": "42",
+			"sid": 7,
+			"exp": jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
+		}
+		ss, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(mockSecret))
+
+		r := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		r.Header.Set("Authorization", "Bearer "+ss)
+		w := httptest.NewRecorder()
+		e := echo.New()
+		c := e.NewContext(r, w)
+
+		got := am.Authenticate(func(c echo.Context) error {
+			return nil
+		})(c)
+
+		if got == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+
 	t.Run("should proceed request/call the `next` handler set context's `user` value", func(t *testing.T) {
+		mas.EXPECT().GetActiveSession(gomock.Eq(uint(7))).Return(model.Session{UserID: 42}, nil)
 		mus.
 			EXPECT().
 			GetOneByID(gomock.Eq(42)).
@@ -97,9 +174,10 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 				},
 			)
 
-		validClaims := jwt.RegisteredClaims{
-			Subject:   "42",
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
+		validClaims := jwt.MapClaims{
+			"sub": "42",
+			"sid": 7,
+			"exp": jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
 		}
 		validToken := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims)
 		validSS, _ := validToken.SignedString([]byte(mockSecret))
@@ -140,3 +218,126 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 
 	})
 }
+
+func TestAuthMiddleware_Authenticate_APIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mus := mock_service.NewMockUserService(ctrl)
+	mas := mock_service.NewMockAuthService(ctrl)
+	mats := mock_service.NewMockAPITokenService(ctrl)
+	am := NewAuthMiddleware(mus, mas, mats, mockSecret)
+
+	request := func(method, path string) echo.Context {
+		r := httptest.NewRequest(method, "/", nil)
+		r.Header.Set("Authorization", "Bearer spt_token")
+		c := echo.New().NewContext(r, httptest.NewRecorder())
+		c.SetPath(path)
+		return c
+	}
+
+	t.Run("should return 401 for an invalid or expired token", func(t *testing.T) {
+		mats.EXPECT().Authenticate(gomock.Eq("spt_token"), gomock.Eq("expenses"), gomock.Eq(false)).Return(model.APIToken{}, service.ErrInvalidAPIToken)
+
+		got := am.Authenticate(func(c echo.Context) error {
+			return nil
+		})(request(http.MethodGet, "/expenses"))
+
+		var httpErr *echo.HTTPError
+		if !errors.As(got, &httpErr) || httpErr.Code != http.StatusUnauthorized {
+			t.Error("exp 401; got", got)
+		}
+	})
+	t.Run("should return 403 when the token's scopes don't cover the route", func(t *testing.T) {
+		mats.EXPECT().Authenticate(gomock.Eq("spt_token"), gomock.Eq("expenses"), gomock.Eq(true)).Return(model.APIToken{}, service.ErrAPITokenScope)
+
+		got := am.Authenticate(func(c echo.Context) error {
+			return nil
+		})(request(http.MethodPost, "/accounts/:accountID/expenses"))
+
+		var httpErr *echo.HTTPError
+		if !errors.As(got, &httpErr) || httpErr.Code != http.StatusForbidden {
+			t.Error("exp 403; got", got)
+		}
+	})
+	t.Run("should set the token's user", func(t *testing.T) {
+		mats.EXPECT().Authenticate(gomock.Eq("spt_token"), gomock.Eq("accounts"), gomock.Eq(false)).Return(model.APIToken{UserID: 42}, nil)
+		mus.EXPECT().GetOneByID(gomock.Eq(42)).Return(model.User{Model: gorm.Model{ID: 42}}, nil)
+
+		got := am.Authenticate(func(c echo.Context) error {
+			if user := c.Get("user").(model.User); user.ID != 42 {
+				t.Error("exp user 42; got", user.ID)
+			}
+			return nil
+		})(request(http.MethodGet, "/accounts/:accountID/balance"))
+
+		if got != nil {
+			t.Error("exp nil; got error:", got)
+		}
+	})
+	t.Run("should not let tokens reach routes outside their resources", func(t *testing.T) {
+		mats.EXPECT().Authenticate(gomock.Eq("spt_token"), gomock.Eq(""), gomock.Eq(true)).Return(model.APIToken{}, service.ErrAPITokenScope)
+
+		got := am.Authenticate(func(c echo.Context) error {
+			return nil
+		})(request(http.MethodPost, "/api-tokens"))
+
+		if got == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+	t.Run("should scope nested routes to the resource they are for", func(t *testing.T) {
+		for _, tc := range []struct {
+			method   string
+			path     string
+			resource string
+		}{
+			{http.MethodGet, "/reports/expenses", "reports"},
+			{http.MethodPost, "/exchange-rates/imports", "exchange-rates"},
+			{http.MethodPost, "/accounts/:accountID/imports/csv", "imports"},
+		} {
+			mats.EXPECT().Authenticate(gomock.Eq("spt_token"), gomock.Eq(tc.resource), gomock.Eq(tc.method != http.MethodGet)).Return(model.APIToken{UserID: 42}, nil)
+			mus.EXPECT().GetOneByID(gomock.Eq(42)).Return(model.User{Model: gorm.Model{ID: 42}}, nil)
+
+			got := am.Authenticate(func(c echo.Context) error {
+				return nil
+			})(request(tc.method, tc.path))
+
+			if got != nil {
+				t.Error("exp nil; got error:", got)
+			}
+		}
+	})
+}
+
+func TestAuthMiddleware_RequireVerifiedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	am := NewAuthMiddleware(mock_service.NewMockUserService(ctrl), mock_service.NewMockAuthService(ctrl), mock_service.NewMockAPITokenService(ctrl), mockSecret)
+
+	request := func(method string, user model.User) echo.Context {
+		c := echo.New().NewContext(httptest.NewRequest(method, "/", nil), httptest.NewRecorder())
+		c.Set("user", user)
+		return c
+	}
+	next := func(c echo.Context) error {
+		return nil
+	}
+
+	t.Run("should let unverified users read", func(t *testing.T) {
+		if got := am.RequireVerifiedEmail(next)(request(http.MethodGet, model.User{})); got != nil {
+			t.Error("exp nil; got error:", got)
+		}
+	})
+	t.Run("should stop unverified users from changing anything", func(t *testing.T) {
+		got := am.RequireVerifiedEmail(next)(request(http.MethodPost, model.User{}))
+
+		var httpErr *echo.HTTPError
+		if !errors.As(got, &httpErr) || httpErr.Code != http.StatusForbidden {
+			t.Error("exp 403; got", got)
+		}
+	})
+	t.Run("should let verified users through", func(t *testing.T) {
+		verifiedAt := time.Now()
+		if got := am.RequireVerifiedEmail(next)(request(http.MethodPost, model.User{EmailVerifiedAt: &verifiedAt})); got != nil {
+			t.Error("exp nil; got error:", got)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+// Package money converts between decimal amounts as people write them,
+// such as "12.50", and integers of a currency's minor units, such as 1250
+// cents, which is how amounts are stored.
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// maxDigits keeps amounts in minor units within an int64.
+const maxDigits = 18
+
+var (
+	ErrInvalidAmount = errors.New("not a decimal number")
+	ErrTooPrecise    = errors.New("more decimal places than the currency has")
+)
+
+// Decimal is an amount as sent by a client. It unmarshals from a JSON
+// string or number, keeping the digits exactly as they were written rather
+// than going through a float.
+type Decimal string
+
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*d = ""
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*d = Decimal(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*d = Decimal(n)
+	return nil
+}
+
+// Parse reads a decimal amount such as "-12.50" into minor units of a
+// currency with minorUnit decimal places. Digits beyond those places are
+// only accepted when they are zeros, so no amount is ever rounded.
+func Parse(value string, minorUnit int) (int, error) {
+	s := strings.TrimSpace(value)
+	negative := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		negative = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	whole, fraction, hasPoint := strings.Cut(s, ".")
+	if whole == "" && fraction == "" || hasPoint && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if len(fraction) > minorUnit {
+		if strings.Trim(fraction[minorUnit:], "0") != "" {
+			return 0, fmt.Errorf("%w: %q allows %d", ErrTooPrecise, value, minorUnit)
+		}
+		fraction = fraction[:minorUnit]
+	}
+	digits := strings.TrimLeft(whole+fraction+strings.Repeat("0", minorUnit-len(fraction)), "0")
+	if len(digits) > maxDigits {
+		return 0, fmt.Errorf("%w: %q is too large", ErrInvalidAmount, value)
+	}
+
+	amount := 0
+	for _, c := range digits {
+		amount = amount*10 + int(c-'0')
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// Format writes amount, in minor units, as a decimal with minorUnit places.
+func Format(amount, minorUnit int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if minorUnit <= 0 {
+		return fmt.Sprintf("%s%d", sign, amount)
+	}
+
+	digits := fmt.Sprintf("%0*d", minorUnit+1, amount)
+	cut := len(digits) - minorUnit
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// FormatFloat is Format for amounts that are not whole minor units, such as
+// averages, which are rounded to the nearest one.
+func FormatFloat(amount float64, minorUnit int) string {
+	return Format(int(math.Round(amount)), minorUnit)
+}
+
+// Convert converts amount, in minor units of a currency with fromMinorUnit
+// places, into minor units of one with toMinorUnit places, given how many
+// units of the latter one unit of the former is worth.
+func Convert(amount int, rate float64, fromMinorUnit, toMinorUnit int) int {
+	return int(math.Round(ConvertFloat(float64(amount), rate, fromMinorUnit, toMinorUnit)))
+}
+
+// ConvertFloat is Convert without rounding.
+func ConvertFloat(amount, rate float64, fromMinorUnit, toMinorUnit int) float64 {
+	return amount * rate * math.Pow10(toMinorUnit-fromMinorUnit)
+}
+
+// Scale converts a number of whole units into minor units.
+func Scale(units, minorUnit int) int {
+	for i := 0; i < minorUnit; i++ {
+		units *= 10
+	}
+
+	return units
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
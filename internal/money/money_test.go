@@ -0,0 +1,104 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should read amounts into minor units", func(t *testing.T) {
+		for _, tc := range []struct {
+			value     string
+			minorUnit int
+			exp       int
+		}{
+			{"12.50", 2, 1250},
+			{"12.5", 2, 1250},
+			{"12", 2, 1200},
+			{".5", 2, 50},
+			{"-0.01", 2, -1},
+			{"+3", 0, 3},
+			{"1500", 0, 1500},
+			{"1500.00", 0, 1500},
+			{"1.234", 3, 1234},
+			{"0", 3, 0},
+		} {
+			got, err := Parse(tc.value, tc.minorUnit)
+			if err != nil {
+				t.Error("exp nil; got error:", err)
+			}
+			if got != tc.exp {
+				t.Error("exp", tc.exp, "for", tc.value, "; got", got)
+			}
+		}
+	})
+	t.Run("should return error for more places than the currency has", func(t *testing.T) {
+		if _, err := Parse("12.505", 2); !errors.Is(err, ErrTooPrecise) {
+			t.Error("exp ErrTooPrecise; got", err)
+		}
+		if _, err := Parse("1500.5", 0); !errors.Is(err, ErrTooPrecise) {
+			t.Error("exp ErrTooPrecise; got", err)
+		}
+	})
+	t.Run("should return error for anything but a decimal number", func(t *testing.T) {
+		for _, value := range []string{"", "-", ".", "1.", "1,50", "1e3", "12.5.0", "ten", "1 000", "9999999999999999999"} {
+			if _, err := Parse(value, 2); !errors.Is(err, ErrInvalidAmount) {
+				t.Error("exp ErrInvalidAmount for", value, "; got", err)
+			}
+		}
+	})
+}
+
+func TestFormat(t *testing.T) {
+	for _, tc := range []struct {
+		amount    int
+		minorUnit int
+		exp       string
+	}{
+		{1250, 2, "12.50"},
+		{5, 2, "0.05"},
+		{-5, 2, "-0.05"},
+		{1500, 0, "1500"},
+		{1234, 3, "1.234"},
+		{0, 3, "0.000"},
+	} {
+		if got := Format(tc.amount, tc.minorUnit); got != tc.exp {
+			t.Error("exp", tc.exp, "; got", got)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("should account for the currencies' minor units", func(t *testing.T) {
+		// 10.00 USD at 15,000 IDR per USD is 150,000.00 IDR.
+		if got := Convert(1000, 15000, 2, 2); got != 15000000 {
+			t.Error("exp 15000000; got", got)
+		}
+		// 1,000 JPY at 0.0067 USD per JPY is 6.70 USD.
+		if got := Convert(1000, 0.0067, 0, 2); got != 670 {
+			t.Error("exp 670; got", got)
+		}
+		// 1.000 BHD at 2.65 USD per BHD is 2.65 USD.
+		if got := Convert(1000, 2.65, 3, 2); got != 265 {
+			t.Error("exp 265; got", got)
+		}
+	})
+}
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	var payload struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+		C Decimal `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a": "12.50", "b": 0.1, "c": null}`), &payload); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if payload.A != "12.50" || payload.B != "0.1" || payload.C != "" {
+		t.Error("exp 12.50, 0.1 and empty; got", payload)
+	}
+	if err := json.Unmarshal([]byte(`{"a": true}`), &payload); err == nil {
+		t.Error("exp error for a boolean; got nil")
+	}
+}
@@ -1,16 +1,24 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"gorm.io/gorm"
 )
 
+// ErrAccountHasTransactions is returned when changing the currency of an
+// account whose amounts are already recorded in minor units of the old one.
+var ErrAccountHasTransactions = errors.New("Account has transactions")
+
 type AccountRepository interface {
 	Insert(userID uint, currencyID uint, name string, initialAmount int) (model.Account, error)
-	GetOneByID(id uint) (model.Account, error)
-	GetMany(userID uint, limit int, offset int) ([]model.Account, error)
-	UpdateOneByID(id uint, currencyID uint, name string, initialAmount int) (model.Account, error)
-	DeleteOneByID(id uint) error
+	GetOneByID(userID, id uint) (model.Account, error)
+	GetMany(userID uint, limit int, cursor string) (model.Page[model.Account], error)
+	UpdateOneByID(userID, id uint, currencyID uint, name string, initialAmount int) (model.Account, error)
+	DeleteOneByID(userID, id uint) error
+	GetTransactionTotals(ids []uint, until time.Time) (map[uint]int, error)
 }
 
 type accountRepository struct {
@@ -35,45 +43,127 @@ func (ar *accountRepository) Insert(userID uint, currencyID uint, name string, i
 	return newAccount, nil
 }
 
-func (ar *accountRepository) GetOneByID(id uint) (model.Account, error) {
+func (ar *accountRepository) GetOneByID(userID, id uint) (model.Account, error) {
 	var account model.Account
-	if err := ar.db.First(&account, "id = ?", id).Error; err != nil {
+	if err := ar.db.Scopes(ownedBy(userID)).Preload("Currency").First(&account, "id = ?", id).Error; err != nil {
 		return model.Account{}, err
 	}
 
 	return account, nil
 }
 
-func (ar *accountRepository) GetMany(userID uint, limit int, offset int) ([]model.Account, error) {
-	var accounts []model.Account
-	if err := ar.db.Limit(limit).Offset(offset).Find(&accounts, "user_id = ?", userID).Error; err != nil {
-		return []model.Account{}, err
-	}
+var accountKeyset = keyset[model.Account]{
+	name:      "id",
+	idColumn:  "id",
+	id:        func(a model.Account) uint { return a.ID },
+	ascending: true,
+}
 
-	return accounts, nil
+// GetMany lists the user's accounts oldest first, a page at a time.
+func (ar *accountRepository) GetMany(userID uint, limit int, cursor string) (model.Page[model.Account], error) {
+	return paginate(ar.db.Model(&model.Account{}).Scopes(ownedBy(userID)), accountKeyset, cursor, limit, withCurrency)
 }
 
-func (ar *accountRepository) UpdateOneByID(id uint, currencyID uint, name string, initialAmount int) (model.Account, error) {
-	updatedAccount := model.Account{
-		Model: gorm.Model{
-			ID: uint(id),
-		},
-		CurrencyID:    currencyID,
-		Name:          name,
-		InitialAmount: initialAmount,
-	}
-	if err := ar.db.Save(&updatedAccount).Error; err != nil {
+// UpdateOneByID only changes the currency of an account with no
+// transactions or recurring templates, since their amounts would otherwise be
+// read in the wrong currency.
+func (ar *accountRepository) UpdateOneByID(userID, id uint, currencyID uint, name string, initialAmount int) (model.Account, error) {
+	var account model.Account
+	if err := ar.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Scopes(ownedBy(userID)).First(&account, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if currencyID != account.CurrencyID {
+			used, err := hasTransactions(tx, account.ID)
+			if err != nil {
+				return err
+			}
+			if used {
+				return ErrAccountHasTransactions
+			}
+		}
+		account.CurrencyID = currencyID
+		account.Name = name
+		account.InitialAmount = initialAmount
+
+		return tx.Save(&account).Error
+	}); err != nil {
 		return model.Account{}, err
 	}
 
-	return updatedAccount, nil
+	return account, nil
+}
+
+func hasTransactions(tx *gorm.DB, accountID uint) (bool, error) {
+	for _, q := range []*gorm.DB{
+		tx.Model(&model.Expense{}).Where("account_id = ?", accountID),
+		tx.Model(&model.Income{}).Where("account_id = ?", accountID),
+		tx.Model(&model.Transfer{}).Where("from_account_id = ? OR to_account_id = ?", accountID, accountID),
+		tx.Model(&model.RecurringTemplate{}).Where("account_id = ?", accountID),
+	} {
+		var count int64
+		if err := q.Limit(1).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
-func (ar *accountRepository) DeleteOneByID(id uint) error {
+func (ar *accountRepository) DeleteOneByID(userID, id uint) error {
 	var account model.Account
-	if err := ar.db.Where("id = ?", id).Delete(&account).Error; err != nil {
-		return err
+	result := ar.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&account)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 
 	return nil
 }
+
+// GetTransactionTotals returns, per account, the sum of incomes and incoming
+// transfers minus the sum of expenses and outgoing transfers (including
+// their fees) recorded before until.
+func (ar *accountRepository) GetTransactionTotals(ids []uint, until time.Time) (map[uint]int, error) {
+	expenses := ar.db.
+		Model(&model.Expense{}).
+		Select("account_id, -amount AS amount").
+		Where("account_id IN ? AND occurred_at < ?", ids, until)
+	incomes := ar.db.
+		Model(&model.Income{}).
+		Select("account_id, amount").
+		Where("account_id IN ? AND occurred_at < ?", ids, until)
+	transfersOut := ar.db.
+		Model(&model.Transfer{}).
+		Select("from_account_id AS account_id, -(amount + fee) AS amount").
+		Where("from_account_id IN ? AND occurred_at < ?", ids, until)
+	transfersIn := ar.db.
+		Model(&model.Transfer{}).
+		Select("to_account_id AS account_id, received_amount AS amount").
+		Where("to_account_id IN ? AND occurred_at < ?", ids, until)
+
+	var rows []struct {
+		AccountID uint
+		Total     int
+	}
+	if err := ar.db.
+		Table("(? UNION ALL ? UNION ALL ? UNION ALL ?) AS transactions", expenses, incomes, transfersOut, transfersIn).
+		Select("account_id, SUM(amount) AS total").
+		Group("account_id").
+		Scan(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uint]int, len(ids))
+	for _, r := range rows {
+		totals[r.AccountID] = r.Total
+	}
+
+	return totals, nil
+}
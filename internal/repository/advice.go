@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+type AdviceRepository interface {
+	Insert(advice model.Advice) (model.Advice, error)
+	GetLatestByInputHash(userID uint, inputHash string) (model.Advice, error)
+	GetManyBelongedToUser(userID uint, limit int, cursor string) (model.Page[model.Advice], error)
+	GetDataVersion(userID uint) (string, error)
+}
+
+type adviceRepository struct {
+	db *gorm.DB
+}
+
+func NewAdviceRepository(db *gorm.DB) *adviceRepository {
+	return &adviceRepository{db}
+}
+
+func (ar *adviceRepository) Insert(advice model.Advice) (model.Advice, error) {
+	if err := ar.db.Create(&advice).Error; err != nil {
+		return model.Advice{}, err
+	}
+
+	return advice, nil
+}
+
+func (ar *adviceRepository) GetLatestByInputHash(userID uint, inputHash string) (model.Advice, error) {
+	var advice model.Advice
+	if err := ar.db.Scopes(ownedBy(userID)).
+		Where("input_hash = ?", inputHash).
+		Order("id desc").
+		First(&advice).Error; err != nil {
+		return model.Advice{}, err
+	}
+
+	return advice, nil
+}
+
+var adviceKeyset = keyset[model.Advice]{
+	name:      "id",
+	idColumn:  "id",
+	id:        func(a model.Advice) uint { return a.ID },
+	ascending: false,
+}
+
+// GetManyBelongedToUser lists the user's advice newest first, a page at a
+// time.
+func (ar *adviceRepository) GetManyBelongedToUser(userID uint, limit int, cursor string) (model.Page[model.Advice], error) {
+	return paginate(ar.db.Model(&model.Advice{}).Scopes(ownedBy(userID)), adviceKeyset, cursor, limit)
+}
+
+// adviceSources are the tables of what advice is based on.
+var adviceSources = []interface{}{
+	&model.Account{},
+	&model.Category{},
+	&model.Expense{},
+	&model.Income{},
+	&model.Budget{},
+	&model.RecurringTemplate{},
+	&model.ExchangeRate{},
+}
+
+// GetDataVersion fingerprints what advice is based on, which changes
+// whenever any of it is created, updated or deleted, as well as when the
+// user's reporting currency does. Deleted rows are counted too, so that
+// deleting one row and creating another does not go unnoticed.
+func (ar *adviceRepository) GetDataVersion(userID uint) (string, error) {
+	h := sha256.New()
+	for _, source := range adviceSources {
+		var row struct {
+			Count     int64
+			UpdatedAt sql.NullString
+			DeletedAt sql.NullString
+		}
+		if err := ar.db.Unscoped().Model(source).
+			Select("COUNT(*) AS count, MAX(updated_at) AS updated_at, MAX(deleted_at) AS deleted_at").
+			Where("user_id = ?", userID).
+			Scan(&row).Error; err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%d|%s|%s\n", row.Count, row.UpdatedAt.String, row.DeletedAt.String)
+	}
+
+	var user model.User
+	if err := ar.db.Select("reporting_currency_id").First(&user, "id = ?", userID).Error; err != nil {
+		return "", err
+	}
+	if user.ReportingCurrencyID != nil {
+		fmt.Fprintf(h, "%d\n", *user.ReportingCurrencyID)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+type APITokenRepository interface {
+	Insert(token model.APIToken) (model.APIToken, error)
+	GetOneByHash(tokenHash string) (model.APIToken, error)
+	GetManyBelongedToUser(userID uint) ([]model.APIToken, error)
+	DeleteOneByID(userID, id uint) error
+}
+
+type apiTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) *apiTokenRepository {
+	return &apiTokenRepository{db}
+}
+
+func (atr *apiTokenRepository) Insert(token model.APIToken) (model.APIToken, error) {
+	if err := atr.db.Create(&token).Error; err != nil {
+		return model.APIToken{}, err
+	}
+
+	return token, nil
+}
+
+func (atr *apiTokenRepository) GetOneByHash(tokenHash string) (model.APIToken, error) {
+	var token model.APIToken
+	if err := atr.db.First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		return model.APIToken{}, err
+	}
+
+	return token, nil
+}
+
+func (atr *apiTokenRepository) GetManyBelongedToUser(userID uint) ([]model.APIToken, error) {
+	var tokens []model.APIToken
+	if err := atr.db.
+		Scopes(ownedBy(userID)).
+		Order("created_at desc").
+		Find(&tokens).
+		Error; err != nil {
+		return []model.APIToken{}, err
+	}
+
+	return tokens, nil
+}
+
+func (atr *apiTokenRepository) DeleteOneByID(userID, id uint) error {
+	var token model.APIToken
+	result := atr.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&token)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
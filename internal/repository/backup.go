@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BackupRepository interface {
+	GetCurrencies() ([]model.Currency, error)
+	Export(userID uint) (model.Backup, error)
+	Restore(userID uint, backup model.Backup) (model.Backup, error)
+}
+
+type backupRepository struct {
+	db *gorm.DB
+}
+
+func NewBackupRepository(db *gorm.DB) *backupRepository {
+	return &backupRepository{db}
+}
+
+func (br *backupRepository) GetCurrencies() ([]model.Currency, error) {
+	var currencies []model.Currency
+	if err := br.db.Order("id").Find(&currencies).Error; err != nil {
+		return []model.Currency{}, err
+	}
+
+	return currencies, nil
+}
+
+func (br *backupRepository) Export(userID uint) (model.Backup, error) {
+	var backup model.Backup
+	var user model.User
+	if err := br.db.Preload("ReportingCurrency").Take(&user, "id = ?", userID).Error; err != nil {
+		return model.Backup{}, err
+	}
+	backup.ReportingCurrency = user.ReportingCurrency
+
+	// Deleted accounts and categories are kept, as their transactions are.
+	if err := br.db.Unscoped().Scopes(ownedBy(userID), withCurrency).Order("id").Find(&backup.Accounts).Error; err != nil {
+		return model.Backup{}, err
+	}
+	if err := br.db.Unscoped().Scopes(ownedBy(userID)).Order("id").Find(&backup.Categories).Error; err != nil {
+		return model.Backup{}, err
+	}
+	for _, records := range []interface{}{
+		&backup.Incomes,
+		&backup.Transfers,
+		&backup.RecurringTemplates,
+		&backup.ImportMappings,
+		&backup.ExchangeRates,
+		&backup.Advice,
+	} {
+		if err := br.db.Scopes(ownedBy(userID)).Order("id").Find(records).Error; err != nil {
+			return model.Backup{}, err
+		}
+	}
+	if err := br.db.Scopes(ownedBy(userID), withTags).Order("id").Find(&backup.Expenses).Error; err != nil {
+		return model.Backup{}, err
+	}
+	if err := br.db.Scopes(ownedBy(userID), withCurrency).Order("id").Find(&backup.Budgets).Error; err != nil {
+		return model.Backup{}, err
+	}
+	if err := br.db.
+		Where("template_id IN (?)", br.db.Model(&model.RecurringTemplate{}).Scopes(ownedBy(userID)).Select("id")).
+		Order("id").
+		Find(&backup.RecurringOccurrences).
+		Error; err != nil {
+		return model.Backup{}, err
+	}
+
+	return backup, nil
+}
+
+// uncategorized is the category restored expenses recorded before expenses
+// had categories are put in, since every expense needs one now.
+const uncategorized = "Uncategorized"
+
+// Restore adds the backup to the user's data in one transaction. Records
+// get new IDs, and references between them are remapped to match; a
+// category the user already has is reused rather than duplicated, as are
+// exchange rates. The backup's reporting currency is only used if the user
+// has none. Deleted accounts and categories are restored deleted. The IDs
+// in backup must be consistent, which the caller checks.
+func (br *backupRepository) Restore(userID uint, backup model.Backup) (model.Backup, error) {
+	var restored model.Backup
+	err := br.db.Transaction(func(tx *gorm.DB) error {
+		if backup.ReportingCurrency != nil {
+			if err := tx.
+				Model(&model.User{}).
+				Where("id = ? AND reporting_currency_id IS NULL", userID).
+				Update("reporting_currency_id", backup.ReportingCurrency.ID).
+				Error; err != nil {
+				return err
+			}
+			restored.ReportingCurrency = backup.ReportingCurrency
+		}
+
+		categoryIDs := make(map[uint]uint, len(backup.Categories))
+		// restoreCategory reuses the user's category by the same name, even
+		// a deleted one since names stay taken, and brings it back if the
+		// backup has it in use.
+		restoreCategory := func(c model.Category) error {
+			category := model.Category{
+				Model:  gorm.Model{CreatedAt: c.CreatedAt, DeletedAt: c.DeletedAt},
+				UserID: userID,
+				Name:   c.Name,
+			}
+			if err := tx.Unscoped().Where("user_id = ? AND name = ?", userID, c.Name).FirstOrCreate(&category).Error; err != nil {
+				return err
+			}
+			if category.DeletedAt.Valid && !c.DeletedAt.Valid {
+				if err := tx.Unscoped().Model(&category).Update("deleted_at", nil).Error; err != nil {
+					return err
+				}
+				category.DeletedAt = gorm.DeletedAt{}
+			}
+			categoryIDs[c.ID] = category.ID
+			restored.Categories = append(restored.Categories, category)
+			return nil
+		}
+		for _, c := range backup.Categories {
+			if err := restoreCategory(c); err != nil {
+				return err
+			}
+		}
+		// categoryOf remaps a category, creating the uncategorized one the
+		// first time a record without a category needs it.
+		categoryOf := func(id uint) (uint, error) {
+			if _, ok := categoryIDs[id]; !ok && id == 0 {
+				if err := restoreCategory(model.Category{Name: uncategorized}); err != nil {
+					return 0, err
+				}
+			}
+			return categoryIDs[id], nil
+		}
+
+		accountIDs := make(map[uint]uint, len(backup.Accounts))
+		for _, a := range backup.Accounts {
+			account := model.Account{
+				Model:         gorm.Model{CreatedAt: a.CreatedAt, DeletedAt: a.DeletedAt},
+				UserID:        userID,
+				CurrencyID:    a.CurrencyID,
+				Name:          a.Name,
+				InitialAmount: a.InitialAmount,
+			}
+			if err := tx.Create(&account).Error; err != nil {
+				return err
+			}
+			accountIDs[a.ID] = account.ID
+			restored.Accounts = append(restored.Accounts, account)
+		}
+
+		for _, e := range backup.Expenses {
+			categoryID, err := categoryOf(e.CategoryID)
+			if err != nil {
+				return err
+			}
+			restored.Expenses = append(restored.Expenses, model.Expense{
+				Model:       gorm.Model{CreatedAt: e.CreatedAt},
+				UserID:      userID,
+				AccountID:   accountIDs[e.AccountID],
+				CategoryID:  categoryID,
+				Name:        e.Name,
+				Description: e.Description,
+				Amount:      e.Amount,
+				OccurredAt:  e.OccurredAt,
+				Timezone:    e.Timezone,
+				ExternalID:  e.ExternalID,
+			})
+		}
+		if err := createInBatches(tx, restored.Expenses); err != nil {
+			return err
+		}
+		expenseIDs := make(map[uint]uint, len(backup.Expenses))
+		for i, e := range backup.Expenses {
+			expenseIDs[e.ID] = restored.Expenses[i].ID
+			if len(e.Tags) == 0 {
+				continue
+			}
+			names := make([]string, 0, len(e.Tags))
+			for _, tag := range e.Tags {
+				names = append(names, tag.Name)
+			}
+			tags, err := tagsNamed(tx, userID, names)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&restored.Expenses[i]).Association("Tags").Append(tags); err != nil {
+				return err
+			}
+		}
+
+		for _, in := range backup.Incomes {
+			restored.Incomes = append(restored.Incomes, model.Income{
+				Model:       gorm.Model{CreatedAt: in.CreatedAt},
+				UserID:      userID,
+				AccountID:   accountIDs[in.AccountID],
+				Name:        in.Name,
+				Description: in.Description,
+				Amount:      in.Amount,
+				OccurredAt:  in.OccurredAt,
+				Timezone:    in.Timezone,
+				ExternalID:  in.ExternalID,
+			})
+		}
+		if err := createInBatches(tx, restored.Incomes); err != nil {
+			return err
+		}
+		incomeIDs := make(map[uint]uint, len(backup.Incomes))
+		for i, in := range backup.Incomes {
+			incomeIDs[in.ID] = restored.Incomes[i].ID
+		}
+
+		for _, t := range backup.Transfers {
+			restored.Transfers = append(restored.Transfers, model.Transfer{
+				Model:          gorm.Model{CreatedAt: t.CreatedAt},
+				UserID:         userID,
+				FromAccountID:  accountIDs[t.FromAccountID],
+				ToAccountID:    accountIDs[t.ToAccountID],
+				Description:    t.Description,
+				Amount:         t.Amount,
+				Fee:            t.Fee,
+				ExchangeRate:   t.ExchangeRate,
+				ReceivedAmount: t.ReceivedAmount,
+				OccurredAt:     t.OccurredAt,
+				Timezone:       t.Timezone,
+			})
+		}
+		if err := createInBatches(tx, restored.Transfers); err != nil {
+			return err
+		}
+
+		for _, b := range backup.Budgets {
+			budget := model.Budget{
+				Model:        gorm.Model{CreatedAt: b.CreatedAt},
+				UserID:       userID,
+				CategoryID:   categoryIDs[b.CategoryID],
+				CurrencyID:   b.CurrencyID,
+				MonthlyLimit: b.MonthlyLimit,
+			}
+			if b.AccountID != nil {
+				accountID := accountIDs[*b.AccountID]
+				budget.AccountID = &accountID
+			}
+			restored.Budgets = append(restored.Budgets, budget)
+		}
+		if err := createInBatches(tx, restored.Budgets); err != nil {
+			return err
+		}
+
+		templateIDs := make(map[uint]uint, len(backup.RecurringTemplates))
+		templateTypes := make(map[uint]string, len(backup.RecurringTemplates))
+		for _, t := range backup.RecurringTemplates {
+			template := t
+			template.Model = gorm.Model{CreatedAt: t.CreatedAt}
+			template.UserID = userID
+			template.AccountID = accountIDs[t.AccountID]
+			template.Account = nil
+			if t.Type == model.TransactionTypeExpense {
+				categoryID, err := categoryOf(t.CategoryID)
+				if err != nil {
+					return err
+				}
+				template.CategoryID = categoryID
+			} else {
+				template.CategoryID = 0
+			}
+			if err := tx.Create(&template).Error; err != nil {
+				return err
+			}
+			templateIDs[t.ID] = template.ID
+			templateTypes[t.ID] = t.Type
+			restored.RecurringTemplates = append(restored.RecurringTemplates, template)
+		}
+
+		// Occurrences whose transaction was deleted, and so not backed up,
+		// keep the link to the template but not to a transaction.
+		for _, o := range backup.RecurringOccurrences {
+			transactionIDs := expenseIDs
+			if templateTypes[o.TemplateID] == model.TransactionTypeIncome {
+				transactionIDs = incomeIDs
+			}
+			restored.RecurringOccurrences = append(restored.RecurringOccurrences, model.RecurringOccurrence{
+				TemplateID:    templateIDs[o.TemplateID],
+				Sequence:      o.Sequence,
+				OccurredAt:    o.OccurredAt,
+				TransactionID: transactionIDs[o.TransactionID],
+				CreatedAt:     o.CreatedAt,
+			})
+		}
+		if err := createInBatches(tx, restored.RecurringOccurrences); err != nil {
+			return err
+		}
+
+		for _, m := range backup.ImportMappings {
+			mapping := m
+			mapping.Model = gorm.Model{CreatedAt: m.CreatedAt}
+			mapping.UserID = userID
+			restored.ImportMappings = append(restored.ImportMappings, mapping)
+		}
+		if err := createInBatches(tx, restored.ImportMappings); err != nil {
+			return err
+		}
+
+		for _, r := range backup.ExchangeRates {
+			restored.ExchangeRates = append(restored.ExchangeRates, model.ExchangeRate{
+				UserID:        userID,
+				Date:          r.Date,
+				BaseCurrency:  r.BaseCurrency,
+				QuoteCurrency: r.QuoteCurrency,
+				Rate:          r.Rate,
+			})
+		}
+		if len(restored.ExchangeRates) > 0 {
+			if err := tx.
+				Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}, {Name: "base_currency"}, {Name: "quote_currency"}},
+					DoNothing: true,
+				}).
+				CreateInBatches(&restored.ExchangeRates, 500).
+				Error; err != nil {
+				return err
+			}
+		}
+
+		for _, a := range backup.Advice {
+			advice := a
+			advice.Model = gorm.Model{CreatedAt: a.CreatedAt}
+			advice.UserID = userID
+			restored.Advice = append(restored.Advice, advice)
+		}
+		return createInBatches(tx, restored.Advice)
+	})
+	if err != nil {
+		return model.Backup{}, err
+	}
+
+	return restored, nil
+}
+
+// createInBatches inserts records, if there are any, setting their IDs.
+func createInBatches[T any](tx *gorm.DB, records []T) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	return tx.CreateInBatches(&records, 100).Error
+}
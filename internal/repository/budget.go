@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+type BudgetRepository interface {
+	Insert(userID, categoryID uint, accountID *uint, currencyID uint, monthlyLimit int) (model.Budget, error)
+	GetOneByID(userID, id uint) (model.Budget, error)
+	GetManyBelongedToUser(userID uint, limit, offset int) ([]model.Budget, error)
+	UpdateOneByID(userID, id, categoryID uint, accountID *uint, currencyID uint, monthlyLimit int) (model.Budget, error)
+	DeleteOneByID(userID, id uint) error
+}
+
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+func NewBudgetRepository(db *gorm.DB) *budgetRepository {
+	return &budgetRepository{db}
+}
+
+func (br *budgetRepository) Insert(userID, categoryID uint, accountID *uint, currencyID uint, monthlyLimit int) (model.Budget, error) {
+	budget := model.Budget{
+		UserID:       userID,
+		CategoryID:   categoryID,
+		AccountID:    accountID,
+		CurrencyID:   currencyID,
+		MonthlyLimit: monthlyLimit,
+	}
+	if err := br.db.Create(&budget).Error; err != nil {
+		return model.Budget{}, err
+	}
+
+	return budget, nil
+}
+
+func (br *budgetRepository) GetOneByID(userID, id uint) (model.Budget, error) {
+	var budget model.Budget
+	if err := br.db.Scopes(ownedBy(userID)).Preload("Currency").First(&budget, "id = ?", id).Error; err != nil {
+		return model.Budget{}, err
+	}
+
+	return budget, nil
+}
+
+func (br *budgetRepository) GetManyBelongedToUser(userID uint, limit, offset int) ([]model.Budget, error) {
+	var budgets []model.Budget
+	if err := br.db.
+		Scopes(ownedBy(userID)).
+		Preload("Currency").
+		Order("id").
+		Limit(limit).
+		Offset(offset).
+		Find(&budgets).
+		Error; err != nil {
+		return []model.Budget{}, err
+	}
+
+	return budgets, nil
+}
+
+func (br *budgetRepository) UpdateOneByID(userID, id, categoryID uint, accountID *uint, currencyID uint, monthlyLimit int) (model.Budget, error) {
+	var budget model.Budget
+	if err := br.db.Scopes(ownedBy(userID)).First(&budget, "id = ?", id).Error; err != nil {
+		return model.Budget{}, err
+	}
+	budget.CategoryID = categoryID
+	budget.AccountID = accountID
+	budget.CurrencyID = currencyID
+	budget.MonthlyLimit = monthlyLimit
+	if err := br.db.Save(&budget).Error; err != nil {
+		return model.Budget{}, err
+	}
+
+	return budget, nil
+}
+
+func (br *budgetRepository) DeleteOneByID(userID, id uint) error {
+	var budget model.Budget
+	result := br.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&budget)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
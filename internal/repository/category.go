@@ -7,10 +7,11 @@ import (
 
 type CategoryRepository interface {
 	Insert(userID uint, name string) (model.Category, error)
-	GetOneByID(id uint) (model.Category, error)
+	GetOneByID(userID, id uint) (model.Category, error)
 	GetOneByName(name string) (model.Category, error)
-	GetMany(userID uint, limit int, offset int) ([]model.Category, error)
-	Delete(id uint) error
+	GetMany(userID uint, limit int, cursor string) (model.Page[model.Category], error)
+	GetManyByIDs(userID uint, ids []uint) ([]model.Category, error)
+	Delete(userID, id uint) error
 }
 
 type categoryRepository struct {
@@ -33,9 +34,9 @@ func (cr *categoryRepository) Insert(userID uint, name string) (model.Category,
 	return category, nil
 }
 
-func (cr *categoryRepository) GetOneByID(id uint) (model.Category, error) {
+func (cr *categoryRepository) GetOneByID(userID, id uint) (model.Category, error) {
 	var category model.Category
-	if err := cr.db.First(&category, "id = ?", id).Error; err != nil {
+	if err := cr.db.Scopes(ownedBy(userID)).First(&category, "id = ?", id).Error; err != nil {
 		return model.Category{}, err
 	}
 
@@ -51,19 +52,40 @@ func (cr *categoryRepository) GetOneByName(name string) (model.Category, error)
 	return category, nil
 }
 
-func (cr *categoryRepository) GetMany(userID uint, limit int, offset int) ([]model.Category, error) {
-	var categories []model.Category
-	if err := cr.db.Limit(limit).Offset(offset).Find(&categories, "user_id = ?", userID).Error; err != nil {
+var categoryKeyset = keyset[model.Category]{
+	name:      "id",
+	idColumn:  "id",
+	id:        func(c model.Category) uint { return c.ID },
+	ascending: true,
+}
+
+// GetMany lists the user's categories oldest first, a page at a time.
+func (cr *categoryRepository) GetMany(userID uint, limit int, cursor string) (model.Page[model.Category], error) {
+	return paginate(cr.db.Model(&model.Category{}).Scopes(ownedBy(userID)), categoryKeyset, cursor, limit)
+}
+
+// GetManyByIDs returns those of the user's categories with ids, deleted ones
+// included since their expenses are kept.
+func (cr *categoryRepository) GetManyByIDs(userID uint, ids []uint) ([]model.Category, error) {
+	categories := []model.Category{}
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	if err := cr.db.Unscoped().Scopes(ownedBy(userID)).Where("id IN ?", ids).Order("id").Find(&categories).Error; err != nil {
 		return nil, err
 	}
 
 	return categories, nil
 }
 
-func (cr *categoryRepository) Delete(id uint) error {
+func (cr *categoryRepository) Delete(userID, id uint) error {
 	var category model.Category
-	if err := cr.db.Where("id = ?", id).Delete(&category).Error; err != nil {
-		return err
+	result := cr.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&category)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 
 	return nil
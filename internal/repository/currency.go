@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CurrencyRepository interface {
+	GetOneByID(id uint) (model.Currency, error)
+	GetOneByCode(code string) (model.Currency, error)
+	GetReportingCurrency(userID uint) (*model.Currency, error)
+	SetReportingCurrency(userID uint, currencyID *uint) error
+	UpsertExchangeRates(userID uint, rates []model.ExchangeRate) error
+	GetExchangeRates(userID uint, base, quote string, period util.Period, limit, offset int) ([]model.ExchangeRate, error)
+	GetExchangeRatesInPeriod(userID uint, period util.Period) ([]model.ExchangeRate, error)
+}
+
+type currencyRepository struct {
+	db *gorm.DB
+}
+
+func NewCurrencyRepository(db *gorm.DB) *currencyRepository {
+	return &currencyRepository{db}
+}
+
+func (cr *currencyRepository) GetOneByID(id uint) (model.Currency, error) {
+	var currency model.Currency
+	if err := cr.db.First(&currency, "id = ?", id).Error; err != nil {
+		return model.Currency{}, err
+	}
+
+	return currency, nil
+}
+
+func (cr *currencyRepository) GetOneByCode(code string) (model.Currency, error) {
+	var currency model.Currency
+	if err := cr.db.First(&currency, "code = ?", code).Error; err != nil {
+		return model.Currency{}, err
+	}
+
+	return currency, nil
+}
+
+// GetReportingCurrency returns nil when the user has not picked one.
+func (cr *currencyRepository) GetReportingCurrency(userID uint) (*model.Currency, error) {
+	var user model.User
+	if err := cr.db.Preload("ReportingCurrency").First(&user, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	return user.ReportingCurrency, nil
+}
+
+func (cr *currencyRepository) SetReportingCurrency(userID uint, currencyID *uint) error {
+	result := cr.db.Model(&model.User{}).Where("id = ?", userID).Update("reporting_currency_id", currencyID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UpsertExchangeRates stores rates, replacing any the user already has for
+// the same date and currencies.
+func (cr *currencyRepository) UpsertExchangeRates(userID uint, rates []model.ExchangeRate) error {
+	for i := range rates {
+		rates[i].UserID = userID
+	}
+
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		return tx.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}, {Name: "base_currency"}, {Name: "quote_currency"}},
+				DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at", "deleted_at"}),
+			}).
+			CreateInBatches(&rates, 500).
+			Error
+	})
+}
+
+// GetExchangeRates pages through the user's rates, newest first. An empty
+// base or quote matches every currency.
+func (cr *currencyRepository) GetExchangeRates(userID uint, base, quote string, period util.Period, limit, offset int) ([]model.ExchangeRate, error) {
+	query := cr.db.Scopes(ownedBy(userID), inPeriod("date", period))
+	if base != "" {
+		query = query.Where("base_currency = ?", base)
+	}
+	if quote != "" {
+		query = query.Where("quote_currency = ?", quote)
+	}
+
+	var rates []model.ExchangeRate
+	if err := query.
+		Order("date desc, base_currency, quote_currency").
+		Limit(limit).
+		Offset(offset).
+		Find(&rates).
+		Error; err != nil {
+		return []model.ExchangeRate{}, err
+	}
+
+	return rates, nil
+}
+
+// GetExchangeRatesInPeriod returns every rate the user has in period,
+// oldest first.
+func (cr *currencyRepository) GetExchangeRatesInPeriod(userID uint, period util.Period) ([]model.ExchangeRate, error) {
+	var rates []model.ExchangeRate
+	if err := cr.db.
+		Scopes(ownedBy(userID), inPeriod("date", period)).
+		Order("date, id").
+		Find(&rates).
+		Error; err != nil {
+		return []model.ExchangeRate{}, err
+	}
+
+	return rates, nil
+}
@@ -1,20 +1,119 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
 	"gorm.io/gorm"
 )
 
 type ExpenseRepository interface {
-	Insert(userID uint, accountID uint, name string, description string, amount int) (model.Expense, error)
-	GetOneByID(id uint) (model.Expense, error)
+	Insert(userID uint, accountID uint, categoryID uint, name string, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error)
+	GetOneByID(userID, id uint) (model.Expense, error)
 	GetMany(limit, offset int) ([]model.Expense, error)
-	GetManyBelongedToUser(userID uint, limit, offset int) ([]model.Expense, error)
-	GetManyBelongedToAccount(userID, accountID uint, limit, offset int) ([]model.Expense, error)
-	GetManyBelongedToCategory(userID, categoryID uint, limit, offset int) ([]model.Expense, error)
-	GetManyBelongedToCategoryAccount(userID, categoryID, accountID uint, limit, offset int) ([]model.Expense, error)
-	UpdateOneByID(id uint, name string, description string, amount int) (model.Expense, error)
-	DeleteOneByID(id uint) error
+	GetManyBelongedToUser(userID uint, filter ExpenseFilter, limit int, cursor string) (model.Page[model.Expense], error)
+	GetTotalsBelongedToCategory(userID, categoryID uint, accountID *uint, period util.Period) ([]model.ExpenseStatsRow, error)
+	UpdateOneByID(userID, id uint, categoryID uint, name string, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error)
+	DeleteOneByID(userID, id uint) error
+}
+
+// AmountFilterPlaces is how many decimal places ExpenseFilter amounts
+// have, which is as many as any currency has.
+const AmountFilterPlaces = 4
+
+// ExpenseFilter narrows down and orders a listing of expenses. Zero fields
+// do not filter. Amounts are in ten-thousandths of a unit of each expense's
+// own currency, so that one bound applies whatever its decimal places.
+type ExpenseFilter struct {
+	Period      util.Period
+	AccountIDs  []uint
+	CategoryIDs []uint
+	MinAmount   *int
+	MaxAmount   *int
+	// Tags keeps expenses with any of these tag names.
+	Tags []string
+	// Search keeps expenses whose name or description contains each of
+	// its words.
+	Search string
+	// SortBy is a key of expenseSortKeys and defaults to occurredAt.
+	SortBy    string
+	Ascending bool
+}
+
+// expenseSortKeys are the fields expenses can be sorted by, with the
+// column each is in and how to read it from an expense.
+var expenseSortKeys = map[string]struct {
+	column string
+	key    func(model.Expense) interface{}
+}{
+	"occurredAt":  {"expenses.occurred_at", func(e model.Expense) interface{} { return e.OccurredAt }},
+	"amount":      {"expenses.amount", func(e model.Expense) interface{} { return e.Amount }},
+	"name":        {"expenses.name", func(e model.Expense) interface{} { return e.Name }},
+	"description": {"expenses.description", func(e model.Expense) interface{} { return e.Description }},
+	"createdAt":   {"expenses.created_at", func(e model.Expense) interface{} { return e.CreatedAt }},
+	"updatedAt":   {"expenses.updated_at", func(e model.Expense) interface{} { return e.UpdatedAt }},
+}
+
+// amountFilterScale converts an amount in minor units of the expense's
+// currency into AmountFilterPlaces decimal places.
+const amountFilterScale = "CASE COALESCE(currencies.minor_unit, 0) WHEN 0 THEN 10000 WHEN 1 THEN 1000 WHEN 2 THEN 100 WHEN 3 THEN 10 ELSE 1 END"
+
+// searchEscaper escapes LIKE wildcards, using ! since backslashes are
+// read differently by MySQL and SQLite.
+var searchEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// apply adds the filter's conditions to a query over expenses joined with
+// their account's currency.
+func (f ExpenseFilter) apply(db *gorm.DB) *gorm.DB {
+	db = db.Scopes(inPeriod("expenses.occurred_at", f.Period))
+	if len(f.AccountIDs) > 0 {
+		db = db.Where("expenses.account_id IN ?", f.AccountIDs)
+	}
+	if len(f.CategoryIDs) > 0 {
+		db = db.Where("expenses.category_id IN ?", f.CategoryIDs)
+	}
+	if len(f.Tags) > 0 {
+		db = db.Where(
+			"expenses.id IN (SELECT expense_tags.expense_id FROM expense_tags JOIN tags ON tags.id = expense_tags.tag_id "+
+				"WHERE tags.user_id = expenses.user_id AND tags.name IN ?)",
+			f.Tags,
+		)
+	}
+	if f.MinAmount != nil {
+		db = db.Where("expenses.amount * "+amountFilterScale+" >= ?", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		db = db.Where("expenses.amount * "+amountFilterScale+" <= ?", *f.MaxAmount)
+	}
+	for _, word := range strings.Fields(f.Search) {
+		pattern := "%" + searchEscaper.Replace(word) + "%"
+		db = db.Where("(expenses.name LIKE ? ESCAPE '!' OR expenses.description LIKE ? ESCAPE '!')", pattern, pattern)
+	}
+
+	return db
+}
+
+// keyset orders expenses as the filter asks, newest first by default.
+func (f ExpenseFilter) keyset() keyset[model.Expense] {
+	sortBy := f.SortBy
+	if _, ok := expenseSortKeys[sortBy]; !ok {
+		sortBy = "occurredAt"
+	}
+	name := sortBy + " desc"
+	if f.Ascending {
+		name = sortBy + " asc"
+	}
+
+	return keyset[model.Expense]{
+		name:      name,
+		column:    expenseSortKeys[sortBy].column,
+		idColumn:  "expenses.id",
+		key:       expenseSortKeys[sortBy].key,
+		id:        func(e model.Expense) uint { return e.ID },
+		ascending: f.Ascending,
+	}
 }
 
 type expenseRepository struct {
@@ -25,24 +124,36 @@ func NewExpenseRepository(db *gorm.DB) *expenseRepository {
 	return &expenseRepository{db}
 }
 
-func (er *expenseRepository) Insert(userID uint, accountID uint, name string, description string, amount int) (model.Expense, error) {
+// Insert records an expense with the tags named, creating the ones the user
+// doesn't have yet.
+func (er *expenseRepository) Insert(userID uint, accountID uint, categoryID uint, name string, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error) {
 	expense := model.Expense{
 		UserID:      userID,
 		AccountID:   accountID,
+		CategoryID:  categoryID,
 		Name:        name,
 		Description: description,
 		Amount:      amount,
+		OccurredAt:  occurredAt,
+		Timezone:    timezone,
 	}
-	if err := er.db.Save(&expense).Error; err != nil {
+	err := er.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if expense.Tags, err = tagsNamed(tx, userID, tags); err != nil {
+			return err
+		}
+		return tx.Create(&expense).Error
+	})
+	if err != nil {
 		return model.Expense{}, err
 	}
 
 	return expense, nil
 }
 
-func (er *expenseRepository) GetOneByID(id uint) (model.Expense, error) {
+func (er *expenseRepository) GetOneByID(userID, id uint) (model.Expense, error) {
 	var expense model.Expense
-	if err := er.db.First(&expense, "id = ?", id).Error; err != nil {
+	if err := er.db.Scopes(ownedBy(userID), withAccountCurrency, withTags).First(&expense, "id = ?", id).Error; err != nil {
 		return model.Expense{}, err
 	}
 
@@ -51,81 +162,87 @@ func (er *expenseRepository) GetOneByID(id uint) (model.Expense, error) {
 
 func (er *expenseRepository) GetMany(limit, offset int) ([]model.Expense, error) {
 	var expenses []model.Expense
-	if err := er.db.Limit(limit).Offset(offset).Find(&expenses).Error; err != nil {
+	if err := er.db.Scopes(withAccountCurrency, withTags).Limit(limit).Offset(offset).Find(&expenses).Error; err != nil {
 		return []model.Expense{}, err
 	}
 
 	return expenses, nil
 }
 
-func (er *expenseRepository) GetManyBelongedToUser(userID uint, limit, offset int) ([]model.Expense, error) {
-	var expenses []model.Expense
-	if err := er.db.Limit(limit).Offset(offset).Find(&expenses, "user_id = ?", userID).Error; err != nil {
-		return []model.Expense{}, err
-	}
-
-	return expenses, nil
-}
-
-func (er *expenseRepository) GetManyBelongedToAccount(userID, accountID uint, limit, offset int) ([]model.Expense, error) {
-	var expenses []model.Expense
-	if err := er.db.
-		Limit(limit).
-		Offset(offset).
-		Find(&expenses, "user_id = ? and account_id = ?", userID, accountID).
-		Error; err != nil {
-		return []model.Expense{}, err
-	}
-
-	return expenses, nil
+// GetManyBelongedToUser lists the user's expenses that match filter, a page
+// at a time.
+func (er *expenseRepository) GetManyBelongedToUser(userID uint, filter ExpenseFilter, limit int, cursor string) (model.Page[model.Expense], error) {
+	query := er.db.
+		Model(&model.Expense{}).
+		Joins("LEFT JOIN accounts ON accounts.id = expenses.account_id").
+		Joins("LEFT JOIN currencies ON currencies.id = accounts.currency_id").
+		Where("expenses.user_id = ?", userID).
+		Scopes(filter.apply)
+
+	return paginate(query, filter.keyset(), cursor, limit, withAccountCurrency, withTags)
 }
 
-func (er *expenseRepository) GetManyBelongedToCategory(userID, categoryID uint, limit, offset int) ([]model.Expense, error) {
-	var expenses []model.Expense
-	if err := er.db.
-		Limit(limit).
-		Offset(offset).
-		Find(&expenses, "user_id = ? and category_id = ?", userID, categoryID).
-		Error; err != nil {
-		return []model.Expense{}, err
+// GetTotalsBelongedToCategory aggregates the user's expenses in a category
+// over period, per currency and UTC date so that they can be converted
+// before being added up. A nil accountID counts expenses from every
+// account.
+func (er *expenseRepository) GetTotalsBelongedToCategory(userID, categoryID uint, accountID *uint, period util.Period) ([]model.ExpenseStatsRow, error) {
+	query := er.db.
+		Model(&model.Expense{}).
+		Joins("LEFT JOIN accounts ON accounts.id = expenses.account_id").
+		Joins("LEFT JOIN currencies ON currencies.id = accounts.currency_id").
+		Scopes(inPeriod("expenses.occurred_at", period)).
+		Where("expenses.user_id = ? AND expenses.category_id = ?", userID, categoryID)
+	if accountID != nil {
+		query = query.Where("expenses.account_id = ?", *accountID)
 	}
 
-	return expenses, nil
-}
-
-func (er *expenseRepository) GetManyBelongedToCategoryAccount(userID, categoryID, accountID uint, limit, offset int) ([]model.Expense, error) {
-	var expenses []model.Expense
-	if err := er.db.
-		Limit(limit).
-		Offset(offset).
-		Find(&expenses, "user_id = ? and category_id = ? and account_id = ?", userID, categoryID, accountID).
-		Error; err != nil {
-		return []model.Expense{}, err
+	var rows []model.ExpenseStatsRow
+	if err := query.Select(expenseStatsColumns).Group(expenseStatsGroup).Scan(&rows).Error; err != nil {
+		return nil, err
 	}
 
-	return expenses, nil
+	return normalizeDates(rows), nil
 }
 
-func (er *expenseRepository) UpdateOneByID(id uint, name string, description string, amount int) (model.Expense, error) {
-	expense := model.Expense{
-		Model: gorm.Model{
-			ID: id,
-		},
-		Name:        name,
-		Description: description,
-		Amount:      amount,
+// UpdateOneByID changes the expense, replacing its tags with the ones
+// named.
+func (er *expenseRepository) UpdateOneByID(userID, id uint, categoryID uint, name string, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error) {
+	var expense model.Expense
+	if err := er.db.Scopes(ownedBy(userID)).First(&expense, "id = ?", id).Error; err != nil {
+		return model.Expense{}, err
 	}
-	if err := er.db.Save(&expense).Error; err != nil {
+	expense.CategoryID = categoryID
+	expense.Name = name
+	expense.Description = description
+	expense.Amount = amount
+	expense.OccurredAt = occurredAt
+	expense.Timezone = timezone
+	err := er.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&expense).Error; err != nil {
+			return err
+		}
+		named, err := tagsNamed(tx, userID, tags)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&expense).Association("Tags").Replace(named)
+	})
+	if err != nil {
 		return model.Expense{}, err
 	}
 
 	return expense, nil
 }
 
-func (er *expenseRepository) DeleteOneByID(id uint) error {
+func (er *expenseRepository) DeleteOneByID(userID, id uint) error {
 	var expense model.Expense
-	if err := er.db.Where("id = ?", id).Delete(&expense).Error; err != nil {
-		return err
+	result := er.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&expense)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
 
 	return nil
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+// externalIDChunkSize keeps IN lists within the bind variable limits of
+// every supported database.
+const externalIDChunkSize = 500
+
+type ImportRepository interface {
+	GetImportedExternalIDs(accountID uint, externalIDs []string) ([]string, error)
+	InsertStatement(expenses []model.Expense, incomes []model.Income) ([]model.Expense, []model.Income, error)
+}
+
+type importRepository struct {
+	db *gorm.DB
+}
+
+func NewImportRepository(db *gorm.DB) *importRepository {
+	return &importRepository{db}
+}
+
+// GetImportedExternalIDs returns which of externalIDs were already imported
+// into the account, as expenses or incomes. Deleted transactions count, so
+// that a transaction removed by hand is not imported again.
+func (ir *importRepository) GetImportedExternalIDs(accountID uint, externalIDs []string) ([]string, error) {
+	imported := []string{}
+	for start := 0; start < len(externalIDs); start += externalIDChunkSize {
+		end := start + externalIDChunkSize
+		if end > len(externalIDs) {
+			end = len(externalIDs)
+		}
+		for _, m := range []interface{}{&model.Expense{}, &model.Income{}} {
+			var ids []string
+			if err := ir.db.
+				Unscoped().
+				Model(m).
+				Where("account_id = ? AND external_id IN ?", accountID, externalIDs[start:end]).
+				Pluck("external_id", &ids).
+				Error; err != nil {
+				return nil, err
+			}
+			imported = append(imported, ids...)
+		}
+	}
+
+	return imported, nil
+}
+
+// InsertStatement inserts the expenses and incomes read from one statement
+// in a single transaction, so either all of them are saved or none is. The
+// unique index on external IDs makes a concurrent import of the same
+// statement fail rather than duplicate anything.
+func (ir *importRepository) InsertStatement(expenses []model.Expense, incomes []model.Income) ([]model.Expense, []model.Income, error) {
+	if err := ir.db.Transaction(func(tx *gorm.DB) error {
+		if len(expenses) > 0 {
+			if err := tx.CreateInBatches(&expenses, 100).Error; err != nil {
+				return err
+			}
+		}
+		if len(incomes) > 0 {
+			if err := tx.CreateInBatches(&incomes, 100).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return expenses, incomes, nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+type ImportMappingRepository interface {
+	Insert(mapping model.ImportMapping) (model.ImportMapping, error)
+	GetOneByID(userID, id uint) (model.ImportMapping, error)
+	GetManyBelongedToUser(userID uint) ([]model.ImportMapping, error)
+	DeleteOneByID(userID, id uint) error
+}
+
+type importMappingRepository struct {
+	db *gorm.DB
+}
+
+func NewImportMappingRepository(db *gorm.DB) *importMappingRepository {
+	return &importMappingRepository{db}
+}
+
+func (imr *importMappingRepository) Insert(mapping model.ImportMapping) (model.ImportMapping, error) {
+	if err := imr.db.Create(&mapping).Error; err != nil {
+		return model.ImportMapping{}, err
+	}
+
+	return mapping, nil
+}
+
+func (imr *importMappingRepository) GetOneByID(userID, id uint) (model.ImportMapping, error) {
+	var mapping model.ImportMapping
+	if err := imr.db.Scopes(ownedBy(userID)).First(&mapping, "id = ?", id).Error; err != nil {
+		return model.ImportMapping{}, err
+	}
+
+	return mapping, nil
+}
+
+func (imr *importMappingRepository) GetManyBelongedToUser(userID uint) ([]model.ImportMapping, error) {
+	var mappings []model.ImportMapping
+	if err := imr.db.
+		Scopes(ownedBy(userID)).
+		Order("name").
+		Find(&mappings).
+		Error; err != nil {
+		return []model.ImportMapping{}, err
+	}
+
+	return mappings, nil
+}
+
+func (imr *importMappingRepository) DeleteOneByID(userID, id uint) error {
+	var mapping model.ImportMapping
+	result := imr.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&mapping)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
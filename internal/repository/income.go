@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/gorm"
+)
+
+type IncomeRepository interface {
+	Insert(userID uint, accountID uint, name string, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error)
+	GetOneByID(userID, id uint) (model.Income, error)
+	GetManyBelongedToUser(userID uint, period util.Period, limit, offset int) ([]model.Income, error)
+	GetManyBelongedToAccount(userID, accountID uint, period util.Period, limit, offset int) ([]model.Income, error)
+	UpdateOneByID(userID, id uint, name string, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error)
+	DeleteOneByID(userID, id uint) error
+}
+
+type incomeRepository struct {
+	db *gorm.DB
+}
+
+func NewIncomeRepository(db *gorm.DB) *incomeRepository {
+	return &incomeRepository{db}
+}
+
+func (ir *incomeRepository) Insert(userID uint, accountID uint, name string, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error) {
+	income := model.Income{
+		UserID:      userID,
+		AccountID:   accountID,
+		Name:        name,
+		Description: description,
+		Amount:      amount,
+		OccurredAt:  occurredAt,
+		Timezone:    timezone,
+	}
+	if err := ir.db.Save(&income).Error; err != nil {
+		return model.Income{}, err
+	}
+
+	return income, nil
+}
+
+func (ir *incomeRepository) GetOneByID(userID, id uint) (model.Income, error) {
+	var income model.Income
+	if err := ir.db.Scopes(ownedBy(userID), withAccountCurrency).First(&income, "id = ?", id).Error; err != nil {
+		return model.Income{}, err
+	}
+
+	return income, nil
+}
+
+func (ir *incomeRepository) GetManyBelongedToUser(userID uint, period util.Period, limit, offset int) ([]model.Income, error) {
+	var incomes []model.Income
+	if err := ir.db.
+		Scopes(inPeriod("occurred_at", period), withAccountCurrency).
+		Order("occurred_at desc, id desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&incomes, "user_id = ?", userID).
+		Error; err != nil {
+		return []model.Income{}, err
+	}
+
+	return incomes, nil
+}
+
+func (ir *incomeRepository) GetManyBelongedToAccount(userID, accountID uint, period util.Period, limit, offset int) ([]model.Income, error) {
+	var incomes []model.Income
+	if err := ir.db.
+		Scopes(inPeriod("occurred_at", period), withAccountCurrency).
+		Order("occurred_at desc, id desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&incomes, "user_id = ? and account_id = ?", userID, accountID).
+		Error; err != nil {
+		return []model.Income{}, err
+	}
+
+	return incomes, nil
+}
+
+func (ir *incomeRepository) UpdateOneByID(userID, id uint, name string, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error) {
+	var income model.Income
+	if err := ir.db.Scopes(ownedBy(userID)).First(&income, "id = ?", id).Error; err != nil {
+		return model.Income{}, err
+	}
+	income.Name = name
+	income.Description = description
+	income.Amount = amount
+	income.OccurredAt = occurredAt
+	income.Timezone = timezone
+	if err := ir.db.Save(&income).Error; err != nil {
+		return model.Income{}, err
+	}
+
+	return income, nil
+}
+
+func (ir *incomeRepository) DeleteOneByID(userID, id uint) error {
+	var income model.Income
+	result := ir.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&income)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+type JournalRepository interface {
+	Get(userID uint) (model.Journal, error)
+}
+
+type journalRepository struct {
+	db *gorm.DB
+}
+
+func NewJournalRepository(db *gorm.DB) *journalRepository {
+	return &journalRepository{db}
+}
+
+func (jr *journalRepository) Get(userID uint) (model.Journal, error) {
+	var journal model.Journal
+	// Deleted accounts and categories are kept so that their transactions
+	// still have somewhere to post to.
+	if err := jr.db.Unscoped().Scopes(ownedBy(userID)).Preload("Currency").Order("id").Find(&journal.Accounts).Error; err != nil {
+		return model.Journal{}, err
+	}
+	if err := jr.db.Unscoped().Scopes(ownedBy(userID)).Order("id").Find(&journal.Categories).Error; err != nil {
+		return model.Journal{}, err
+	}
+	for _, dest := range []interface{}{&journal.Expenses, &journal.Incomes, &journal.Transfers} {
+		if err := jr.db.Scopes(ownedBy(userID)).Order("occurred_at, id").Find(dest).Error; err != nil {
+			return model.Journal{}, err
+		}
+	}
+
+	return journal, nil
+}
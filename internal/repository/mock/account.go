@@ -6,6 +6,7 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
 	gomock "go.uber.org/mock/gomock"
@@ -35,47 +36,62 @@ func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
 }
 
 // DeleteOneByID mocks base method.
-func (m *MockAccountRepository) DeleteOneByID(id uint) error {
+func (m *MockAccountRepository) DeleteOneByID(userID, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOneByID", id)
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteOneByID indicates an expected call of DeleteOneByID.
-func (mr *MockAccountRepositoryMockRecorder) DeleteOneByID(id interface{}) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockAccountRepository)(nil).DeleteOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockAccountRepository)(nil).DeleteOneByID), userID, id)
 }
 
 // GetMany mocks base method.
-func (m *MockAccountRepository) GetMany(userID uint, limit, offset int) ([]model.Account, error) {
+func (m *MockAccountRepository) GetMany(userID uint, limit int, cursor string) (model.Page[model.Account], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMany", userID, limit, offset)
-	ret0, _ := ret[0].([]model.Account)
+	ret := m.ctrl.Call(m, "GetMany", userID, limit, cursor)
+	ret0, _ := ret[0].(model.Page[model.Account])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMany indicates an expected call of GetMany.
-func (mr *MockAccountRepositoryMockRecorder) GetMany(userID, limit, offset interface{}) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) GetMany(userID, limit, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockAccountRepository)(nil).GetMany), userID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockAccountRepository)(nil).GetMany), userID, limit, cursor)
 }
 
 // GetOneByID mocks base method.
-func (m *MockAccountRepository) GetOneByID(id uint) (model.Account, error) {
+func (m *MockAccountRepository) GetOneByID(userID, id uint) (model.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOneByID", id)
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
 	ret0, _ := ret[0].(model.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOneByID indicates an expected call of GetOneByID.
-func (mr *MockAccountRepositoryMockRecorder) GetOneByID(id interface{}) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockAccountRepository)(nil).GetOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockAccountRepository)(nil).GetOneByID), userID, id)
+}
+
+// GetTransactionTotals mocks base method.
+func (m *MockAccountRepository) GetTransactionTotals(ids []uint, until time.Time) (map[uint]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionTotals", ids, until)
+	ret0, _ := ret[0].(map[uint]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionTotals indicates an expected call of GetTransactionTotals.
+func (mr *MockAccountRepositoryMockRecorder) GetTransactionTotals(ids, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionTotals", reflect.TypeOf((*MockAccountRepository)(nil).GetTransactionTotals), ids, until)
 }
 
 // Insert mocks base method.
@@ -94,16 +110,16 @@ func (mr *MockAccountRepositoryMockRecorder) Insert(userID, currencyID, name, in
 }
 
 // UpdateOneByID mocks base method.
-func (m *MockAccountRepository) UpdateOneByID(id, currencyID uint, name string, initialAmount int) (model.Account, error) {
+func (m *MockAccountRepository) UpdateOneByID(userID, id, currencyID uint, name string, initialAmount int) (model.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOneByID", id, currencyID, name, initialAmount)
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, currencyID, name, initialAmount)
 	ret0, _ := ret[0].(model.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateOneByID indicates an expected call of UpdateOneByID.
-func (mr *MockAccountRepositoryMockRecorder) UpdateOneByID(id, currencyID, name, initialAmount interface{}) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) UpdateOneByID(userID, id, currencyID, name, initialAmount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockAccountRepository)(nil).UpdateOneByID), id, currencyID, name, initialAmount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockAccountRepository)(nil).UpdateOneByID), userID, id, currencyID, name, initialAmount)
 }
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/advice.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdviceRepository is a mock of AdviceRepository interface.
+type MockAdviceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdviceRepositoryMockRecorder
+}
+
+// MockAdviceRepositoryMockRecorder is the mock recorder for MockAdviceRepository.
+type MockAdviceRepositoryMockRecorder struct {
+	mock *MockAdviceRepository
+}
+
+// NewMockAdviceRepository creates a new mock instance.
+func NewMockAdviceRepository(ctrl *gomock.Controller) *MockAdviceRepository {
+	mock := &MockAdviceRepository{ctrl: ctrl}
+	mock.recorder = &MockAdviceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdviceRepository) EXPECT() *MockAdviceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDataVersion mocks base method.
+func (m *MockAdviceRepository) GetDataVersion(userID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataVersion", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataVersion indicates an expected call of GetDataVersion.
+func (mr *MockAdviceRepositoryMockRecorder) GetDataVersion(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataVersion", reflect.TypeOf((*MockAdviceRepository)(nil).GetDataVersion), userID)
+}
+
+// GetLatestByInputHash mocks base method.
+func (m *MockAdviceRepository) GetLatestByInputHash(userID uint, inputHash string) (model.Advice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByInputHash", userID, inputHash)
+	ret0, _ := ret[0].(model.Advice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByInputHash indicates an expected call of GetLatestByInputHash.
+func (mr *MockAdviceRepositoryMockRecorder) GetLatestByInputHash(userID, inputHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByInputHash", reflect.TypeOf((*MockAdviceRepository)(nil).GetLatestByInputHash), userID, inputHash)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockAdviceRepository) GetManyBelongedToUser(userID uint, limit int, cursor string) (model.Page[model.Advice], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, limit, cursor)
+	ret0, _ := ret[0].(model.Page[model.Advice])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockAdviceRepositoryMockRecorder) GetManyBelongedToUser(userID, limit, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockAdviceRepository)(nil).GetManyBelongedToUser), userID, limit, cursor)
+}
+
+// Insert mocks base method.
+func (m *MockAdviceRepository) Insert(advice model.Advice) (model.Advice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", advice)
+	ret0, _ := ret[0].(model.Advice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAdviceRepositoryMockRecorder) Insert(advice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAdviceRepository)(nil).Insert), advice)
+}
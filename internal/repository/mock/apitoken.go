@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/apitoken.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPITokenRepository is a mock of APITokenRepository interface.
+type MockAPITokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenRepositoryMockRecorder
+}
+
+// MockAPITokenRepositoryMockRecorder is the mock recorder for MockAPITokenRepository.
+type MockAPITokenRepositoryMockRecorder struct {
+	mock *MockAPITokenRepository
+}
+
+// NewMockAPITokenRepository creates a new mock instance.
+func NewMockAPITokenRepository(ctrl *gomock.Controller) *MockAPITokenRepository {
+	mock := &MockAPITokenRepository{ctrl: ctrl}
+	mock.recorder = &MockAPITokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPITokenRepository) EXPECT() *MockAPITokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOneByID mocks base method.
+func (m *MockAPITokenRepository) DeleteOneByID(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneByID indicates an expected call of DeleteOneByID.
+func (mr *MockAPITokenRepositoryMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockAPITokenRepository)(nil).DeleteOneByID), userID, id)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockAPITokenRepository) GetManyBelongedToUser(userID uint) ([]model.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID)
+	ret0, _ := ret[0].([]model.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockAPITokenRepositoryMockRecorder) GetManyBelongedToUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockAPITokenRepository)(nil).GetManyBelongedToUser), userID)
+}
+
+// GetOneByHash mocks base method.
+func (m *MockAPITokenRepository) GetOneByHash(tokenHash string) (model.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByHash", tokenHash)
+	ret0, _ := ret[0].(model.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByHash indicates an expected call of GetOneByHash.
+func (mr *MockAPITokenRepositoryMockRecorder) GetOneByHash(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByHash", reflect.TypeOf((*MockAPITokenRepository)(nil).GetOneByHash), tokenHash)
+}
+
+// Insert mocks base method.
+func (m *MockAPITokenRepository) Insert(token model.APIToken) (model.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", token)
+	ret0, _ := ret[0].(model.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAPITokenRepositoryMockRecorder) Insert(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAPITokenRepository)(nil).Insert), token)
+}
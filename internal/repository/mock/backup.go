@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/backup.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackupRepository is a mock of BackupRepository interface.
+type MockBackupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupRepositoryMockRecorder
+}
+
+// MockBackupRepositoryMockRecorder is the mock recorder for MockBackupRepository.
+type MockBackupRepositoryMockRecorder struct {
+	mock *MockBackupRepository
+}
+
+// NewMockBackupRepository creates a new mock instance.
+func NewMockBackupRepository(ctrl *gomock.Controller) *MockBackupRepository {
+	mock := &MockBackupRepository{ctrl: ctrl}
+	mock.recorder = &MockBackupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupRepository) EXPECT() *MockBackupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockBackupRepository) Export(userID uint) (model.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", userID)
+	ret0, _ := ret[0].(model.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBackupRepositoryMockRecorder) Export(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBackupRepository)(nil).Export), userID)
+}
+
+// GetCurrencies mocks base method.
+func (m *MockBackupRepository) GetCurrencies() ([]model.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrencies")
+	ret0, _ := ret[0].([]model.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrencies indicates an expected call of GetCurrencies.
+func (mr *MockBackupRepositoryMockRecorder) GetCurrencies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrencies", reflect.TypeOf((*MockBackupRepository)(nil).GetCurrencies))
+}
+
+// Restore mocks base method.
+func (m *MockBackupRepository) Restore(userID uint, backup model.Backup) (model.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", userID, backup)
+	ret0, _ := ret[0].(model.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBackupRepositoryMockRecorder) Restore(userID, backup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBackupRepository)(nil).Restore), userID, backup)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/budget.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBudgetRepository is a mock of BudgetRepository interface.
+type MockBudgetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetRepositoryMockRecorder
+}
+
+// MockBudgetRepositoryMockRecorder is the mock recorder for MockBudgetRepository.
+type MockBudgetRepositoryMockRecorder struct {
+	mock *MockBudgetRepository
+}
+
+// NewMockBudgetRepository creates a new mock instance.
+func NewMockBudgetRepository(ctrl *gomock.Controller) *MockBudgetRepository {
+	mock := &MockBudgetRepository{ctrl: ctrl}
+	mock.recorder = &MockBudgetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetRepository) EXPECT() *MockBudgetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOneByID mocks base method.
+func (m *MockBudgetRepository) DeleteOneByID(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneByID indicates an expected call of DeleteOneByID.
+func (mr *MockBudgetRepositoryMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockBudgetRepository)(nil).DeleteOneByID), userID, id)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockBudgetRepository) GetManyBelongedToUser(userID uint, limit, offset int) ([]model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, limit, offset)
+	ret0, _ := ret[0].([]model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockBudgetRepositoryMockRecorder) GetManyBelongedToUser(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockBudgetRepository)(nil).GetManyBelongedToUser), userID, limit, offset)
+}
+
+// GetOneByID mocks base method.
+func (m *MockBudgetRepository) GetOneByID(userID, id uint) (model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
+	ret0, _ := ret[0].(model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockBudgetRepositoryMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockBudgetRepository)(nil).GetOneByID), userID, id)
+}
+
+// Insert mocks base method.
+func (m *MockBudgetRepository) Insert(userID, categoryID uint, accountID *uint, currencyID uint, monthlyLimit int) (model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", userID, categoryID, accountID, currencyID, monthlyLimit)
+	ret0, _ := ret[0].(model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockBudgetRepositoryMockRecorder) Insert(userID, categoryID, accountID, currencyID, monthlyLimit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockBudgetRepository)(nil).Insert), userID, categoryID, accountID, currencyID, monthlyLimit)
+}
+
+// UpdateOneByID mocks base method.
+func (m *MockBudgetRepository) UpdateOneByID(userID, id, categoryID uint, accountID *uint, currencyID uint, monthlyLimit int) (model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, categoryID, accountID, currencyID, monthlyLimit)
+	ret0, _ := ret[0].(model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOneByID indicates an expected call of UpdateOneByID.
+func (mr *MockBudgetRepositoryMockRecorder) UpdateOneByID(userID, id, categoryID, accountID, currencyID, monthlyLimit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockBudgetRepository)(nil).UpdateOneByID), userID, id, categoryID, accountID, currencyID, monthlyLimit)
+}
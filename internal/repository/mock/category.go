@@ -35,47 +35,62 @@ func (m *MockCategoryRepository) EXPECT() *MockCategoryRepositoryMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockCategoryRepository) Delete(id uint) error {
+func (m *MockCategoryRepository) Delete(userID, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCategoryRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) Delete(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepository)(nil).Delete), userID, id)
 }
 
 // GetMany mocks base method.
-func (m *MockCategoryRepository) GetMany(userID uint, limit, offset int) ([]model.Category, error) {
+func (m *MockCategoryRepository) GetMany(userID uint, limit int, cursor string) (model.Page[model.Category], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMany", userID, limit, offset)
-	ret0, _ := ret[0].([]model.Category)
+	ret := m.ctrl.Call(m, "GetMany", userID, limit, cursor)
+	ret0, _ := ret[0].(model.Page[model.Category])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMany indicates an expected call of GetMany.
-func (mr *MockCategoryRepositoryMockRecorder) GetMany(userID, limit, offset interface{}) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) GetMany(userID, limit, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockCategoryRepository)(nil).GetMany), userID, limit, cursor)
+}
+
+// GetManyByIDs mocks base method.
+func (m *MockCategoryRepository) GetManyByIDs(userID uint, ids []uint) ([]model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyByIDs", userID, ids)
+	ret0, _ := ret[0].([]model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyByIDs indicates an expected call of GetManyByIDs.
+func (mr *MockCategoryRepositoryMockRecorder) GetManyByIDs(userID, ids interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockCategoryRepository)(nil).GetMany), userID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyByIDs", reflect.TypeOf((*MockCategoryRepository)(nil).GetManyByIDs), userID, ids)
 }
 
 // GetOneByID mocks base method.
-func (m *MockCategoryRepository) GetOneByID(id uint) (model.Category, error) {
+func (m *MockCategoryRepository) GetOneByID(userID, id uint) (model.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOneByID", id)
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
 	ret0, _ := ret[0].(model.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOneByID indicates an expected call of GetOneByID.
-func (mr *MockCategoryRepositoryMockRecorder) GetOneByID(id interface{}) *gomock.Call {
+func (mr *MockCategoryRepositoryMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockCategoryRepository)(nil).GetOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockCategoryRepository)(nil).GetOneByID), userID, id)
 }
 
 // GetOneByName mocks base method.
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/currency.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	util "github.com/muhrizqiardi/spendtracker/internal/util"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCurrencyRepository is a mock of CurrencyRepository interface.
+type MockCurrencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyRepositoryMockRecorder
+}
+
+// MockCurrencyRepositoryMockRecorder is the mock recorder for MockCurrencyRepository.
+type MockCurrencyRepositoryMockRecorder struct {
+	mock *MockCurrencyRepository
+}
+
+// NewMockCurrencyRepository creates a new mock instance.
+func NewMockCurrencyRepository(ctrl *gomock.Controller) *MockCurrencyRepository {
+	mock := &MockCurrencyRepository{ctrl: ctrl}
+	mock.recorder = &MockCurrencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyRepository) EXPECT() *MockCurrencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockCurrencyRepository) GetExchangeRates(userID uint, base, quote string, period util.Period, limit, offset int) ([]model.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", userID, base, quote, period, limit, offset)
+	ret0, _ := ret[0].([]model.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockCurrencyRepositoryMockRecorder) GetExchangeRates(userID, base, quote, period, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockCurrencyRepository)(nil).GetExchangeRates), userID, base, quote, period, limit, offset)
+}
+
+// GetExchangeRatesInPeriod mocks base method.
+func (m *MockCurrencyRepository) GetExchangeRatesInPeriod(userID uint, period util.Period) ([]model.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRatesInPeriod", userID, period)
+	ret0, _ := ret[0].([]model.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRatesInPeriod indicates an expected call of GetExchangeRatesInPeriod.
+func (mr *MockCurrencyRepositoryMockRecorder) GetExchangeRatesInPeriod(userID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRatesInPeriod", reflect.TypeOf((*MockCurrencyRepository)(nil).GetExchangeRatesInPeriod), userID, period)
+}
+
+// GetOneByCode mocks base method.
+func (m *MockCurrencyRepository) GetOneByCode(code string) (model.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByCode", code)
+	ret0, _ := ret[0].(model.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByCode indicates an expected call of GetOneByCode.
+func (mr *MockCurrencyRepositoryMockRecorder) GetOneByCode(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByCode", reflect.TypeOf((*MockCurrencyRepository)(nil).GetOneByCode), code)
+}
+
+// GetOneByID mocks base method.
+func (m *MockCurrencyRepository) GetOneByID(id uint) (model.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", id)
+	ret0, _ := ret[0].(model.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockCurrencyRepositoryMockRecorder) GetOneByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockCurrencyRepository)(nil).GetOneByID), id)
+}
+
+// GetReportingCurrency mocks base method.
+func (m *MockCurrencyRepository) GetReportingCurrency(userID uint) (*model.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportingCurrency", userID)
+	ret0, _ := ret[0].(*model.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportingCurrency indicates an expected call of GetReportingCurrency.
+func (mr *MockCurrencyRepositoryMockRecorder) GetReportingCurrency(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportingCurrency", reflect.TypeOf((*MockCurrencyRepository)(nil).GetReportingCurrency), userID)
+}
+
+// SetReportingCurrency mocks base method.
+func (m *MockCurrencyRepository) SetReportingCurrency(userID uint, currencyID *uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReportingCurrency", userID, currencyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReportingCurrency indicates an expected call of SetReportingCurrency.
+func (mr *MockCurrencyRepositoryMockRecorder) SetReportingCurrency(userID, currencyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReportingCurrency", reflect.TypeOf((*MockCurrencyRepository)(nil).SetReportingCurrency), userID, currencyID)
+}
+
+// UpsertExchangeRates mocks base method.
+func (m *MockCurrencyRepository) UpsertExchangeRates(userID uint, rates []model.ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExchangeRates", userID, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertExchangeRates indicates an expected call of UpsertExchangeRates.
+func (mr *MockCurrencyRepositoryMockRecorder) UpsertExchangeRates(userID, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExchangeRates", reflect.TypeOf((*MockCurrencyRepository)(nil).UpsertExchangeRates), userID, rates)
+}
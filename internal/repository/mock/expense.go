@@ -6,8 +6,11 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	repository "github.com/muhrizqiardi/spendtracker/internal/repository"
+	util "github.com/muhrizqiardi/spendtracker/internal/util"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -35,17 +38,17 @@ func (m *MockExpenseRepository) EXPECT() *MockExpenseRepositoryMockRecorder {
 }
 
 // DeleteOneByID mocks base method.
-func (m *MockExpenseRepository) DeleteOneByID(id uint) error {
+func (m *MockExpenseRepository) DeleteOneByID(userID, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOneByID", id)
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteOneByID indicates an expected call of DeleteOneByID.
-func (mr *MockExpenseRepositoryMockRecorder) DeleteOneByID(id interface{}) *gomock.Call {
+func (mr *MockExpenseRepositoryMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockExpenseRepository)(nil).DeleteOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockExpenseRepository)(nil).DeleteOneByID), userID, id)
 }
 
 // GetMany mocks base method.
@@ -63,107 +66,77 @@ func (mr *MockExpenseRepositoryMockRecorder) GetMany(limit, offset interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockExpenseRepository)(nil).GetMany), limit, offset)
 }
 
-// GetManyBelongedToAccount mocks base method.
-func (m *MockExpenseRepository) GetManyBelongedToAccount(userID, accountID uint, limit, offset int) ([]model.Expense, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManyBelongedToAccount", userID, accountID, limit, offset)
-	ret0, _ := ret[0].([]model.Expense)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetManyBelongedToAccount indicates an expected call of GetManyBelongedToAccount.
-func (mr *MockExpenseRepositoryMockRecorder) GetManyBelongedToAccount(userID, accountID, limit, offset interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToAccount", reflect.TypeOf((*MockExpenseRepository)(nil).GetManyBelongedToAccount), userID, accountID, limit, offset)
-}
-
-// GetManyBelongedToCategory mocks base method.
-func (m *MockExpenseRepository) GetManyBelongedToCategory(userID, categoryID uint, limit, offset int) ([]model.Expense, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManyBelongedToCategory", userID, categoryID, limit, offset)
-	ret0, _ := ret[0].([]model.Expense)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetManyBelongedToCategory indicates an expected call of GetManyBelongedToCategory.
-func (mr *MockExpenseRepositoryMockRecorder) GetManyBelongedToCategory(userID, categoryID, limit, offset interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToCategory", reflect.TypeOf((*MockExpenseRepository)(nil).GetManyBelongedToCategory), userID, categoryID, limit, offset)
-}
-
-// GetManyBelongedToCategoryAccount mocks base method.
-func (m *MockExpenseRepository) GetManyBelongedToCategoryAccount(userID, categoryID, accountID uint, limit, offset int) ([]model.Expense, error) {
+// GetManyBelongedToUser mocks base method.
+func (m *MockExpenseRepository) GetManyBelongedToUser(userID uint, filter repository.ExpenseFilter, limit int, cursor string) (model.Page[model.Expense], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManyBelongedToCategoryAccount", userID, categoryID, accountID, limit, offset)
-	ret0, _ := ret[0].([]model.Expense)
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, filter, limit, cursor)
+	ret0, _ := ret[0].(model.Page[model.Expense])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetManyBelongedToCategoryAccount indicates an expected call of GetManyBelongedToCategoryAccount.
-func (mr *MockExpenseRepositoryMockRecorder) GetManyBelongedToCategoryAccount(userID, categoryID, accountID, limit, offset interface{}) *gomock.Call {
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockExpenseRepositoryMockRecorder) GetManyBelongedToUser(userID, filter, limit, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToCategoryAccount", reflect.TypeOf((*MockExpenseRepository)(nil).GetManyBelongedToCategoryAccount), userID, categoryID, accountID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockExpenseRepository)(nil).GetManyBelongedToUser), userID, filter, limit, cursor)
 }
 
-// GetManyBelongedToUser mocks base method.
-func (m *MockExpenseRepository) GetManyBelongedToUser(userID uint, limit, offset int) ([]model.Expense, error) {
+// GetOneByID mocks base method.
+func (m *MockExpenseRepository) GetOneByID(userID, id uint) (model.Expense, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, limit, offset)
-	ret0, _ := ret[0].([]model.Expense)
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
+	ret0, _ := ret[0].(model.Expense)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
-func (mr *MockExpenseRepositoryMockRecorder) GetManyBelongedToUser(userID, limit, offset interface{}) *gomock.Call {
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockExpenseRepositoryMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockExpenseRepository)(nil).GetManyBelongedToUser), userID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockExpenseRepository)(nil).GetOneByID), userID, id)
 }
 
-// GetOneByID mocks base method.
-func (m *MockExpenseRepository) GetOneByID(id uint) (model.Expense, error) {
+// GetTotalsBelongedToCategory mocks base method.
+func (m *MockExpenseRepository) GetTotalsBelongedToCategory(userID, categoryID uint, accountID *uint, period util.Period) ([]model.ExpenseStatsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOneByID", id)
-	ret0, _ := ret[0].(model.Expense)
+	ret := m.ctrl.Call(m, "GetTotalsBelongedToCategory", userID, categoryID, accountID, period)
+	ret0, _ := ret[0].([]model.ExpenseStatsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOneByID indicates an expected call of GetOneByID.
-func (mr *MockExpenseRepositoryMockRecorder) GetOneByID(id interface{}) *gomock.Call {
+// GetTotalsBelongedToCategory indicates an expected call of GetTotalsBelongedToCategory.
+func (mr *MockExpenseRepositoryMockRecorder) GetTotalsBelongedToCategory(userID, categoryID, accountID, period interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockExpenseRepository)(nil).GetOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalsBelongedToCategory", reflect.TypeOf((*MockExpenseRepository)(nil).GetTotalsBelongedToCategory), userID, categoryID, accountID, period)
 }
 
 // Insert mocks base method.
-func (m *MockExpenseRepository) Insert(userID, accountID uint, name, description string, amount int) (model.Expense, error) {
+func (m *MockExpenseRepository) Insert(userID, accountID, categoryID uint, name, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Insert", userID, accountID, name, description, amount)
+	ret := m.ctrl.Call(m, "Insert", userID, accountID, categoryID, name, description, amount, occurredAt, timezone, tags)
 	ret0, _ := ret[0].(model.Expense)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Insert indicates an expected call of Insert.
-func (mr *MockExpenseRepositoryMockRecorder) Insert(userID, accountID, name, description, amount interface{}) *gomock.Call {
+func (mr *MockExpenseRepositoryMockRecorder) Insert(userID, accountID, categoryID, name, description, amount, occurredAt, timezone, tags interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockExpenseRepository)(nil).Insert), userID, accountID, name, description, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockExpenseRepository)(nil).Insert), userID, accountID, categoryID, name, description, amount, occurredAt, timezone, tags)
 }
 
 // UpdateOneByID mocks base method.
-func (m *MockExpenseRepository) UpdateOneByID(id uint, name, description string, amount int) (model.Expense, error) {
+func (m *MockExpenseRepository) UpdateOneByID(userID, id, categoryID uint, name, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOneByID", id, name, description, amount)
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, categoryID, name, description, amount, occurredAt, timezone, tags)
 	ret0, _ := ret[0].(model.Expense)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateOneByID indicates an expected call of UpdateOneByID.
-func (mr *MockExpenseRepositoryMockRecorder) UpdateOneByID(id, name, description, amount interface{}) *gomock.Call {
+func (mr *MockExpenseRepositoryMockRecorder) UpdateOneByID(userID, id, categoryID, name, description, amount, occurredAt, timezone, tags interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockExpenseRepository)(nil).UpdateOneByID), id, name, description, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockExpenseRepository)(nil).UpdateOneByID), userID, id, categoryID, name, description, amount, occurredAt, timezone, tags)
 }
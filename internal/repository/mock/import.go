@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/import.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportRepository is a mock of ImportRepository interface.
+type MockImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepositoryMockRecorder
+}
+
+// MockImportRepositoryMockRecorder is the mock recorder for MockImportRepository.
+type MockImportRepositoryMockRecorder struct {
+	mock *MockImportRepository
+}
+
+// NewMockImportRepository creates a new mock instance.
+func NewMockImportRepository(ctrl *gomock.Controller) *MockImportRepository {
+	mock := &MockImportRepository{ctrl: ctrl}
+	mock.recorder = &MockImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepository) EXPECT() *MockImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetImportedExternalIDs mocks base method.
+func (m *MockImportRepository) GetImportedExternalIDs(accountID uint, externalIDs []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportedExternalIDs", accountID, externalIDs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportedExternalIDs indicates an expected call of GetImportedExternalIDs.
+func (mr *MockImportRepositoryMockRecorder) GetImportedExternalIDs(accountID, externalIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportedExternalIDs", reflect.TypeOf((*MockImportRepository)(nil).GetImportedExternalIDs), accountID, externalIDs)
+}
+
+// InsertStatement mocks base method.
+func (m *MockImportRepository) InsertStatement(expenses []model.Expense, incomes []model.Income) ([]model.Expense, []model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertStatement", expenses, incomes)
+	ret0, _ := ret[0].([]model.Expense)
+	ret1, _ := ret[1].([]model.Income)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// InsertStatement indicates an expected call of InsertStatement.
+func (mr *MockImportRepositoryMockRecorder) InsertStatement(expenses, incomes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertStatement", reflect.TypeOf((*MockImportRepository)(nil).InsertStatement), expenses, incomes)
+}
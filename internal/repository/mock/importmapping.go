@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/importmapping.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportMappingRepository is a mock of ImportMappingRepository interface.
+type MockImportMappingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportMappingRepositoryMockRecorder
+}
+
+// MockImportMappingRepositoryMockRecorder is the mock recorder for MockImportMappingRepository.
+type MockImportMappingRepositoryMockRecorder struct {
+	mock *MockImportMappingRepository
+}
+
+// NewMockImportMappingRepository creates a new mock instance.
+func NewMockImportMappingRepository(ctrl *gomock.Controller) *MockImportMappingRepository {
+	mock := &MockImportMappingRepository{ctrl: ctrl}
+	mock.recorder = &MockImportMappingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportMappingRepository) EXPECT() *MockImportMappingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOneByID mocks base method.
+func (m *MockImportMappingRepository) DeleteOneByID(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneByID indicates an expected call of DeleteOneByID.
+func (mr *MockImportMappingRepositoryMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockImportMappingRepository)(nil).DeleteOneByID), userID, id)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockImportMappingRepository) GetManyBelongedToUser(userID uint) ([]model.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID)
+	ret0, _ := ret[0].([]model.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockImportMappingRepositoryMockRecorder) GetManyBelongedToUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockImportMappingRepository)(nil).GetManyBelongedToUser), userID)
+}
+
+// GetOneByID mocks base method.
+func (m *MockImportMappingRepository) GetOneByID(userID, id uint) (model.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
+	ret0, _ := ret[0].(model.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockImportMappingRepositoryMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockImportMappingRepository)(nil).GetOneByID), userID, id)
+}
+
+// Insert mocks base method.
+func (m *MockImportMappingRepository) Insert(mapping model.ImportMapping) (model.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", mapping)
+	ret0, _ := ret[0].(model.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockImportMappingRepositoryMockRecorder) Insert(mapping interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockImportMappingRepository)(nil).Insert), mapping)
+}
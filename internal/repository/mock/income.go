@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/income.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	util "github.com/muhrizqiardi/spendtracker/internal/util"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIncomeRepository is a mock of IncomeRepository interface.
+type MockIncomeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncomeRepositoryMockRecorder
+}
+
+// MockIncomeRepositoryMockRecorder is the mock recorder for MockIncomeRepository.
+type MockIncomeRepositoryMockRecorder struct {
+	mock *MockIncomeRepository
+}
+
+// NewMockIncomeRepository creates a new mock instance.
+func NewMockIncomeRepository(ctrl *gomock.Controller) *MockIncomeRepository {
+	mock := &MockIncomeRepository{ctrl: ctrl}
+	mock.recorder = &MockIncomeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncomeRepository) EXPECT() *MockIncomeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOneByID mocks base method.
+func (m *MockIncomeRepository) DeleteOneByID(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneByID indicates an expected call of DeleteOneByID.
+func (mr *MockIncomeRepositoryMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockIncomeRepository)(nil).DeleteOneByID), userID, id)
+}
+
+// GetManyBelongedToAccount mocks base method.
+func (m *MockIncomeRepository) GetManyBelongedToAccount(userID, accountID uint, period util.Period, limit, offset int) ([]model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToAccount", userID, accountID, period, limit, offset)
+	ret0, _ := ret[0].([]model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToAccount indicates an expected call of GetManyBelongedToAccount.
+func (mr *MockIncomeRepositoryMockRecorder) GetManyBelongedToAccount(userID, accountID, period, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToAccount", reflect.TypeOf((*MockIncomeRepository)(nil).GetManyBelongedToAccount), userID, accountID, period, limit, offset)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockIncomeRepository) GetManyBelongedToUser(userID uint, period util.Period, limit, offset int) ([]model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, period, limit, offset)
+	ret0, _ := ret[0].([]model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockIncomeRepositoryMockRecorder) GetManyBelongedToUser(userID, period, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockIncomeRepository)(nil).GetManyBelongedToUser), userID, period, limit, offset)
+}
+
+// GetOneByID mocks base method.
+func (m *MockIncomeRepository) GetOneByID(userID, id uint) (model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
+	ret0, _ := ret[0].(model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockIncomeRepositoryMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockIncomeRepository)(nil).GetOneByID), userID, id)
+}
+
+// Insert mocks base method.
+func (m *MockIncomeRepository) Insert(userID, accountID uint, name, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", userID, accountID, name, description, amount, occurredAt, timezone)
+	ret0, _ := ret[0].(model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockIncomeRepositoryMockRecorder) Insert(userID, accountID, name, description, amount, occurredAt, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockIncomeRepository)(nil).Insert), userID, accountID, name, description, amount, occurredAt, timezone)
+}
+
+// UpdateOneByID mocks base method.
+func (m *MockIncomeRepository) UpdateOneByID(userID, id uint, name, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, name, description, amount, occurredAt, timezone)
+	ret0, _ := ret[0].(model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOneByID indicates an expected call of UpdateOneByID.
+func (mr *MockIncomeRepositoryMockRecorder) UpdateOneByID(userID, id, name, description, amount, occurredAt, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockIncomeRepository)(nil).UpdateOneByID), userID, id, name, description, amount, occurredAt, timezone)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/journal.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJournalRepository is a mock of JournalRepository interface.
+type MockJournalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJournalRepositoryMockRecorder
+}
+
+// MockJournalRepositoryMockRecorder is the mock recorder for MockJournalRepository.
+type MockJournalRepositoryMockRecorder struct {
+	mock *MockJournalRepository
+}
+
+// NewMockJournalRepository creates a new mock instance.
+func NewMockJournalRepository(ctrl *gomock.Controller) *MockJournalRepository {
+	mock := &MockJournalRepository{ctrl: ctrl}
+	mock.recorder = &MockJournalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJournalRepository) EXPECT() *MockJournalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockJournalRepository) Get(userID uint) (model.Journal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID)
+	ret0, _ := ret[0].(model.Journal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockJournalRepositoryMockRecorder) Get(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockJournalRepository)(nil).Get), userID)
+}
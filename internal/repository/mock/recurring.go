@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/recurring.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecurringRepository is a mock of RecurringRepository interface.
+type MockRecurringRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurringRepositoryMockRecorder
+}
+
+// MockRecurringRepositoryMockRecorder is the mock recorder for MockRecurringRepository.
+type MockRecurringRepositoryMockRecorder struct {
+	mock *MockRecurringRepository
+}
+
+// NewMockRecurringRepository creates a new mock instance.
+func NewMockRecurringRepository(ctrl *gomock.Controller) *MockRecurringRepository {
+	mock := &MockRecurringRepository{ctrl: ctrl}
+	mock.recorder = &MockRecurringRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurringRepository) EXPECT() *MockRecurringRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOneByID mocks base method.
+func (m *MockRecurringRepository) DeleteOneByID(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneByID indicates an expected call of DeleteOneByID.
+func (mr *MockRecurringRepositoryMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockRecurringRepository)(nil).DeleteOneByID), userID, id)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockRecurringRepository) GetManyBelongedToUser(userID uint, limit, offset int) ([]model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, limit, offset)
+	ret0, _ := ret[0].([]model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockRecurringRepositoryMockRecorder) GetManyBelongedToUser(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockRecurringRepository)(nil).GetManyBelongedToUser), userID, limit, offset)
+}
+
+// GetManyDue mocks base method.
+func (m *MockRecurringRepository) GetManyDue(until time.Time, skip []uint, limit int) ([]model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyDue", until, skip, limit)
+	ret0, _ := ret[0].([]model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyDue indicates an expected call of GetManyDue.
+func (mr *MockRecurringRepositoryMockRecorder) GetManyDue(until, skip, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyDue", reflect.TypeOf((*MockRecurringRepository)(nil).GetManyDue), until, skip, limit)
+}
+
+// GetOneByID mocks base method.
+func (m *MockRecurringRepository) GetOneByID(userID, id uint) (model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
+	ret0, _ := ret[0].(model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockRecurringRepositoryMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockRecurringRepository)(nil).GetOneByID), userID, id)
+}
+
+// Insert mocks base method.
+func (m *MockRecurringRepository) Insert(template model.RecurringTemplate) (model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", template)
+	ret0, _ := ret[0].(model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRecurringRepositoryMockRecorder) Insert(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRecurringRepository)(nil).Insert), template)
+}
+
+// Materialize mocks base method.
+func (m *MockRecurringRepository) Materialize(template model.RecurringTemplate, next *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Materialize", template, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Materialize indicates an expected call of Materialize.
+func (mr *MockRecurringRepositoryMockRecorder) Materialize(template, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Materialize", reflect.TypeOf((*MockRecurringRepository)(nil).Materialize), template, next)
+}
+
+// UpdateOneByID mocks base method.
+func (m *MockRecurringRepository) UpdateOneByID(userID, id, accountID, categoryID uint, name, description string, amount int) (model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, accountID, categoryID, name, description, amount)
+	ret0, _ := ret[0].(model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOneByID indicates an expected call of UpdateOneByID.
+func (mr *MockRecurringRepositoryMockRecorder) UpdateOneByID(userID, id, accountID, categoryID, name, description, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockRecurringRepository)(nil).UpdateOneByID), userID, id, accountID, categoryID, name, description, amount)
+}
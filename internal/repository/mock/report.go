@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/report.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	util "github.com/muhrizqiardi/spendtracker/internal/util"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetExpenseStats mocks base method.
+func (m *MockReportRepository) GetExpenseStats(userID uint, period util.Period) ([]model.ExpenseStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseStats", userID, period)
+	ret0, _ := ret[0].([]model.ExpenseStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseStats indicates an expected call of GetExpenseStats.
+func (mr *MockReportRepositoryMockRecorder) GetExpenseStats(userID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseStats", reflect.TypeOf((*MockReportRepository)(nil).GetExpenseStats), userID, period)
+}
+
+// GetExpenseStatsByAccount mocks base method.
+func (m *MockReportRepository) GetExpenseStatsByAccount(userID uint, period util.Period) ([]model.ExpenseStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseStatsByAccount", userID, period)
+	ret0, _ := ret[0].([]model.ExpenseStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseStatsByAccount indicates an expected call of GetExpenseStatsByAccount.
+func (mr *MockReportRepositoryMockRecorder) GetExpenseStatsByAccount(userID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseStatsByAccount", reflect.TypeOf((*MockReportRepository)(nil).GetExpenseStatsByAccount), userID, period)
+}
+
+// GetExpenseStatsByCategory mocks base method.
+func (m *MockReportRepository) GetExpenseStatsByCategory(userID uint, period util.Period) ([]model.ExpenseStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseStatsByCategory", userID, period)
+	ret0, _ := ret[0].([]model.ExpenseStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseStatsByCategory indicates an expected call of GetExpenseStatsByCategory.
+func (mr *MockReportRepositoryMockRecorder) GetExpenseStatsByCategory(userID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseStatsByCategory", reflect.TypeOf((*MockReportRepository)(nil).GetExpenseStatsByCategory), userID, period)
+}
+
+// GetExpenseStatsByPeriods mocks base method.
+func (m *MockReportRepository) GetExpenseStatsByPeriods(userID uint, periods []util.Period) ([]model.ExpenseStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseStatsByPeriods", userID, periods)
+	ret0, _ := ret[0].([]model.ExpenseStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseStatsByPeriods indicates an expected call of GetExpenseStatsByPeriods.
+func (mr *MockReportRepositoryMockRecorder) GetExpenseStatsByPeriods(userID, periods interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseStatsByPeriods", reflect.TypeOf((*MockReportRepository)(nil).GetExpenseStatsByPeriods), userID, periods)
+}
+
+// GetLargestExpense mocks base method.
+func (m *MockReportRepository) GetLargestExpense(userID uint, currency string, period util.Period) (model.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLargestExpense", userID, currency, period)
+	ret0, _ := ret[0].(model.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLargestExpense indicates an expected call of GetLargestExpense.
+func (mr *MockReportRepositoryMockRecorder) GetLargestExpense(userID, currency, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLargestExpense", reflect.TypeOf((*MockReportRepository)(nil).GetLargestExpense), userID, currency, period)
+}
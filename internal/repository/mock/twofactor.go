@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/twofactor.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTwoFactorRepository is a mock of TwoFactorRepository interface.
+type MockTwoFactorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorRepositoryMockRecorder
+}
+
+// MockTwoFactorRepositoryMockRecorder is the mock recorder for MockTwoFactorRepository.
+type MockTwoFactorRepositoryMockRecorder struct {
+	mock *MockTwoFactorRepository
+}
+
+// NewMockTwoFactorRepository creates a new mock instance.
+func NewMockTwoFactorRepository(ctrl *gomock.Controller) *MockTwoFactorRepository {
+	mock := &MockTwoFactorRepository{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorRepository) EXPECT() *MockTwoFactorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AttemptChallenge mocks base method.
+func (m *MockTwoFactorRepository) AttemptChallenge(id uint, maxAttempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttemptChallenge", id, maxAttempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttemptChallenge indicates an expected call of AttemptChallenge.
+func (mr *MockTwoFactorRepositoryMockRecorder) AttemptChallenge(id, maxAttempts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttemptChallenge", reflect.TypeOf((*MockTwoFactorRepository)(nil).AttemptChallenge), id, maxAttempts)
+}
+
+// CountFailedAttempts mocks base method.
+func (m *MockTwoFactorRepository) CountFailedAttempts(userID uint, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFailedAttempts", userID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFailedAttempts indicates an expected call of CountFailedAttempts.
+func (mr *MockTwoFactorRepositoryMockRecorder) CountFailedAttempts(userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFailedAttempts", reflect.TypeOf((*MockTwoFactorRepository)(nil).CountFailedAttempts), userID, since)
+}
+
+// Disable mocks base method.
+func (m *MockTwoFactorRepository) Disable(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockTwoFactorRepositoryMockRecorder) Disable(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockTwoFactorRepository)(nil).Disable), userID)
+}
+
+// Enable mocks base method.
+func (m *MockTwoFactorRepository) Enable(userID uint, step int64, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", userID, step, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockTwoFactorRepositoryMockRecorder) Enable(userID, step, codeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockTwoFactorRepository)(nil).Enable), userID, step, codeHashes)
+}
+
+// GetChallenge mocks base method.
+func (m *MockTwoFactorRepository) GetChallenge(tokenHash string) (model.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenge", tokenHash)
+	ret0, _ := ret[0].(model.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenge indicates an expected call of GetChallenge.
+func (mr *MockTwoFactorRepositoryMockRecorder) GetChallenge(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenge", reflect.TypeOf((*MockTwoFactorRepository)(nil).GetChallenge), tokenHash)
+}
+
+// InsertChallenge mocks base method.
+func (m *MockTwoFactorRepository) InsertChallenge(userID uint, tokenHash string, expiresAt time.Time) (model.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertChallenge", userID, tokenHash, expiresAt)
+	ret0, _ := ret[0].(model.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertChallenge indicates an expected call of InsertChallenge.
+func (mr *MockTwoFactorRepositoryMockRecorder) InsertChallenge(userID, tokenHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertChallenge", reflect.TypeOf((*MockTwoFactorRepository)(nil).InsertChallenge), userID, tokenHash, expiresAt)
+}
+
+// SetSecret mocks base method.
+func (m *MockTwoFactorRepository) SetSecret(userID uint, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSecret", userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSecret indicates an expected call of SetSecret.
+func (mr *MockTwoFactorRepositoryMockRecorder) SetSecret(userID, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecret", reflect.TypeOf((*MockTwoFactorRepository)(nil).SetSecret), userID, secret)
+}
+
+// UseChallenge mocks base method.
+func (m *MockTwoFactorRepository) UseChallenge(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseChallenge", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseChallenge indicates an expected call of UseChallenge.
+func (mr *MockTwoFactorRepositoryMockRecorder) UseChallenge(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseChallenge", reflect.TypeOf((*MockTwoFactorRepository)(nil).UseChallenge), id)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorRepository) UseRecoveryCode(userID uint, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorRepositoryMockRecorder) UseRecoveryCode(userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorRepository)(nil).UseRecoveryCode), userID, codeHash)
+}
+
+// UseStep mocks base method.
+func (m *MockTwoFactorRepository) UseStep(userID uint, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockTwoFactorRepositoryMockRecorder) UseStep(userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockTwoFactorRepository)(nil).UseStep), userID, step)
+}
@@ -6,6 +6,7 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
 	gomock "go.uber.org/mock/gomock"
@@ -93,6 +94,20 @@ func (mr *MockUserRepositoryMockRecorder) Insert(email, fullName, password inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserRepository)(nil).Insert), email, fullName, password)
 }
 
+// SetEmailVerifiedAt mocks base method.
+func (m *MockUserRepository) SetEmailVerifiedAt(id int, verifiedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerifiedAt", id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerifiedAt indicates an expected call of SetEmailVerifiedAt.
+func (mr *MockUserRepositoryMockRecorder) SetEmailVerifiedAt(id, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerifiedAt", reflect.TypeOf((*MockUserRepository)(nil).SetEmailVerifiedAt), id, verifiedAt)
+}
+
 // UpdateOneByID mocks base method.
 func (m *MockUserRepository) UpdateOneByID(id int, email, fullName, password string) (model.User, error) {
 	m.ctrl.T.Helper()
@@ -107,3 +122,31 @@ func (mr *MockUserRepositoryMockRecorder) UpdateOneByID(id, email, fullName, pas
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockUserRepository)(nil).UpdateOneByID), id, email, fullName, password)
 }
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(id int, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), id, password)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUserRepository) VerifyEmail(id int, email string, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", id, email, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserRepositoryMockRecorder) VerifyEmail(id, email, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserRepository)(nil).VerifyEmail), id, email, verifiedAt)
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// keyset is the order of a listing: by column, if any, then by id, which
+// makes it total. key reads a row's value of column.
+type keyset[T any] struct {
+	// name tells orders apart, so that a cursor is only used with the
+	// order it was made for.
+	name      string
+	column    string
+	idColumn  string
+	key       func(T) interface{}
+	id        func(T) uint
+	ascending bool
+}
+
+// cursor points at the row a page starts after, or before when Backward.
+// It is handed out base64 encoded and is opaque to clients.
+type cursor struct {
+	Order    string          `json:"o"`
+	Key      json.RawMessage `json:"k,omitempty"`
+	ID       uint            `json:"i"`
+	Backward bool            `json:"b,omitempty"`
+}
+
+func (ks keyset[T]) encode(row T, backward bool) string {
+	c := cursor{Order: ks.name, ID: ks.id(row), Backward: backward}
+	if ks.column != "" {
+		c.Key, _ = json.Marshal(ks.key(row))
+	}
+	b, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decode reads an encoded cursor back along with its key, typed like the
+// values key returns.
+func (ks keyset[T]) decode(encoded string) (cursor, interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor{}, nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Order != ks.name {
+		return cursor{}, nil, ErrInvalidCursor
+	}
+	if ks.column == "" {
+		return c, nil, nil
+	}
+
+	var zero T
+	key := reflect.New(reflect.TypeOf(ks.key(zero)))
+	if err := json.Unmarshal(c.Key, key.Interface()); err != nil {
+		return cursor{}, nil, ErrInvalidCursor
+	}
+
+	return c, key.Elem().Interface(), nil
+}
+
+// paginate reads the page of query's rows that encoded points at, or the
+// first one without a cursor, in the order of ks. scopes only apply to
+// reading the rows, not to counting them, and are where preloads go.
+func paginate[T any](query *gorm.DB, ks keyset[T], encoded string, limit int, scopes ...func(*gorm.DB) *gorm.DB) (model.Page[T], error) {
+	var after *cursor
+	var key interface{}
+	if encoded != "" {
+		c, k, err := ks.decode(encoded)
+		if err != nil {
+			return model.Page[T]{}, err
+		}
+		after, key = &c, k
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return model.Page[T]{}, err
+	}
+
+	backward := after != nil && after.Backward
+	ascending := ks.ascending != backward
+	op, direction := " < ", " desc"
+	if ascending {
+		op, direction = " > ", " asc"
+	}
+
+	rows := query.Session(&gorm.Session{}).Scopes(scopes...)
+	if after != nil {
+		if ks.column == "" {
+			rows = rows.Where(ks.idColumn+op+"?", after.ID)
+		} else {
+			rows = rows.Where(
+				"("+ks.column+op+"? OR ("+ks.column+" = ? AND "+ks.idColumn+op+"?))",
+				key, key, after.ID,
+			)
+		}
+	}
+	if ks.column != "" {
+		rows = rows.Order(ks.column + direction)
+	}
+
+	var items []T
+	if err := rows.Order(ks.idColumn + direction).Limit(limit + 1).Find(&items).Error; err != nil {
+		return model.Page[T]{}, err
+	}
+	more := len(items) > limit
+	if more {
+		items = items[:limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	page := model.Page[T]{Items: items, Total: total}
+	if len(items) == 0 {
+		return page, nil
+	}
+	if more || backward {
+		page.NextCursor = ks.encode(items[len(items)-1], false)
+	}
+	if backward && more || !backward && after != nil {
+		page.PrevCursor = ks.encode(items[0], true)
+	}
+
+	return page, nil
+}
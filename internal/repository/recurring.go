@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+var ErrOccurrenceAlreadyMaterialized = errors.New("Occurrence already materialized")
+
+type RecurringRepository interface {
+	Insert(template model.RecurringTemplate) (model.RecurringTemplate, error)
+	GetOneByID(userID, id uint) (model.RecurringTemplate, error)
+	GetManyBelongedToUser(userID uint, limit, offset int) ([]model.RecurringTemplate, error)
+	GetManyDue(until time.Time, skip []uint, limit int) ([]model.RecurringTemplate, error)
+	UpdateOneByID(userID, id, accountID, categoryID uint, name string, description string, amount int) (model.RecurringTemplate, error)
+	DeleteOneByID(userID, id uint) error
+	Materialize(template model.RecurringTemplate, next *time.Time) error
+}
+
+type recurringRepository struct {
+	db *gorm.DB
+}
+
+func NewRecurringRepository(db *gorm.DB) *recurringRepository {
+	return &recurringRepository{db}
+}
+
+func (rr *recurringRepository) Insert(template model.RecurringTemplate) (model.RecurringTemplate, error) {
+	if err := rr.db.Create(&template).Error; err != nil {
+		return model.RecurringTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func (rr *recurringRepository) GetOneByID(userID, id uint) (model.RecurringTemplate, error) {
+	var template model.RecurringTemplate
+	if err := rr.db.Scopes(ownedBy(userID), withAccountCurrency).First(&template, "id = ?", id).Error; err != nil {
+		return model.RecurringTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func (rr *recurringRepository) GetManyBelongedToUser(userID uint, limit, offset int) ([]model.RecurringTemplate, error) {
+	var templates []model.RecurringTemplate
+	if err := rr.db.
+		Scopes(ownedBy(userID), withAccountCurrency).
+		Order("id").
+		Limit(limit).
+		Offset(offset).
+		Find(&templates).
+		Error; err != nil {
+		return []model.RecurringTemplate{}, err
+	}
+
+	return templates, nil
+}
+
+// GetManyDue returns templates, of every user, whose next occurrence is at
+// or before until, other than those in skip.
+func (rr *recurringRepository) GetManyDue(until time.Time, skip []uint, limit int) ([]model.RecurringTemplate, error) {
+	query := rr.db.Where("next_at IS NOT NULL AND next_at <= ?", until)
+	if len(skip) > 0 {
+		query = query.Where("id NOT IN ?", skip)
+	}
+
+	var templates []model.RecurringTemplate
+	if err := query.
+		Order("next_at, id").
+		Limit(limit).
+		Find(&templates).
+		Error; err != nil {
+		return []model.RecurringTemplate{}, err
+	}
+
+	return templates, nil
+}
+
+// UpdateOneByID changes what the template records, leaving its schedule and
+// progress alone. Only the given columns are written so that a concurrent
+// Materialize is never overwritten with stale progress.
+func (rr *recurringRepository) UpdateOneByID(userID, id, accountID, categoryID uint, name string, description string, amount int) (model.RecurringTemplate, error) {
+	var template model.RecurringTemplate
+	if err := rr.db.Scopes(ownedBy(userID)).First(&template, "id = ?", id).Error; err != nil {
+		return model.RecurringTemplate{}, err
+	}
+	template.AccountID = accountID
+	template.CategoryID = categoryID
+	template.Name = name
+	template.Description = description
+	template.Amount = amount
+	if err := rr.db.
+		Model(&template).
+		Select("account_id", "category_id", "name", "description", "amount").
+		Updates(&template).
+		Error; err != nil {
+		return model.RecurringTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func (rr *recurringRepository) DeleteOneByID(userID, id uint) error {
+	var template model.RecurringTemplate
+	result := rr.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&template)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Materialize records the template's pending occurrence, the one at NextAt,
+// as an expense or income and moves the template on to next. The template
+// row is only advanced if nobody else has recorded that occurrence since it
+// was read, and everything happens in one database transaction, so each
+// occurrence is recorded exactly once even with several schedulers running
+// or after a crash halfway through.
+func (rr *recurringRepository) Materialize(template model.RecurringTemplate, next *time.Time) error {
+	if template.NextAt == nil {
+		return ErrOccurrenceAlreadyMaterialized
+	}
+	occurredAt := *template.NextAt
+
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&model.RecurringTemplate{}).
+			Where("id = ? AND materialized = ?", template.ID, template.Materialized).
+			Updates(map[string]interface{}{
+				"materialized": template.Materialized + 1,
+				"next_at":      next,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOccurrenceAlreadyMaterialized
+		}
+
+		var transactionID uint
+		switch template.Type {
+		case model.TransactionTypeIncome:
+			income := model.Income{
+				UserID:      template.UserID,
+				AccountID:   template.AccountID,
+				Name:        template.Name,
+				Description: template.Description,
+				Amount:      template.Amount,
+				OccurredAt:  occurredAt,
+				Timezone:    template.Timezone,
+			}
+			if err := tx.Create(&income).Error; err != nil {
+				return err
+			}
+			transactionID = income.ID
+		default:
+			expense := model.Expense{
+				UserID:      template.UserID,
+				AccountID:   template.AccountID,
+				CategoryID:  template.CategoryID,
+				Name:        template.Name,
+				Description: template.Description,
+				Amount:      template.Amount,
+				OccurredAt:  occurredAt,
+				Timezone:    template.Timezone,
+			}
+			if err := tx.Create(&expense).Error; err != nil {
+				return err
+			}
+			transactionID = expense.ID
+		}
+
+		return tx.Create(&model.RecurringOccurrence{
+			TemplateID:    template.ID,
+			Sequence:      template.Materialized,
+			OccurredAt:    occurredAt,
+			TransactionID: transactionID,
+		}).Error
+	})
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/gorm"
+)
+
+// expenseStatsColumns aggregates expenses per currency and UTC date, which
+// every query here groups by on top of its own key.
+const expenseStatsColumns = "COALESCE(currencies.code, '') AS currency, " +
+	"COALESCE(currencies.minor_unit, 0) AS minor_unit, " +
+	"DATE(expenses.occurred_at) AS date, " +
+	"COUNT(*) AS count, " +
+	"COALESCE(SUM(expenses.amount), 0) AS total, " +
+	"COALESCE(AVG(expenses.amount), 0) AS average, " +
+	"COALESCE(MAX(expenses.amount), 0) AS largest"
+
+const expenseStatsGroup = "currencies.code, currencies.minor_unit, DATE(expenses.occurred_at)"
+
+type ReportRepository interface {
+	GetExpenseStats(userID uint, period util.Period) ([]model.ExpenseStatsRow, error)
+	GetLargestExpense(userID uint, currency string, period util.Period) (model.Expense, error)
+	GetExpenseStatsByPeriods(userID uint, periods []util.Period) ([]model.ExpenseStatsRow, error)
+	GetExpenseStatsByCategory(userID uint, period util.Period) ([]model.ExpenseStatsRow, error)
+	GetExpenseStatsByAccount(userID uint, period util.Period) ([]model.ExpenseStatsRow, error)
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) *reportRepository {
+	return &reportRepository{db}
+}
+
+// expenses starts a query over the user's expenses in period, joined with
+// the currency of their account. Columns are qualified so that callers can
+// join other tables.
+func (rr *reportRepository) expenses(userID uint, period util.Period) *gorm.DB {
+	return rr.db.
+		Model(&model.Expense{}).
+		Joins("LEFT JOIN accounts ON accounts.id = expenses.account_id").
+		Joins("LEFT JOIN currencies ON currencies.id = accounts.currency_id").
+		Scopes(inPeriod("expenses.occurred_at", period)).
+		Where("expenses.user_id = ?", userID)
+}
+
+func (rr *reportRepository) GetExpenseStats(userID uint, period util.Period) ([]model.ExpenseStatsRow, error) {
+	var rows []model.ExpenseStatsRow
+	if err := rr.expenses(userID, period).
+		Select(expenseStatsColumns).
+		Group(expenseStatsGroup).
+		Scan(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return normalizeDates(rows), nil
+}
+
+// GetLargestExpense returns the user's largest expense in period among
+// accounts in currency.
+func (rr *reportRepository) GetLargestExpense(userID uint, currency string, period util.Period) (model.Expense, error) {
+	var expense model.Expense
+	if err := rr.expenses(userID, period).
+		Where("COALESCE(currencies.code, '') = ?", currency).
+		Scopes(withAccountCurrency).
+		Order("expenses.amount desc, expenses.occurred_at, expenses.id").
+		First(&expense).
+		Error; err != nil {
+		return model.Expense{}, err
+	}
+
+	return expense, nil
+}
+
+// GetExpenseStatsByPeriods aggregates the user's expenses into each of
+// periods in a single query. Periods must not overlap and must be in
+// order; each row's Key is the index of its period.
+func (rr *reportRepository) GetExpenseStatsByPeriods(userID uint, periods []util.Period) ([]model.ExpenseStatsRow, error) {
+	if len(periods) == 0 {
+		return nil, nil
+	}
+
+	// The buckets are computed by the caller, in the user's timezone, so
+	// the database only has to compare instants.
+	var bucket strings.Builder
+	args := make([]interface{}, 0, 2*len(periods))
+	bucket.WriteString("CASE")
+	for i, p := range periods {
+		bucket.WriteString(" WHEN expenses.occurred_at >= ? AND expenses.occurred_at < ? THEN ")
+		bucket.WriteString(strconv.Itoa(i))
+		args = append(args, p.From, p.To)
+	}
+	bucket.WriteString(" END")
+
+	var rows []model.ExpenseStatsRow
+	span := util.Period{From: periods[0].From, To: periods[len(periods)-1].To}
+	if err := rr.expenses(userID, span).
+		Select(bucket.String()+" AS `key`, "+expenseStatsColumns, args...).
+		Group("`key`, " + expenseStatsGroup).
+		Scan(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return normalizeDates(rows), nil
+}
+
+func (rr *reportRepository) GetExpenseStatsByCategory(userID uint, period util.Period) ([]model.ExpenseStatsRow, error) {
+	var rows []model.ExpenseStatsRow
+	if err := rr.expenses(userID, period).
+		Select("expenses.category_id AS `key`, COALESCE(categories.name, '') AS name, " + expenseStatsColumns).
+		Joins("LEFT JOIN categories ON categories.id = expenses.category_id").
+		Group("expenses.category_id, categories.name, " + expenseStatsGroup).
+		Scan(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return normalizeDates(rows), nil
+}
+
+func (rr *reportRepository) GetExpenseStatsByAccount(userID uint, period util.Period) ([]model.ExpenseStatsRow, error) {
+	var rows []model.ExpenseStatsRow
+	if err := rr.expenses(userID, period).
+		Select("expenses.account_id AS `key`, COALESCE(accounts.name, '') AS name, " + expenseStatsColumns).
+		Group("expenses.account_id, accounts.name, " + expenseStatsGroup).
+		Scan(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return normalizeDates(rows), nil
+}
+
+// normalizeDates trims dates to YYYY-MM-DD. SQLite returns DATE() as text,
+// while MySQL returns a DATE that the driver may render as a full
+// timestamp.
+func normalizeDates(rows []model.ExpenseStatsRow) []model.ExpenseStatsRow {
+	for i := range rows {
+		if len(rows[i].Date) > len(util.DateLayout) {
+			rows[i].Date = rows[i].Date[:len(util.DateLayout)]
+		}
+	}
+
+	return rows
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/gorm"
+)
+
+// inPeriod limits a query to rows whose column falls within period.
+func inPeriod(column string, period util.Period) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !period.From.IsZero() {
+			db = db.Where(column+" >= ?", period.From)
+		}
+		if !period.To.IsZero() {
+			db = db.Where(column+" < ?", period.To)
+		}
+
+		return db
+	}
+}
+
+// ownedBy limits a query to rows that belong to userID.
+func ownedBy(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID)
+	}
+}
+
+// withCurrency preloads the currency of accounts.
+func withCurrency(db *gorm.DB) *gorm.DB {
+	return db.Preload("Currency")
+}
+
+// withAccountCurrency preloads the account that rows belong to, deleted or
+// not, along with its currency, which their amounts are in.
+func withAccountCurrency(db *gorm.DB) *gorm.DB {
+	return db.Preload("Account", unscoped).Preload("Account.Currency")
+}
+
+// withTransferCurrencies is withAccountCurrency for both accounts of a
+// transfer.
+func withTransferCurrencies(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("FromAccount", unscoped).
+		Preload("FromAccount.Currency").
+		Preload("ToAccount", unscoped).
+		Preload("ToAccount.Currency")
+}
+
+// withTags preloads the tags of expenses by name.
+func withTags(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name")
+	})
+}
+
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+var ErrRefreshTokenUsed = errors.New("Refresh token already used")
+
+type SessionRepository interface {
+	Insert(userID uint, tokenHash string, expiresAt time.Time) (model.Session, error)
+	GetOneByID(id uint) (model.Session, error)
+	GetRefreshToken(tokenHash string) (model.RefreshToken, error)
+	RotateRefreshToken(id, sessionID uint, tokenHash string, expiresAt time.Time) error
+	RevokeOneByID(id uint) error
+	RevokeManyBelongedToUser(userID uint) error
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *sessionRepository {
+	return &sessionRepository{db}
+}
+
+// Insert starts a session along with its first refresh token.
+func (sr *sessionRepository) Insert(userID uint, tokenHash string, expiresAt time.Time) (model.Session, error) {
+	session := model.Session{UserID: userID}
+	if err := sr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&model.RefreshToken{
+			SessionID: session.ID,
+			TokenHash: tokenHash,
+			ExpiresAt: expiresAt,
+		}).Error
+	}); err != nil {
+		return model.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (sr *sessionRepository) GetOneByID(id uint) (model.Session, error) {
+	var session model.Session
+	if err := sr.db.First(&session, "id = ?", id).Error; err != nil {
+		return model.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (sr *sessionRepository) GetRefreshToken(tokenHash string) (model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := sr.db.Preload("Session").First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		return model.RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+// RotateRefreshToken marks the refresh token used and stores the one that
+// replaces it. Marking it used is conditional on it not having been used, so
+// that of two requests racing with the same token only one gets through and
+// the other gets ErrRefreshTokenUsed.
+func (sr *sessionRepository) RotateRefreshToken(id, sessionID uint, tokenHash string, expiresAt time.Time) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&model.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenUsed
+		}
+
+		return tx.Create(&model.RefreshToken{
+			SessionID: sessionID,
+			TokenHash: tokenHash,
+			ExpiresAt: expiresAt,
+		}).Error
+	})
+}
+
+func (sr *sessionRepository) RevokeOneByID(id uint) error {
+	return sr.db.
+		Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).
+		Error
+}
+
+func (sr *sessionRepository) RevokeManyBelongedToUser(userID uint) error {
+	return sr.db.
+		Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).
+		Error
+}
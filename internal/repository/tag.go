@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+// tagsNamed returns the user's tags with names, creating those they don't
+// have yet.
+func tagsNamed(tx *gorm.DB, userID uint, names []string) ([]model.Tag, error) {
+	tags := make([]model.Tag, 0, len(names))
+	for _, name := range names {
+		tag := model.Tag{UserID: userID, Name: name}
+		if err := tx.Where("user_id = ? AND name = ?", userID, name).FirstOrCreate(&tag).Error; err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/gorm"
+)
+
+// TransactionRepository lists expenses and incomes together as signed
+// transactions, most recently occurred first.
+type TransactionRepository interface {
+	GetManyBelongedToUser(userID uint, period util.Period, limit, offset int) ([]model.Transaction, error)
+	GetManyBelongedToAccount(userID, accountID uint, period util.Period, limit, offset int) ([]model.Transaction, error)
+}
+
+type transactionRepository struct {
+	db *gorm.DB
+}
+
+func NewTransactionRepository(db *gorm.DB) *transactionRepository {
+	return &transactionRepository{db}
+}
+
+func (tr *transactionRepository) GetManyBelongedToUser(userID uint, period util.Period, limit, offset int) ([]model.Transaction, error) {
+	return tr.find(period, limit, offset, "user_id = ?", userID)
+}
+
+func (tr *transactionRepository) GetManyBelongedToAccount(userID, accountID uint, period util.Period, limit, offset int) ([]model.Transaction, error) {
+	return tr.find(period, limit, offset, "user_id = ? and account_id = ?", userID, accountID)
+}
+
+func (tr *transactionRepository) find(period util.Period, limit, offset int, query string, args ...interface{}) ([]model.Transaction, error) {
+	expenses := tr.db.
+		Model(&model.Expense{}).
+		Select("? AS type, id, user_id, account_id, name, description, -amount AS amount, occurred_at, timezone, created_at, updated_at", model.TransactionTypeExpense).
+		Where(query, args...).
+		Scopes(inPeriod("occurred_at", period))
+	incomes := tr.db.
+		Model(&model.Income{}).
+		Select("? AS type, id, user_id, account_id, name, description, amount, occurred_at, timezone, created_at, updated_at", model.TransactionTypeIncome).
+		Where(query, args...).
+		Scopes(inPeriod("occurred_at", period))
+
+	var transactions []model.Transaction
+	if err := tr.db.
+		Table("(? UNION ALL ?) AS transactions", expenses, incomes).
+		Select("transactions.*, COALESCE(currencies.code, '') AS currency, COALESCE(currencies.minor_unit, 0) AS minor_unit").
+		Joins("LEFT JOIN accounts ON accounts.id = transactions.account_id").
+		Joins("LEFT JOIN currencies ON currencies.id = accounts.currency_id").
+		Order("transactions.occurred_at desc, transactions.id desc").
+		Limit(limit).
+		Offset(offset).
+		Scan(&transactions).
+		Error; err != nil {
+		return []model.Transaction{}, err
+	}
+
+	return transactions, nil
+}
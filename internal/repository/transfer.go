@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+var ErrTransferAccountNotFound = errors.New("Transfer account not found")
+
+type TransferRepository interface {
+	Insert(userID, fromAccountID, toAccountID uint, description string, amount, fee int, exchangeRate float64, receivedAmount int, occurredAt time.Time, timezone string) (model.Transfer, error)
+	GetOneByID(userID, id uint) (model.Transfer, error)
+	GetManyBelongedToUser(userID uint, limit, offset int) ([]model.Transfer, error)
+	GetManyBelongedToAccount(userID, accountID uint, limit, offset int) ([]model.Transfer, error)
+	DeleteOneByID(userID, id uint) error
+}
+
+type transferRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) *transferRepository {
+	return &transferRepository{db}
+}
+
+// Insert records the transfer in a single database transaction, making sure
+// both accounts still exist and belong to the user at the time of writing so
+// that neither side can be recorded without the other.
+func (tr *transferRepository) Insert(userID, fromAccountID, toAccountID uint, description string, amount, fee int, exchangeRate float64, receivedAmount int, occurredAt time.Time, timezone string) (model.Transfer, error) {
+	transfer := model.Transfer{
+		UserID:         userID,
+		FromAccountID:  fromAccountID,
+		ToAccountID:    toAccountID,
+		Description:    description,
+		Amount:         amount,
+		Fee:            fee,
+		ExchangeRate:   exchangeRate,
+		ReceivedAmount: receivedAmount,
+		OccurredAt:     occurredAt,
+		Timezone:       timezone,
+	}
+	if err := tr.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.
+			Model(&model.Account{}).
+			Where("id IN ? AND user_id = ?", []uint{fromAccountID, toAccountID}, userID).
+			Count(&count).
+			Error; err != nil {
+			return err
+		}
+		if count != 2 {
+			return ErrTransferAccountNotFound
+		}
+
+		return tx.Create(&transfer).Error
+	}); err != nil {
+		return model.Transfer{}, err
+	}
+
+	return transfer, nil
+}
+
+func (tr *transferRepository) GetOneByID(userID, id uint) (model.Transfer, error) {
+	var transfer model.Transfer
+	if err := tr.db.Scopes(ownedBy(userID), withTransferCurrencies).First(&transfer, "id = ?", id).Error; err != nil {
+		return model.Transfer{}, err
+	}
+
+	return transfer, nil
+}
+
+func (tr *transferRepository) GetManyBelongedToUser(userID uint, limit, offset int) ([]model.Transfer, error) {
+	var transfers []model.Transfer
+	if err := tr.db.
+		Scopes(withTransferCurrencies).
+		Order("occurred_at desc, id desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&transfers, "user_id = ?", userID).
+		Error; err != nil {
+		return []model.Transfer{}, err
+	}
+
+	return transfers, nil
+}
+
+func (tr *transferRepository) GetManyBelongedToAccount(userID, accountID uint, limit, offset int) ([]model.Transfer, error) {
+	var transfers []model.Transfer
+	if err := tr.db.
+		Scopes(withTransferCurrencies).
+		Order("occurred_at desc, id desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&transfers, "user_id = ? and (from_account_id = ? or to_account_id = ?)", userID, accountID, accountID).
+		Error; err != nil {
+		return []model.Transfer{}, err
+	}
+
+	return transfers, nil
+}
+
+func (tr *transferRepository) DeleteOneByID(userID, id uint) error {
+	var transfer model.Transfer
+	result := tr.db.Scopes(ownedBy(userID)).Where("id = ?", id).Delete(&transfer)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
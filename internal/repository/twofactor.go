@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTOTPStepUsed             = errors.New("TOTP code already used")
+	ErrTwoFactorChallengeClosed = errors.New("Two-factor challenge was used or has no attempts left")
+)
+
+type TwoFactorRepository interface {
+	SetSecret(userID uint, secret string) error
+	Enable(userID uint, step int64, codeHashes []string) error
+	UseStep(userID uint, step int64) error
+	UseRecoveryCode(userID uint, codeHash string) error
+	Disable(userID uint) error
+	InsertChallenge(userID uint, tokenHash string, expiresAt time.Time) (model.TwoFactorChallenge, error)
+	GetChallenge(tokenHash string) (model.TwoFactorChallenge, error)
+	AttemptChallenge(id uint, maxAttempts int) error
+	UseChallenge(id uint) error
+	CountFailedAttempts(userID uint, since time.Time) (int, error)
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) *twoFactorRepository {
+	return &twoFactorRepository{db}
+}
+
+// SetSecret starts enrollment over with a new secret, leaving two-factor
+// authentication off until it is confirmed.
+func (tfr *twoFactorRepository) SetSecret(userID uint, secret string) error {
+	return tfr.db.
+		Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"totp_secret":     secret,
+			"totp_enabled_at": nil,
+			"totp_last_step":  0,
+		}).
+		Error
+}
+
+// Enable turns two-factor authentication on, with step as the first code
+// used, and replaces the user's recovery codes.
+func (tfr *twoFactorRepository) Enable(userID uint, step int64, codeHashes []string) error {
+	return tfr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&model.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"totp_enabled_at": time.Now(),
+				"totp_last_step":  step,
+			}).
+			Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]model.RecoveryCode, 0, len(codeHashes))
+		for _, h := range codeHashes {
+			codes = append(codes, model.RecoveryCode{UserID: userID, CodeHash: h})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseStep records that the code of step was used. It returns
+// ErrTOTPStepUsed if that or a later step already was, so that a code
+// can't be replayed, even by two requests at once.
+func (tfr *twoFactorRepository) UseStep(userID uint, step int64) error {
+	result := tfr.db.
+		Model(&model.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTOTPStepUsed
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks the user's unused recovery code used, or returns
+// gorm.ErrRecordNotFound if there is none with that hash.
+func (tfr *twoFactorRepository) UseRecoveryCode(userID uint, codeHash string) error {
+	result := tfr.db.
+		Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (tfr *twoFactorRepository) Disable(userID uint) error {
+	return tfr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&model.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"totp_secret":     "",
+				"totp_enabled_at": nil,
+				"totp_last_step":  0,
+			}).
+			Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error
+	})
+}
+
+func (tfr *twoFactorRepository) InsertChallenge(userID uint, tokenHash string, expiresAt time.Time) (model.TwoFactorChallenge, error) {
+	challenge := model.TwoFactorChallenge{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+	if err := tfr.db.Create(&challenge).Error; err != nil {
+		return model.TwoFactorChallenge{}, err
+	}
+
+	return challenge, nil
+}
+
+func (tfr *twoFactorRepository) GetChallenge(tokenHash string) (model.TwoFactorChallenge, error) {
+	var challenge model.TwoFactorChallenge
+	if err := tfr.db.Where("token_hash = ?", tokenHash).First(&challenge).Error; err != nil {
+		return model.TwoFactorChallenge{}, err
+	}
+
+	return challenge, nil
+}
+
+// AttemptChallenge counts a code being tried against the challenge before
+// it is checked. It returns ErrTwoFactorChallengeClosed once maxAttempts
+// were, or the challenge was used, so that requests at once can't try more.
+func (tfr *twoFactorRepository) AttemptChallenge(id uint, maxAttempts int) error {
+	result := tfr.db.
+		Model(&model.TwoFactorChallenge{}).
+		Where("id = ? AND used_at IS NULL AND attempts < ?", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTwoFactorChallengeClosed
+	}
+
+	return nil
+}
+
+// UseChallenge marks the challenge redeemed, or returns
+// ErrTwoFactorChallengeClosed if it already was.
+func (tfr *twoFactorRepository) UseChallenge(id uint) error {
+	result := tfr.db.
+		Model(&model.TwoFactorChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTwoFactorChallengeClosed
+	}
+
+	return nil
+}
+
+// CountFailedAttempts adds up the codes tried against the user's challenges
+// created since then that were never redeemed.
+func (tfr *twoFactorRepository) CountFailedAttempts(userID uint, since time.Time) (int, error) {
+	var count int
+	if err := tfr.db.
+		Model(&model.TwoFactorChallenge{}).
+		Where("user_id = ? AND used_at IS NULL AND created_at >= ?", userID, since).
+		Select("COALESCE(SUM(attempts), 0)").
+		Scan(&count).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
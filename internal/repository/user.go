@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"gorm.io/gorm"
 )
@@ -11,6 +13,9 @@ type UserRepository interface {
 	GetOneByID(id int) (model.User, error)
 	UpdateOneByID(id int, email string, fullName string, password string) (model.User, error)
 	DeleteOneByID(id int) error
+	SetEmailVerifiedAt(id int, verifiedAt *time.Time) error
+	VerifyEmail(id int, email string, verifiedAt time.Time) error
+	UpdatePassword(id int, password string) error
 }
 
 type userRepository struct {
@@ -63,7 +68,7 @@ func (ur *userRepository) UpdateOneByID(id int, email string, fullName string, p
 	if err := ur.db.Where("id = ?", id).First(&model.User{}).Error; err != nil {
 		return model.User{}, err
 	}
-	if err := ur.db.Save(&user).Error; err != nil {
+	if err := ur.db.Select("email", "password").Updates(&user).Error; err != nil {
 		return model.User{}, err
 	}
 
@@ -85,3 +90,25 @@ func (ur *userRepository) DeleteOneByID(id int) error {
 
 	return nil
 }
+
+func (ur *userRepository) SetEmailVerifiedAt(id int, verifiedAt *time.Time) error {
+	return ur.db.Model(&model.User{}).Where("id = ?", id).Update("email_verified_at", verifiedAt).Error
+}
+
+// VerifyEmail sets when the user's email was verified, as long as it still
+// is email, or returns gorm.ErrRecordNotFound.
+func (ur *userRepository) VerifyEmail(id int, email string, verifiedAt time.Time) error {
+	result := ur.db.Model(&model.User{}).Where("id = ? AND email = ?", id, email).Update("email_verified_at", verifiedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (ur *userRepository) UpdatePassword(id int, password string) error {
+	return ur.db.Model(&model.User{}).Where("id = ?", id).Update("password", password).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"gorm.io/gorm"
+)
+
+type UserTokenRepository interface {
+	Insert(token model.UserToken) (model.UserToken, error)
+	Use(purpose, tokenHash string) (model.UserToken, error)
+	RevokeManyBelongedToUser(userID uint) error
+}
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) *userTokenRepository {
+	return &userTokenRepository{db}
+}
+
+// Insert stores the token and uses up the user's earlier tokens for the
+// same purpose, so that only the latest email sent works.
+func (utr *userTokenRepository) Insert(token model.UserToken) (model.UserToken, error) {
+	if err := utr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&model.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserID, token.Purpose).
+			Update("used_at", time.Now()).
+			Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&token).Error
+	}); err != nil {
+		return model.UserToken{}, err
+	}
+
+	return token, nil
+}
+
+// Use marks the unused, unexpired token with tokenHash used and returns it,
+// or returns gorm.ErrRecordNotFound if there is no such token. Marking it is
+// conditional on it being unused, so that it can only be used once even by
+// requests racing each other.
+func (utr *userTokenRepository) Use(purpose, tokenHash string) (model.UserToken, error) {
+	var token model.UserToken
+	err := utr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&model.UserToken{}).
+			Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, tokenHash, time.Now()).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.First(&token, "token_hash = ?", tokenHash).Error
+	})
+	if err != nil {
+		return model.UserToken{}, err
+	}
+
+	return token, nil
+}
+
+// RevokeManyBelongedToUser uses up every token of the user's that is still
+// unused, whatever its purpose.
+func (utr *userTokenRepository) RevokeManyBelongedToUser(userID uint) error {
+	return utr.db.
+		Model(&model.UserToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).
+		Error
+}
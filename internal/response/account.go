@@ -1,9 +1,18 @@
 package response
 
+import "time"
+
 type CommonAccountResponse struct {
 	ID            uint   `json:"id"`
 	UserID        uint   `json:"userId"`
 	CurrencyID    uint   `json:"currencyId"`
 	Name          string `json:"name"`
-	InitialAmount int    `json:"initialAmount"`
+	InitialAmount string `json:"initialAmount"`
+	Balance       string `json:"balance"`
+}
+
+type AccountBalanceResponse struct {
+	AccountID uint      `json:"accountId"`
+	At        time.Time `json:"at"`
+	Balance   string    `json:"balance"`
 }
@@ -1,5 +1,46 @@
 package response
 
-type GetAdviceResponse struct {
-	Advice string `json:"advice"`
+import "time"
+
+// CommonAdviceResponse is advice on spending between From and To. Cached
+// is set when it was generated earlier for the same input.
+type CommonAdviceResponse struct {
+	ID            uint                `json:"id"`
+	Advice        string              `json:"advice"`
+	Months        int                 `json:"months"`
+	Focus         string              `json:"focus"`
+	Timezone      string              `json:"timezone"`
+	From          time.Time           `json:"from"`
+	To            time.Time           `json:"to"`
+	Model         string              `json:"model"`
+	PromptVersion int                 `json:"promptVersion"`
+	Usage         AdviceUsageResponse `json:"usage"`
+	Cached        bool                `json:"cached"`
+	CreatedAt     time.Time           `json:"createdAt"`
+}
+
+// AdviceTokenEvent is a piece of streamed advice.
+type AdviceTokenEvent struct {
+	Token string `json:"token"`
+}
+
+// AdviceUsageResponse is how many tokens advice took. Estimated is set
+// when the model did not report it.
+type AdviceUsageResponse struct {
+	PromptTokens     int  `json:"promptTokens"`
+	CompletionTokens int  `json:"completionTokens"`
+	TotalTokens      int  `json:"totalTokens"`
+	Estimated        bool `json:"estimated"`
+}
+
+// AdviceDoneEvent ends streamed advice.
+type AdviceDoneEvent struct {
+	ID     uint                `json:"id"`
+	Cached bool                `json:"cached"`
+	Usage  AdviceUsageResponse `json:"usage"`
+}
+
+// AdviceErrorEvent ends streamed advice that failed.
+type AdviceErrorEvent struct {
+	Message string `json:"message"`
 }
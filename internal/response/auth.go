@@ -1,5 +1,40 @@
 package response
 
+import "time"
+
+// LogInResponse carries a short-lived access token in Token and the refresh
+// token to trade for the next one once it expires at ExpiresAt.
 type LogInResponse struct {
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	RefreshToken string    `json:"refreshToken"`
+}
+
+// TwoFactorRequiredResponse is what logging in returns instead of
+// LogInResponse for users with two-factor authentication.
+type TwoFactorRequiredResponse struct {
+	TwoFactorToken string `json:"twoFactorToken"`
+}
+
+type TOTPEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type CommonAPITokenResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateAPITokenResponse is the only response that carries Token.
+type CreateAPITokenResponse struct {
+	CommonAPITokenResponse
 	Token string `json:"token"`
 }
@@ -0,0 +1,14 @@
+package response
+
+type RestoreResponse struct {
+	Accounts           int `json:"accounts"`
+	Categories         int `json:"categories"`
+	Expenses           int `json:"expenses"`
+	Incomes            int `json:"incomes"`
+	Transfers          int `json:"transfers"`
+	Budgets            int `json:"budgets"`
+	RecurringTemplates int `json:"recurringTemplates"`
+	ImportMappings     int `json:"importMappings"`
+	ExchangeRates      int `json:"exchangeRates"`
+	Advice             int `json:"advice"`
+}
@@ -0,0 +1,27 @@
+package response
+
+import "time"
+
+type CommonBudgetResponse struct {
+	ID           uint      `json:"id"`
+	UserID       uint      `json:"userId"`
+	CategoryID   uint      `json:"categoryId"`
+	AccountID    *uint     `json:"accountId"`
+	Currency     string    `json:"currency"`
+	MonthlyLimit string    `json:"monthlyLimit"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type BudgetStatusResponse struct {
+	BudgetID   uint      `json:"budgetId"`
+	CategoryID uint      `json:"categoryId"`
+	AccountID  *uint     `json:"accountId"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Currency   string    `json:"currency"`
+	Limit      string    `json:"limit"`
+	Spent      string    `json:"spent"`
+	Remaining  string    `json:"remaining"`
+	Projected  string    `json:"projected"`
+}
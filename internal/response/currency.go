@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type ReportingCurrencyResponse struct {
+	// Currency is empty when the user has not picked one.
+	Currency string `json:"currency"`
+}
+
+type CommonExchangeRateResponse struct {
+	Date          string    `json:"date"`
+	BaseCurrency  string    `json:"baseCurrency"`
+	QuoteCurrency string    `json:"quoteCurrency"`
+	Rate          float64   `json:"rate"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type ImportExchangeRatesResponse struct {
+	Imported int `json:"imported"`
+}
@@ -6,9 +6,13 @@ type CommonExpenseResponse struct {
 	ID          int       `json:"id"`
 	UserID      uint      `json:"userId"`
 	AccountID   uint      `json:"accountId"`
+	CategoryID  uint      `json:"categoryId"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
-	Amount      int       `json:"amount"`
+	Amount      string    `json:"amount"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	Timezone    string    `json:"timezone"`
+	Tags        []string  `json:"tags"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
@@ -0,0 +1,42 @@
+package response
+
+import "time"
+
+type CommonImportMappingResponse struct {
+	ID                uint      `json:"id"`
+	UserID            uint      `json:"userId"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	HasHeader         bool      `json:"hasHeader"`
+	DateColumn        int       `json:"dateColumn"`
+	DescriptionColumn int       `json:"descriptionColumn"`
+	AmountColumn      int       `json:"amountColumn"`
+	SignConvention    string    `json:"signConvention"`
+	DecimalSeparator  string    `json:"decimalSeparator"`
+	DateFormat        string    `json:"dateFormat"`
+	Timezone          string    `json:"timezone"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+type ImportRowResponse struct {
+	Line          int       `json:"line"`
+	Type          string    `json:"type"`
+	ExternalID    string    `json:"externalId,omitempty"`
+	OccurredAt    time.Time `json:"occurredAt"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Amount        string    `json:"amount"`
+	Skipped       bool      `json:"skipped"`
+	Duplicate     bool      `json:"duplicate"`
+	Error         string    `json:"error,omitempty"`
+	TransactionID uint      `json:"transactionId,omitempty"`
+}
+
+type ImportResultResponse struct {
+	DryRun  bool                `json:"dryRun"`
+	Created int                 `json:"created"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+	Rows    []ImportRowResponse `json:"rows"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type CommonIncomeResponse struct {
+	ID          int       `json:"id"`
+	UserID      uint      `json:"userId"`
+	AccountID   uint      `json:"accountId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Amount      string    `json:"amount"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	Timezone    string    `json:"timezone"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
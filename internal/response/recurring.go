@@ -0,0 +1,29 @@
+package response
+
+import "time"
+
+type CommonRecurringTemplateResponse struct {
+	ID           uint       `json:"id"`
+	UserID       uint       `json:"userId"`
+	Type         string     `json:"type"`
+	AccountID    uint       `json:"accountId"`
+	CategoryID   uint       `json:"categoryId"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Amount       string     `json:"amount"`
+	Frequency    string     `json:"frequency"`
+	Interval     int        `json:"interval"`
+	StartAt      time.Time  `json:"startAt"`
+	Until        *time.Time `json:"until"`
+	Count        int        `json:"count"`
+	Timezone     string     `json:"timezone"`
+	Materialized int        `json:"materialized"`
+	NextAt       *time.Time `json:"nextAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+type UpcomingOccurrencesResponse struct {
+	TemplateID  uint        `json:"templateId"`
+	Occurrences []time.Time `json:"occurrences"`
+}
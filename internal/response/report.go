@@ -0,0 +1,41 @@
+package response
+
+import "time"
+
+type ExpenseStatsResponse struct {
+	Count   int    `json:"count"`
+	Total   string `json:"total"`
+	Average string `json:"average"`
+	Largest string `json:"largest"`
+}
+
+type PeriodExpenseStatsResponse struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	ExpenseStatsResponse
+}
+
+type CategoryExpenseStatsResponse struct {
+	CategoryID   uint   `json:"categoryId"`
+	CategoryName string `json:"categoryName"`
+	ExpenseStatsResponse
+}
+
+type AccountExpenseStatsResponse struct {
+	AccountID   uint   `json:"accountId"`
+	AccountName string `json:"accountName"`
+	ExpenseStatsResponse
+}
+
+type ExpenseReportResponse struct {
+	From           time.Time                      `json:"from"`
+	To             time.Time                      `json:"to"`
+	Timezone       string                         `json:"timezone"`
+	GroupBy        string                         `json:"groupBy"`
+	Currency       string                         `json:"currency"`
+	Summary        ExpenseStatsResponse           `json:"summary"`
+	LargestExpense *CommonExpenseResponse         `json:"largestExpense"`
+	Periods        []PeriodExpenseStatsResponse   `json:"periods"`
+	Categories     []CategoryExpenseStatsResponse `json:"categories"`
+	Accounts       []AccountExpenseStatsResponse  `json:"accounts"`
+}
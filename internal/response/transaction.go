@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+type CommonTransactionResponse struct {
+	Type        string    `json:"type"`
+	ID          int       `json:"id"`
+	UserID      uint      `json:"userId"`
+	AccountID   uint      `json:"accountId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Amount      string    `json:"amount"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	Timezone    string    `json:"timezone"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
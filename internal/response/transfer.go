@@ -0,0 +1,19 @@
+package response
+
+import "time"
+
+type CommonTransferResponse struct {
+	ID             uint      `json:"id"`
+	UserID         uint      `json:"userId"`
+	FromAccountID  uint      `json:"fromAccountId"`
+	ToAccountID    uint      `json:"toAccountId"`
+	Description    string    `json:"description"`
+	Amount         string    `json:"amount"`
+	Fee            string    `json:"fee"`
+	ExchangeRate   float64   `json:"exchangeRate"`
+	ReceivedAmount string    `json:"receivedAmount"`
+	OccurredAt     time.Time `json:"occurredAt"`
+	Timezone       string    `json:"timezone"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
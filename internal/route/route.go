@@ -18,7 +18,20 @@ type router struct {
 	accounth  handler.AccountHandler
 	categoryh handler.CategoryHandler
 	expenseh  handler.ExpenseHandler
+	incomeh   handler.IncomeHandler
+	txh       handler.TransactionHandler
+	transferh handler.TransferHandler
+	budgeth   handler.BudgetHandler
+	recurh    handler.RecurringHandler
+	importh   handler.ImportHandler
+	backuph   handler.BackupHandler
+	exporth   handler.ExportHandler
+	reporth   handler.ReportHandler
+	currencyh handler.CurrencyHandler
 	adviceh   handler.AdviceHandler
+	apitokenh handler.APITokenHandler
+	tfh       handler.TwoFactorHandler
+	verifyh   handler.VerificationHandler
 }
 
 func NewRouter(
@@ -29,22 +42,51 @@ func NewRouter(
 	accounth handler.AccountHandler,
 	categoryh handler.CategoryHandler,
 	expenseh handler.ExpenseHandler,
+	incomeh handler.IncomeHandler,
+	txh handler.TransactionHandler,
+	transferh handler.TransferHandler,
+	budgeth handler.BudgetHandler,
+	recurh handler.RecurringHandler,
+	importh handler.ImportHandler,
+	backuph handler.BackupHandler,
+	exporth handler.ExportHandler,
+	reporth handler.ReportHandler,
+	currencyh handler.CurrencyHandler,
 	adviceh handler.AdviceHandler,
+	apitokenh handler.APITokenHandler,
+	tfh handler.TwoFactorHandler,
+	verifyh handler.VerificationHandler,
 ) *router {
-	return &router{e, authh, authm, userh, accounth, categoryh, expenseh, adviceh}
+	return &router{e, authh, authm, userh, accounth, categoryh, expenseh, incomeh, txh, transferh, budgeth, recurh, importh, backuph, exporth, reporth, currencyh, adviceh, apitokenh, tfh, verifyh}
 }
 
 func (r *router) Define() *echo.Echo {
 	r.e.POST("/auth", r.authh.LogIn)
+	r.e.POST("/auth/totp", r.authh.LogInTwoFactor)
+	r.e.POST("/auth/refresh", r.authh.Refresh)
+	r.e.POST("/auth/logout", r.authh.LogOut, r.authm.Authenticate)
+	r.e.POST("/auth/verify-email", r.verifyh.VerifyEmail)
+	r.e.POST("/auth/verify-email/resend", r.verifyh.SendEmailVerification, r.authm.Authenticate)
+	r.e.POST("/auth/password-reset", r.verifyh.RequestPasswordReset)
+	r.e.POST("/auth/password-reset/confirm", r.verifyh.ResetPassword)
 	r.e.POST("/users", r.userh.Register)
+	// Unverified users can still fix a mistyped email address.
+	r.e.PUT("/users/:userID", r.userh.UpdateOneByID, r.authm.Authenticate)
 
-	protected := r.e.Group("/", r.authm.Authenticate)
+	protected := r.e.Group("/", r.authm.Authenticate, r.authm.RequireVerifiedEmail)
 	{
-		protected.PUT("users/:userID", r.userh.UpdateOneByID)
+		protected.POST("totp", r.tfh.Enroll)
+		protected.POST("totp/confirm", r.tfh.Confirm)
+		protected.DELETE("totp", r.tfh.Disable)
+
+		protected.POST("api-tokens", r.apitokenh.Create)
+		protected.GET("api-tokens", r.apitokenh.GetMany)
+		protected.DELETE("api-tokens/:tokenID", r.apitokenh.DeleteOneByID)
 
 		protected.POST("accounts", r.accounth.Create)
 		protected.GET("accounts", r.accounth.GetMany)
 		protected.GET("accounts/:accountID", r.accounth.GetOneByID)
+		protected.GET("accounts/:accountID/balance", r.accounth.GetBalance)
 		protected.PUT("accounts/:accountID", r.accounth.UpdateOneByID)
 		protected.DELETE("accounts/:accountID", r.accounth.DeleteOneByID)
 
@@ -59,7 +101,55 @@ func (r *router) Define() *echo.Echo {
 		protected.PUT("expenses/:expenseID", r.expenseh.UpdateOneByID)
 		protected.DELETE("expenses/:expenseID", r.expenseh.DeleteOneByID)
 
+		protected.POST("accounts/:accountID/incomes", r.incomeh.Create)
+		protected.GET("incomes/:incomeID", r.incomeh.GetOneByID)
+		protected.GET("incomes", r.incomeh.GetMany)
+		protected.PUT("incomes/:incomeID", r.incomeh.UpdateOneByID)
+		protected.DELETE("incomes/:incomeID", r.incomeh.DeleteOneByID)
+
+		protected.GET("transactions", r.txh.GetMany)
+
+		protected.POST("transfers", r.transferh.Create)
+		protected.GET("transfers/:transferID", r.transferh.GetOneByID)
+		protected.GET("transfers", r.transferh.GetMany)
+		protected.DELETE("transfers/:transferID", r.transferh.DeleteOneByID)
+
+		protected.POST("budgets", r.budgeth.Create)
+		protected.GET("budgets", r.budgeth.GetMany)
+		protected.GET("budgets/:budgetID", r.budgeth.GetOneByID)
+		protected.GET("budgets/:budgetID/status", r.budgeth.GetStatus)
+		protected.PUT("budgets/:budgetID", r.budgeth.UpdateOneByID)
+		protected.DELETE("budgets/:budgetID", r.budgeth.DeleteOneByID)
+
+		protected.POST("recurring-templates", r.recurh.Create)
+		protected.GET("recurring-templates", r.recurh.GetMany)
+		protected.GET("recurring-templates/:templateID", r.recurh.GetOneByID)
+		protected.GET("recurring-templates/:templateID/upcoming", r.recurh.GetUpcoming)
+		protected.PUT("recurring-templates/:templateID", r.recurh.UpdateOneByID)
+		protected.DELETE("recurring-templates/:templateID", r.recurh.DeleteOneByID)
+
+		protected.POST("import-mappings", r.importh.CreateMapping)
+		protected.GET("import-mappings", r.importh.GetMappings)
+		protected.DELETE("import-mappings/:mappingID", r.importh.DeleteMapping)
+		protected.POST("accounts/:accountID/imports/csv", r.importh.ImportCSV)
+		protected.POST("accounts/:accountID/imports/ofx", r.importh.ImportOFX)
+
+		protected.GET("backup", r.backuph.Export)
+		protected.POST("backup/restore", r.backuph.Restore)
+
+		protected.GET("export/ledger", r.exporth.Ledger)
+		protected.GET("export/beancount", r.exporth.Beancount)
+
+		protected.GET("reports/expenses", r.reporth.GetExpenseReport)
+
+		protected.GET("reporting-currency", r.currencyh.GetReportingCurrency)
+		protected.PUT("reporting-currency", r.currencyh.SetReportingCurrency)
+		protected.POST("exchange-rates/imports", r.currencyh.ImportExchangeRates)
+		protected.GET("exchange-rates", r.currencyh.GetExchangeRates)
+
 		protected.GET("advice", r.adviceh.GetAdvice)
+		protected.GET("advice/stream", r.adviceh.StreamAdvice)
+		protected.GET("advice/history", r.adviceh.GetHistory)
 	}
 
 	return r.e
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/service"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type Scheduler interface {
+	Start(ctx context.Context)
+}
+
+type scheduler struct {
+	rs       service.RecurringService
+	lg       util.Logger
+	interval time.Duration
+}
+
+func NewScheduler(rs service.RecurringService, lg util.Logger, interval time.Duration) *scheduler {
+	return &scheduler{rs, lg, interval}
+}
+
+// Start records due recurring transactions right away and then once every
+// interval until ctx is done. It blocks, so run it in its own goroutine.
+func (s *scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *scheduler) run(now time.Time) {
+	materialized, err := s.rs.MaterializeDue(now)
+	if err != nil {
+		s.lg.Error("Failed to materialize recurring transactions", err)
+	}
+	if materialized > 0 {
+		s.lg.Log("Materialized", strconv.Itoa(materialized), "recurring transaction(s)")
+	}
+}
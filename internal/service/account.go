@@ -1,67 +1,138 @@
 package service
 
 import (
+	"errors"
+	"time"
+
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
 	"github.com/muhrizqiardi/spendtracker/internal/repository"
 )
 
+var ErrAccountCurrencyLocked = errors.New("Currency cannot change once the account has transactions")
+
 type AccountService interface {
 	Create(userID int, payload dto.CreateAccountDTO) (model.Account, error)
-	GetOneByID(id int) (model.Account, error)
-	GetMany(userID, itemPerPage, page int) ([]model.Account, error)
-	UpdateOneByID(id int, payload dto.UpdateAccountDTO) (model.Account, error)
-	DeleteOneByID(id int) error
+	GetOneByID(userID, id int) (model.Account, error)
+	GetMany(userID, itemPerPage int, cursor string) (model.Page[model.Account], error)
+	UpdateOneByID(userID, id int, payload dto.UpdateAccountDTO) (model.Account, error)
+	DeleteOneByID(userID, id int) error
+	GetBalance(userID, id int, at time.Time) (int, error)
+	GetBalances(accounts []model.Account, at time.Time) (map[uint]int, error)
 }
 
 type accountService struct {
-	ar repository.AccountRepository
+	ar   repository.AccountRepository
+	curs CurrencyService
 }
 
-func NewAccountService(ar repository.AccountRepository) *accountService {
-	return &accountService{ar}
+func NewAccountService(ar repository.AccountRepository, curs CurrencyService) *accountService {
+	return &accountService{ar, curs}
 }
 
+// Create reads the initial amount in the account's currency, which may be
+// negative for accounts that start in debt.
 func (as *accountService) Create(userID int, payload dto.CreateAccountDTO) (model.Account, error) {
-	account, err := as.ar.Insert(uint(userID), payload.CurrencyID, payload.Name, payload.InitialAmount)
+	currency, err := as.curs.GetOneByID(int(payload.CurrencyID))
+	if err != nil {
+		return model.Account{}, err
+	}
+	initialAmount, err := parseAmount(payload.InitialAmount, &currency)
 	if err != nil {
 		return model.Account{}, err
 	}
 
+	account, err := as.ar.Insert(uint(userID), payload.CurrencyID, payload.Name, initialAmount)
+	if err != nil {
+		return model.Account{}, err
+	}
+	account.Currency = &currency
+
 	return account, nil
 }
 
-func (as *accountService) GetOneByID(id int) (model.Account, error) {
-	account, err := as.ar.GetOneByID(uint(id))
+func (as *accountService) GetOneByID(userID, id int) (model.Account, error) {
+	account, err := as.ar.GetOneByID(uint(userID), uint(id))
 	if err != nil {
-		return model.Account{}, err
+		return model.Account{}, notFound(err)
 	}
 
 	return account, nil
 }
 
-func (as *accountService) GetMany(userID, itemPerPage, page int) ([]model.Account, error) {
-	account, err := as.ar.GetMany(uint(userID), itemPerPage, (page-1)*itemPerPage)
+func (as *accountService) GetMany(userID, itemPerPage int, cursor string) (model.Page[model.Account], error) {
+	accounts, err := as.ar.GetMany(uint(userID), itemPerPage, cursor)
 	if err != nil {
-		return nil, err
+		return model.Page[model.Account]{}, invalidCursor(err)
 	}
 
-	return account, nil
+	return accounts, nil
 }
 
-func (as *accountService) UpdateOneByID(id int, payload dto.UpdateAccountDTO) (model.Account, error) {
-	account, err := as.ar.UpdateOneByID(uint(id), payload.CurrencyID, payload.Name, payload.InitialAmount)
+func (as *accountService) UpdateOneByID(userID, id int, payload dto.UpdateAccountDTO) (model.Account, error) {
+	currency, err := as.curs.GetOneByID(int(payload.CurrencyID))
+	if err != nil {
+		return model.Account{}, err
+	}
+	initialAmount, err := parseAmount(payload.InitialAmount, &currency)
 	if err != nil {
 		return model.Account{}, err
 	}
 
+	account, err := as.ar.UpdateOneByID(uint(userID), uint(id), payload.CurrencyID, payload.Name, initialAmount)
+	if err != nil {
+		if errors.Is(err, repository.ErrAccountHasTransactions) {
+			return model.Account{}, ErrAccountCurrencyLocked
+		}
+		return model.Account{}, notFound(err)
+	}
+	account.Currency = &currency
+
 	return account, nil
 }
 
-func (as *accountService) DeleteOneByID(id int) error {
-	if err := as.ar.DeleteOneByID(uint(id)); err != nil {
-		return err
+func (as *accountService) DeleteOneByID(userID, id int) error {
+	if err := as.ar.DeleteOneByID(uint(userID), uint(id)); err != nil {
+		return notFound(err)
 	}
 
 	return nil
 }
+
+// GetBalance returns the balance of the account right before at: its initial
+// amount plus every income and minus every expense recorded until then.
+func (as *accountService) GetBalance(userID, id int, at time.Time) (int, error) {
+	account, err := as.ar.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return 0, notFound(err)
+	}
+
+	balances, err := as.GetBalances([]model.Account{account}, at)
+	if err != nil {
+		return 0, err
+	}
+
+	return balances[account.ID], nil
+}
+
+func (as *accountService) GetBalances(accounts []model.Account, at time.Time) (map[uint]int, error) {
+	balances := make(map[uint]int, len(accounts))
+	if len(accounts) == 0 {
+		return balances, nil
+	}
+
+	ids := make([]uint, 0, len(accounts))
+	for _, a := range accounts {
+		ids = append(ids, a.ID)
+	}
+	totals, err := as.ar.GetTransactionTotals(ids, at)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range accounts {
+		balances[a.ID] = a.InitialAmount + totals[a.ID]
+	}
+
+	return balances, nil
+}
@@ -3,12 +3,15 @@ package service
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
 	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
 	"github.com/muhrizqiardi/spendtracker/tests/testutil"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
@@ -17,9 +20,23 @@ import (
 func TestAccountService_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mar := mock_repository.NewMockAccountRepository(ctrl)
-	as := NewAccountService(mar)
+	mcurs := mock_service.NewMockCurrencyService(ctrl)
+	as := NewAccountService(mar, mcurs)
 
+	t.Run("should return error when the amount has more places than the currency", func(t *testing.T) {
+		mcurs.EXPECT().GetOneByID(gomock.Eq(2)).Return(model.Currency{Code: "USD", MinorUnit: 2}, nil)
+
+		_, err := as.Create(1, dto.CreateAccountDTO{
+			CurrencyID:    2,
+			Name:          "Acme Bank",
+			InitialAmount: "10.005",
+		})
+		if !errors.Is(err, ErrInvalidAmount) {
+			t.Error("exp ErrInvalidAmount; got", err)
+		}
+	})
 	t.Run("should create account", func(t *testing.T) {
+		mcurs.EXPECT().GetOneByID(gomock.Eq(2)).Return(model.Currency{Code: "USD", MinorUnit: 2}, nil)
 		mar.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq("Acme Bank"), gomock.Eq(1000)).
 			DoAndReturn(func(userID uint, currencyID uint, name string, initialAmount int) (model.Account, error) {
 				return model.Account{
@@ -35,11 +52,12 @@ func TestAccountService_Create(t *testing.T) {
 			CurrencyID:    2,
 			Name:          "Acme Bank",
 			InitialAmount: 1000,
+			Currency:      &model.Currency{Code: "USD", MinorUnit: 2},
 		}
 		got, err := as.Create(1, dto.CreateAccountDTO{
 			CurrencyID:    2,
 			Name:          "Acme Bank",
-			InitialAmount: 1000,
+			InitialAmount: "10.00",
 		})
 		if err != nil {
 			t.Error("exp nil; got error:", err)
@@ -55,10 +73,11 @@ func TestAccountService_Create(t *testing.T) {
 func TestAccountService_GetOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mar := mock_repository.NewMockAccountRepository(ctrl)
-	as := NewAccountService(mar)
+	mcurs := mock_service.NewMockCurrencyService(ctrl)
+	as := NewAccountService(mar, mcurs)
 
 	t.Run("should get one by ID", func(t *testing.T) {
-		mar.EXPECT().GetOneByID(gomock.Eq(uint(1))).DoAndReturn(func(id uint) (model.Account, error) {
+		mar.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Account, error) {
 			return model.Account{
 				Model: gorm.Model{
 					ID: uint(id),
@@ -71,7 +90,7 @@ func TestAccountService_GetOneByID(t *testing.T) {
 				ID: uint(1),
 			},
 		}
-		got, err := as.GetOneByID(1)
+		got, err := as.GetOneByID(1, 1)
 		if err != nil {
 			t.Error("exp nil; got error:", err)
 		}
@@ -91,12 +110,13 @@ func TestAccountService_GetOneByID(t *testing.T) {
 func TestAccountService_GetMany(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mar := mock_repository.NewMockAccountRepository(ctrl)
-	as := NewAccountService(mar)
+	mcurs := mock_service.NewMockCurrencyService(ctrl)
+	as := NewAccountService(mar, mcurs)
 
 	t.Run("should get many accoutns", func(t *testing.T) {
-		mar.EXPECT().GetMany(gomock.Eq(uint(1)), gomock.Eq(10), gomock.Eq(20)).
-			DoAndReturn(func(userID uint, limit int, offset int) ([]model.Account, error) {
-				return []model.Account{
+		mar.EXPECT().GetMany(gomock.Eq(uint(1)), gomock.Eq(10), gomock.Eq("abc")).
+			DoAndReturn(func(userID uint, limit int, cursor string) (model.Page[model.Account], error) {
+				return model.Page[model.Account]{Items: []model.Account{
 					{
 						UserID: userID,
 						Name:   "Lorem Bank",
@@ -109,7 +129,7 @@ func TestAccountService_GetMany(t *testing.T) {
 						UserID: userID,
 						Name:   "Ipsum Bank",
 					},
-				}, nil
+				}, Total: 3}, nil
 			})
 
 		exp := []model.Account{
@@ -126,7 +146,7 @@ func TestAccountService_GetMany(t *testing.T) {
 				Name:   "Ipsum Bank",
 			},
 		}
-		got, err := as.GetMany(1, 10, 3)
+		got, err := as.GetMany(1, 10, "abc")
 		if err != nil {
 			t.Error("exp nil; got error:", err)
 		}
@@ -136,18 +156,27 @@ func TestAccountService_GetMany(t *testing.T) {
 			cmpopts.IgnoreFields(model.Account{}, "CurrencyID"),
 			cmpopts.IgnoreFields(model.Account{}, "InitialAmount"),
 		}
-		testutil.CompareAndAssert(t, exp, got, opts...)
+		testutil.CompareAndAssert(t, exp, got.Items, opts...)
+	})
+	t.Run("should return ErrInvalidCursor for a cursor the repository cannot read", func(t *testing.T) {
+		mar.EXPECT().GetMany(gomock.Eq(uint(1)), gomock.Eq(10), gomock.Eq("abc")).Return(model.Page[model.Account]{}, repository.ErrInvalidCursor)
+
+		if _, err := as.GetMany(1, 10, "abc"); !errors.Is(err, ErrInvalidCursor) {
+			t.Error("exp ErrInvalidCursor; got", err)
+		}
 	})
 }
 
 func TestAccountService_UpdateOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mar := mock_repository.NewMockAccountRepository(ctrl)
-	as := NewAccountService(mar)
+	mcurs := mock_service.NewMockCurrencyService(ctrl)
+	as := NewAccountService(mar, mcurs)
 
 	t.Run("should update account", func(t *testing.T) {
-		mar.EXPECT().UpdateOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq("Acme Bank"), gomock.Eq(1000)).
-			DoAndReturn(func(id uint, currencyID uint, name string, initialAmount int) (model.Account, error) {
+		mcurs.EXPECT().GetOneByID(gomock.Eq(2)).Return(model.Currency{Code: "USD", MinorUnit: 2}, nil)
+		mar.EXPECT().UpdateOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq("Acme Bank"), gomock.Eq(1000)).
+			DoAndReturn(func(userID, id uint, currencyID uint, name string, initialAmount int) (model.Account, error) {
 				return model.Account{
 					Model: gorm.Model{
 						ID: uint(id),
@@ -165,11 +194,12 @@ func TestAccountService_UpdateOneByID(t *testing.T) {
 			CurrencyID:    2,
 			Name:          "Acme Bank",
 			InitialAmount: 1000,
+			Currency:      &model.Currency{Code: "USD", MinorUnit: 2},
 		}
-		got, err := as.UpdateOneByID(1, dto.UpdateAccountDTO{
+		got, err := as.UpdateOneByID(1, 1, dto.UpdateAccountDTO{
 			CurrencyID:    2,
 			Name:          "Acme Bank",
-			InitialAmount: 1000,
+			InitialAmount: "10.00",
 		})
 		if err != nil {
 			t.Error("exp nil; got error:", err)
@@ -182,29 +212,116 @@ func TestAccountService_UpdateOneByID(t *testing.T) {
 		}
 		testutil.CompareAndAssert(t, exp, got, opts...)
 	})
+	t.Run("should return ErrAccountCurrencyLocked when the account has transactions", func(t *testing.T) {
+		mcurs.EXPECT().GetOneByID(gomock.Eq(3)).Return(model.Currency{Code: "JPY"}, nil)
+		mar.EXPECT().UpdateOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1)), gomock.Eq(uint(3)), gomock.Eq("Acme Bank"), gomock.Eq(10)).
+			Return(model.Account{}, repository.ErrAccountHasTransactions)
+
+		if _, err := as.UpdateOneByID(1, 1, dto.UpdateAccountDTO{
+			CurrencyID:    3,
+			Name:          "Acme Bank",
+			InitialAmount: "10",
+		}); !errors.Is(err, ErrAccountCurrencyLocked) {
+			t.Error("exp ErrAccountCurrencyLocked; got", err)
+		}
+	})
 }
 
 func TestAccountService_DeleteOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mar := mock_repository.NewMockAccountRepository(ctrl)
-	as := NewAccountService(mar)
+	mcurs := mock_service.NewMockCurrencyService(ctrl)
+	as := NewAccountService(mar, mcurs)
 
 	t.Run("should return error when repository layer returns error", func(t *testing.T) {
-		mar.EXPECT().DeleteOneByID(gomock.Eq(uint(1))).DoAndReturn(func(id uint) error {
+		mar.EXPECT().DeleteOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) error {
 			return errors.New("")
 		})
 
-		if err := as.DeleteOneByID(1); err == nil {
+		if err := as.DeleteOneByID(1, 1); err == nil {
 			t.Error("exp error; got nil")
 		}
 	})
 	t.Run("should delete and return nil", func(t *testing.T) {
-		mar.EXPECT().DeleteOneByID(gomock.Eq(uint(1))).DoAndReturn(func(id uint) error {
+		mar.EXPECT().DeleteOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) error {
 			return nil
 		})
 
-		if err := as.DeleteOneByID(1); err != nil {
+		if err := as.DeleteOneByID(1, 1); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
+
+func TestAccountService_GetBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mar := mock_repository.NewMockAccountRepository(ctrl)
+	mcurs := mock_service.NewMockCurrencyService(ctrl)
+	as := NewAccountService(mar, mcurs)
+	at := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should return error when repository layer returns error", func(t *testing.T) {
+		mar.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Account, error) {
+			return model.Account{}, errors.New("")
+		})
+
+		if _, err := as.GetBalance(1, 1, at); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+	t.Run("should add transaction totals to initial amount", func(t *testing.T) {
+		mar.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Account, error) {
+			return model.Account{
+				Model: gorm.Model{
+					ID: id,
+				},
+				InitialAmount: 1000,
+			}, nil
+		})
+		mar.EXPECT().GetTransactionTotals(gomock.Eq([]uint{1}), gomock.Eq(at)).
+			DoAndReturn(func(ids []uint, until time.Time) (map[uint]int, error) {
+				return map[uint]int{1: -250}, nil
+			})
+
+		got, err := as.GetBalance(1, 1, at)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != 750 {
+			t.Error("exp 750; got", got)
+		}
+	})
+}
+
+func TestAccountService_GetBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mar := mock_repository.NewMockAccountRepository(ctrl)
+	mcurs := mock_service.NewMockCurrencyService(ctrl)
+	as := NewAccountService(mar, mcurs)
+	at := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should not query repository when there are no accounts", func(t *testing.T) {
+		got, err := as.GetBalances([]model.Account{}, at)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 0 {
+			t.Error("exp 0; got", len(got))
+		}
+	})
+	t.Run("should return initial amount for accounts without transactions", func(t *testing.T) {
+		mar.EXPECT().GetTransactionTotals(gomock.Eq([]uint{1, 2}), gomock.Eq(at)).
+			DoAndReturn(func(ids []uint, until time.Time) (map[uint]int, error) {
+				return map[uint]int{2: 500}, nil
+			})
+
+		got, err := as.GetBalances([]model.Account{
+			{Model: gorm.Model{ID: 1}, InitialAmount: 100},
+			{Model: gorm.Model{ID: 2}, InitialAmount: 200},
+		}, at)
+		if err != nil {
 			t.Error("exp nil; got error:", err)
 		}
+		testutil.CompareAndAssert(t, map[uint]int{1: 100, 2: 700}, got)
 	})
 }
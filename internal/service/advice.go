@@ -1,42 +1,559 @@
 package service
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/llm"
 	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/gorm"
 )
 
-const Prompt string = "Given the maximum of 20 expenses consists of name, description, and the amount, give me a financial advice based on that, in two sentence maximum."
+const (
+	AdviceFocusSaving        = "saving"
+	AdviceFocusSubscriptions = "subscriptions"
+	AdviceFocusOverspending  = "overspending"
+)
+
+const (
+	defaultAdviceMonths = 3
+	maxAdviceMonths     = 12
+
+	// Bounds on what goes into a prompt, which keep it short.
+	maxAdviceCategories = 10
+	maxAdviceBudgets    = 20
+	maxAdviceRecurring  = 20
+	maxAdviceExpenses   = 5
+)
+
+var (
+	ErrInvalidAdviceMonths = fmt.Errorf("Months must be between 1 and %d", maxAdviceMonths)
+	ErrInvalidAdviceFocus  = errors.New("Focus must be one of saving, subscriptions or overspending")
+)
+
+// AdvicePromptVersion is bumped whenever the prompt or what goes into it
+// changes, so that advice from an older one is not reused.
+const AdvicePromptVersion = 4
+
+const basePrompt = "You are a personal finance assistant. The user's spending summary follows. Using only its figures, give specific, actionable advice in three sentences at most."
+
+// adviceFocuses are what is added to basePrompt for each focus, the empty
+// one being no focus in particular.
+var adviceFocuses = map[string]string{
+	"":                       "Point out whatever matters most.",
+	AdviceFocusSaving:        "Focus on where they could save money.",
+	AdviceFocusSubscriptions: "Focus on their recurring charges, and which of them could be cut.",
+	AdviceFocusOverspending:  "Focus on where they spend more than usual or more than they budgeted.",
+}
 
 type AdviceService interface {
-	GetAdvice(userID int) (string, error)
+	// GetAdvice reuses the latest advice generated for the same input,
+	// which it is within a month as long as nothing advice is based on
+	// has changed.
+	GetAdvice(userID int, payload dto.AdviceDTO) (model.Advice, error)
+	// StreamAdvice is GetAdvice, handing each piece of the advice to
+	// onToken as the model writes it. Reused advice comes in one piece.
+	StreamAdvice(ctx context.Context, userID int, payload dto.AdviceDTO, onToken func(token string) error) (model.Advice, error)
+	GetHistory(userID, itemPerPage int, cursor string) (model.Page[model.Advice], error)
 }
 
 type adviceService struct {
-	es  ExpenseService
-	oar repository.OpenAIRepository
+	ar   repository.AdviceRepository
+	es   ExpenseService
+	cs   CategoryService
+	rs   ReportService
+	bs   BudgetService
+	recs RecurringService
+	curs CurrencyService
+	lp   llm.Provider
+}
+
+func NewAdviceService(ar repository.AdviceRepository, es ExpenseService, cs CategoryService, rs ReportService, bs BudgetService, recs RecurringService, curs CurrencyService, lp llm.Provider) *adviceService {
+	return &adviceService{ar, es, cs, rs, bs, recs, curs, lp}
 }
 
-func NewAdviceService(es ExpenseService, oar repository.OpenAIRepository) *adviceService {
-	return &adviceService{es, oar}
+func (ads *adviceService) GetAdvice(userID int, payload dto.AdviceDTO) (model.Advice, error) {
+	return ads.StreamAdvice(context.Background(), userID, payload, func(string) error { return nil })
 }
 
-func (ads *adviceService) GetAdvice(userID int) (string, error) {
-	expenses, err := ads.es.GetManyBelongedToUser(userID, 20, 1)
+func (ads *adviceService) StreamAdvice(ctx context.Context, userID int, payload dto.AdviceDTO, onToken func(token string) error) (model.Advice, error) {
+	advice, now, err := ads.newAdvice(userID, payload)
+	if err != nil {
+		return model.Advice{}, err
+	}
+
+	cached, err := ads.ar.GetLatestByInputHash(uint(userID), advice.InputHash)
+	if err == nil {
+		cached.Cached = true
+		return cached, onToken(cached.Text)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.Advice{}, err
+	}
+
+	in, err := ads.gather(userID, advice.Months, now, advice.Timezone)
 	if err != nil {
-		return "", err
+		return model.Advice{}, err
 	}
 
-	message := `My last expenses were:
-`
-	for _, e := range expenses {
-		message += fmt.Sprintf(`- name: %s, description: %s, amount: %d`, e.Name, e.Description, e.Amount)
+	var text strings.Builder
+	usage, err := ads.lp.Stream(ctx, basePrompt+" "+adviceFocuses[advice.Focus], adviceMessage(in), func(token string) error {
+		text.WriteString(token)
+		return onToken(token)
+	})
+	if err != nil {
+		return model.Advice{}, err
 	}
+	advice.Text = text.String()
+	advice.PromptTokens = usage.PromptTokens
+	advice.CompletionTokens = usage.CompletionTokens
+	advice.TotalTokens = usage.TotalTokens
+	advice.UsageEstimated = usage.Estimated
 
-	response, err := ads.oar.GetResponse(Prompt, message)
+	return ads.ar.Insert(advice)
+}
+
+func (ads *adviceService) GetHistory(userID, itemPerPage int, cursor string) (model.Page[model.Advice], error) {
+	history, err := ads.ar.GetManyBelongedToUser(uint(userID), itemPerPage, cursor)
 	if err != nil {
-		return "", err
+		return model.Page[model.Advice]{}, invalidCursor(err)
+	}
+
+	return history, nil
+}
+
+// adviceInput is what advice is based on: spending this month and in the
+// months before it, budgets and recurring charges. MoreBudgets and
+// MoreRecurring tell that there were more than fit in the prompt.
+type adviceInput struct {
+	Now           time.Time
+	Months        int
+	Spending      []adviceSpending
+	Budgets       []model.BudgetStatus
+	MoreBudgets   bool
+	Recurring     []model.RecurringTemplate
+	MoreRecurring bool
+	Categories    map[uint]string
+}
+
+// adviceSpending is spending in one currency: the reporting currency, or
+// each currency spent in when the user has none to convert to. Largest are
+// the largest expenses in it, once converted.
+type adviceSpending struct {
+	Current  model.ExpenseReport
+	Previous model.ExpenseReport
+	Largest  []model.Expense
+}
+
+func (s adviceSpending) currency() model.Currency {
+	if s.Current.Currency.Code != "" {
+		return s.Current.Currency
+	}
+
+	return s.Previous.Currency
+}
+
+// newAdvice checks payload and describes the advice it asks for, still
+// without text, along with the time it is asked at.
+func (ads *adviceService) newAdvice(userID int, payload dto.AdviceDTO) (model.Advice, time.Time, error) {
+	if _, ok := adviceFocuses[payload.Focus]; !ok {
+		return model.Advice{}, time.Time{}, ErrInvalidAdviceFocus
+	}
+	months := payload.Months
+	if months == 0 {
+		months = defaultAdviceMonths
+	}
+	if months < 1 || months > maxAdviceMonths {
+		return model.Advice{}, time.Time{}, ErrInvalidAdviceMonths
+	}
+	loc, err := util.LoadTimezone(payload.Timezone)
+	if err != nil {
+		return model.Advice{}, time.Time{}, ErrInvalidTimezone
+	}
+
+	dataVersion, err := ads.ar.GetDataVersion(uint(userID))
+	if err != nil {
+		return model.Advice{}, time.Time{}, err
+	}
+
+	now := time.Now().In(loc)
+	current := util.MonthOf(now)
+	advice := model.Advice{
+		UserID:        uint(userID),
+		Months:        months,
+		Focus:         payload.Focus,
+		Timezone:      payload.Timezone,
+		WindowFrom:    current.From.AddDate(0, -months, 0),
+		WindowTo:      current.To,
+		ModelName:     ads.lp.Model(),
+		PromptVersion: AdvicePromptVersion,
+	}
+	advice.InputHash = adviceInputHash(advice, now, dataVersion)
+
+	return advice, now, nil
+}
+
+// adviceInputHash identifies what advice is asked for and the data it is
+// based on. Today's date is part of it since the prompt tells how far into
+// the month it is.
+func adviceInputHash(advice model.Advice, now time.Time, dataVersion string) string {
+	input := sha256.Sum256([]byte(fmt.Sprintf(
+		"%d|%s|%d|%s|%s|%s|%s|%s",
+		advice.PromptVersion, advice.ModelName, advice.Months, advice.Focus, advice.Timezone,
+		advice.WindowFrom.UTC().Format(time.RFC3339), now.Format(util.DateLayout), dataVersion,
+	)))
+
+	return hex.EncodeToString(input[:])
+}
+
+func (ads *adviceService) gather(userID, months int, now time.Time, timezone string) (adviceInput, error) {
+	in := adviceInput{Now: now, Months: months, Categories: map[uint]string{}}
+	current := util.MonthOf(now)
+	previous := util.Period{From: current.From.AddDate(0, -months, 0), To: current.From}
+
+	var err error
+	if in.Spending, err = ads.spending(userID, current, previous, timezone); err != nil {
+		return adviceInput{}, err
+	}
+	if err := ads.largest(userID, in.Spending, util.Period{From: previous.From, To: current.To}); err != nil {
+		return adviceInput{}, err
+	}
+
+	// One more than fits is asked for to tell whether there are more.
+	budgets, err := ads.bs.GetManyBelongedToUser(userID, maxAdviceBudgets+1, 1)
+	if err != nil {
+		return adviceInput{}, err
+	}
+	if len(budgets) > maxAdviceBudgets {
+		budgets, in.MoreBudgets = budgets[:maxAdviceBudgets], true
+	}
+	for _, b := range budgets {
+		status, err := ads.bs.GetStatus(userID, int(b.ID), current)
+		if err != nil {
+			return adviceInput{}, err
+		}
+		in.Budgets = append(in.Budgets, status)
+	}
+
+	templates, err := ads.recs.GetManyBelongedToUser(userID, maxAdviceRecurring+1, 1)
+	if err != nil {
+		return adviceInput{}, err
+	}
+	if len(templates) > maxAdviceRecurring {
+		templates, in.MoreRecurring = templates[:maxAdviceRecurring], true
+	}
+	for _, t := range templates {
+		// Ended schedules no longer cost anything.
+		if t.Type == model.TransactionTypeExpense && t.NextAt != nil {
+			in.Recurring = append(in.Recurring, t)
+		}
+	}
+
+	if err := ads.nameCategories(userID, &in); err != nil {
+		return adviceInput{}, err
+	}
+
+	return in, nil
+}
+
+// nameCategories fills in.Categories with the name of every category in,
+// which the reports already have for most of them.
+func (ads *adviceService) nameCategories(userID int, in *adviceInput) error {
+	for _, s := range in.Spending {
+		for _, c := range append(s.Current.Categories, s.Previous.Categories...) {
+			in.Categories[c.CategoryID] = c.CategoryName
+		}
+	}
+
+	var ids []uint
+	unnamed := func(id uint) {
+		if _, ok := in.Categories[id]; !ok && id != 0 {
+			in.Categories[id] = ""
+			ids = append(ids, id)
+		}
+	}
+	for _, s := range in.Spending {
+		for _, e := range s.Largest {
+			unnamed(e.CategoryID)
+		}
+	}
+	for _, b := range in.Budgets {
+		unnamed(b.Budget.CategoryID)
+	}
+	for _, t := range in.Recurring {
+		unnamed(t.CategoryID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	categories, err := ads.cs.GetManyByIDs(userID, ids)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		delete(in.Categories, id)
+	}
+	for _, c := range categories {
+		in.Categories[c.ID] = c.Name
+	}
+
+	return nil
+}
+
+// spending reports on the current and previous periods in the reporting
+// currency, falling back to a report per currency when amounts are in more
+// than one and there is no reporting currency to add them up in.
+func (ads *adviceService) spending(userID int, current, previous util.Period, timezone string) ([]adviceSpending, error) {
+	var s adviceSpending
+	var err error
+	if s.Current, err = ads.rs.GetExpenseReport(userID, GroupByMonth, current, timezone); err == nil {
+		if s.Previous, err = ads.rs.GetExpenseReport(userID, GroupByMonth, previous, timezone); err == nil {
+			// Each period may be in a currency of its own all the same.
+			if s.Current.Currency.Code == "" || s.Previous.Currency.Code == "" || s.Current.Currency.Code == s.Previous.Currency.Code {
+				return []adviceSpending{s}, nil
+			}
+			err = ErrReportingCurrencyRequired
+		}
+	}
+	if !errors.Is(err, ErrReportingCurrencyRequired) {
+		return nil, err
+	}
+
+	currents, err := ads.rs.GetExpenseReportsByCurrency(userID, GroupByMonth, current, timezone)
+	if err != nil {
+		return nil, err
+	}
+	previouses, err := ads.rs.GetExpenseReportsByCurrency(userID, GroupByMonth, previous, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	byCurrency := map[string]*adviceSpending{}
+	var codes []string
+	spendingIn := func(currency model.Currency) *adviceSpending {
+		if _, ok := byCurrency[currency.Code]; !ok {
+			byCurrency[currency.Code] = &adviceSpending{
+				Current:  model.ExpenseReport{From: current.From, To: current.To, Currency: currency},
+				Previous: model.ExpenseReport{From: previous.From, To: previous.To, Currency: currency},
+			}
+			codes = append(codes, currency.Code)
+		}
+		return byCurrency[currency.Code]
+	}
+	for _, r := range currents {
+		spendingIn(r.Currency).Current = r
+	}
+	for _, r := range previouses {
+		spendingIn(r.Currency).Previous = r
+	}
+	sort.Strings(codes)
+
+	spending := make([]adviceSpending, 0, len(codes))
+	for _, code := range codes {
+		spending = append(spending, *byCurrency[code])
+	}
+
+	return spending, nil
+}
+
+// largest finds the largest expenses over period in each of spending, by
+// their amount once converted to its currency. The largest expenses of each
+// account the reports found spending in are taken, which include the
+// largest overall whatever the rates are.
+func (ads *adviceService) largest(userID int, spending []adviceSpending, period util.Period) error {
+	accounts := map[uint]bool{}
+	for _, s := range spending {
+		for _, a := range append(s.Current.Accounts, s.Previous.Accounts...) {
+			accounts[a.AccountID] = true
+		}
+	}
+	accountIDs := make([]uint, 0, len(accounts))
+	for id := range accounts {
+		accountIDs = append(accountIDs, id)
+	}
+	sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+
+	var candidates []model.Expense
+	for _, id := range accountIDs {
+		expenses, err := ads.es.GetManyBelongedToUser(userID, dto.ExpenseFilterDTO{
+			Period:     period,
+			AccountIDs: []uint{id},
+			SortBy:     "amount",
+			Order:      "desc",
+		}, maxAdviceExpenses, "")
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, expenses.Items...)
+	}
+
+	for i := range spending {
+		currency := spending[i].currency()
+		var expenses []model.Expense
+		var rows []model.ExpenseStatsRow
+		for _, e := range candidates {
+			c := accountCurrency(e.Account)
+			if c == nil || (len(spending) > 1 && c.Code != currency.Code) {
+				continue
+			}
+			expenses = append(expenses, e)
+			rows = append(rows, model.ExpenseStatsRow{
+				Currency:     c.Code,
+				MinorUnit:    c.MinorUnit,
+				Date:         e.OccurredAt.UTC().Format(util.DateLayout),
+				ExpenseStats: model.ExpenseStats{Count: 1, Total: e.Amount, Largest: e.Amount},
+			})
+		}
+		converted, err := ads.curs.ConvertTo(userID, rows, currency)
+		if err != nil {
+			return err
+		}
+
+		order := make([]int, len(expenses))
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool { return converted[order[a]].Total > converted[order[b]].Total })
+		if len(order) > maxAdviceExpenses {
+			order = order[:maxAdviceExpenses]
+		}
+		for _, j := range order {
+			spending[i].Largest = append(spending[i].Largest, expenses[j])
+		}
+	}
+
+	return nil
+}
+
+// adviceMessage writes in as a plain text summary, a section at a time.
+// Sections without data say so rather than being left out, so the model
+// does not guess at them. Spending in more than one currency is summarized
+// in each of them on its own.
+func adviceMessage(in adviceInput) string {
+	var b strings.Builder
+	month := util.MonthOf(in.Now)
+	daysInMonth := int(month.To.Sub(month.From).Hours()/24 + 0.5)
+
+	fmt.Fprintf(&b, "Today is %s, day %d of %d of the month.\n", in.Now.Format(util.DateLayout), in.Now.Day(), daysInMonth)
+
+	spending := in.Spending
+	if len(spending) == 0 {
+		spending = []adviceSpending{{}}
+	}
+	// inCurrency tells sections of spending apart by currency when there
+	// is more than one.
+	inCurrency := func(s adviceSpending) string {
+		if len(spending) == 1 {
+			return ""
+		}
+		return " in " + s.currency().Code
+	}
+
+	for _, s := range spending {
+		currency := s.currency()
+		amount := func(a int) string {
+			if currency.Code == "" {
+				// Without expenses there is no currency to speak of.
+				return formatAmount(a, nil)
+			}
+			return formatAmount(a, &currency)
+		}
+
+		fmt.Fprintf(&b, "\nSpending per month%s:\n", inCurrency(s))
+		for _, p := range s.Previous.Periods {
+			fmt.Fprintf(&b, "- %s: %s in %d expenses\n", p.From.Format("2006-01"), amount(p.Total), p.Count)
+		}
+		fmt.Fprintf(&b, "- %s (so far): %s in %d expenses\n", month.From.Format("2006-01"), amount(s.Current.Summary.Total), s.Current.Summary.Count)
+
+		fmt.Fprintf(&b, "\nSpending%s per category this month so far, against the monthly average of the previous %d months:\n", inCurrency(s), in.Months)
+		averages := map[uint]int{}
+		for _, c := range s.Previous.Categories {
+			averages[c.CategoryID] = c.Total / in.Months
+		}
+		categories := s.Current.Categories
+		if len(categories) > maxAdviceCategories {
+			categories = categories[:maxAdviceCategories]
+		}
+		if len(categories) == 0 {
+			b.WriteString("- No expenses yet this month.\n")
+		}
+		for _, c := range categories {
+			average := averages[c.CategoryID]
+			if average == 0 {
+				fmt.Fprintf(&b, "- %s: %s, none before\n", c.CategoryName, amount(c.Total))
+				continue
+			}
+			fmt.Fprintf(&b, "- %s: %s, average %s (%+d%%)\n", c.CategoryName, amount(c.Total), amount(average), (c.Total-average)*100/average)
+		}
+	}
+
+	b.WriteString("\nBudgets this month:\n")
+	if len(in.Budgets) == 0 {
+		b.WriteString("- No budgets set.\n")
+	}
+	for _, s := range in.Budgets {
+		budgetAmount := func(a int) string { return formatAmount(a, &s.Currency) }
+		status := "on track"
+		if s.Spent > s.Limit {
+			status = "over budget"
+		} else if s.Projected > s.Limit {
+			status = "projected to go over"
+		}
+		fmt.Fprintf(&b, "- %s: spent %s of %s, projected %s, %s\n", categoryName(in.Categories, s.Budget.CategoryID), budgetAmount(s.Spent), budgetAmount(s.Limit), budgetAmount(s.Projected), status)
+	}
+	if in.MoreBudgets {
+		b.WriteString("- More budgets are not listed.\n")
+	}
+
+	b.WriteString("\nRecurring charges:\n")
+	if len(in.Recurring) == 0 {
+		b.WriteString("- None.\n")
+	}
+	for _, t := range in.Recurring {
+		fmt.Fprintf(&b, "- %s (%s): %s %s\n", t.Name, categoryName(in.Categories, t.CategoryID), formatAmount(t.Amount, accountCurrency(t.Account)), schedule(t.Frequency, t.Interval))
+	}
+	if in.MoreRecurring {
+		b.WriteString("- More recurring charges are not listed.\n")
+	}
+
+	for _, s := range spending {
+		fmt.Fprintf(&b, "\nLargest expenses%s since %s:\n", inCurrency(s), s.Previous.From.Format(util.DateLayout))
+		if len(s.Largest) == 0 {
+			b.WriteString("- None.\n")
+		}
+		for _, e := range s.Largest {
+			fmt.Fprintf(&b, "- %s, %s (%s): %s\n", e.OccurredAt.In(in.Now.Location()).Format(util.DateLayout), e.Name, categoryName(in.Categories, e.CategoryID), formatAmount(e.Amount, accountCurrency(e.Account)))
+		}
+	}
+
+	return b.String()
+}
+
+func categoryName(categories map[uint]string, id uint) string {
+	if name, ok := categories[id]; ok {
+		return name
+	}
+
+	return "uncategorized"
+}
+
+// schedule writes how often a recurring charge repeats, such as "monthly"
+// or "every 2 weeks".
+func schedule(frequency string, interval int) string {
+	if interval <= 1 {
+		return frequency
 	}
+	units := map[string]string{"daily": "days", "weekly": "weeks", "monthly": "months", "yearly": "years"}
 
-	return response, nil
+	return fmt.Sprintf("every %d %s", interval, units[frequency])
 }
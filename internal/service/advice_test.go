@@ -0,0 +1,455 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/llm"
+	mock_llm "github.com/muhrizqiardi/spendtracker/internal/llm/mock"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestAdviceService_GetAdvice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mar := mock_repository.NewMockAdviceRepository(ctrl)
+	mes := mock_service.NewMockExpenseService(ctrl)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	mrs := mock_service.NewMockReportService(ctrl)
+	mbs := mock_service.NewMockBudgetService(ctrl)
+	mrecs := mock_service.NewMockRecurringService(ctrl)
+	mcurs := mock_service.NewMockCurrencyService(ctrl)
+	mlp := mock_llm.NewMockProvider(ctrl)
+	ads := NewAdviceService(mar, mes, mcs, mrs, mbs, mrecs, mcurs, mlp)
+	mlp.EXPECT().Model().Return("model").AnyTimes()
+
+	expectMiss := func() {
+		mar.EXPECT().GetDataVersion(gomock.Eq(uint(1))).Return("v1", nil)
+		mar.EXPECT().GetLatestByInputHash(gomock.Eq(uint(1)), gomock.Any()).Return(model.Advice{}, gorm.ErrRecordNotFound)
+	}
+	expectNoData := func() {
+		mrs.EXPECT().GetExpenseReport(gomock.Eq(1), gomock.Eq(GroupByMonth), gomock.Any(), gomock.Any()).Return(model.ExpenseReport{}, nil).Times(2)
+		mbs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(1)).Return(nil, nil)
+		mrecs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(1)).Return(nil, nil)
+		mcurs.EXPECT().ConvertTo(gomock.Eq(1), gomock.Any(), gomock.Any()).Return(nil, nil)
+	}
+	expectInsert := func() {
+		mar.EXPECT().Insert(gomock.Any()).DoAndReturn(func(advice model.Advice) (model.Advice, error) {
+			advice.ID = 9
+			return advice, nil
+		})
+	}
+	streamAdvice := func(ctx context.Context, prompt, message string, onToken func(string) error) (llm.Usage, error) {
+		onToken("Spend ")
+		onToken("less.")
+		return llm.Usage{PromptTokens: 100, CompletionTokens: 2, TotalTokens: 102}, nil
+	}
+
+	t.Run("should return error on an invalid focus", func(t *testing.T) {
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{Focus: "luck"}); !errors.Is(err, ErrInvalidAdviceFocus) {
+			t.Error("exp ErrInvalidAdviceFocus; got", err)
+		}
+	})
+	t.Run("should return error on a window out of range", func(t *testing.T) {
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{Months: maxAdviceMonths + 1}); !errors.Is(err, ErrInvalidAdviceMonths) {
+			t.Error("exp ErrInvalidAdviceMonths; got", err)
+		}
+	})
+	t.Run("should return error on an invalid timezone", func(t *testing.T) {
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{Timezone: "Mars/Olympus"}); !errors.Is(err, ErrInvalidTimezone) {
+			t.Error("exp ErrInvalidTimezone; got", err)
+		}
+	})
+	t.Run("should return the report's error", func(t *testing.T) {
+		expectMiss()
+		mrs.EXPECT().GetExpenseReport(gomock.Eq(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(model.ExpenseReport{}, ErrExchangeRateNotFound)
+
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{}); !errors.Is(err, ErrExchangeRateNotFound) {
+			t.Error("exp ErrExchangeRateNotFound; got", err)
+		}
+	})
+	t.Run("should fall back to spending per currency without a reporting currency", func(t *testing.T) {
+		usd := model.Currency{Code: "USD", MinorUnit: 2}
+		jpy := model.Currency{Code: "JPY"}
+		expectMiss()
+		mrs.EXPECT().GetExpenseReport(gomock.Eq(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(model.ExpenseReport{Currency: usd}, nil)
+		mrs.EXPECT().GetExpenseReport(gomock.Eq(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(model.ExpenseReport{}, ErrReportingCurrencyRequired)
+		mrs.EXPECT().GetExpenseReportsByCurrency(gomock.Eq(1), gomock.Eq(GroupByMonth), gomock.Any(), gomock.Any()).Return([]model.ExpenseReport{
+			{Currency: usd, Summary: model.ExpenseStats{Count: 1, Total: 1500}},
+		}, nil)
+		mrs.EXPECT().GetExpenseReportsByCurrency(gomock.Eq(1), gomock.Eq(GroupByMonth), gomock.Any(), gomock.Any()).Return([]model.ExpenseReport{
+			{Currency: jpy, Summary: model.ExpenseStats{Count: 2, Total: 3000}},
+			{Currency: usd, Summary: model.ExpenseStats{Count: 1, Total: 500}},
+		}, nil)
+		mbs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(1)).Return(nil, nil)
+		mrecs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(1)).Return(nil, nil)
+		mcurs.EXPECT().ConvertTo(gomock.Eq(1), gomock.Any(), gomock.Eq(jpy)).Return(nil, nil)
+		mcurs.EXPECT().ConvertTo(gomock.Eq(1), gomock.Any(), gomock.Eq(usd)).Return(nil, nil)
+		mlp.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, prompt, message string, onToken func(string) error) (llm.Usage, error) {
+			for _, exp := range []string{"Spending per month in JPY:\n", "Spending per month in USD:\n", "(so far): 15.00 USD in 1 expenses\n"} {
+				if !strings.Contains(message, exp) {
+					t.Errorf("exp %q in message; got %q", exp, message)
+				}
+			}
+			return streamAdvice(ctx, prompt, message, onToken)
+		})
+		expectInsert()
+
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should list the largest expenses by their converted amount", func(t *testing.T) {
+		idr := model.Currency{Code: "IDR", MinorUnit: 2}
+		usd := model.Currency{Code: "USD", MinorUnit: 2}
+		expectMiss()
+		mrs.EXPECT().GetExpenseReport(gomock.Eq(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(model.ExpenseReport{
+			Currency: idr,
+			Accounts: []model.AccountExpenseStats{{AccountID: 2}, {AccountID: 3}},
+		}, nil).Times(2)
+		mbs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(1)).Return(nil, nil)
+		mrecs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(1)).Return(nil, nil)
+		for _, tc := range []struct {
+			accountID uint
+			expense   model.Expense
+		}{
+			{2, model.Expense{Name: "Rent", Amount: 500000000, Account: &model.Account{Currency: &idr}}},
+			{3, model.Expense{Name: "Flight", Amount: 80000, Account: &model.Account{Currency: &usd}}},
+		} {
+			tc := tc
+			mes.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(maxAdviceExpenses), gomock.Eq("")).DoAndReturn(func(userID int, filter dto.ExpenseFilterDTO, itemPerPage int, cursor string) (model.Page[model.Expense], error) {
+				if len(filter.AccountIDs) != 1 || filter.AccountIDs[0] != tc.accountID || filter.SortBy != "amount" || filter.Order != "desc" {
+					t.Error("exp the largest expenses of account", tc.accountID, "; got", filter)
+				}
+				return model.Page[model.Expense]{Items: []model.Expense{tc.expense}}, nil
+			})
+		}
+		mcurs.EXPECT().ConvertTo(gomock.Eq(1), gomock.Any(), gomock.Eq(idr)).DoAndReturn(func(userID int, rows []model.ExpenseStatsRow, currency model.Currency) ([]model.ExpenseStatsRow, error) {
+			if len(rows) != 2 || rows[1].Currency != "USD" || rows[1].Total != 80000 {
+				t.Fatal("exp both expenses in their own currency; got", rows)
+			}
+			converted := append([]model.ExpenseStatsRow{}, rows...)
+			converted[1].Total *= 15000
+			return converted, nil
+		})
+		mlp.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, prompt, message string, onToken func(string) error) (llm.Usage, error) {
+			if flight, rent := strings.Index(message, "Flight"), strings.Index(message, "Rent"); flight < 0 || rent < 0 || flight > rent {
+				t.Errorf("exp Flight before Rent; got %q", message)
+			}
+			return streamAdvice(ctx, prompt, message, onToken)
+		})
+		expectInsert()
+
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should compare this month with the months before it", func(t *testing.T) {
+		expectMiss()
+		var periods []util.Period
+		mrs.EXPECT().GetExpenseReport(gomock.Eq(1), gomock.Eq(GroupByMonth), gomock.Any(), gomock.Eq("Asia/Jakarta")).DoAndReturn(func(userID int, groupBy string, period util.Period, timezone string) (model.ExpenseReport, error) {
+			periods = append(periods, period)
+			return model.ExpenseReport{}, nil
+		}).Times(2)
+		mbs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(1)).Return([]model.Budget{{Model: gorm.Model{ID: 4}}}, nil)
+		mbs.EXPECT().GetStatus(gomock.Eq(1), gomock.Eq(4), gomock.Any()).Return(model.BudgetStatus{}, nil)
+		mrecs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Any(), gomock.Eq(1)).Return(nil, nil)
+		mcurs.EXPECT().ConvertTo(gomock.Eq(1), gomock.Any(), gomock.Any()).Return(nil, nil)
+		mlp.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamAdvice)
+		expectInsert()
+
+		got, err := ads.GetAdvice(1, dto.AdviceDTO{Months: 6, Timezone: "Asia/Jakarta"})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Text != "Spend less." {
+			t.Errorf("exp %q; got %q", "Spend less.", got.Text)
+		}
+		if len(periods) != 2 {
+			t.Fatal("exp 2 reports; got", len(periods))
+		}
+		current, previous := periods[0], periods[1]
+		if current.From.Day() != 1 || current.From.Location().String() != "Asia/Jakarta" {
+			t.Error("exp the current month in Asia/Jakarta; got", current)
+		}
+		if !previous.To.Equal(current.From) || !previous.From.Equal(current.From.AddDate(0, -6, 0)) {
+			t.Error("exp the 6 months before it; got", previous)
+		}
+		if !got.WindowFrom.Equal(previous.From) || !got.WindowTo.Equal(current.To) {
+			t.Error("exp the advice to cover both; got", got.WindowFrom, got.WindowTo)
+		}
+	})
+	t.Run("should name the categories it lists and note what did not fit", func(t *testing.T) {
+		expectMiss()
+		mrs.EXPECT().GetExpenseReport(gomock.Eq(1), gomock.Eq(GroupByMonth), gomock.Any(), gomock.Any()).Return(model.ExpenseReport{
+			Categories: []model.CategoryExpenseStats{{CategoryID: 1, CategoryName: "Food"}},
+		}, nil).Times(2)
+		budgets := make([]model.Budget, maxAdviceBudgets+1)
+		for i := range budgets {
+			budgets[i] = model.Budget{Model: gorm.Model{ID: uint(i + 1)}, CategoryID: 1}
+		}
+		budgets[0].CategoryID = 150
+		mbs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Eq(maxAdviceBudgets+1), gomock.Eq(1)).Return(budgets, nil)
+		mbs.EXPECT().GetStatus(gomock.Eq(1), gomock.Any(), gomock.Any()).DoAndReturn(func(userID, id int, period util.Period) (model.BudgetStatus, error) {
+			return model.BudgetStatus{Budget: budgets[id-1]}, nil
+		}).Times(maxAdviceBudgets)
+		mrecs.EXPECT().GetManyBelongedToUser(gomock.Eq(1), gomock.Eq(maxAdviceRecurring+1), gomock.Eq(1)).Return(make([]model.RecurringTemplate, maxAdviceRecurring+1), nil)
+		mcs.EXPECT().GetManyByIDs(gomock.Eq(1), gomock.Eq([]uint{150})).Return([]model.Category{{Model: gorm.Model{ID: 150}, Name: "Pets"}}, nil)
+		mcurs.EXPECT().ConvertTo(gomock.Eq(1), gomock.Any(), gomock.Any()).Return(nil, nil)
+		mlp.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, prompt, message string, onToken func(string) error) (llm.Usage, error) {
+			for _, exp := range []string{"- Pets: spent", "- More budgets are not listed.\n", "- More recurring charges are not listed.\n"} {
+				if !strings.Contains(message, exp) {
+					t.Errorf("exp %q in message; got %q", exp, message)
+				}
+			}
+			if strings.Contains(message, "uncategorized") {
+				t.Errorf("exp every category named; got %q", message)
+			}
+			return streamAdvice(ctx, prompt, message, onToken)
+		})
+		expectInsert()
+
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should add the focus to the prompt", func(t *testing.T) {
+		expectMiss()
+		expectNoData()
+		mlp.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, prompt, message string, onToken func(string) error) (llm.Usage, error) {
+			if !strings.HasSuffix(prompt, adviceFocuses[AdviceFocusSubscriptions]) {
+				t.Error("exp the subscriptions focus; got", prompt)
+			}
+			return streamAdvice(ctx, prompt, message, onToken)
+		})
+		expectInsert()
+
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{Focus: AdviceFocusSubscriptions}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should not keep advice that failed", func(t *testing.T) {
+		expectMiss()
+		expectNoData()
+		mlp.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(llm.Usage{}, llm.ErrEmptyCompletion)
+
+		if _, err := ads.GetAdvice(1, dto.AdviceDTO{}); !errors.Is(err, llm.ErrEmptyCompletion) {
+			t.Error("exp llm.ErrEmptyCompletion; got", err)
+		}
+	})
+	t.Run("should keep streamed advice with its input and usage", func(t *testing.T) {
+		expectMiss()
+		expectNoData()
+		mlp.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamAdvice)
+		var kept model.Advice
+		mar.EXPECT().Insert(gomock.Any()).DoAndReturn(func(advice model.Advice) (model.Advice, error) {
+			kept = advice
+			return advice, nil
+		})
+
+		var got string
+		if _, err := ads.StreamAdvice(context.Background(), 1, dto.AdviceDTO{Focus: AdviceFocusSaving}, func(token string) error {
+			got += token
+			return nil
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != "Spend less." || kept.Text != got {
+			t.Error("exp the streamed advice to be kept; got", got, kept.Text)
+		}
+		if kept.UserID != 1 || kept.Months != defaultAdviceMonths || kept.Focus != AdviceFocusSaving ||
+			kept.ModelName != "model" || kept.PromptVersion != AdvicePromptVersion || kept.InputHash == "" ||
+			kept.TotalTokens != 102 {
+			t.Error("exp the input, model, prompt version and usage to be kept; got", kept)
+		}
+	})
+	t.Run("should reuse advice for the same input", func(t *testing.T) {
+		var hashes []string
+		for i := 0; i < 2; i++ {
+			mar.EXPECT().GetDataVersion(gomock.Eq(uint(1))).Return("v1", nil)
+			mar.EXPECT().GetLatestByInputHash(gomock.Eq(uint(1)), gomock.Any()).DoAndReturn(func(userID uint, inputHash string) (model.Advice, error) {
+				hashes = append(hashes, inputHash)
+				return model.Advice{Model: gorm.Model{ID: 9}, Text: "Spend less."}, nil
+			})
+		}
+
+		got, err := ads.GetAdvice(1, dto.AdviceDTO{})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.ID != 9 || !got.Cached {
+			t.Error("exp cached advice 9; got", got)
+		}
+
+		var streamed string
+		if _, err := ads.StreamAdvice(context.Background(), 1, dto.AdviceDTO{}, func(token string) error {
+			streamed += token
+			return nil
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if streamed != "Spend less." {
+			t.Error("exp the cached advice in one piece; got", streamed)
+		}
+		if hashes[0] != hashes[1] {
+			t.Error("exp the same input hash; got", hashes)
+		}
+	})
+	t.Run("should tell inputs apart", func(t *testing.T) {
+		var hashes []string
+		for _, tc := range []struct {
+			version string
+			payload dto.AdviceDTO
+		}{
+			{"v1", dto.AdviceDTO{}},
+			{"v2", dto.AdviceDTO{}},
+			{"v1", dto.AdviceDTO{Focus: AdviceFocusSaving}},
+			{"v1", dto.AdviceDTO{Months: 6}},
+			{"v1", dto.AdviceDTO{Timezone: "Asia/Jakarta"}},
+		} {
+			mar.EXPECT().GetDataVersion(gomock.Eq(uint(1))).Return(tc.version, nil)
+			mar.EXPECT().GetLatestByInputHash(gomock.Eq(uint(1)), gomock.Any()).DoAndReturn(func(userID uint, inputHash string) (model.Advice, error) {
+				hashes = append(hashes, inputHash)
+				return model.Advice{}, nil
+			})
+
+			if _, err := ads.GetAdvice(1, tc.payload); err != nil {
+				t.Error("exp nil; got error:", err)
+			}
+		}
+
+		seen := map[string]bool{}
+		for _, h := range hashes {
+			if seen[h] {
+				t.Error("exp every input hash to differ; got", hashes)
+			}
+			seen[h] = true
+		}
+	})
+}
+
+func TestAdviceInputHash(t *testing.T) {
+	advice := model.Advice{Months: 3, ModelName: "model", PromptVersion: AdvicePromptVersion}
+	today := time.Date(2023, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should stay the same through the day", func(t *testing.T) {
+		if adviceInputHash(advice, today, "v1") != adviceInputHash(advice, today.Add(12*time.Hour), "v1") {
+			t.Error("exp the same input hash")
+		}
+	})
+	t.Run("should not reuse yesterday's advice", func(t *testing.T) {
+		if adviceInputHash(advice, today, "v1") == adviceInputHash(advice, today.AddDate(0, 0, 1), "v1") {
+			t.Error("exp the input hash to change with the day")
+		}
+	})
+}
+
+func TestAdviceService_GetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mar := mock_repository.NewMockAdviceRepository(ctrl)
+	ads := NewAdviceService(mar, nil, nil, nil, nil, nil, nil, nil)
+
+	t.Run("should return ErrInvalidCursor on an invalid cursor", func(t *testing.T) {
+		mar.EXPECT().GetManyBelongedToUser(gomock.Eq(uint(1)), gomock.Eq(10), gomock.Eq("bad")).Return(model.Page[model.Advice]{}, repository.ErrInvalidCursor)
+
+		if _, err := ads.GetHistory(1, 10, "bad"); !errors.Is(err, ErrInvalidCursor) {
+			t.Error("exp ErrInvalidCursor; got", err)
+		}
+	})
+}
+
+func TestAdviceMessage(t *testing.T) {
+	idr := model.Currency{Code: "IDR", MinorUnit: 2}
+	usd := model.Currency{Code: "USD", MinorUnit: 2}
+	next := time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should summarize each section", func(t *testing.T) {
+		got := adviceMessage(adviceInput{
+			Now:    time.Date(2023, time.October, 16, 9, 0, 0, 0, time.UTC),
+			Months: 2,
+			Spending: []adviceSpending{{
+				Current: model.ExpenseReport{
+					Currency: idr,
+					Summary:  model.ExpenseStats{Count: 3, Total: 4500000},
+					Categories: []model.CategoryExpenseStats{
+						{CategoryID: 1, CategoryName: "Food", ExpenseStats: model.ExpenseStats{Total: 3000000}},
+						{CategoryID: 2, CategoryName: "Travel", ExpenseStats: model.ExpenseStats{Total: 1500000}},
+					},
+				},
+				Previous: model.ExpenseReport{
+					From:     time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC),
+					Currency: idr,
+					Periods: []model.PeriodExpenseStats{
+						{From: time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC), ExpenseStats: model.ExpenseStats{Count: 2, Total: 2000000}},
+						{From: time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC), ExpenseStats: model.ExpenseStats{Count: 3, Total: 2000000}},
+					},
+					Categories: []model.CategoryExpenseStats{
+						{CategoryID: 1, CategoryName: "Food", ExpenseStats: model.ExpenseStats{Total: 4000000}},
+					},
+				},
+				Largest: []model.Expense{
+					{Name: "Flight", CategoryID: 2, Amount: 1500000, OccurredAt: time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC), Account: &model.Account{Currency: &idr}},
+				},
+			}},
+			Budgets: []model.BudgetStatus{
+				{Budget: model.Budget{CategoryID: 1}, Currency: idr, Limit: 2500000, Spent: 3000000, Projected: 5800000},
+			},
+			Recurring: []model.RecurringTemplate{
+				{Name: "Streaming", CategoryID: 3, Amount: 1599, Frequency: "monthly", Interval: 1, Account: &model.Account{Currency: &usd}, NextAt: &next},
+			},
+			Categories: map[uint]string{1: "Food", 2: "Travel", 3: "Entertainment"},
+		})
+
+		for _, exp := range []string{
+			"Today is 2023-10-16, day 16 of 31 of the month.\n",
+			"- 2023-08: 20000.00 IDR in 2 expenses\n- 2023-09: 20000.00 IDR in 3 expenses\n- 2023-10 (so far): 45000.00 IDR in 3 expenses\n",
+			"- Food: 30000.00 IDR, average 20000.00 IDR (+50%)\n",
+			"- Travel: 15000.00 IDR, none before\n",
+			"- Food: spent 30000.00 IDR of 25000.00 IDR, projected 58000.00 IDR, over budget\n",
+			"- Streaming (Entertainment): 15.99 USD monthly\n",
+			"Largest expenses since 2023-08-01:\n- 2023-10-02, Flight (Travel): 15000.00 IDR\n",
+		} {
+			if !strings.Contains(got, exp) {
+				t.Errorf("exp %q in message; got %q", exp, got)
+			}
+		}
+	})
+	t.Run("should say when there is nothing to go on", func(t *testing.T) {
+		got := adviceMessage(adviceInput{Now: time.Date(2023, time.October, 16, 9, 0, 0, 0, time.UTC), Months: 3})
+
+		for _, exp := range []string{
+			"- No expenses yet this month.\n",
+			"- No budgets set.\n",
+			"Recurring charges:\n- None.\n",
+		} {
+			if !strings.Contains(got, exp) {
+				t.Errorf("exp %q in message; got %q", exp, got)
+			}
+		}
+	})
+}
+
+func TestSchedule(t *testing.T) {
+	for _, tc := range []struct {
+		frequency string
+		interval  int
+		exp       string
+	}{
+		{"monthly", 1, "monthly"},
+		{"weekly", 2, "every 2 weeks"},
+		{"yearly", 0, "yearly"},
+	} {
+		if got := schedule(tc.frequency, tc.interval); got != tc.exp {
+			t.Errorf("exp %q; got %q", tc.exp, got)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+)
+
+var (
+	ErrInvalidAmount     = errors.New("Invalid amount")
+	ErrAmountNotPositive = errors.New("Amount must be greater than zero")
+)
+
+// parseAmount reads a decimal amount in currency into its minor units,
+// refusing more decimal places than the currency has.
+func parseAmount(amount money.Decimal, currency *model.Currency) (int, error) {
+	minorUnit := minorUnitOf(currency)
+	value, err := money.Parse(string(amount), minorUnit)
+	if errors.Is(err, money.ErrTooPrecise) {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, amount, minorUnit)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, amount)
+	}
+
+	return value, nil
+}
+
+// parsePositiveAmount is parseAmount for amounts that must be above zero.
+func parsePositiveAmount(amount money.Decimal, currency *model.Currency) (int, error) {
+	value, err := parseAmount(amount, currency)
+	if err != nil {
+		return 0, err
+	}
+	if value <= 0 {
+		return 0, ErrAmountNotPositive
+	}
+
+	return value, nil
+}
+
+// parseAmountBound reads an amount to filter by, which is nil when empty,
+// into repository.AmountFilterPlaces decimal places.
+func parseAmountBound(amount money.Decimal) (*int, error) {
+	if amount == "" {
+		return nil, nil
+	}
+	value, err := parseAmount(amount, &model.Currency{MinorUnit: repository.AmountFilterPlaces})
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// formatAmount writes an amount in minor units of currency as a decimal
+// followed by the currency's code, such as "12.50 USD".
+func formatAmount(amount int, currency *model.Currency) string {
+	formatted := money.Format(amount, minorUnitOf(currency))
+	if currency == nil {
+		return formatted
+	}
+
+	return formatted + " " + currency.Code
+}
+
+// minorUnitOf returns how many decimal places amounts in currency have. A
+// currency that was not loaded has none.
+func minorUnitOf(currency *model.Currency) int {
+	if currency == nil {
+		return 0
+	}
+
+	return currency.MinorUnit
+}
+
+// accountCurrency returns the currency of an account loaded with it.
+func accountCurrency(account *model.Account) *model.Currency {
+	if account == nil {
+		return nil
+	}
+
+	return account.Currency
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+// APITokenPrefix starts every API token, which tells them apart from JWTs
+// and makes them easy to find when leaked.
+const APITokenPrefix = "spt_"
+
+// APITokenResources are what an API token can be scoped to. Managing users,
+// sessions and API tokens themselves is left to logged in users.
+var APITokenResources = []string{
+	"accounts",
+	"categories",
+	"expenses",
+	"incomes",
+	"transactions",
+	"transfers",
+	"budgets",
+	"recurring-templates",
+	"import-mappings",
+	"imports",
+	"backup",
+	"export",
+	"reports",
+	"reporting-currency",
+	"exchange-rates",
+	"advice",
+}
+
+var (
+	ErrInvalidAPIToken   = errors.New("Invalid or expired API token")
+	ErrAPITokenScope     = errors.New("API token is not allowed to do this")
+	ErrInvalidScope      = errors.New("Scopes must be resource:read or resource:write, where resource is * or one of " + strings.Join(APITokenResources, ", "))
+	ErrExpiryNotInFuture = errors.New("Expiry must be in the future")
+)
+
+type APITokenService interface {
+	Create(userID int, payload dto.CreateAPITokenDTO) (model.APIToken, string, error)
+	GetMany(userID int) ([]model.APIToken, error)
+	DeleteOneByID(userID, id int) error
+	Authenticate(token, resource string, write bool) (model.APIToken, error)
+}
+
+type apiTokenService struct {
+	atr repository.APITokenRepository
+}
+
+func NewAPITokenService(atr repository.APITokenRepository) *apiTokenService {
+	return &apiTokenService{atr}
+}
+
+// Create returns the new token next to its record. It is only ever
+// available here, since only its hash is stored.
+func (ats *apiTokenService) Create(userID int, payload dto.CreateAPITokenDTO) (model.APIToken, string, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.APIToken{}, "", err
+	}
+	if !payload.ExpiresAt.After(time.Now()) {
+		return model.APIToken{}, "", ErrExpiryNotInFuture
+	}
+	scopes, err := normalizeScopes(payload.Scopes)
+	if err != nil {
+		return model.APIToken{}, "", err
+	}
+
+	token, tokenHash, err := newToken(APITokenPrefix)
+	if err != nil {
+		return model.APIToken{}, "", err
+	}
+	apiToken, err := ats.atr.Insert(model.APIToken{
+		UserID:    uint(userID),
+		Name:      payload.Name,
+		TokenHash: tokenHash,
+		Scopes:    scopes,
+		ExpiresAt: payload.ExpiresAt,
+	})
+	if err != nil {
+		return model.APIToken{}, "", err
+	}
+
+	return apiToken, token, nil
+}
+
+func (ats *apiTokenService) GetMany(userID int) ([]model.APIToken, error) {
+	return ats.atr.GetManyBelongedToUser(uint(userID))
+}
+
+func (ats *apiTokenService) DeleteOneByID(userID, id int) error {
+	return notFound(ats.atr.DeleteOneByID(uint(userID), uint(id)))
+}
+
+// Authenticate looks the token up and checks that it may read, or write when
+// write is set, the resource.
+func (ats *apiTokenService) Authenticate(token, resource string, write bool) (model.APIToken, error) {
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return model.APIToken{}, ErrInvalidAPIToken
+	}
+	apiToken, err := ats.atr.GetOneByHash(hashToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.APIToken{}, ErrInvalidAPIToken
+	}
+	if err != nil {
+		return model.APIToken{}, err
+	}
+	if time.Now().After(apiToken.ExpiresAt) {
+		return model.APIToken{}, ErrInvalidAPIToken
+	}
+	if !scopesAllow(apiToken.Scopes, resource, write) {
+		return model.APIToken{}, ErrAPITokenScope
+	}
+
+	return apiToken, nil
+}
+
+// normalizeScopes checks scopes and joins them, sorted and without
+// duplicates, the way they are stored.
+func normalizeScopes(scopes []string) (string, error) {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		resource, access, ok := strings.Cut(scope, ":")
+		if !ok || access != "read" && access != "write" || resource != "*" && !isAPITokenResource(resource) {
+			return "", ErrInvalidScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	sort.Strings(normalized)
+
+	return strings.Join(normalized, " "), nil
+}
+
+func scopesAllow(scopes, resource string, write bool) bool {
+	if !isAPITokenResource(resource) {
+		return false
+	}
+	for _, scope := range strings.Fields(scopes) {
+		r, access, _ := strings.Cut(scope, ":")
+		if r != "*" && r != resource {
+			continue
+		}
+		if access == "write" || !write {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAPITokenResource(resource string) bool {
+	for _, r := range APITokenResources {
+		if r == resource {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestAPITokenService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	matr := mock_repository.NewMockAPITokenRepository(ctrl)
+	ats := NewAPITokenService(matr)
+
+	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+
+	t.Run("should return ErrInvalidScope for unknown resources or access", func(t *testing.T) {
+		for _, scope := range []string{"users:write", "expenses:delete", "expenses", "api-tokens:read"} {
+			if _, _, err := ats.Create(1, dto.CreateAPITokenDTO{
+				Name:      "importer",
+				Scopes:    []string{scope},
+				ExpiresAt: expiresAt,
+			}); !errors.Is(err, ErrInvalidScope) {
+				t.Error("exp ErrInvalidScope for", scope, "; got", err)
+			}
+		}
+	})
+	t.Run("should return ErrExpiryNotInFuture for an expiry in the past", func(t *testing.T) {
+		if _, _, err := ats.Create(1, dto.CreateAPITokenDTO{
+			Name:      "importer",
+			Scopes:    []string{"expenses:write"},
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}); !errors.Is(err, ErrExpiryNotInFuture) {
+			t.Error("exp ErrExpiryNotInFuture; got", err)
+		}
+	})
+	t.Run("should store the token hashed with its scopes sorted and return it once", func(t *testing.T) {
+		var stored model.APIToken
+		matr.EXPECT().Insert(gomock.Any()).DoAndReturn(func(token model.APIToken) (model.APIToken, error) {
+			stored = token
+			return token, nil
+		})
+
+		got, token, err := ats.Create(1, dto.CreateAPITokenDTO{
+			Name:      "importer",
+			Scopes:    []string{"expenses:write", "accounts:read", "expenses:write"},
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if !strings.HasPrefix(token, APITokenPrefix) {
+			t.Error("exp token to start with", APITokenPrefix, "; got", token)
+		}
+		if stored.TokenHash != hashToken(token) || strings.Contains(stored.TokenHash, token) {
+			t.Error("exp the token to be stored hashed; got", stored.TokenHash)
+		}
+		if got.Scopes != "accounts:read expenses:write" || got.UserID != 1 {
+			t.Error("exp sorted scopes of user 1; got", got.Scopes, got.UserID)
+		}
+	})
+}
+
+func TestAPITokenService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	matr := mock_repository.NewMockAPITokenRepository(ctrl)
+	ats := NewAPITokenService(matr)
+
+	token := APITokenPrefix + "token"
+	apiToken := model.APIToken{
+		UserID:    1,
+		Scopes:    "accounts:read expenses:write",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("should return ErrInvalidAPIToken for an unknown token", func(t *testing.T) {
+		matr.EXPECT().GetOneByHash(gomock.Eq(hashToken(token))).Return(model.APIToken{}, gorm.ErrRecordNotFound)
+
+		if _, err := ats.Authenticate(token, "accounts", false); !errors.Is(err, ErrInvalidAPIToken) {
+			t.Error("exp ErrInvalidAPIToken; got", err)
+		}
+	})
+	t.Run("should return ErrInvalidAPIToken for an expired token", func(t *testing.T) {
+		expired := apiToken
+		expired.ExpiresAt = time.Now().Add(-time.Hour)
+		matr.EXPECT().GetOneByHash(gomock.Any()).Return(expired, nil)
+
+		if _, err := ats.Authenticate(token, "accounts", false); !errors.Is(err, ErrInvalidAPIToken) {
+			t.Error("exp ErrInvalidAPIToken; got", err)
+		}
+	})
+	t.Run("should enforce the token's scopes", func(t *testing.T) {
+		for _, tc := range []struct {
+			resource string
+			write    bool
+			allowed  bool
+		}{
+			{"accounts", false, true},
+			{"accounts", true, false},
+			{"expenses", false, true},
+			{"expenses", true, true},
+			{"incomes", false, false},
+			{"", false, false},
+		} {
+			matr.EXPECT().GetOneByHash(gomock.Any()).Return(apiToken, nil)
+
+			_, err := ats.Authenticate(token, tc.resource, tc.write)
+			if tc.allowed && err != nil {
+				t.Error("exp nil for", tc.resource, tc.write, "; got error:", err)
+			}
+			if !tc.allowed && !errors.Is(err, ErrAPITokenScope) {
+				t.Error("exp ErrAPITokenScope for", tc.resource, tc.write, "; got", err)
+			}
+		}
+	})
+	t.Run("should let * cover every resource", func(t *testing.T) {
+		readAll := apiToken
+		readAll.Scopes = "*:read"
+		matr.EXPECT().GetOneByHash(gomock.Any()).Return(readAll, nil).Times(2)
+
+		if _, err := ats.Authenticate(token, "budgets", false); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if _, err := ats.Authenticate(token, "budgets", true); !errors.Is(err, ErrAPITokenScope) {
+			t.Error("exp ErrAPITokenScope; got", err)
+		}
+	})
+}
+
+func TestAPITokenService_DeleteOneByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	matr := mock_repository.NewMockAPITokenRepository(ctrl)
+	ats := NewAPITokenService(matr)
+
+	t.Run("should return ErrNotFound for another user's token", func(t *testing.T) {
+		matr.EXPECT().DeleteOneByID(gomock.Eq(uint(2)), gomock.Eq(uint(1))).Return(gorm.ErrRecordNotFound)
+
+		if err := ats.DeleteOneByID(2, 1); !errors.Is(err, ErrNotFound) {
+			t.Error("exp ErrNotFound; got", err)
+		}
+	})
+}
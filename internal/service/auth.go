@@ -1,49 +1,212 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"strconv"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidRefreshToken   = errors.New("Invalid or expired refresh token")
+	ErrRefreshTokenReused    = errors.New("Refresh token was already used; its session has been logged out")
+	ErrSessionRevoked        = errors.New("Session has been logged out")
+	ErrInvalidTwoFactorToken = errors.New("Invalid or expired two-factor token; log in again")
 )
 
 type AuthService interface {
-	LogIn(payload dto.LogInDTO) (string, error)
+	LogIn(payload dto.LogInDTO) (model.AuthTokens, error)
+	LogInTwoFactor(payload dto.LogInTwoFactorDTO) (model.AuthTokens, error)
+	Refresh(payload dto.RefreshTokenDTO) (model.AuthTokens, error)
+	LogOut(sessionID uint) error
+	GetActiveSession(sessionID uint) (model.Session, error)
 }
 
 type authService struct {
 	us     UserService
+	tfs    TwoFactorService
+	sr     repository.SessionRepository
 	secret string
 }
 
-func NewAuthService(us UserService, secret string) *authService {
-	return &authService{us, secret}
+func NewAuthService(us UserService, tfs TwoFactorService, sr repository.SessionRepository, secret string) *authService {
+	return &authService{us, tfs, sr, secret}
 }
 
-func (as *authService) LogIn(payload dto.LogInDTO) (string, error) {
+// LogIn starts a session, unless the user has two-factor authentication, in
+// which case it only returns a TwoFactorToken for LogInTwoFactor.
+func (as *authService) LogIn(payload dto.LogInDTO) (model.AuthTokens, error) {
 	user, err := as.us.GetOneByEmail(payload.Email)
 	if err != nil {
-		return "", err
+		return model.AuthTokens{}, err
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
-		return "", err
+		return model.AuthTokens{}, err
+	}
+	if user.TOTPEnabledAt != nil {
+		twoFactorToken, err := as.tfs.Challenge(user)
+		if err != nil {
+			return model.AuthTokens{}, err
+		}
+		return model.AuthTokens{TwoFactorToken: twoFactorToken}, nil
+	}
+
+	return as.startSession(user)
+}
+
+// LogInTwoFactor finishes logging in with the TwoFactorToken from LogIn and
+// a TOTP or recovery code.
+func (as *authService) LogInTwoFactor(payload dto.LogInTwoFactorDTO) (model.AuthTokens, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.AuthTokens{}, err
+	}
+
+	user, err := as.tfs.Redeem(payload.TwoFactorToken, payload.Code)
+	if err != nil {
+		return model.AuthTokens{}, err
+	}
+
+	return as.startSession(user)
+}
+
+// Refresh trades a refresh token for a new access token and the refresh
+// token that replaces it. A refresh token that was already traded means it
+// has leaked, since the client would be holding its replacement, so the
+// session is revoked for whoever holds either of them.
+func (as *authService) Refresh(payload dto.RefreshTokenDTO) (model.AuthTokens, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.AuthTokens{}, err
+	}
+
+	token, err := as.sr.GetRefreshToken(hashToken(payload.RefreshToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.AuthTokens{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return model.AuthTokens{}, err
+	}
+	if token.Session == nil || token.Session.RevokedAt != nil {
+		return model.AuthTokens{}, ErrSessionRevoked
+	}
+	if token.UsedAt != nil {
+		return model.AuthTokens{}, as.revokeReused(token.SessionID)
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return model.AuthTokens{}, ErrInvalidRefreshToken
+	}
+
+	refreshToken, tokenHash, err := newToken("")
+	if err != nil {
+		return model.AuthTokens{}, err
+	}
+	if err := as.sr.RotateRefreshToken(token.ID, token.SessionID, tokenHash, time.Now().Add(refreshTokenTTL)); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenUsed) {
+			return model.AuthTokens{}, as.revokeReused(token.SessionID)
+		}
+		return model.AuthTokens{}, err
+	}
+
+	return as.issue(*token.Session, refreshToken)
+}
+
+func (as *authService) LogOut(sessionID uint) error {
+	return as.sr.RevokeOneByID(sessionID)
+}
+
+// GetActiveSession returns ErrSessionRevoked for sessions that were logged
+// out or no longer exist.
+func (as *authService) GetActiveSession(sessionID uint) (model.Session, error) {
+	session, err := as.sr.GetOneByID(sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.Session{}, ErrSessionRevoked
+	}
+	if err != nil {
+		return model.Session{}, err
+	}
+	if session.RevokedAt != nil {
+		return model.Session{}, ErrSessionRevoked
+	}
+
+	return session, nil
+}
+
+func (as *authService) startSession(user model.User) (model.AuthTokens, error) {
+	refreshToken, tokenHash, err := newToken("")
+	if err != nil {
+		return model.AuthTokens{}, err
+	}
+	session, err := as.sr.Insert(user.ID, tokenHash, time.Now().Add(refreshTokenTTL))
+	if err != nil {
+		return model.AuthTokens{}, err
 	}
 
-	userIDStr := strconv.Itoa(int(user.ID))
-	claim := jwt.RegisteredClaims{
-		Subject:   userIDStr,
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		NotBefore: jwt.NewNumericDate(time.Now()),
+	return as.issue(session, refreshToken)
+}
+
+func (as *authService) revokeReused(sessionID uint) error {
+	if err := as.sr.RevokeOneByID(sessionID); err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// issue signs an access token for the session. Its sid claim is what lets
+// the session be revoked before the token expires.
+func (as *authService) issue(session model.Session, refreshToken string) (model.AuthTokens, error) {
+	now := time.Now()
+	expiresAt := now.Add(accessTokenTTL)
+	claims := jwt.MapClaims{
+		"sub": strconv.Itoa(int(session.UserID)),
+		"sid": session.ID,
+		"exp": jwt.NewNumericDate(expiresAt),
+		"iat": jwt.NewNumericDate(now),
+		"nbf": jwt.NewNumericDate(now),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	ss, err := token.SignedString([]byte(as.secret))
 	if err != nil {
-		return "", err
+		return model.AuthTokens{}, err
 	}
 
-	return ss, nil
+	return model.AuthTokens{
+		AccessToken:          ss,
+		AccessTokenExpiresAt: expiresAt,
+		RefreshToken:         refreshToken,
+	}, nil
+}
+
+// newToken returns a random token starting with prefix and the hash it is
+// stored by.
+func newToken(prefix string) (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := prefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
 }
@@ -3,17 +3,23 @@ package service
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
 	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func TestAuthService_LogIn(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mus := mock_service.NewMockUserService(ctrl)
-	as := NewAuthService(mus, "mocksecret")
+	mtfs := mock_service.NewMockTwoFactorService(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	as := NewAuthService(mus, mtfs, msr, "mocksecret")
 
 	t.Run("should return error if UserService returns error", func(t *testing.T) {
 		mus.EXPECT().GetOneByEmail(gomock.Eq("email@example.com")).DoAndReturn(
@@ -29,15 +35,23 @@ func TestAuthService_LogIn(t *testing.T) {
 			t.Error("exp error; got nil")
 		}
 	})
-	t.Run("should return token", func(t *testing.T) {
+	t.Run("should start a session and return its tokens", func(t *testing.T) {
 		mus.EXPECT().GetOneByEmail(gomock.Eq("email@example.com")).DoAndReturn(
 			func(email string) (model.User, error) {
 				return model.User{
+					Model:    gorm.Model{ID: 1},
 					Email:    email,
 					Password: "$2a$12$htC6KUeMQ10/mBdUoVeRp.UW47NYED2gMG.mF/7oJ39p02XPJvuI2",
 				}, nil
 			},
 		)
+		var tokenHash string
+		msr.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(userID uint, hash string, expiresAt time.Time) (model.Session, error) {
+				tokenHash = hash
+				return model.Session{Model: gorm.Model{ID: 7}, UserID: userID}, nil
+			},
+		)
 
 		got, err := as.LogIn(dto.LogInDTO{
 			Email:    "email@example.com",
@@ -46,8 +60,212 @@ func TestAuthService_LogIn(t *testing.T) {
 		if err != nil {
 			t.Error("exp nil; got error:", err)
 		}
-		if got == "" {
-			t.Error(`exp ""; got`, got)
+		if got.AccessToken == "" || got.RefreshToken == "" {
+			t.Error("exp tokens; got", got)
+		}
+		if tokenHash != hashToken(got.RefreshToken) || tokenHash == got.RefreshToken {
+			t.Error("exp the refresh token to be stored hashed; got", tokenHash)
+		}
+		if got.AccessTokenExpiresAt.After(time.Now().Add(accessTokenTTL)) {
+			t.Error("exp a short-lived access token; got expiry", got.AccessTokenExpiresAt)
+		}
+	})
+}
+
+func TestAuthService_LogInTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mus := mock_service.NewMockUserService(ctrl)
+	mtfs := mock_service.NewMockTwoFactorService(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	as := NewAuthService(mus, mtfs, msr, "mocksecret")
+
+	enabledAt := time.Now()
+	user := model.User{
+		Model:         gorm.Model{ID: 1},
+		Email:         "email@example.com",
+		Password:      "$2a$12$htC6KUeMQ10/mBdUoVeRp.UW47NYED2gMG.mF/7oJ39p02XPJvuI2",
+		TOTPEnabledAt: &enabledAt,
+	}
+
+	t.Run("should only return a two-factor token for users with two-factor authentication", func(t *testing.T) {
+		mus.EXPECT().GetOneByEmail(gomock.Eq("email@example.com")).Return(user, nil)
+		mtfs.EXPECT().Challenge(gomock.Eq(user)).Return("mockchallenge", nil)
+
+		got, err := as.LogIn(dto.LogInDTO{
+			Email:    "email@example.com",
+			Password: "topsecret",
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.TwoFactorToken != "mockchallenge" || got.AccessToken != "" || got.RefreshToken != "" {
+			t.Error("exp only a two-factor token; got", got)
+		}
+	})
+	t.Run("should return the error of a locked out user", func(t *testing.T) {
+		mus.EXPECT().GetOneByEmail(gomock.Eq("email@example.com")).Return(user, nil)
+		mtfs.EXPECT().Challenge(gomock.Eq(user)).Return("", ErrTooManyTwoFactorAttempts)
+
+		if _, err := as.LogIn(dto.LogInDTO{
+			Email:    "email@example.com",
+			Password: "topsecret",
+		}); !errors.Is(err, ErrTooManyTwoFactorAttempts) {
+			t.Error("exp ErrTooManyTwoFactorAttempts; got", err)
+		}
+	})
+	t.Run("should return the error of a wrong code", func(t *testing.T) {
+		mtfs.EXPECT().Redeem(gomock.Eq("mockchallenge"), gomock.Eq("000000")).Return(model.User{}, ErrInvalidTOTPCode)
+
+		if _, err := as.LogInTwoFactor(dto.LogInTwoFactorDTO{
+			TwoFactorToken: "mockchallenge",
+			Code:           "000000",
+		}); !errors.Is(err, ErrInvalidTOTPCode) {
+			t.Error("exp ErrInvalidTOTPCode; got", err)
+		}
+	})
+	t.Run("should start a session once the code is right", func(t *testing.T) {
+		mtfs.EXPECT().Redeem(gomock.Eq("mockchallenge"), gomock.Eq("123456")).Return(user, nil)
+		msr.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Any(), gomock.Any()).Return(model.Session{Model: gorm.Model{ID: 7}, UserID: 1}, nil)
+
+		got, err := as.LogInTwoFactor(dto.LogInTwoFactorDTO{
+			TwoFactorToken: "mockchallenge",
+			Code:           "123456",
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.AccessToken == "" || got.RefreshToken == "" {
+			t.Error("exp tokens; got", got)
+		}
+	})
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mus := mock_service.NewMockUserService(ctrl)
+	mtfs := mock_service.NewMockTwoFactorService(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	as := NewAuthService(mus, mtfs, msr, "mocksecret")
+
+	session := &model.Session{Model: gorm.Model{ID: 7}, UserID: 1}
+	payload := dto.RefreshTokenDTO{RefreshToken: "refreshtoken"}
+	usedAt := time.Now().Add(-time.Minute)
+
+	t.Run("should return ErrInvalidRefreshToken for an unknown token", func(t *testing.T) {
+		msr.EXPECT().GetRefreshToken(gomock.Eq(hashToken("refreshtoken"))).Return(model.RefreshToken{}, gorm.ErrRecordNotFound)
+
+		if _, err := as.Refresh(payload); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Error("exp ErrInvalidRefreshToken; got", err)
+		}
+	})
+	t.Run("should return ErrInvalidRefreshToken for an expired token", func(t *testing.T) {
+		msr.EXPECT().GetRefreshToken(gomock.Any()).Return(model.RefreshToken{
+			SessionID: 7,
+			Session:   session,
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}, nil)
+
+		if _, err := as.Refresh(payload); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Error("exp ErrInvalidRefreshToken; got", err)
+		}
+	})
+	t.Run("should return ErrSessionRevoked if the session was logged out", func(t *testing.T) {
+		msr.EXPECT().GetRefreshToken(gomock.Any()).Return(model.RefreshToken{
+			SessionID: 7,
+			Session:   &model.Session{Model: gorm.Model{ID: 7}, RevokedAt: &usedAt},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+
+		if _, err := as.Refresh(payload); !errors.Is(err, ErrSessionRevoked) {
+			t.Error("exp ErrSessionRevoked; got", err)
+		}
+	})
+	t.Run("should revoke the session when a used token is presented again", func(t *testing.T) {
+		msr.EXPECT().GetRefreshToken(gomock.Any()).Return(model.RefreshToken{
+			SessionID: 7,
+			Session:   session,
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+		}, nil)
+		msr.EXPECT().RevokeOneByID(gomock.Eq(uint(7))).Return(nil)
+
+		if _, err := as.Refresh(payload); !errors.Is(err, ErrRefreshTokenReused) {
+			t.Error("exp ErrRefreshTokenReused; got", err)
+		}
+	})
+	t.Run("should revoke the session when another request rotated the token first", func(t *testing.T) {
+		msr.EXPECT().GetRefreshToken(gomock.Any()).Return(model.RefreshToken{
+			Model:     gorm.Model{ID: 3},
+			SessionID: 7,
+			Session:   session,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		msr.EXPECT().RotateRefreshToken(gomock.Eq(uint(3)), gomock.Eq(uint(7)), gomock.Any(), gomock.Any()).Return(repository.ErrRefreshTokenUsed)
+		msr.EXPECT().RevokeOneByID(gomock.Eq(uint(7))).Return(nil)
+
+		if _, err := as.Refresh(payload); !errors.Is(err, ErrRefreshTokenReused) {
+			t.Error("exp ErrRefreshTokenReused; got", err)
+		}
+	})
+	t.Run("should rotate the refresh token and return new tokens", func(t *testing.T) {
+		msr.EXPECT().GetRefreshToken(gomock.Any()).Return(model.RefreshToken{
+			Model:     gorm.Model{ID: 3},
+			SessionID: 7,
+			Session:   session,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		var tokenHash string
+		msr.EXPECT().RotateRefreshToken(gomock.Eq(uint(3)), gomock.Eq(uint(7)), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(id, sessionID uint, hash string, expiresAt time.Time) error {
+				tokenHash = hash
+				return nil
+			},
+		)
+
+		got, err := as.Refresh(payload)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.AccessToken == "" || got.RefreshToken == "" || got.RefreshToken == payload.RefreshToken {
+			t.Error("exp new tokens; got", got)
+		}
+		if tokenHash != hashToken(got.RefreshToken) {
+			t.Error("exp the new refresh token to be stored; got", tokenHash)
+		}
+	})
+}
+
+func TestAuthService_GetActiveSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mus := mock_service.NewMockUserService(ctrl)
+	mtfs := mock_service.NewMockTwoFactorService(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	as := NewAuthService(mus, mtfs, msr, "mocksecret")
+
+	t.Run("should return ErrSessionRevoked for a revoked session", func(t *testing.T) {
+		revokedAt := time.Now()
+		msr.EXPECT().GetOneByID(gomock.Eq(uint(7))).Return(model.Session{RevokedAt: &revokedAt}, nil)
+
+		if _, err := as.GetActiveSession(7); !errors.Is(err, ErrSessionRevoked) {
+			t.Error("exp ErrSessionRevoked; got", err)
+		}
+	})
+	t.Run("should return ErrSessionRevoked for a missing session", func(t *testing.T) {
+		msr.EXPECT().GetOneByID(gomock.Eq(uint(7))).Return(model.Session{}, gorm.ErrRecordNotFound)
+
+		if _, err := as.GetActiveSession(7); !errors.Is(err, ErrSessionRevoked) {
+			t.Error("exp ErrSessionRevoked; got", err)
+		}
+	})
+	t.Run("should return the active session", func(t *testing.T) {
+		msr.EXPECT().GetOneByID(gomock.Eq(uint(7))).Return(model.Session{UserID: 1}, nil)
+
+		got, err := as.GetActiveSession(7)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.UserID != 1 {
+			t.Error("exp user 1; got", got.UserID)
 		}
 	})
 }
@@ -0,0 +1,502 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/gorm"
+)
+
+// backupVersion is the version of dto.BackupDTO this build writes. Restore
+// accepts it and every earlier version.
+const backupVersion = 5
+
+var (
+	ErrUnsupportedBackupVersion = errors.New("Backup was made by a newer version and cannot be restored")
+	ErrInvalidBackup            = errors.New("Backup is invalid")
+)
+
+type BackupService interface {
+	Export(userID int) (dto.BackupDTO, error)
+	Restore(userID int, payload dto.BackupDTO) (model.Backup, error)
+}
+
+type backupService struct {
+	br repository.BackupRepository
+}
+
+func NewBackupService(br repository.BackupRepository) *backupService {
+	return &backupService{br}
+}
+
+func (bs *backupService) Export(userID int) (dto.BackupDTO, error) {
+	backup, err := bs.br.Export(uint(userID))
+	if err != nil {
+		return dto.BackupDTO{}, err
+	}
+
+	payload := dto.BackupDTO{
+		Version:              backupVersion,
+		ExportedAt:           time.Now().UTC(),
+		Accounts:             make([]dto.BackupAccountDTO, 0, len(backup.Accounts)),
+		Categories:           make([]dto.BackupCategoryDTO, 0, len(backup.Categories)),
+		Expenses:             make([]dto.BackupExpenseDTO, 0, len(backup.Expenses)),
+		Incomes:              make([]dto.BackupIncomeDTO, 0, len(backup.Incomes)),
+		Transfers:            make([]dto.BackupTransferDTO, 0, len(backup.Transfers)),
+		Budgets:              make([]dto.BackupBudgetDTO, 0, len(backup.Budgets)),
+		RecurringTemplates:   make([]dto.BackupRecurringTemplateDTO, 0, len(backup.RecurringTemplates)),
+		RecurringOccurrences: make([]dto.BackupRecurringOccurrenceDTO, 0, len(backup.RecurringOccurrences)),
+		ImportMappings:       make([]dto.BackupImportMappingDTO, 0, len(backup.ImportMappings)),
+		ExchangeRates:        make([]dto.BackupExchangeRateDTO, 0, len(backup.ExchangeRates)),
+		Advice:               make([]dto.BackupAdviceDTO, 0, len(backup.Advice)),
+	}
+	if backup.ReportingCurrency != nil {
+		payload.ReportingCurrency = backup.ReportingCurrency.Code
+	}
+	currencies := make(map[uint]*model.Currency, len(backup.Accounts))
+	for _, a := range backup.Accounts {
+		currencies[a.ID] = a.Currency
+		account := dto.BackupAccountDTO{
+			ID:            a.ID,
+			Name:          a.Name,
+			InitialAmount: money.Decimal(money.Format(a.InitialAmount, minorUnitOf(a.Currency))),
+			CreatedAt:     a.CreatedAt,
+			DeletedAt:     deletedAt(a.DeletedAt),
+		}
+		if a.Currency != nil {
+			account.Currency = a.Currency.Code
+		}
+		payload.Accounts = append(payload.Accounts, account)
+	}
+	amountOf := func(amount int, accountID uint) money.Decimal {
+		return money.Decimal(money.Format(amount, minorUnitOf(currencies[accountID])))
+	}
+	for _, c := range backup.Categories {
+		payload.Categories = append(payload.Categories, dto.BackupCategoryDTO{
+			ID:        c.ID,
+			Name:      c.Name,
+			CreatedAt: c.CreatedAt,
+			DeletedAt: deletedAt(c.DeletedAt),
+		})
+	}
+	for _, e := range backup.Expenses {
+		var tags []string
+		for _, tag := range e.Tags {
+			tags = append(tags, tag.Name)
+		}
+		payload.Expenses = append(payload.Expenses, dto.BackupExpenseDTO{
+			ID:          e.ID,
+			AccountID:   e.AccountID,
+			CategoryID:  e.CategoryID,
+			Name:        e.Name,
+			Description: e.Description,
+			Amount:      amountOf(e.Amount, e.AccountID),
+			OccurredAt:  e.OccurredAt,
+			Timezone:    e.Timezone,
+			ExternalID:  e.ExternalID,
+			Tags:        tags,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+	for _, in := range backup.Incomes {
+		payload.Incomes = append(payload.Incomes, dto.BackupIncomeDTO{
+			ID:          in.ID,
+			AccountID:   in.AccountID,
+			Name:        in.Name,
+			Description: in.Description,
+			Amount:      amountOf(in.Amount, in.AccountID),
+			OccurredAt:  in.OccurredAt,
+			Timezone:    in.Timezone,
+			ExternalID:  in.ExternalID,
+			CreatedAt:   in.CreatedAt,
+		})
+	}
+	for _, t := range backup.Transfers {
+		payload.Transfers = append(payload.Transfers, dto.BackupTransferDTO{
+			ID:             t.ID,
+			FromAccountID:  t.FromAccountID,
+			ToAccountID:    t.ToAccountID,
+			Description:    t.Description,
+			Amount:         amountOf(t.Amount, t.FromAccountID),
+			Fee:            amountOf(t.Fee, t.FromAccountID),
+			ExchangeRate:   t.ExchangeRate,
+			ReceivedAmount: amountOf(t.ReceivedAmount, t.ToAccountID),
+			OccurredAt:     t.OccurredAt,
+			Timezone:       t.Timezone,
+			CreatedAt:      t.CreatedAt,
+		})
+	}
+	for _, b := range backup.Budgets {
+		budget := dto.BackupBudgetDTO{
+			ID:           b.ID,
+			CategoryID:   b.CategoryID,
+			AccountID:    b.AccountID,
+			MonthlyLimit: money.Decimal(money.Format(b.MonthlyLimit, minorUnitOf(b.Currency))),
+			CreatedAt:    b.CreatedAt,
+		}
+		if b.Currency != nil {
+			budget.Currency = b.Currency.Code
+		}
+		payload.Budgets = append(payload.Budgets, budget)
+	}
+	for _, t := range backup.RecurringTemplates {
+		payload.RecurringTemplates = append(payload.RecurringTemplates, dto.BackupRecurringTemplateDTO{
+			ID:           t.ID,
+			Type:         t.Type,
+			AccountID:    t.AccountID,
+			CategoryID:   t.CategoryID,
+			Name:         t.Name,
+			Description:  t.Description,
+			Amount:       amountOf(t.Amount, t.AccountID),
+			Frequency:    t.Frequency,
+			Interval:     t.Interval,
+			StartAt:      t.StartAt,
+			Until:        t.Until,
+			Count:        t.Count,
+			Timezone:     t.Timezone,
+			Materialized: t.Materialized,
+			NextAt:       t.NextAt,
+			CreatedAt:    t.CreatedAt,
+		})
+	}
+	for _, o := range backup.RecurringOccurrences {
+		payload.RecurringOccurrences = append(payload.RecurringOccurrences, dto.BackupRecurringOccurrenceDTO{
+			TemplateID:    o.TemplateID,
+			Sequence:      o.Sequence,
+			OccurredAt:    o.OccurredAt,
+			TransactionID: o.TransactionID,
+			CreatedAt:     o.CreatedAt,
+		})
+	}
+	for _, m := range backup.ImportMappings {
+		payload.ImportMappings = append(payload.ImportMappings, dto.BackupImportMappingDTO{
+			Name:              m.Name,
+			Delimiter:         m.Delimiter,
+			HasHeader:         m.HasHeader,
+			DateColumn:        m.DateColumn,
+			DescriptionColumn: m.DescriptionColumn,
+			AmountColumn:      m.AmountColumn,
+			SignConvention:    m.SignConvention,
+			DecimalSeparator:  m.DecimalSeparator,
+			DateFormat:        m.DateFormat,
+			Timezone:          m.Timezone,
+			CreatedAt:         m.CreatedAt,
+		})
+	}
+	for _, r := range backup.ExchangeRates {
+		payload.ExchangeRates = append(payload.ExchangeRates, dto.BackupExchangeRateDTO{
+			Date:          r.Date,
+			BaseCurrency:  r.BaseCurrency,
+			QuoteCurrency: r.QuoteCurrency,
+			Rate:          r.Rate,
+		})
+	}
+	for _, a := range backup.Advice {
+		payload.Advice = append(payload.Advice, dto.BackupAdviceDTO{
+			Months:           a.Months,
+			Focus:            a.Focus,
+			Timezone:         a.Timezone,
+			WindowFrom:       a.WindowFrom,
+			WindowTo:         a.WindowTo,
+			ModelName:        a.ModelName,
+			PromptVersion:    a.PromptVersion,
+			Text:             a.Text,
+			PromptTokens:     a.PromptTokens,
+			CompletionTokens: a.CompletionTokens,
+			TotalTokens:      a.TotalTokens,
+			UsageEstimated:   a.UsageEstimated,
+			CreatedAt:        a.CreatedAt,
+		})
+	}
+
+	return payload, nil
+}
+
+// Restore adds a backup to the user's data, all or nothing. Records get new
+// IDs; categories the user already has are matched by name. Backups from a
+// newer version are refused, since they may hold data this version would
+// silently drop.
+func (bs *backupService) Restore(userID int, payload dto.BackupDTO) (model.Backup, error) {
+	if payload.Version > backupVersion {
+		return model.Backup{}, ErrUnsupportedBackupVersion
+	}
+	if err := validator.New().Struct(payload); err != nil {
+		return model.Backup{}, err
+	}
+
+	currencies, err := bs.br.GetCurrencies()
+	if err != nil {
+		return model.Backup{}, err
+	}
+	currenciesByCode := make(map[string]model.Currency, len(currencies))
+	for _, c := range currencies {
+		if _, ok := currenciesByCode[c.Code]; !ok {
+			currenciesByCode[c.Code] = c
+		}
+	}
+
+	var backup model.Backup
+	if payload.ReportingCurrency != "" {
+		currency, ok := currenciesByCode[payload.ReportingCurrency]
+		if !ok {
+			return model.Backup{}, fmt.Errorf("%w: unknown reporting currency %q", ErrInvalidBackup, payload.ReportingCurrency)
+		}
+		backup.ReportingCurrency = &currency
+	}
+	accountCurrencies := make(map[uint]*model.Currency, len(payload.Accounts))
+	for _, a := range payload.Accounts {
+		currency, ok := currenciesByCode[a.Currency]
+		if !ok {
+			return model.Backup{}, fmt.Errorf("%w: account %d has unknown currency %q", ErrInvalidBackup, a.ID, a.Currency)
+		}
+		if accountCurrencies[a.ID] != nil {
+			return model.Backup{}, fmt.Errorf("%w: account %d appears twice", ErrInvalidBackup, a.ID)
+		}
+		accountCurrencies[a.ID] = &currency
+		initialAmount := 0
+		if a.InitialAmount != "" {
+			if initialAmount, err = parseAmount(a.InitialAmount, &currency); err != nil {
+				return model.Backup{}, fmt.Errorf("%w: account %d: %v", ErrInvalidBackup, a.ID, err)
+			}
+		}
+		backup.Accounts = append(backup.Accounts, model.Account{
+			Model:         gorm.Model{ID: a.ID, CreatedAt: a.CreatedAt, DeletedAt: softDeletedAt(a.DeletedAt)},
+			CurrencyID:    currency.ID,
+			Name:          a.Name,
+			InitialAmount: initialAmount,
+		})
+	}
+	// amountIn parses an amount of what, in the currency of the account it
+	// belongs to.
+	amountIn := func(accountID uint, amount money.Decimal, what string, id uint) (int, error) {
+		currency := accountCurrencies[accountID]
+		if currency == nil {
+			return 0, fmt.Errorf("%w: %s %d refers to missing account %d", ErrInvalidBackup, what, id, accountID)
+		}
+		value, err := parseAmount(amount, currency)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s %d: %v", ErrInvalidBackup, what, id, err)
+		}
+		return value, nil
+	}
+
+	categoryIDs := make(map[uint]bool, len(payload.Categories))
+	for _, c := range payload.Categories {
+		if categoryIDs[c.ID] {
+			return model.Backup{}, fmt.Errorf("%w: category %d appears twice", ErrInvalidBackup, c.ID)
+		}
+		categoryIDs[c.ID] = true
+		backup.Categories = append(backup.Categories, model.Category{
+			Model: gorm.Model{ID: c.ID, CreatedAt: c.CreatedAt, DeletedAt: softDeletedAt(c.DeletedAt)},
+			Name:  c.Name,
+		})
+	}
+	for _, e := range payload.Expenses {
+		if e.CategoryID != 0 && !categoryIDs[e.CategoryID] {
+			return model.Backup{}, fmt.Errorf("%w: expense %d refers to missing category %d", ErrInvalidBackup, e.ID, e.CategoryID)
+		}
+		amount, err := amountIn(e.AccountID, e.Amount, "expense", e.ID)
+		if err != nil {
+			return model.Backup{}, err
+		}
+		names, err := normalizeTags(e.Tags)
+		if err != nil {
+			return model.Backup{}, fmt.Errorf("%w: expense %d: %v", ErrInvalidBackup, e.ID, err)
+		}
+		tags := make([]model.Tag, 0, len(names))
+		for _, name := range names {
+			tags = append(tags, model.Tag{Name: name})
+		}
+		backup.Expenses = append(backup.Expenses, model.Expense{
+			Model:       gorm.Model{ID: e.ID, CreatedAt: e.CreatedAt},
+			AccountID:   e.AccountID,
+			CategoryID:  e.CategoryID,
+			Name:        e.Name,
+			Description: e.Description,
+			Amount:      amount,
+			OccurredAt:  e.OccurredAt.UTC(),
+			Timezone:    e.Timezone,
+			ExternalID:  e.ExternalID,
+			Tags:        tags,
+		})
+	}
+	for _, in := range payload.Incomes {
+		amount, err := amountIn(in.AccountID, in.Amount, "income", in.ID)
+		if err != nil {
+			return model.Backup{}, err
+		}
+		backup.Incomes = append(backup.Incomes, model.Income{
+			Model:       gorm.Model{ID: in.ID, CreatedAt: in.CreatedAt},
+			AccountID:   in.AccountID,
+			Name:        in.Name,
+			Description: in.Description,
+			Amount:      amount,
+			OccurredAt:  in.OccurredAt.UTC(),
+			Timezone:    in.Timezone,
+			ExternalID:  in.ExternalID,
+		})
+	}
+	for _, t := range payload.Transfers {
+		amount, err := amountIn(t.FromAccountID, t.Amount, "transfer", t.ID)
+		if err != nil {
+			return model.Backup{}, err
+		}
+		fee, err := amountIn(t.FromAccountID, t.Fee, "transfer", t.ID)
+		if err != nil {
+			return model.Backup{}, err
+		}
+		receivedAmount, err := amountIn(t.ToAccountID, t.ReceivedAmount, "transfer", t.ID)
+		if err != nil {
+			return model.Backup{}, err
+		}
+		backup.Transfers = append(backup.Transfers, model.Transfer{
+			Model:          gorm.Model{ID: t.ID, CreatedAt: t.CreatedAt},
+			FromAccountID:  t.FromAccountID,
+			ToAccountID:    t.ToAccountID,
+			Description:    t.Description,
+			Amount:         amount,
+			Fee:            fee,
+			ExchangeRate:   t.ExchangeRate,
+			ReceivedAmount: receivedAmount,
+			OccurredAt:     t.OccurredAt.UTC(),
+			Timezone:       t.Timezone,
+		})
+	}
+	for _, b := range payload.Budgets {
+		if !categoryIDs[b.CategoryID] {
+			return model.Backup{}, fmt.Errorf("%w: budget %d refers to missing category %d", ErrInvalidBackup, b.ID, b.CategoryID)
+		}
+		if b.AccountID != nil && accountCurrencies[*b.AccountID] == nil {
+			return model.Backup{}, fmt.Errorf("%w: budget %d refers to missing account %d", ErrInvalidBackup, b.ID, *b.AccountID)
+		}
+		currency, ok := currenciesByCode[b.Currency]
+		if !ok {
+			return model.Backup{}, fmt.Errorf("%w: budget %d has unknown currency %q", ErrInvalidBackup, b.ID, b.Currency)
+		}
+		monthlyLimit, err := parseAmount(b.MonthlyLimit, &currency)
+		if err != nil {
+			return model.Backup{}, fmt.Errorf("%w: budget %d: %v", ErrInvalidBackup, b.ID, err)
+		}
+		backup.Budgets = append(backup.Budgets, model.Budget{
+			Model:        gorm.Model{ID: b.ID, CreatedAt: b.CreatedAt},
+			CategoryID:   b.CategoryID,
+			AccountID:    b.AccountID,
+			CurrencyID:   currency.ID,
+			MonthlyLimit: monthlyLimit,
+		})
+	}
+	templateIDs := make(map[uint]bool, len(payload.RecurringTemplates))
+	for _, t := range payload.RecurringTemplates {
+		if templateIDs[t.ID] {
+			return model.Backup{}, fmt.Errorf("%w: recurring template %d appears twice", ErrInvalidBackup, t.ID)
+		}
+		templateIDs[t.ID] = true
+		if t.Type == model.TransactionTypeExpense && t.CategoryID != 0 && !categoryIDs[t.CategoryID] {
+			return model.Backup{}, fmt.Errorf("%w: recurring template %d refers to missing category %d", ErrInvalidBackup, t.ID, t.CategoryID)
+		}
+		amount, err := amountIn(t.AccountID, t.Amount, "recurring template", t.ID)
+		if err != nil {
+			return model.Backup{}, err
+		}
+		backup.RecurringTemplates = append(backup.RecurringTemplates, model.RecurringTemplate{
+			Model:        gorm.Model{ID: t.ID, CreatedAt: t.CreatedAt},
+			Type:         t.Type,
+			AccountID:    t.AccountID,
+			CategoryID:   t.CategoryID,
+			Name:         t.Name,
+			Description:  t.Description,
+			Amount:       amount,
+			Frequency:    t.Frequency,
+			Interval:     t.Interval,
+			StartAt:      t.StartAt.UTC(),
+			Until:        t.Until,
+			Count:        t.Count,
+			Timezone:     t.Timezone,
+			Materialized: t.Materialized,
+			NextAt:       t.NextAt,
+		})
+	}
+	for _, o := range payload.RecurringOccurrences {
+		if !templateIDs[o.TemplateID] {
+			return model.Backup{}, fmt.Errorf("%w: occurrence %d refers to missing recurring template %d", ErrInvalidBackup, o.Sequence, o.TemplateID)
+		}
+		backup.RecurringOccurrences = append(backup.RecurringOccurrences, model.RecurringOccurrence{
+			TemplateID:    o.TemplateID,
+			Sequence:      o.Sequence,
+			OccurredAt:    o.OccurredAt.UTC(),
+			TransactionID: o.TransactionID,
+			CreatedAt:     o.CreatedAt,
+		})
+	}
+	for _, m := range payload.ImportMappings {
+		backup.ImportMappings = append(backup.ImportMappings, model.ImportMapping{
+			Model:             gorm.Model{CreatedAt: m.CreatedAt},
+			Name:              m.Name,
+			Delimiter:         m.Delimiter,
+			HasHeader:         m.HasHeader,
+			DateColumn:        m.DateColumn,
+			DescriptionColumn: m.DescriptionColumn,
+			AmountColumn:      m.AmountColumn,
+			SignConvention:    m.SignConvention,
+			DecimalSeparator:  m.DecimalSeparator,
+			DateFormat:        m.DateFormat,
+			Timezone:          m.Timezone,
+		})
+	}
+	for _, r := range payload.ExchangeRates {
+		backup.ExchangeRates = append(backup.ExchangeRates, model.ExchangeRate{
+			Date:          r.Date.UTC(),
+			BaseCurrency:  r.BaseCurrency,
+			QuoteCurrency: r.QuoteCurrency,
+			Rate:          r.Rate,
+		})
+	}
+	for _, a := range payload.Advice {
+		backup.Advice = append(backup.Advice, model.Advice{
+			Model:            gorm.Model{CreatedAt: a.CreatedAt},
+			Months:           a.Months,
+			Focus:            a.Focus,
+			Timezone:         a.Timezone,
+			WindowFrom:       a.WindowFrom.UTC(),
+			WindowTo:         a.WindowTo.UTC(),
+			ModelName:        a.ModelName,
+			PromptVersion:    a.PromptVersion,
+			Text:             a.Text,
+			PromptTokens:     a.PromptTokens,
+			CompletionTokens: a.CompletionTokens,
+			TotalTokens:      a.TotalTokens,
+			UsageEstimated:   a.UsageEstimated,
+		})
+	}
+
+	restored, err := bs.br.Restore(uint(userID), backup)
+	if err != nil {
+		return model.Backup{}, err
+	}
+
+	return restored, nil
+}
+
+// deletedAt is when a soft-deleted record was deleted, or nil.
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+
+	return &d.Time
+}
+
+// softDeletedAt is deletedAt the other way round.
+func softDeletedAt(t *time.Time) gorm.DeletedAt {
+	if t == nil {
+		return gorm.DeletedAt{}
+	}
+
+	return gorm.DeletedAt{Time: t.UTC(), Valid: true}
+}
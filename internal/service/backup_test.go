@@ -0,0 +1,247 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestBackupService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mbr := mock_repository.NewMockBackupRepository(ctrl)
+	bs := NewBackupService(mbr)
+
+	t.Run("should refer to currencies by code", func(t *testing.T) {
+		mbr.EXPECT().Export(gomock.Eq(uint(1))).Return(model.Backup{
+			Accounts: []model.Account{
+				{Model: gorm.Model{ID: 3}, CurrencyID: 7, Currency: &model.Currency{Code: "IDR", MinorUnit: 2}, Name: "Wallet", InitialAmount: 1000050},
+			},
+		}, nil)
+
+		got, err := bs.Export(1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Version != backupVersion {
+			t.Error("exp", backupVersion, "; got", got.Version)
+		}
+		if len(got.Accounts) != 1 || got.Accounts[0].Currency != "IDR" {
+			t.Error("exp IDR; got", got.Accounts)
+		}
+		if got.Accounts[0].InitialAmount != "10000.50" {
+			t.Error("exp 10000.50; got", got.Accounts[0].InitialAmount)
+		}
+	})
+	t.Run("should write transfer amounts in the currencies of their accounts", func(t *testing.T) {
+		mbr.EXPECT().Export(gomock.Eq(uint(1))).Return(model.Backup{
+			ReportingCurrency: &model.Currency{Code: "USD", MinorUnit: 2},
+			Accounts: []model.Account{
+				{Model: gorm.Model{ID: 3}, Currency: &model.Currency{Code: "IDR", MinorUnit: 2}, Name: "Wallet"},
+				{Model: gorm.Model{ID: 4}, Currency: &model.Currency{Code: "JPY", MinorUnit: 0}, Name: "Card"},
+			},
+			Transfers: []model.Transfer{
+				{Model: gorm.Model{ID: 8}, FromAccountID: 3, ToAccountID: 4, Amount: 100050, Fee: 50, ReceivedAmount: 95},
+			},
+		}, nil)
+
+		got, err := bs.Export(1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.ReportingCurrency != "USD" {
+			t.Error("exp USD; got", got.ReportingCurrency)
+		}
+		if len(got.Transfers) != 1 {
+			t.Fatal("exp 1; got", len(got.Transfers))
+		}
+		if transfer := got.Transfers[0]; transfer.Amount != "1000.50" || transfer.Fee != "0.50" || transfer.ReceivedAmount != "95" {
+			t.Error("exp 1000.50, 0.50 and 95; got", transfer.Amount, transfer.Fee, transfer.ReceivedAmount)
+		}
+	})
+}
+
+func TestBackupService_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mbr := mock_repository.NewMockBackupRepository(ctrl)
+	bs := NewBackupService(mbr)
+
+	backup := func() dto.BackupDTO {
+		return dto.BackupDTO{
+			Version:    1,
+			Accounts:   []dto.BackupAccountDTO{{ID: 3, Currency: "IDR", Name: "Wallet"}},
+			Categories: []dto.BackupCategoryDTO{{ID: 5, Name: "Food"}},
+			Expenses:   []dto.BackupExpenseDTO{{ID: 9, AccountID: 3, CategoryID: 5, Name: "Lunch", Amount: "50000.50"}},
+		}
+	}
+
+	t.Run("should refuse a backup from a newer version", func(t *testing.T) {
+		payload := backup()
+		payload.Version = backupVersion + 1
+
+		if _, err := bs.Restore(1, payload); !errors.Is(err, ErrUnsupportedBackupVersion) {
+			t.Error("exp ErrUnsupportedBackupVersion; got", err)
+		}
+	})
+	t.Run("should refuse an unknown currency", func(t *testing.T) {
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{{Model: gorm.Model{ID: 1}, Code: "USD"}}, nil)
+
+		if _, err := bs.Restore(1, backup()); !errors.Is(err, ErrInvalidBackup) {
+			t.Error("exp ErrInvalidBackup; got", err)
+		}
+	})
+	t.Run("should refuse an expense of a missing account", func(t *testing.T) {
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{{Model: gorm.Model{ID: 7}, Code: "IDR"}}, nil)
+		payload := backup()
+		payload.Expenses[0].AccountID = 4
+
+		if _, err := bs.Restore(1, payload); !errors.Is(err, ErrInvalidBackup) {
+			t.Error("exp ErrInvalidBackup; got", err)
+		}
+	})
+	t.Run("should refuse an amount with more places than the currency", func(t *testing.T) {
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{{Model: gorm.Model{ID: 7}, Code: "IDR", MinorUnit: 2}}, nil)
+		payload := backup()
+		payload.Expenses[0].Amount = "50000.505"
+
+		if _, err := bs.Restore(1, payload); !errors.Is(err, ErrInvalidBackup) {
+			t.Error("exp ErrInvalidBackup; got", err)
+		}
+	})
+	t.Run("should refuse a budget of a missing category", func(t *testing.T) {
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{{Model: gorm.Model{ID: 7}, Code: "IDR"}}, nil)
+		payload := backup()
+		payload.Budgets = []dto.BackupBudgetDTO{{ID: 2, CategoryID: 6, Currency: "IDR", MonthlyLimit: "100"}}
+
+		if _, err := bs.Restore(1, payload); !errors.Is(err, ErrInvalidBackup) {
+			t.Error("exp ErrInvalidBackup; got", err)
+		}
+	})
+	t.Run("should refuse a transfer to a missing account", func(t *testing.T) {
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{{Model: gorm.Model{ID: 7}, Code: "IDR"}}, nil)
+		payload := backup()
+		payload.Transfers = []dto.BackupTransferDTO{{ID: 2, FromAccountID: 3, ToAccountID: 4, Amount: "100", Fee: "0", ReceivedAmount: "100"}}
+
+		if _, err := bs.Restore(1, payload); !errors.Is(err, ErrInvalidBackup) {
+			t.Error("exp ErrInvalidBackup; got", err)
+		}
+	})
+	t.Run("should refuse an occurrence of a missing template", func(t *testing.T) {
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{{Model: gorm.Model{ID: 7}, Code: "IDR"}}, nil)
+		payload := backup()
+		payload.RecurringOccurrences = []dto.BackupRecurringOccurrenceDTO{{TemplateID: 2, Sequence: 1}}
+
+		if _, err := bs.Restore(1, payload); !errors.Is(err, ErrInvalidBackup) {
+			t.Error("exp ErrInvalidBackup; got", err)
+		}
+	})
+	t.Run("should parse incomes and transfers in the currencies of their accounts", func(t *testing.T) {
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{
+			{Model: gorm.Model{ID: 7}, Code: "IDR", MinorUnit: 2},
+			{Model: gorm.Model{ID: 8}, Code: "JPY", MinorUnit: 0},
+		}, nil)
+		mbr.EXPECT().Restore(gomock.Eq(uint(1)), gomock.Any()).DoAndReturn(func(userID uint, backup model.Backup) (model.Backup, error) {
+			if len(backup.Incomes) != 1 || backup.Incomes[0].Amount != 100050 {
+				t.Error("exp an income of 100050; got", backup.Incomes)
+			}
+			if len(backup.Transfers) != 1 {
+				t.Fatal("exp 1; got", len(backup.Transfers))
+			}
+			if transfer := backup.Transfers[0]; transfer.Amount != 100050 || transfer.Fee != 50 || transfer.ReceivedAmount != 95 {
+				t.Error("exp 100050, 50 and 95; got", transfer.Amount, transfer.Fee, transfer.ReceivedAmount)
+			}
+			return backup, nil
+		})
+		payload := backup()
+		payload.Accounts = append(payload.Accounts, dto.BackupAccountDTO{ID: 4, Currency: "JPY", Name: "Card"})
+		payload.Incomes = []dto.BackupIncomeDTO{{ID: 1, AccountID: 3, Name: "Salary", Amount: "1000.50"}}
+		payload.Transfers = []dto.BackupTransferDTO{{ID: 2, FromAccountID: 3, ToAccountID: 4, Amount: "1000.50", Fee: "0.50", ReceivedAmount: "95"}}
+
+		if _, err := bs.Restore(1, payload); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should resolve currencies and restore", func(t *testing.T) {
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{{Model: gorm.Model{ID: 7}, Code: "IDR", MinorUnit: 2}}, nil)
+		mbr.EXPECT().Restore(gomock.Eq(uint(1)), gomock.Any()).DoAndReturn(func(userID uint, backup model.Backup) (model.Backup, error) {
+			if backup.Accounts[0].ID != 3 || backup.Accounts[0].CurrencyID != 7 {
+				t.Error("exp account 3 in currency 7; got", backup.Accounts[0].ID, backup.Accounts[0].CurrencyID)
+			}
+			if backup.Expenses[0].AccountID != 3 || backup.Expenses[0].CategoryID != 5 {
+				t.Error("exp expense of account 3 in category 5; got", backup.Expenses[0].AccountID, backup.Expenses[0].CategoryID)
+			}
+			if backup.Expenses[0].Amount != 5000050 {
+				t.Error("exp 5000050; got", backup.Expenses[0].Amount)
+			}
+			return backup, nil
+		})
+
+		got, err := bs.Restore(1, backup())
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got.Expenses) != 1 {
+			t.Error("exp 1; got", len(got.Expenses))
+		}
+	})
+}
+
+func TestBackupService_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mbr := mock_repository.NewMockBackupRepository(ctrl)
+	bs := NewBackupService(mbr)
+	idr := model.Currency{Model: gorm.Model{ID: 7}, Code: "IDR", MinorUnit: 2}
+	deletedAt := time.Date(2023, time.October, 20, 8, 0, 0, 0, time.UTC)
+
+	t.Run("should restore deleted accounts and categories along with their transactions", func(t *testing.T) {
+		mbr.EXPECT().Export(gomock.Eq(uint(1))).Return(model.Backup{
+			Accounts: []model.Account{
+				{Model: gorm.Model{ID: 3}, CurrencyID: 7, Currency: &idr, Name: "Wallet"},
+				{Model: gorm.Model{ID: 4, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}, CurrencyID: 7, Currency: &idr, Name: "Old card"},
+			},
+			Categories: []model.Category{
+				{Model: gorm.Model{ID: 6, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}, Name: "Hobbies"},
+			},
+			Expenses: []model.Expense{
+				{Model: gorm.Model{ID: 9}, AccountID: 4, CategoryID: 6, Name: "Paint", Amount: 5000050},
+			},
+			Incomes: []model.Income{
+				{Model: gorm.Model{ID: 10}, AccountID: 4, Name: "Refund", Amount: 100},
+			},
+			Transfers: []model.Transfer{
+				{Model: gorm.Model{ID: 11}, FromAccountID: 3, ToAccountID: 4, Amount: 200, ExchangeRate: 1, ReceivedAmount: 200},
+			},
+		}, nil)
+
+		payload, err := bs.Export(1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+
+		mbr.EXPECT().GetCurrencies().Return([]model.Currency{idr}, nil)
+		mbr.EXPECT().Restore(gomock.Eq(uint(2)), gomock.Any()).DoAndReturn(func(userID uint, backup model.Backup) (model.Backup, error) {
+			if len(backup.Accounts) != 2 || backup.Accounts[0].DeletedAt.Valid || !backup.Accounts[1].DeletedAt.Time.Equal(deletedAt) {
+				t.Error("exp the old card deleted at", deletedAt, "; got", backup.Accounts)
+			}
+			if len(backup.Categories) != 1 || !backup.Categories[0].DeletedAt.Time.Equal(deletedAt) {
+				t.Error("exp Hobbies deleted at", deletedAt, "; got", backup.Categories)
+			}
+			if len(backup.Expenses) != 1 || backup.Expenses[0].AccountID != 4 || backup.Expenses[0].CategoryID != 6 {
+				t.Error("exp the expense of the deleted account and category; got", backup.Expenses)
+			}
+			if len(backup.Incomes) != 1 || len(backup.Transfers) != 1 {
+				t.Error("exp 1 income and 1 transfer; got", len(backup.Incomes), len(backup.Transfers))
+			}
+			return backup, nil
+		})
+
+		if _, err := bs.Restore(2, payload); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
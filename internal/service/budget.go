@@ -0,0 +1,225 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+var (
+	ErrInvalidBudgetPeriod    = errors.New("Budget period needs both from and to, with from before to")
+	ErrBudgetCurrencyRequired = errors.New("Budget needs a currency, an account or a reporting currency to take its currency from")
+)
+
+type BudgetService interface {
+	Create(userID int, payload dto.CreateBudgetDTO) (model.Budget, error)
+	GetOneByID(userID, id int) (model.Budget, error)
+	GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.Budget, error)
+	UpdateOneByID(userID, id int, payload dto.UpdateBudgetDTO) (model.Budget, error)
+	DeleteOneByID(userID, id int) error
+	GetStatus(userID, id int, period util.Period) (model.BudgetStatus, error)
+}
+
+type budgetService struct {
+	br   repository.BudgetRepository
+	er   repository.ExpenseRepository
+	as   AccountService
+	cs   CategoryService
+	curs CurrencyService
+}
+
+func NewBudgetService(br repository.BudgetRepository, er repository.ExpenseRepository, as AccountService, cs CategoryService, curs CurrencyService) *budgetService {
+	return &budgetService{br, er, as, cs, curs}
+}
+
+func (bs *budgetService) Create(userID int, payload dto.CreateBudgetDTO) (model.Budget, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.Budget{}, err
+	}
+	account, err := bs.checkOwnership(userID, payload.CategoryID, payload.AccountID)
+	if err != nil {
+		return model.Budget{}, err
+	}
+	currency, err := bs.resolveCurrency(userID, payload.Currency, account)
+	if err != nil {
+		return model.Budget{}, err
+	}
+	monthlyLimit, err := parsePositiveAmount(payload.MonthlyLimit, &currency)
+	if err != nil {
+		return model.Budget{}, err
+	}
+
+	budget, err := bs.br.Insert(uint(userID), payload.CategoryID, payload.AccountID, currency.ID, monthlyLimit)
+	if err != nil {
+		return model.Budget{}, err
+	}
+	budget.Currency = &currency
+
+	return budget, nil
+}
+
+func (bs *budgetService) GetOneByID(userID, id int) (model.Budget, error) {
+	budget, err := bs.br.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return model.Budget{}, notFound(err)
+	}
+
+	return budget, nil
+}
+
+func (bs *budgetService) GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.Budget, error) {
+	budgets, err := bs.br.GetManyBelongedToUser(uint(userID), itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return budgets, nil
+}
+
+func (bs *budgetService) UpdateOneByID(userID, id int, payload dto.UpdateBudgetDTO) (model.Budget, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.Budget{}, err
+	}
+	account, err := bs.checkOwnership(userID, payload.CategoryID, payload.AccountID)
+	if err != nil {
+		return model.Budget{}, err
+	}
+	currency, err := bs.resolveCurrency(userID, payload.Currency, account)
+	if err != nil {
+		return model.Budget{}, err
+	}
+	monthlyLimit, err := parsePositiveAmount(payload.MonthlyLimit, &currency)
+	if err != nil {
+		return model.Budget{}, err
+	}
+
+	budget, err := bs.br.UpdateOneByID(uint(userID), uint(id), payload.CategoryID, payload.AccountID, currency.ID, monthlyLimit)
+	if err != nil {
+		return model.Budget{}, notFound(err)
+	}
+	budget.Currency = &currency
+
+	return budget, nil
+}
+
+func (bs *budgetService) DeleteOneByID(userID, id int) error {
+	if err := bs.br.DeleteOneByID(uint(userID), uint(id)); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// GetStatus reports spending against the budget over period, which defaults
+// to the current calendar month when both ends are open.
+func (bs *budgetService) GetStatus(userID, id int, period util.Period) (model.BudgetStatus, error) {
+	now := time.Now().UTC()
+	if period.From.IsZero() && period.To.IsZero() {
+		period = util.MonthOf(now)
+	}
+	if period.From.IsZero() || period.To.IsZero() || !period.From.Before(period.To) {
+		return model.BudgetStatus{}, ErrInvalidBudgetPeriod
+	}
+
+	budget, err := bs.br.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return model.BudgetStatus{}, notFound(err)
+	}
+
+	totals, err := bs.er.GetTotalsBelongedToCategory(uint(userID), budget.CategoryID, budget.AccountID, period)
+	if err != nil {
+		return model.BudgetStatus{}, err
+	}
+	var currency model.Currency
+	if budget.Currency != nil {
+		currency = *budget.Currency
+	}
+	totals, err = bs.curs.ConvertTo(userID, totals, currency)
+	if err != nil {
+		return model.BudgetStatus{}, err
+	}
+	spent := sumExpenseStats(totals).Total
+
+	limit := prorateMonthlyLimit(budget.MonthlyLimit, period)
+	return model.BudgetStatus{
+		Budget:    budget,
+		From:      period.From,
+		To:        period.To,
+		Currency:  currency,
+		Limit:     limit,
+		Spent:     spent,
+		Remaining: limit - spent,
+		Projected: projectSpending(spent, period, now),
+	}, nil
+}
+
+// checkOwnership returns the budget's account, if it has one.
+func (bs *budgetService) checkOwnership(userID int, categoryID uint, accountID *uint) (*model.Account, error) {
+	if _, err := bs.cs.GetOneByID(userID, int(categoryID)); err != nil {
+		return nil, ErrCategoryNotBelongedToUser
+	}
+	if accountID == nil {
+		return nil, nil
+	}
+	account, err := bs.as.GetOneByID(userID, int(*accountID))
+	if err != nil {
+		return nil, ErrAccountNotBelongedToUser
+	}
+
+	return &account, nil
+}
+
+// resolveCurrency picks the currency a budget's limit is in: the one asked
+// for, else that of its account, else the user's reporting currency.
+func (bs *budgetService) resolveCurrency(userID int, code string, account *model.Account) (model.Currency, error) {
+	if code != "" {
+		return bs.curs.GetOneByCode(code)
+	}
+	if currency := accountCurrency(account); currency != nil {
+		return *currency, nil
+	}
+	reporting, err := bs.curs.GetReportingCurrency(userID)
+	if err != nil {
+		return model.Currency{}, err
+	}
+	if reporting == nil {
+		return model.Currency{}, ErrBudgetCurrencyRequired
+	}
+
+	return *reporting, nil
+}
+
+// prorateMonthlyLimit spreads a monthly limit over period: every calendar
+// month the period touches contributes the share of its length covered.
+func prorateMonthlyLimit(monthlyLimit int, period util.Period) int {
+	var total float64
+	for month := util.MonthOf(period.From); month.From.Before(period.To); month = util.MonthOf(month.To) {
+		from, to := month.From, month.To
+		if period.From.After(from) {
+			from = period.From
+		}
+		if period.To.Before(to) {
+			to = period.To
+		}
+		total += float64(monthlyLimit) * float64(to.Sub(from)) / float64(month.To.Sub(month.From))
+	}
+
+	return int(math.Round(total))
+}
+
+// projectSpending extrapolates spent to the end of period at the rate it has
+// accrued so far. Outside the period there is nothing left to project.
+func projectSpending(spent int, period util.Period, now time.Time) int {
+	if !now.After(period.From) || !now.Before(period.To) {
+		return spent
+	}
+
+	elapsed := now.Sub(period.From)
+	return int(math.Round(float64(spent) * float64(period.To.Sub(period.From)) / float64(elapsed)))
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"github.com/muhrizqiardi/spendtracker/tests/testutil"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestBudgetService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mbr := mock_repository.NewMockBudgetRepository(ctrl)
+	mer := mock_repository.NewMockExpenseRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	mcur := mock_service.NewMockCurrencyService(ctrl)
+	bs := NewBudgetService(mbr, mer, mas, mcs, mcur)
+	accountID := uint(2)
+	idr := model.Currency{Model: gorm.Model{ID: 7}, Code: "IDR", MinorUnit: 2}
+
+	t.Run("should return error when category belongs to another user", func(t *testing.T) {
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).Return(model.Category{}, ErrNotFound)
+
+		if _, err := bs.Create(1, dto.CreateBudgetDTO{
+			CategoryID:   3,
+			MonthlyLimit: "10000.00",
+		}); !errors.Is(err, ErrCategoryNotBelongedToUser) {
+			t.Error("exp ErrCategoryNotBelongedToUser; got", err)
+		}
+	})
+	t.Run("should return error when account belongs to another user", func(t *testing.T) {
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).Return(model.Category{}, nil)
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).Return(model.Account{}, ErrNotFound)
+
+		if _, err := bs.Create(1, dto.CreateBudgetDTO{
+			CategoryID:   3,
+			AccountID:    &accountID,
+			MonthlyLimit: "10000.00",
+		}); !errors.Is(err, ErrAccountNotBelongedToUser) {
+			t.Error("exp ErrAccountNotBelongedToUser; got", err)
+		}
+	})
+	t.Run("should return error when limit is not positive", func(t *testing.T) {
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).Return(model.Category{}, nil)
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).Return(model.Account{Currency: &idr}, nil)
+
+		if _, err := bs.Create(1, dto.CreateBudgetDTO{
+			CategoryID:   3,
+			AccountID:    &accountID,
+			MonthlyLimit: "-100",
+		}); !errors.Is(err, ErrAmountNotPositive) {
+			t.Error("exp ErrAmountNotPositive; got", err)
+		}
+	})
+	t.Run("should return error when there is no currency to take", func(t *testing.T) {
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).Return(model.Category{}, nil)
+		mcur.EXPECT().GetReportingCurrency(gomock.Eq(1)).Return(nil, nil)
+
+		if _, err := bs.Create(1, dto.CreateBudgetDTO{
+			CategoryID:   3,
+			MonthlyLimit: "10000.00",
+		}); !errors.Is(err, ErrBudgetCurrencyRequired) {
+			t.Error("exp ErrBudgetCurrencyRequired; got", err)
+		}
+	})
+	t.Run("should create budget in the account's currency", func(t *testing.T) {
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).Return(model.Category{}, nil)
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).Return(model.Account{Currency: &idr}, nil)
+		mbr.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(3)), gomock.Eq(&accountID), gomock.Eq(uint(7)), gomock.Eq(1000000)).
+			DoAndReturn(func(userID, categoryID uint, accountID *uint, currencyID uint, monthlyLimit int) (model.Budget, error) {
+				return model.Budget{
+					UserID:       userID,
+					CategoryID:   categoryID,
+					AccountID:    accountID,
+					CurrencyID:   currencyID,
+					MonthlyLimit: monthlyLimit,
+				}, nil
+			})
+
+		got, err := bs.Create(1, dto.CreateBudgetDTO{
+			CategoryID:   3,
+			AccountID:    &accountID,
+			MonthlyLimit: "10000.00",
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, model.Budget{
+			UserID:       1,
+			CategoryID:   3,
+			AccountID:    &accountID,
+			CurrencyID:   7,
+			Currency:     &idr,
+			MonthlyLimit: 1000000,
+		}, got)
+	})
+	t.Run("should create budget in the currency asked for", func(t *testing.T) {
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).Return(model.Category{}, nil)
+		mcur.EXPECT().GetOneByCode(gomock.Eq("JPY")).Return(model.Currency{Model: gorm.Model{ID: 9}, Code: "JPY"}, nil)
+		mbr.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(3)), gomock.Nil(), gomock.Eq(uint(9)), gomock.Eq(5000)).
+			Return(model.Budget{CurrencyID: 9, MonthlyLimit: 5000}, nil)
+
+		if _, err := bs.Create(1, dto.CreateBudgetDTO{
+			CategoryID:   3,
+			Currency:     "JPY",
+			MonthlyLimit: "5000",
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
+
+func TestBudgetService_GetStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mbr := mock_repository.NewMockBudgetRepository(ctrl)
+	mer := mock_repository.NewMockExpenseRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	mcur := mock_service.NewMockCurrencyService(ctrl)
+	bs := NewBudgetService(mbr, mer, mas, mcs, mcur)
+	october := util.Period{
+		From: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("should return error when period is half open", func(t *testing.T) {
+		if _, err := bs.GetStatus(1, 1, util.Period{From: october.From}); !errors.Is(err, ErrInvalidBudgetPeriod) {
+			t.Error("exp ErrInvalidBudgetPeriod; got", err)
+		}
+	})
+	t.Run("should return ErrNotFound when budget belongs to another user", func(t *testing.T) {
+		mbr.EXPECT().GetOneByID(gomock.Eq(uint(2)), gomock.Eq(uint(1))).Return(model.Budget{}, gorm.ErrRecordNotFound)
+
+		if _, err := bs.GetStatus(2, 1, october); !errors.Is(err, ErrNotFound) {
+			t.Error("exp ErrNotFound; got", err)
+		}
+	})
+	t.Run("should compare spending against the limit", func(t *testing.T) {
+		mbr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).
+			DoAndReturn(func(userID, id uint) (model.Budget, error) {
+				return model.Budget{
+					Model:        gorm.Model{ID: id},
+					UserID:       userID,
+					CategoryID:   3,
+					Currency:     &model.Currency{Code: "IDR", MinorUnit: 2},
+					MonthlyLimit: 1000000,
+				}, nil
+			})
+		totals := []model.ExpenseStatsRow{
+			{Currency: "USD", Date: "2023-10-05", ExpenseStats: model.ExpenseStats{Count: 1, Total: 50}},
+			{Currency: "IDR", Date: "2023-10-20", ExpenseStats: model.ExpenseStats{Count: 2, Total: 400000}},
+		}
+		mer.EXPECT().GetTotalsBelongedToCategory(gomock.Eq(uint(1)), gomock.Eq(uint(3)), gomock.Nil(), gomock.Eq(october)).Return(totals, nil)
+		mcur.EXPECT().ConvertTo(gomock.Eq(1), gomock.Eq(totals), gomock.Eq(model.Currency{Code: "IDR", MinorUnit: 2})).Return([]model.ExpenseStatsRow{
+			{Currency: "IDR", Date: "2023-10-05", ExpenseStats: model.ExpenseStats{Count: 1, Total: 800000}},
+			{Currency: "IDR", Date: "2023-10-20", ExpenseStats: model.ExpenseStats{Count: 2, Total: 400000}},
+		}, nil)
+
+		got, err := bs.GetStatus(1, 1, october)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Limit != 1000000 || got.Spent != 1200000 || got.Remaining != -200000 {
+			t.Error("exp limit 1000000, spent 1200000, remaining -200000; got", got.Limit, got.Spent, got.Remaining)
+		}
+		if got.Currency.Code != "IDR" {
+			t.Error("exp IDR; got", got.Currency)
+		}
+		if got.Projected != got.Spent {
+			t.Error("exp projection of a past period to equal spent; got", got.Projected)
+		}
+	})
+	t.Run("should return error when spending cannot be converted", func(t *testing.T) {
+		mbr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).Return(model.Budget{CategoryID: 3}, nil)
+		mer.EXPECT().GetTotalsBelongedToCategory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]model.ExpenseStatsRow{{Currency: "USD"}, {Currency: "IDR"}}, nil)
+		mcur.EXPECT().ConvertTo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, ErrExchangeRateNotFound)
+
+		if _, err := bs.GetStatus(1, 1, october); !errors.Is(err, ErrExchangeRateNotFound) {
+			t.Error("exp ErrExchangeRateNotFound; got", err)
+		}
+	})
+}
+
+func TestProrateMonthlyLimit(t *testing.T) {
+	t.Run("should return the limit for a whole month", func(t *testing.T) {
+		got := prorateMonthlyLimit(3100, util.Period{
+			From: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+		})
+		if got != 3100 {
+			t.Error("exp 3100; got", got)
+		}
+	})
+	t.Run("should prorate partial months by their own length", func(t *testing.T) {
+		// The last 10 days of October and the first 15 days of November.
+		got := prorateMonthlyLimit(3000, util.Period{
+			From: time.Date(2023, time.October, 22, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2023, time.November, 16, 0, 0, 0, 0, time.UTC),
+		})
+		if exp := 2468; got != exp {
+			t.Errorf("exp %d; got %d", exp, got)
+		}
+	})
+}
+
+func TestProjectSpending(t *testing.T) {
+	october := util.Period{
+		From: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, time.October, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("should extrapolate spending to the end of the period", func(t *testing.T) {
+		if got := projectSpending(500, october, time.Date(2023, time.October, 11, 0, 0, 0, 0, time.UTC)); got != 1500 {
+			t.Error("exp 1500; got", got)
+		}
+	})
+	t.Run("should not extrapolate before the period starts", func(t *testing.T) {
+		if got := projectSpending(500, october, time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)); got != 500 {
+			t.Error("exp 500; got", got)
+		}
+	})
+}
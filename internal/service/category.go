@@ -8,9 +8,10 @@ import (
 
 type CategoryService interface {
 	Create(userID int, payload dto.CreateCategoryDTO) (model.Category, error)
-	GetOneByID(id int) (model.Category, error)
-	GetMany(userID, itemPerPage, page int) ([]model.Category, error)
-	DeleteOneByID(id int) error
+	GetOneByID(userID, id int) (model.Category, error)
+	GetMany(userID, itemPerPage int, cursor string) (model.Page[model.Category], error)
+	GetManyByIDs(userID int, ids []uint) ([]model.Category, error)
+	DeleteOneByID(userID, id int) error
 }
 
 type categoryService struct {
@@ -30,27 +31,31 @@ func (cs *categoryService) Create(userID int, payload dto.CreateCategoryDTO) (mo
 	return category, nil
 }
 
-func (cs *categoryService) GetOneByID(id int) (model.Category, error) {
-	category, err := cs.cr.GetOneByID(uint(id))
+func (cs *categoryService) GetOneByID(userID, id int) (model.Category, error) {
+	category, err := cs.cr.GetOneByID(uint(userID), uint(id))
 	if err != nil {
-		return model.Category{}, err
+		return model.Category{}, notFound(err)
 	}
 
 	return category, nil
 }
 
-func (cs *categoryService) GetMany(userID, itemPerPage, page int) ([]model.Category, error) {
-	category, err := cs.cr.GetMany(uint(userID), itemPerPage, (page-1)*itemPerPage)
+func (cs *categoryService) GetMany(userID, itemPerPage int, cursor string) (model.Page[model.Category], error) {
+	categories, err := cs.cr.GetMany(uint(userID), itemPerPage, cursor)
 	if err != nil {
-		return nil, nil
+		return model.Page[model.Category]{}, invalidCursor(err)
 	}
 
-	return category, nil
+	return categories, nil
+}
+
+func (cs *categoryService) GetManyByIDs(userID int, ids []uint) ([]model.Category, error) {
+	return cs.cr.GetManyByIDs(uint(userID), ids)
 }
 
-func (cs *categoryService) DeleteOneByID(id int) error {
-	if err := cs.cr.Delete(uint(id)); err != nil {
-		return err
+func (cs *categoryService) DeleteOneByID(userID, id int) error {
+	if err := cs.cr.Delete(uint(userID), uint(id)); err != nil {
+		return notFound(err)
 	}
 
 	return nil
@@ -51,7 +51,7 @@ func TestCategoryService_GetOneByID(t *testing.T) {
 	cs := NewCategoryService(mcr)
 
 	t.Run("should return category", func(t *testing.T) {
-		mcr.EXPECT().GetOneByID(gomock.Eq(uint(1))).DoAndReturn(func(id uint) (model.Category, error) {
+		mcr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Category, error) {
 			return model.Category{
 				Model: gorm.Model{
 					ID: id,
@@ -64,7 +64,7 @@ func TestCategoryService_GetOneByID(t *testing.T) {
 				ID: 1,
 			},
 		}
-		got, err := cs.GetOneByID(1)
+		got, err := cs.GetOneByID(1, 1)
 		if err != nil {
 			t.Error("exp nil; got error:", err)
 		}
@@ -85,8 +85,8 @@ func TestCategoryService_GetMany(t *testing.T) {
 	cs := NewCategoryService(mcr)
 
 	t.Run("should return categories", func(t *testing.T) {
-		mcr.EXPECT().GetMany(gomock.Eq(uint(1)), gomock.Eq(10), gomock.Eq(10)).DoAndReturn(func(userID uint, itemPerPage, page int) ([]model.Category, error) {
-			return []model.Category{
+		mcr.EXPECT().GetMany(gomock.Eq(uint(1)), gomock.Eq(10), gomock.Eq("")).DoAndReturn(func(userID uint, itemPerPage int, cursor string) (model.Page[model.Category], error) {
+			return model.Page[model.Category]{Items: []model.Category{
 				{
 					UserID: 1,
 					Name:   "Bill",
@@ -99,7 +99,7 @@ func TestCategoryService_GetMany(t *testing.T) {
 					UserID: 1,
 					Name:   "Entertainment",
 				},
-			}, nil
+			}}, nil
 		})
 
 		exp := []model.Category{
@@ -116,14 +116,14 @@ func TestCategoryService_GetMany(t *testing.T) {
 				Name:   "Entertainment",
 			},
 		}
-		got, err := cs.GetMany(1, 10, 2)
+		got, err := cs.GetMany(1, 10, "")
 		if err != nil {
 			t.Error("exp nil; got error:", err)
 		}
 		opts := []cmp.Option{
 			cmpopts.IgnoreFields(model.Category{}, "Model"),
 		}
-		testutil.CompareAndAssert(t, exp, got, opts...)
+		testutil.CompareAndAssert(t, exp, got.Items, opts...)
 	})
 }
 
@@ -133,20 +133,20 @@ func TestCategoryService_DeleteOneByID(t *testing.T) {
 	cs := NewCategoryService(mcr)
 
 	t.Run("should return error when repository returns error", func(t *testing.T) {
-		mcr.EXPECT().Delete(gomock.Eq(uint(1))).DoAndReturn(func(id uint) error {
+		mcr.EXPECT().Delete(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) error {
 			return errors.New("")
 		})
 
-		if err := cs.DeleteOneByID(1); err == nil {
+		if err := cs.DeleteOneByID(1, 1); err == nil {
 			t.Error("exp error; got nil")
 		}
 	})
 	t.Run("should delete category", func(t *testing.T) {
-		mcr.EXPECT().Delete(gomock.Eq(uint(1))).DoAndReturn(func(id uint) error {
+		mcr.EXPECT().Delete(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) error {
 			return nil
 		})
 
-		if err := cs.DeleteOneByID(1); err != nil {
+		if err := cs.DeleteOneByID(1, 1); err != nil {
 			t.Error("exp nil; got error:", err)
 		}
 	})
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/importer"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/gorm"
+)
+
+// maxRateAge is how old the latest rate before a date may be and still be
+// used for it. Rates are not published on weekends and holidays.
+const maxRateAge = 7 * 24 * time.Hour
+
+// maxExchangeRates bounds one upload. The ECB's full history is about
+// 200,000 rates.
+const maxExchangeRates = 250000
+
+var (
+	ErrUnknownCurrency           = errors.New("Unknown currency")
+	ErrTooManyExchangeRates      = errors.New("File has too many exchange rates")
+	ErrReportingCurrencyRequired = errors.New("Amounts are in more than one currency; set a reporting currency to add them up")
+	ErrExchangeRateNotFound      = errors.New("No exchange rate found")
+)
+
+type CurrencyService interface {
+	GetOneByID(id int) (model.Currency, error)
+	GetOneByCode(code string) (model.Currency, error)
+	GetReportingCurrency(userID int) (*model.Currency, error)
+	SetReportingCurrency(userID int, payload dto.SetReportingCurrencyDTO) (*model.Currency, error)
+	ImportExchangeRates(userID int, r io.Reader, base string) (int, error)
+	GetExchangeRates(userID int, base, quote string, period util.Period, itemPerPage, page int) ([]model.ExchangeRate, error)
+	Convert(userID int, rows []model.ExpenseStatsRow) (model.Currency, []model.ExpenseStatsRow, error)
+	ConvertTo(userID int, rows []model.ExpenseStatsRow, currency model.Currency) ([]model.ExpenseStatsRow, error)
+}
+
+type currencyService struct {
+	cr repository.CurrencyRepository
+}
+
+func NewCurrencyService(cr repository.CurrencyRepository) *currencyService {
+	return &currencyService{cr}
+}
+
+func (cs *currencyService) GetOneByID(id int) (model.Currency, error) {
+	currency, err := cs.cr.GetOneByID(uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.Currency{}, ErrUnknownCurrency
+	}
+	if err != nil {
+		return model.Currency{}, err
+	}
+
+	return currency, nil
+}
+
+func (cs *currencyService) GetOneByCode(code string) (model.Currency, error) {
+	currency, err := cs.cr.GetOneByCode(strings.ToUpper(code))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.Currency{}, ErrUnknownCurrency
+	}
+	if err != nil {
+		return model.Currency{}, err
+	}
+
+	return currency, nil
+}
+
+// GetReportingCurrency returns nil when the user has not picked one.
+func (cs *currencyService) GetReportingCurrency(userID int) (*model.Currency, error) {
+	currency, err := cs.cr.GetReportingCurrency(uint(userID))
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return currency, nil
+}
+
+func (cs *currencyService) SetReportingCurrency(userID int, payload dto.SetReportingCurrencyDTO) (*model.Currency, error) {
+	validate := validator.New()
+	if err := validate.Struct(payload); err != nil {
+		return nil, err
+	}
+
+	if payload.Currency == "" {
+		if err := cs.cr.SetReportingCurrency(uint(userID), nil); err != nil {
+			return nil, notFound(err)
+		}
+		return nil, nil
+	}
+
+	currency, err := cs.GetOneByCode(payload.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := cs.cr.SetReportingCurrency(uint(userID), &currency.ID); err != nil {
+		return nil, notFound(err)
+	}
+
+	return &currency, nil
+}
+
+// ImportExchangeRates stores the rates in r, replacing rates the user
+// already has for the same day and currencies. base is the currency of
+// files that do not name one, such as the ECB's, and defaults to EUR.
+func (cs *currencyService) ImportExchangeRates(userID int, r io.Reader, base string) (int, error) {
+	if base == "" {
+		base = "EUR"
+	}
+	rates, err := importer.ParseExchangeRates(r, base)
+	if err != nil {
+		return 0, err
+	}
+	if len(rates) > maxExchangeRates {
+		return 0, ErrTooManyExchangeRates
+	}
+	if len(rates) == 0 {
+		return 0, nil
+	}
+	if err := cs.cr.UpsertExchangeRates(uint(userID), rates); err != nil {
+		return 0, err
+	}
+
+	return len(rates), nil
+}
+
+func (cs *currencyService) GetExchangeRates(userID int, base, quote string, period util.Period, itemPerPage, page int) ([]model.ExchangeRate, error) {
+	rates, err := cs.cr.GetExchangeRates(uint(userID), strings.ToUpper(base), strings.ToUpper(quote), period, itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return []model.ExchangeRate{}, err
+	}
+
+	return rates, nil
+}
+
+// Convert converts rows into the user's reporting currency with ConvertTo
+// and returns them along with the currency they are now in.
+// Without a reporting currency, rows are returned as they are as long as
+// they share one currency.
+func (cs *currencyService) Convert(userID int, rows []model.ExpenseStatsRow) (model.Currency, []model.ExpenseStatsRow, error) {
+	reporting, err := cs.cr.GetReportingCurrency(uint(userID))
+	if err != nil {
+		return model.Currency{}, nil, err
+	}
+	if reporting == nil {
+		var currency model.Currency
+		for i, r := range rows {
+			if i > 0 && r.Currency != currency.Code {
+				return model.Currency{}, nil, ErrReportingCurrencyRequired
+			}
+			currency = model.Currency{Code: r.Currency, MinorUnit: r.MinorUnit}
+		}
+		return currency, rows, nil
+	}
+
+	converted, err := cs.ConvertTo(userID, rows, *reporting)
+	if err != nil {
+		return model.Currency{}, nil, err
+	}
+
+	return *reporting, converted, nil
+}
+
+// ConvertTo converts rows into currency, each at the rate valid on its
+// date, and returns them in the same order.
+func (cs *currencyService) ConvertTo(userID int, rows []model.ExpenseStatsRow, currency model.Currency) ([]model.ExpenseStatsRow, error) {
+	target := currency.Code
+	var first, last time.Time
+	days := make([]time.Time, len(rows))
+	for i, r := range rows {
+		if r.Currency == target {
+			continue
+		}
+		day, err := time.Parse(util.DateLayout, r.Date)
+		if err != nil {
+			return nil, err
+		}
+		days[i] = day
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+	if first.IsZero() {
+		return rows, nil
+	}
+
+	rates, err := cs.cr.GetExchangeRatesInPeriod(uint(userID), util.Period{
+		From: first.Add(-maxRateAge),
+		To:   last.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	table := newRateTable(rates)
+
+	converted := make([]model.ExpenseStatsRow, len(rows))
+	for i, r := range rows {
+		converted[i] = r
+		if r.Currency == target {
+			continue
+		}
+		rate, ok := table.rate(r.Currency, target, days[i])
+		if !ok {
+			return nil, fmt.Errorf("%w from %q to %s on %s", ErrExchangeRateNotFound, r.Currency, target, r.Date)
+		}
+		converted[i].Currency = target
+		converted[i].MinorUnit = currency.MinorUnit
+		converted[i].Total = money.Convert(r.Total, rate, r.MinorUnit, currency.MinorUnit)
+		converted[i].Largest = money.Convert(r.Largest, rate, r.MinorUnit, currency.MinorUnit)
+		converted[i].Average = money.ConvertFloat(r.Average, rate, r.MinorUnit, currency.MinorUnit)
+	}
+
+	return converted, nil
+}
+
+type ratePair struct {
+	base, quote string
+}
+
+// rateTable looks up the rate valid on a day among a user's rates.
+type rateTable struct {
+	// rates holds each pair's rates, oldest first.
+	rates      map[ratePair][]model.ExchangeRate
+	currencies []string
+}
+
+func newRateTable(rates []model.ExchangeRate) rateTable {
+	rt := rateTable{rates: map[ratePair][]model.ExchangeRate{}}
+	seen := map[string]bool{}
+	for _, r := range rates {
+		pair := ratePair{r.BaseCurrency, r.QuoteCurrency}
+		rt.rates[pair] = append(rt.rates[pair], r)
+		for _, c := range []string{r.BaseCurrency, r.QuoteCurrency} {
+			if !seen[c] {
+				seen[c] = true
+				rt.currencies = append(rt.currencies, c)
+			}
+		}
+	}
+	for _, rs := range rt.rates {
+		sort.SliceStable(rs, func(i, j int) bool { return rs[i].Date.Before(rs[j].Date) })
+	}
+	sort.Strings(rt.currencies)
+
+	return rt
+}
+
+// rate returns how many units of to one unit of from was worth on day,
+// going through a third currency when there is no rate between the two,
+// as with the ECB's rates which are all against the euro.
+func (rt rateTable) rate(from, to string, day time.Time) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+	if r, ok := rt.lookup(from, to, day); ok {
+		return r, true
+	}
+	for _, via := range rt.currencies {
+		a, ok := rt.lookup(from, via, day)
+		if !ok {
+			continue
+		}
+		if b, ok := rt.lookup(via, to, day); ok {
+			return a * b, true
+		}
+	}
+
+	return 0, false
+}
+
+// lookup returns the rate between base and quote, either way round.
+func (rt rateTable) lookup(base, quote string, day time.Time) (float64, bool) {
+	if r, ok := rt.latest(ratePair{base, quote}, day); ok {
+		return r, true
+	}
+	if r, ok := rt.latest(ratePair{quote, base}, day); ok {
+		return 1 / r, true
+	}
+
+	return 0, false
+}
+
+// latest returns the last rate published for pair on or before day, unless
+// it is older than maxRateAge.
+func (rt rateTable) latest(pair ratePair, day time.Time) (float64, bool) {
+	rates := rt.rates[pair]
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].Date.After(day) })
+	if i == 0 || day.Sub(rates[i-1].Date) > maxRateAge {
+		return 0, false
+	}
+
+	return rates[i-1].Rate, true
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCurrencyService_Convert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mcr := mock_repository.NewMockCurrencyRepository(ctrl)
+	cs := NewCurrencyService(mcr)
+
+	idr := &model.Currency{Model: gorm.Model{ID: 1}, Code: "IDR"}
+	day := func(d int) time.Time { return time.Date(2023, time.October, d, 0, 0, 0, 0, time.UTC) }
+	// ECB-style rates, all against the euro. October 14 and 15 were a
+	// weekend.
+	rates := []model.ExchangeRate{
+		{Date: day(12), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1.06},
+		{Date: day(13), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1.05},
+		{Date: day(13), BaseCurrency: "EUR", QuoteCurrency: "IDR", Rate: 16500},
+		{Date: day(16), BaseCurrency: "EUR", QuoteCurrency: "IDR", Rate: 16600},
+	}
+	row := func(currency, date string, total int) model.ExpenseStatsRow {
+		return model.ExpenseStatsRow{Currency: currency, Date: date, ExpenseStats: model.ExpenseStats{Count: 1, Total: total, Average: float64(total), Largest: total}}
+	}
+
+	t.Run("should leave rows in one currency alone without a reporting currency", func(t *testing.T) {
+		mcr.EXPECT().GetReportingCurrency(gomock.Eq(uint(1))).Return(nil, nil)
+
+		rows := []model.ExpenseStatsRow{row("IDR", "2023-10-13", 100), row("IDR", "2023-10-14", 200)}
+		currency, got, err := cs.Convert(1, rows)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if currency.Code != "IDR" || got[1].Total != 200 {
+			t.Error("exp rows unchanged in IDR; got", currency, got)
+		}
+	})
+	t.Run("should refuse to add up currencies without a reporting currency", func(t *testing.T) {
+		mcr.EXPECT().GetReportingCurrency(gomock.Eq(uint(1))).Return(nil, nil)
+
+		if _, _, err := cs.Convert(1, []model.ExpenseStatsRow{row("IDR", "2023-10-13", 100), row("USD", "2023-10-13", 1)}); !errors.Is(err, ErrReportingCurrencyRequired) {
+			t.Error("exp ErrReportingCurrencyRequired; got", err)
+		}
+	})
+	t.Run("should convert at the rate valid on each date", func(t *testing.T) {
+		mcr.EXPECT().GetReportingCurrency(gomock.Eq(uint(1))).Return(idr, nil)
+		mcr.EXPECT().GetExchangeRatesInPeriod(gomock.Eq(uint(1)), gomock.Eq(util.Period{From: day(8), To: day(17)})).Return(rates, nil)
+
+		currency, got, err := cs.Convert(1, []model.ExpenseStatsRow{
+			row("IDR", "2023-10-13", 50000),
+			// Through the euro, at Friday's rates on the weekend.
+			row("USD", "2023-10-15", 21),
+			// The inverse of the EUR/IDR rate published that day.
+			row("EUR", "2023-10-16", 2),
+		})
+		if err != nil {
+			t.Fatal("exp nil; got error:", err)
+		}
+		if currency.Code != "IDR" {
+			t.Error("exp IDR; got", currency)
+		}
+		for i, exp := range []int{50000, 330000, 33200} {
+			if got[i].Total != exp || got[i].Currency != "IDR" {
+				t.Errorf("exp row %d to be %d IDR; got %d %s", i, exp, got[i].Total, got[i].Currency)
+			}
+		}
+		if got[1].Largest != 330000 || got[1].Average != 330000 {
+			t.Error("exp largest and average to be converted; got", got[1].ExpenseStats)
+		}
+	})
+	t.Run("should convert between the currencies' minor units", func(t *testing.T) {
+		mcr.EXPECT().GetExchangeRatesInPeriod(gomock.Any(), gomock.Any()).Return(rates, nil)
+
+		// 21.00 USD into IDR, which is written without decimals.
+		usd := row("USD", "2023-10-13", 2100)
+		usd.MinorUnit = 2
+		got, err := cs.ConvertTo(1, []model.ExpenseStatsRow{usd}, model.Currency{Code: "IDR"})
+		if err != nil {
+			t.Fatal("exp nil; got error:", err)
+		}
+		if got[0].Total != 330000 || got[0].MinorUnit != 0 {
+			t.Error("exp 330000 IDR without minor units; got", got[0].Total, got[0].MinorUnit)
+		}
+	})
+	t.Run("should not use rates more than a week old", func(t *testing.T) {
+		mcr.EXPECT().GetReportingCurrency(gomock.Eq(uint(1))).Return(idr, nil)
+		mcr.EXPECT().GetExchangeRatesInPeriod(gomock.Any(), gomock.Any()).Return(rates, nil)
+
+		if _, _, err := cs.Convert(1, []model.ExpenseStatsRow{row("USD", "2023-10-25", 1)}); !errors.Is(err, ErrExchangeRateNotFound) {
+			t.Error("exp ErrExchangeRateNotFound; got", err)
+		}
+	})
+	t.Run("should not load rates when nothing needs converting", func(t *testing.T) {
+		mcr.EXPECT().GetReportingCurrency(gomock.Eq(uint(1))).Return(idr, nil)
+
+		if _, _, err := cs.Convert(1, []model.ExpenseStatsRow{row("IDR", "2023-10-13", 1)}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
+
+func TestCurrencyService_SetReportingCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mcr := mock_repository.NewMockCurrencyRepository(ctrl)
+	cs := NewCurrencyService(mcr)
+
+	t.Run("should return error when currency is unknown", func(t *testing.T) {
+		mcr.EXPECT().GetOneByCode(gomock.Eq("XYZ")).Return(model.Currency{}, gorm.ErrRecordNotFound)
+
+		if _, err := cs.SetReportingCurrency(1, dto.SetReportingCurrencyDTO{Currency: "xyz"}); !errors.Is(err, ErrUnknownCurrency) {
+			t.Error("exp ErrUnknownCurrency; got", err)
+		}
+	})
+	t.Run("should set the reporting currency", func(t *testing.T) {
+		mcr.EXPECT().GetOneByCode(gomock.Eq("USD")).Return(model.Currency{Model: gorm.Model{ID: 2}, Code: "USD"}, nil)
+		mcr.EXPECT().SetReportingCurrency(gomock.Eq(uint(1)), gomock.Eq(func() *uint { id := uint(2); return &id }())).Return(nil)
+
+		got, err := cs.SetReportingCurrency(1, dto.SetReportingCurrencyDTO{Currency: "USD"})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got == nil || got.Code != "USD" {
+			t.Error("exp USD; got", got)
+		}
+	})
+	t.Run("should clear the reporting currency", func(t *testing.T) {
+		mcr.EXPECT().SetReportingCurrency(gomock.Eq(uint(1)), gomock.Nil()).Return(nil)
+
+		if got, err := cs.SetReportingCurrency(1, dto.SetReportingCurrencyDTO{}); err != nil || got != nil {
+			t.Error("exp nil, nil; got", got, err)
+		}
+	})
+}
+
+func TestCurrencyService_ImportExchangeRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mcr := mock_repository.NewMockCurrencyRepository(ctrl)
+	cs := NewCurrencyService(mcr)
+
+	t.Run("should store rates against the euro by default", func(t *testing.T) {
+		mcr.EXPECT().UpsertExchangeRates(gomock.Eq(uint(1)), gomock.Any()).DoAndReturn(func(userID uint, rates []model.ExchangeRate) error {
+			if len(rates) != 2 || rates[0].BaseCurrency != "EUR" {
+				t.Error("exp 2 rates against EUR; got", rates)
+			}
+			return nil
+		})
+
+		got, err := cs.ImportExchangeRates(1, strings.NewReader("Date,USD,JPY,\n2023-10-13,1.0533,157.62,\n"), "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != 2 {
+			t.Error("exp 2; got", got)
+		}
+	})
+}
@@ -2,70 +2,95 @@ package service
 
 import (
 	"errors"
-	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
 	"github.com/muhrizqiardi/spendtracker/internal/repository"
 )
 
-var ErrAccountNotBelongedToUser = errors.New("Account doesn't belong to current user")
+var (
+	ErrAccountNotBelongedToUser  = errors.New("Account doesn't belong to current user")
+	ErrCategoryNotBelongedToUser = errors.New("Category doesn't belong to current user")
+	ErrInvalidExpenseSort        = errors.New("Sort by must be one of occurredAt, amount, name, description, createdAt or updatedAt, and order asc or desc")
+	ErrInvalidTag                = errors.New("Tags must be at most 64 characters long and have no commas")
+)
+
+const maxTagLength = 64
+
+var expenseSortFields = map[string]bool{
+	"occurredAt":  true,
+	"amount":      true,
+	"name":        true,
+	"description": true,
+	"createdAt":   true,
+	"updatedAt":   true,
+}
 
 type ExpenseService interface {
 	Create(userID int, accountID int, payload dto.CreateExpenseDTO) (model.Expense, error)
-	GetOneByID(id int) (model.Expense, error)
+	GetOneByID(userID, id int) (model.Expense, error)
 	GetMany(itemPerPage, page int) ([]model.Expense, error)
-	GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.Expense, error)
-	GetManyBelongedToAccount(userID, accountID, itemPerPage, page int) ([]model.Expense, error)
-	GetManyBelongedToCategory(userID, categoryID, itemPerPage, page int) ([]model.Expense, error)
-	GetManyBelongedToCategoryAccount(userID, categoryID, accountID, itemPerPage, page int) ([]model.Expense, error)
-	UpdateOneByID(id int, payload dto.UpdateExpenseDTO) (model.Expense, error)
-	DeleteOneByID(id int) error
+	GetManyBelongedToUser(userID int, filter dto.ExpenseFilterDTO, itemPerPage int, cursor string) (model.Page[model.Expense], error)
+	UpdateOneByID(userID, id int, payload dto.UpdateExpenseDTO) (model.Expense, error)
+	DeleteOneByID(userID, id int) error
 }
 
 type expenseService struct {
 	er repository.ExpenseRepository
 	as AccountService
+	cs CategoryService
 }
 
-func NewExpenseService(er repository.ExpenseRepository, as AccountService) *expenseService {
-	return &expenseService{er, as}
+func NewExpenseService(er repository.ExpenseRepository, as AccountService, cs CategoryService) *expenseService {
+	return &expenseService{er, as, cs}
 }
 
 func (es *expenseService) Create(userID int, accountID int, payload dto.CreateExpenseDTO) (model.Expense, error) {
-	fmt.Println("mamamia")
-	if account, err := es.as.GetOneByID(accountID); err != nil || account.UserID != uint(userID) {
-		return model.Expense{}, ErrAccountNotBelongedToUser
+	account, err := es.as.GetOneByID(userID, accountID)
+	if err != nil {
+		return model.Expense{}, err
+	}
+	if _, err := es.cs.GetOneByID(userID, payload.CategoryID); err != nil {
+		return model.Expense{}, ErrCategoryNotBelongedToUser
+	}
+	amount, err := parsePositiveAmount(payload.Amount, account.Currency)
+	if err != nil {
+		return model.Expense{}, err
 	}
 
-	expense, err := es.er.Insert(uint(userID), uint(accountID), payload.Name, payload.Description, payload.Amount)
+	tags, err := normalizeTags(payload.Tags)
 	if err != nil {
 		return model.Expense{}, err
 	}
 
-	return expense, nil
-}
+	occurredAt, timezone, err := resolveOccurredAt(payload.OccurredAt, payload.Timezone, time.Now())
+	if err != nil {
+		return model.Expense{}, err
+	}
 
-func (es *expenseService) GetOneByID(id int) (model.Expense, error) {
-	expense, err := es.er.GetOneByID(uint(id))
+	expense, err := es.er.Insert(uint(userID), uint(accountID), uint(payload.CategoryID), payload.Name, payload.Description, amount, occurredAt, timezone, tags)
 	if err != nil {
 		return model.Expense{}, err
 	}
+	expense.Account = &account
 
 	return expense, nil
 }
 
-func (es *expenseService) GetMany(itemPerPage, page int) ([]model.Expense, error) {
-	expenses, err := es.er.GetMany(itemPerPage, (page-1)*itemPerPage)
+func (es *expenseService) GetOneByID(userID, id int) (model.Expense, error) {
+	expense, err := es.er.GetOneByID(uint(userID), uint(id))
 	if err != nil {
-		return nil, err
+		return model.Expense{}, notFound(err)
 	}
 
-	return expenses, nil
+	return expense, nil
 }
 
-func (es *expenseService) GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.Expense, error) {
-	expenses, err := es.er.GetManyBelongedToUser(uint(userID), itemPerPage, (page-1)*itemPerPage)
+func (es *expenseService) GetMany(itemPerPage, page int) ([]model.Expense, error) {
+	expenses, err := es.er.GetMany(itemPerPage, (page-1)*itemPerPage)
 	if err != nil {
 		return nil, err
 	}
@@ -73,46 +98,104 @@ func (es *expenseService) GetManyBelongedToUser(userID, itemPerPage, page int) (
 	return expenses, nil
 }
 
-func (es *expenseService) GetManyBelongedToAccount(userID, accountID, itemPerPage, page int) ([]model.Expense, error) {
-	expenses, err := es.er.GetManyBelongedToAccount(uint(userID), uint(accountID), itemPerPage, (page-1)*itemPerPage)
+// GetManyBelongedToUser lists the user's expenses that match filter, most
+// recently occurred first unless it asks for another order, a page at a
+// time.
+func (es *expenseService) GetManyBelongedToUser(userID int, filter dto.ExpenseFilterDTO, itemPerPage int, cursor string) (model.Page[model.Expense], error) {
+	if filter.SortBy != "" && !expenseSortFields[filter.SortBy] {
+		return model.Page[model.Expense]{}, ErrInvalidExpenseSort
+	}
+	if filter.Order != "" && filter.Order != "asc" && filter.Order != "desc" {
+		return model.Page[model.Expense]{}, ErrInvalidExpenseSort
+	}
+	minAmount, err := parseAmountBound(filter.MinAmount)
 	if err != nil {
-		return nil, err
+		return model.Page[model.Expense]{}, err
+	}
+	maxAmount, err := parseAmountBound(filter.MaxAmount)
+	if err != nil {
+		return model.Page[model.Expense]{}, err
+	}
+	tags, err := normalizeTags(filter.Tags)
+	if err != nil {
+		return model.Page[model.Expense]{}, err
 	}
 
-	return expenses, nil
-}
-
-func (es *expenseService) GetManyBelongedToCategory(userID, categoryID, itemPerPage, page int) ([]model.Expense, error) {
-	expenses, err := es.er.GetManyBelongedToCategory(uint(userID), uint(categoryID), itemPerPage, (page-1)*itemPerPage)
+	expenses, err := es.er.GetManyBelongedToUser(uint(userID), repository.ExpenseFilter{
+		Period:      filter.Period,
+		AccountIDs:  filter.AccountIDs,
+		CategoryIDs: filter.CategoryIDs,
+		MinAmount:   minAmount,
+		MaxAmount:   maxAmount,
+		Tags:        tags,
+		Search:      filter.Search,
+		SortBy:      filter.SortBy,
+		Ascending:   filter.Order == "asc",
+	}, itemPerPage, cursor)
 	if err != nil {
-		return nil, err
+		return model.Page[model.Expense]{}, invalidCursor(err)
 	}
 
 	return expenses, nil
 }
 
-func (es *expenseService) GetManyBelongedToCategoryAccount(userID, categoryID, accountID, itemPerPage, page int) ([]model.Expense, error) {
-	expenses, err := es.er.GetManyBelongedToCategoryAccount(uint(userID), uint(categoryID), uint(accountID), itemPerPage, (page-1)*itemPerPage)
+func (es *expenseService) UpdateOneByID(userID, id int, payload dto.UpdateExpenseDTO) (model.Expense, error) {
+	current, err := es.er.GetOneByID(uint(userID), uint(id))
 	if err != nil {
-		return nil, err
+		return model.Expense{}, notFound(err)
+	}
+	if _, err := es.cs.GetOneByID(userID, payload.CategoryID); err != nil {
+		return model.Expense{}, ErrCategoryNotBelongedToUser
+	}
+	amount, err := parsePositiveAmount(payload.Amount, accountCurrency(current.Account))
+	if err != nil {
+		return model.Expense{}, err
+	}
+	tags, err := normalizeTags(payload.Tags)
+	if err != nil {
+		return model.Expense{}, err
 	}
 
-	return expenses, nil
-}
-
-func (es *expenseService) UpdateOneByID(id int, payload dto.UpdateExpenseDTO) (model.Expense, error) {
-	expense, err := es.er.UpdateOneByID(uint(id), payload.Name, payload.Description, payload.Amount)
+	occurredAt, timezone, err := resolveOccurredAt(payload.OccurredAt, payload.Timezone, current.OccurredAt)
 	if err != nil {
 		return model.Expense{}, err
 	}
+	if payload.OccurredAt == nil && payload.Timezone == "" {
+		timezone = current.Timezone
+	}
+
+	expense, err := es.er.UpdateOneByID(uint(userID), uint(id), uint(payload.CategoryID), payload.Name, payload.Description, amount, occurredAt, timezone, tags)
+	if err != nil {
+		return model.Expense{}, notFound(err)
+	}
+	expense.Account = current.Account
 
 	return expense, nil
 }
 
-func (es *expenseService) DeleteOneByID(id int) error {
-	if err := es.er.DeleteOneByID(uint(id)); err != nil {
-		return err
+func (es *expenseService) DeleteOneByID(userID, id int) error {
+	if err := es.er.DeleteOneByID(uint(userID), uint(id)); err != nil {
+		return notFound(err)
 	}
 
 	return nil
 }
+
+// normalizeTags trims tag names and drops empty and repeated ones.
+func normalizeTags(names []string) ([]string, error) {
+	var tags []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if utf8.RuneCountInString(name) > maxTagLength || strings.Contains(name, ",") {
+			return nil, ErrInvalidTag
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+
+	return tags, nil
+}
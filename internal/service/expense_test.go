@@ -2,12 +2,15 @@ package service
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
 	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
 	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
 	"github.com/muhrizqiardi/spendtracker/tests/testutil"
@@ -19,49 +22,97 @@ func TestExpenseService_Create(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mer := mock_repository.NewMockExpenseRepository(ctrl)
 	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	es := NewExpenseService(mer, mas, mcs)
+	usd := model.Currency{Code: "USD", MinorUnit: 2}
 
 	t.Run("should return error when account service call returns error", func(t *testing.T) {
-		mas.EXPECT().GetOneByID(gomock.Eq(2)).DoAndReturn(func(id int) (model.Account, error) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(func(userID, id int) (model.Account, error) {
 			return model.Account{}, errors.New("")
 		})
 		if _, err := es.Create(1, 2, dto.CreateExpenseDTO{
+			CategoryID:  3,
 			Name:        "Dinner",
 			Description: "Eating out with friends",
-			Amount:      120000,
+			Amount:      "1200.00",
 		}); err == nil {
 			t.Error("exp error; got nil")
 		}
 	})
+	t.Run("should return error when category belongs to another user", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(func(userID, id int) (model.Account, error) {
+			return model.Account{
+				UserID:   uint(1),
+				Currency: &usd,
+			}, nil
+		})
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(func(userID, id int) (model.Category, error) {
+			return model.Category{}, ErrNotFound
+		})
+		if _, err := es.Create(1, 2, dto.CreateExpenseDTO{
+			CategoryID:  3,
+			Name:        "Dinner",
+			Description: "Eating out with friends",
+			Amount:      "1200.00",
+		}); !errors.Is(err, ErrCategoryNotBelongedToUser) {
+			t.Error("exp ErrCategoryNotBelongedToUser; got", err)
+		}
+	})
 	t.Run("should return error when repository call returns error", func(t *testing.T) {
-		mas.EXPECT().GetOneByID(gomock.Eq(2)).DoAndReturn(func(id int) (model.Account, error) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(func(userID, id int) (model.Account, error) {
 			return model.Account{
+				UserID:   uint(1),
+				Currency: &usd,
+			}, nil
+		})
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(func(userID, id int) (model.Category, error) {
+			return model.Category{
 				UserID: uint(1),
 			}, nil
 		})
-		mer.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq("Dinner"), gomock.Eq("Eating out with friends"), gomock.Eq(120000)).
-			DoAndReturn(func(userID uint, accountID uint, name string, description string, amount int) (model.Expense, error) {
+		mer.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(uint(3)), gomock.Eq("Dinner"), gomock.Eq("Eating out with friends"), gomock.Eq(120000), gomock.Any(), gomock.Eq("UTC"), gomock.Any()).
+			DoAndReturn(func(userID uint, accountID uint, categoryID uint, name string, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error) {
 				return model.Expense{}, errors.New("")
 			})
 		if _, err := es.Create(1, 2, dto.CreateExpenseDTO{
+			CategoryID:  3,
 			Name:        "Dinner",
 			Description: "Eating out with friends",
-			Amount:      120000,
+			Amount:      "1200.00",
 		}); err == nil {
 			t.Error("exp error; got nil")
 		}
 	})
+	t.Run("should return error when amount has more places than the currency", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).Return(model.Account{UserID: 1, Currency: &usd}, nil)
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).Return(model.Category{UserID: 1}, nil)
+
+		if _, err := es.Create(1, 2, dto.CreateExpenseDTO{
+			CategoryID: 3,
+			Name:       "Dinner",
+			Amount:     "1200.005",
+		}); !errors.Is(err, ErrInvalidAmount) {
+			t.Error("exp ErrInvalidAmount; got", err)
+		}
+	})
 	t.Run("should return new expense", func(t *testing.T) {
-		mas.EXPECT().GetOneByID(gomock.Eq(2)).DoAndReturn(func(id int) (model.Account, error) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(func(userID, id int) (model.Account, error) {
 			return model.Account{
+				UserID:   uint(1),
+				Currency: &usd,
+			}, nil
+		})
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(func(userID, id int) (model.Category, error) {
+			return model.Category{
 				UserID: uint(1),
 			}, nil
 		})
-		mer.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq("Dinner"), gomock.Eq("Eating out with friends"), gomock.Eq(120000)).
-			DoAndReturn(func(userID uint, accountID uint, name string, description string, amount int) (model.Expense, error) {
+		mer.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(uint(3)), gomock.Eq("Dinner"), gomock.Eq("Eating out with friends"), gomock.Eq(120000), gomock.Any(), gomock.Eq("UTC"), gomock.Any()).
+			DoAndReturn(func(userID uint, accountID uint, categoryID uint, name string, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error) {
 				return model.Expense{
 					UserID:      userID,
 					AccountID:   accountID,
+					CategoryID:  categoryID,
 					Name:        name,
 					Description: description,
 					Amount:      amount,
@@ -71,14 +122,17 @@ func TestExpenseService_Create(t *testing.T) {
 		exp := model.Expense{
 			UserID:      uint(1),
 			AccountID:   uint(2),
+			CategoryID:  uint(3),
 			Name:        "Dinner",
 			Description: "Eating out with friends",
 			Amount:      120000,
+			Account:     &model.Account{UserID: 1, Currency: &usd},
 		}
 		got, err := es.Create(1, 2, dto.CreateExpenseDTO{
+			CategoryID:  3,
 			Name:        "Dinner",
 			Description: "Eating out with friends",
-			Amount:      120000,
+			Amount:      "1200.00",
 		})
 		if err != nil {
 			t.Error("exp nil; got error:", err)
@@ -95,10 +149,11 @@ func TestExpenseService_GetOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mer := mock_repository.NewMockExpenseRepository(ctrl)
 	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	es := NewExpenseService(mer, mas, mcs)
 
 	t.Run("should return expense", func(t *testing.T) {
-		mer.EXPECT().GetOneByID(gomock.Eq(uint(1))).DoAndReturn(func(id uint) (model.Expense, error) {
+		mer.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Expense, error) {
 			return model.Expense{
 				Model: gorm.Model{
 					ID: id,
@@ -106,7 +161,7 @@ func TestExpenseService_GetOneByID(t *testing.T) {
 			}, nil
 		})
 
-		got, err := es.GetOneByID(1)
+		got, err := es.GetOneByID(1, 1)
 		if err != nil {
 			t.Error("exp nil; got error:", err)
 		}
@@ -114,13 +169,21 @@ func TestExpenseService_GetOneByID(t *testing.T) {
 			t.Error("exp 1; got", got.Model.ID)
 		}
 	})
+	t.Run("should return ErrNotFound when expense belongs to another user", func(t *testing.T) {
+		mer.EXPECT().GetOneByID(gomock.Eq(uint(2)), gomock.Eq(uint(1))).Return(model.Expense{}, gorm.ErrRecordNotFound)
+
+		if _, err := es.GetOneByID(2, 1); !errors.Is(err, ErrNotFound) {
+			t.Error("exp ErrNotFound; got", err)
+		}
+	})
 }
 
 func TestExpenseService_GetMany(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mer := mock_repository.NewMockExpenseRepository(ctrl)
 	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	es := NewExpenseService(mer, mas, mcs)
 
 	t.Run("should return many expenses", func(t *testing.T) {
 		mer.EXPECT().GetMany(gomock.Eq(10), gomock.Eq(20)).DoAndReturn(func(limit, offset int) ([]model.Expense, error) {
@@ -157,11 +220,12 @@ func TestExpenseService_GetManyBelongedToUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mer := mock_repository.NewMockExpenseRepository(ctrl)
 	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	es := NewExpenseService(mer, mas, mcs)
 
 	t.Run("should return many expenses", func(t *testing.T) {
-		mer.EXPECT().GetManyBelongedToUser(gomock.Eq(uint(1)), gomock.Eq(10), gomock.Eq(20)).DoAndReturn(func(userID uint, limit, offset int) ([]model.Expense, error) {
-			return []model.Expense{
+		mer.EXPECT().GetManyBelongedToUser(gomock.Eq(uint(1)), gomock.Eq(repository.ExpenseFilter{}), gomock.Eq(10), gomock.Eq("")).DoAndReturn(func(userID uint, filter repository.ExpenseFilter, limit int, cursor string) (model.Page[model.Expense], error) {
+			return model.Page[model.Expense]{Items: []model.Expense{
 				{
 					Name:        "Expense 1",
 					Description: "Desc",
@@ -177,143 +241,113 @@ func TestExpenseService_GetManyBelongedToUser(t *testing.T) {
 					Description: "Desc",
 					Amount:      1000,
 				},
-			}, nil
+			}}, nil
 		})
 
-		got, err := es.GetManyBelongedToUser(1, 10, 3)
+		got, err := es.GetManyBelongedToUser(1, dto.ExpenseFilterDTO{}, 10, "")
 		if err != nil {
 			t.Error("exp nil; got error:", err)
 		}
-		if len(got) < 1 {
+		if len(got.Items) < 1 {
 			t.Error("exp => 1; got < 1")
 		}
 	})
-}
-
-func TestExpenseService_GetManyBelongedToCategory(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	mer := mock_repository.NewMockExpenseRepository(ctrl)
-	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
-
-	t.Run("should return many expenses", func(t *testing.T) {
-		mer.EXPECT().GetManyBelongedToCategory(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(10), gomock.Eq(20)).DoAndReturn(func(userID, categoryID uint, limit, offset int) ([]model.Expense, error) {
-			return []model.Expense{
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
-			}, nil
-		})
-
-		got, err := es.GetManyBelongedToCategory(1, 2, 10, 3)
-		if err != nil {
+	t.Run("should pass the filter on with amounts in ten-thousandths", func(t *testing.T) {
+		minAmount, maxAmount := 125000, 1000000
+		mer.EXPECT().GetManyBelongedToUser(gomock.Eq(uint(1)), gomock.Eq(repository.ExpenseFilter{
+			AccountIDs:  []uint{2, 3},
+			CategoryIDs: []uint{4},
+			MinAmount:   &minAmount,
+			MaxAmount:   &maxAmount,
+			Tags:        []string{"work", "travel"},
+			Search:      "coffee",
+			SortBy:      "amount",
+			Ascending:   true,
+		}), gomock.Eq(10), gomock.Eq("")).Return(model.Page[model.Expense]{}, nil)
+
+		if _, err := es.GetManyBelongedToUser(1, dto.ExpenseFilterDTO{
+			AccountIDs:  []uint{2, 3},
+			CategoryIDs: []uint{4},
+			MinAmount:   "12.5",
+			MaxAmount:   "100",
+			Tags:        []string{" work", "travel", "work "},
+			Search:      "coffee",
+			SortBy:      "amount",
+			Order:       "asc",
+		}, 10, ""); err != nil {
 			t.Error("exp nil; got error:", err)
 		}
-		if len(got) < 1 {
-			t.Error("exp => 1; got < 1")
+	})
+	t.Run("should return error for an unknown sort field or order", func(t *testing.T) {
+		if _, err := es.GetManyBelongedToUser(1, dto.ExpenseFilterDTO{SortBy: "userId"}, 10, ""); !errors.Is(err, ErrInvalidExpenseSort) {
+			t.Error("exp ErrInvalidExpenseSort; got", err)
+		}
+		if _, err := es.GetManyBelongedToUser(1, dto.ExpenseFilterDTO{Order: "up"}, 10, ""); !errors.Is(err, ErrInvalidExpenseSort) {
+			t.Error("exp ErrInvalidExpenseSort; got", err)
 		}
 	})
-}
-
-func TestExpenseService_GetManyBelongedToAccount(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	mer := mock_repository.NewMockExpenseRepository(ctrl)
-	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
-
-	t.Run("should return many expenses", func(t *testing.T) {
-		mer.EXPECT().GetManyBelongedToAccount(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(10), gomock.Eq(20)).DoAndReturn(func(userID uint, accountID uint, limit, offset int) ([]model.Expense, error) {
-			return []model.Expense{
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
-			}, nil
-		})
-
-		got, err := es.GetManyBelongedToAccount(1, 2, 10, 3)
-		if err != nil {
-			t.Error("exp nil; got error:", err)
+	t.Run("should return error for an amount that is not a decimal", func(t *testing.T) {
+		if _, err := es.GetManyBelongedToUser(1, dto.ExpenseFilterDTO{MinAmount: "ten"}, 10, ""); !errors.Is(err, ErrInvalidAmount) {
+			t.Error("exp ErrInvalidAmount; got", err)
 		}
-		if len(got) < 1 {
-			t.Error("exp => 1; got < 1")
+	})
+	t.Run("should return error for a tag that is too long or has a comma", func(t *testing.T) {
+		for _, tag := range []string{strings.Repeat("a", 65), "work,travel"} {
+			if _, err := es.GetManyBelongedToUser(1, dto.ExpenseFilterDTO{Tags: []string{tag}}, 10, ""); !errors.Is(err, ErrInvalidTag) {
+				t.Error("exp ErrInvalidTag; got", err)
+			}
 		}
 	})
 }
 
-func TestExpenseService_GetManyBelongedToCategoryAccount(t *testing.T) {
+func TestExpenseService_UpdateOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mer := mock_repository.NewMockExpenseRepository(ctrl)
 	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	es := NewExpenseService(mer, mas, mcs)
+	account := &model.Account{UserID: 1, Currency: &model.Currency{Code: "USD", MinorUnit: 2}}
 
-	t.Run("should return many expenses", func(t *testing.T) {
-		mer.EXPECT().GetManyBelongedToCategoryAccount(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(uint(3)), gomock.Eq(10), gomock.Eq(20)).DoAndReturn(func(userID, categoryID, accountID uint, limit, offset int) ([]model.Expense, error) {
-			return []model.Expense{
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
-				{
-					Name:        "Expense 1",
-					Description: "Desc",
-					Amount:      1000,
-				},
+	t.Run("should return error when category belongs to another user", func(t *testing.T) {
+		mer.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Expense, error) {
+			return model.Expense{
+				Model:  gorm.Model{ID: id},
+				UserID: uint(1),
 			}, nil
 		})
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(func(userID, id int) (model.Category, error) {
+			return model.Category{}, ErrNotFound
+		})
 
-		got, err := es.GetManyBelongedToCategoryAccount(1, 2, 3, 10, 3)
-		if err != nil {
-			t.Error("exp nil; got error:", err)
-		}
-		if len(got) < 1 {
-			t.Error("exp => 1; got < 1")
+		if _, err := es.UpdateOneByID(1, 1, dto.UpdateExpenseDTO{
+			CategoryID:  3,
+			Name:        "Dinner",
+			Description: "Eating out with friends",
+			Amount:      "1200.00",
+		}); !errors.Is(err, ErrCategoryNotBelongedToUser) {
+			t.Error("exp ErrCategoryNotBelongedToUser; got", err)
 		}
 	})
-}
-
-func TestExpenseService_UpdateOneByID(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	mer := mock_repository.NewMockExpenseRepository(ctrl)
-	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
-
 	t.Run("should return updated expense", func(t *testing.T) {
-		mer.EXPECT().UpdateOneByID(gomock.Eq(uint(1)), gomock.Eq("Dinner"), gomock.Eq("Eating out with friends"), gomock.Eq(120000)).
-			DoAndReturn(func(id uint, name string, description string, amount int) (model.Expense, error) {
+		mer.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Expense, error) {
+			return model.Expense{
+				Model:   gorm.Model{ID: id},
+				UserID:  uint(1),
+				Account: account,
+			}, nil
+		})
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(func(userID, id int) (model.Category, error) {
+			return model.Category{
+				UserID: uint(1),
+			}, nil
+		})
+		mer.EXPECT().UpdateOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1)), gomock.Eq(uint(3)), gomock.Eq("Dinner"), gomock.Eq("Eating out with friends"), gomock.Eq(120000), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(userID, id uint, categoryID uint, name string, description string, amount int, occurredAt time.Time, timezone string, tags []string) (model.Expense, error) {
 				return model.Expense{
 					Model: gorm.Model{
 						ID: id,
 					},
+					CategoryID:  categoryID,
 					Name:        name,
 					Description: description,
 					Amount:      amount,
@@ -324,14 +358,17 @@ func TestExpenseService_UpdateOneByID(t *testing.T) {
 			Model: gorm.Model{
 				ID: uint(1),
 			},
+			CategoryID:  uint(3),
 			Name:        "Dinner",
 			Description: "Eating out with friends",
 			Amount:      120000,
+			Account:     account,
 		}
-		got, err := es.UpdateOneByID(1, dto.UpdateExpenseDTO{
+		got, err := es.UpdateOneByID(1, 1, dto.UpdateExpenseDTO{
+			CategoryID:  3,
 			Name:        "Dinner",
 			Description: "Eating out with friends",
-			Amount:      120000,
+			Amount:      "1200.00",
 		})
 		if err != nil {
 			t.Error("exp nil; got error:", err)
@@ -350,23 +387,24 @@ func TestExpenseService_DeleteOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mer := mock_repository.NewMockExpenseRepository(ctrl)
 	mas := mock_service.NewMockAccountService(ctrl)
-	es := NewExpenseService(mer, mas)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	es := NewExpenseService(mer, mas, mcs)
 
 	t.Run("should return error when repository returns error", func(t *testing.T) {
-		mer.EXPECT().DeleteOneByID(gomock.Eq(uint(1))).DoAndReturn(func(id uint) error {
+		mer.EXPECT().DeleteOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) error {
 			return errors.New("")
 		})
 
-		if err := es.DeleteOneByID(1); err == nil {
+		if err := es.DeleteOneByID(1, 1); err == nil {
 			t.Error("exp error; got nil")
 		}
 	})
 	t.Run("should delete and return nil", func(t *testing.T) {
-		mer.EXPECT().DeleteOneByID(gomock.Eq(uint(1))).DoAndReturn(func(id uint) error {
+		mer.EXPECT().DeleteOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) error {
 			return nil
 		})
 
-		if err := es.DeleteOneByID(1); err != nil {
+		if err := es.DeleteOneByID(1, 1); err != nil {
 			t.Error("exp nil; got error:", err)
 		}
 	})
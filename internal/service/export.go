@@ -0,0 +1,45 @@
+package service
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/journal"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+)
+
+type ExportService interface {
+	Ledger(userID int) ([]byte, error)
+	Beancount(userID int) ([]byte, error)
+}
+
+type exportService struct {
+	jr repository.JournalRepository
+}
+
+func NewExportService(jr repository.JournalRepository) *exportService {
+	return &exportService{jr}
+}
+
+func (es *exportService) Ledger(userID int) ([]byte, error) {
+	return es.write(userID, journal.WriteLedger)
+}
+
+func (es *exportService) Beancount(userID int) ([]byte, error) {
+	return es.write(userID, journal.WriteBeancount)
+}
+
+func (es *exportService) write(userID int, render func(io.Writer, model.Journal) error) ([]byte, error) {
+	j, err := es.jr.Get(uint(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, j); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"errors"
+	"io"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/importer"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+const maxImportRows = 5000
+
+var (
+	ErrImportMappingNotBelongedToUser = errors.New("Import mapping doesn't belong to current user")
+	ErrImportHasInvalidRows           = errors.New("Some rows could not be read, so nothing was imported")
+	ErrImportTooLarge                 = errors.New("Statement has too many rows")
+)
+
+type ImportService interface {
+	CreateMapping(userID int, payload dto.CreateImportMappingDTO) (model.ImportMapping, error)
+	GetMappings(userID int) ([]model.ImportMapping, error)
+	DeleteMapping(userID, id int) error
+	ImportCSV(userID, accountID int, payload dto.ImportCSVDTO, statement io.Reader) (model.ImportResult, error)
+	ImportOFX(userID, accountID int, payload dto.ImportOFXDTO, statement io.Reader) (model.ImportResult, error)
+}
+
+type importService struct {
+	imr repository.ImportMappingRepository
+	ir  repository.ImportRepository
+	as  AccountService
+	cs  CategoryService
+}
+
+func NewImportService(imr repository.ImportMappingRepository, ir repository.ImportRepository, as AccountService, cs CategoryService) *importService {
+	return &importService{imr, ir, as, cs}
+}
+
+func (is *importService) CreateMapping(userID int, payload dto.CreateImportMappingDTO) (model.ImportMapping, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.ImportMapping{}, err
+	}
+	if _, err := importer.DateLayout(payload.DateFormat); err != nil {
+		return model.ImportMapping{}, err
+	}
+	if _, err := util.LoadTimezone(payload.Timezone); err != nil {
+		return model.ImportMapping{}, ErrInvalidTimezone
+	}
+
+	mapping := model.ImportMapping{
+		UserID:            uint(userID),
+		Name:              payload.Name,
+		Delimiter:         payload.Delimiter,
+		HasHeader:         payload.HasHeader,
+		DateColumn:        payload.DateColumn,
+		DescriptionColumn: payload.DescriptionColumn,
+		AmountColumn:      payload.AmountColumn,
+		SignConvention:    payload.SignConvention,
+		DecimalSeparator:  payload.DecimalSeparator,
+		DateFormat:        payload.DateFormat,
+		Timezone:          payload.Timezone,
+	}
+	if mapping.Delimiter == "" {
+		mapping.Delimiter = ","
+	}
+	if mapping.DecimalSeparator == "" {
+		mapping.DecimalSeparator = "."
+	}
+	if mapping.Timezone == "" {
+		mapping.Timezone = "UTC"
+	}
+
+	mapping, err := is.imr.Insert(mapping)
+	if err != nil {
+		return model.ImportMapping{}, err
+	}
+
+	return mapping, nil
+}
+
+func (is *importService) GetMappings(userID int) ([]model.ImportMapping, error) {
+	mappings, err := is.imr.GetManyBelongedToUser(uint(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+func (is *importService) DeleteMapping(userID, id int) error {
+	if err := is.imr.DeleteOneByID(uint(userID), uint(id)); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// ImportCSV reads a bank statement into the account as expenses in the
+// given category. Credits are skipped. If any row cannot be read nothing is
+// created and ErrImportHasInvalidRows is returned along with the rows, so
+// the statement can be fixed and imported again. A dry run only previews
+// the rows.
+func (is *importService) ImportCSV(userID, accountID int, payload dto.ImportCSVDTO, statement io.Reader) (model.ImportResult, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.ImportResult{}, err
+	}
+	account, err := is.as.GetOneByID(userID, accountID)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+	if _, err := is.cs.GetOneByID(userID, payload.CategoryID); err != nil {
+		return model.ImportResult{}, ErrCategoryNotBelongedToUser
+	}
+	mapping, err := is.imr.GetOneByID(uint(userID), uint(payload.MappingID))
+	if err != nil {
+		return model.ImportResult{}, ErrImportMappingNotBelongedToUser
+	}
+	loc, err := util.LoadTimezone(mapping.Timezone)
+	if err != nil {
+		return model.ImportResult{}, ErrInvalidTimezone
+	}
+
+	rows, err := importer.ParseCSV(statement, mapping, loc, minorUnitOf(account.Currency))
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+
+	result, err := is.importRows(userID, accountID, payload.CategoryID, rows, nil, payload.DryRun)
+	result.Currency = account.Currency
+
+	return result, err
+}
+
+// ImportOFX reads an OFX or QFX statement into the account. Debits become
+// expenses in the given category and credits become incomes. Transactions
+// whose FITID was imported into the account before are skipped as
+// duplicates, so overlapping statements can be imported safely. Errors and
+// dry runs work as in ImportCSV.
+func (is *importService) ImportOFX(userID, accountID int, payload dto.ImportOFXDTO, statement io.Reader) (model.ImportResult, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.ImportResult{}, err
+	}
+	account, err := is.as.GetOneByID(userID, accountID)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+	if _, err := is.cs.GetOneByID(userID, payload.CategoryID); err != nil {
+		return model.ImportResult{}, ErrCategoryNotBelongedToUser
+	}
+	loc, err := util.LoadTimezone(payload.Timezone)
+	if err != nil {
+		return model.ImportResult{}, ErrInvalidTimezone
+	}
+
+	rows, err := importer.ParseOFX(statement, loc, minorUnitOf(account.Currency))
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+	externalIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.ExternalID != "" {
+			externalIDs = append(externalIDs, row.ExternalID)
+		}
+	}
+	imported, err := is.ir.GetImportedExternalIDs(uint(accountID), externalIDs)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+
+	result, err := is.importRows(userID, accountID, payload.CategoryID, rows, imported, payload.DryRun)
+	result.Currency = account.Currency
+
+	return result, err
+}
+
+// importRows saves the rows read from a statement unless any of them has an
+// error or this is a dry run. Rows whose external ID is in imported, or
+// repeats an earlier row, are marked as duplicates.
+func (is *importService) importRows(userID, accountID, categoryID int, rows []model.ImportRow, imported []string, dryRun bool) (model.ImportResult, error) {
+	if len(rows) > maxImportRows {
+		return model.ImportResult{}, ErrImportTooLarge
+	}
+
+	seen := make(map[string]bool, len(imported))
+	for _, id := range imported {
+		seen[id] = true
+	}
+	result := model.ImportResult{DryRun: dryRun, Rows: rows}
+	var (
+		expenses []model.Expense
+		incomes  []model.Income
+	)
+	for i := range rows {
+		row := &rows[i]
+		if row.Error != "" {
+			result.Failed++
+			continue
+		}
+		if row.ExternalID != "" {
+			if seen[row.ExternalID] {
+				row.Duplicate, row.Skipped = true, true
+			}
+			seen[row.ExternalID] = true
+		}
+		if row.Skipped {
+			result.Skipped++
+			continue
+		}
+
+		var externalID *string
+		if row.ExternalID != "" {
+			externalID = &row.ExternalID
+		}
+		switch row.Type {
+		case model.TransactionTypeIncome:
+			incomes = append(incomes, model.Income{
+				UserID:      uint(userID),
+				AccountID:   uint(accountID),
+				Name:        row.Name,
+				Description: row.Description,
+				Amount:      row.Amount,
+				OccurredAt:  row.OccurredAt,
+				Timezone:    row.Timezone,
+				ExternalID:  externalID,
+			})
+		default:
+			expenses = append(expenses, model.Expense{
+				UserID:      uint(userID),
+				AccountID:   uint(accountID),
+				CategoryID:  uint(categoryID),
+				Name:        row.Name,
+				Description: row.Description,
+				Amount:      row.Amount,
+				OccurredAt:  row.OccurredAt,
+				Timezone:    row.Timezone,
+				ExternalID:  externalID,
+			})
+		}
+	}
+	if result.Failed > 0 && !dryRun {
+		return result, ErrImportHasInvalidRows
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	expenses, incomes, err := is.ir.InsertStatement(expenses, incomes)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+	for i := range rows {
+		if rows[i].Skipped {
+			continue
+		}
+		if rows[i].Type == model.TransactionTypeIncome {
+			rows[i].TransactionID, incomes = incomes[0].ID, incomes[1:]
+		} else {
+			rows[i].TransactionID, expenses = expenses[0].ID, expenses[1:]
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestImportService_ImportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mimr := mock_repository.NewMockImportMappingRepository(ctrl)
+	mir := mock_repository.NewMockImportRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	is := NewImportService(mimr, mir, mas, mcs)
+
+	mapping := model.ImportMapping{
+		Model:             gorm.Model{ID: 3},
+		UserID:            1,
+		Delimiter:         ",",
+		HasHeader:         true,
+		DateColumn:        1,
+		DescriptionColumn: 2,
+		AmountColumn:      3,
+		SignConvention:    "negative",
+		DecimalSeparator:  ".",
+		DateFormat:        "YYYY-MM-DD",
+		Timezone:          "UTC",
+	}
+	expectOwnership := func() {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).Return(model.Account{}, nil)
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(4)).Return(model.Category{}, nil)
+		mimr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(3))).Return(mapping, nil)
+	}
+	statement := "Date,Description,Amount\n2023-10-14,Coffee,-25000\n2023-10-15,Salary,5000000\n2023-10-16,Lunch,-50000\n"
+
+	t.Run("should return error when mapping belongs to another user", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).Return(model.Account{}, nil)
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(4)).Return(model.Category{}, nil)
+		mimr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(3))).Return(model.ImportMapping{}, gorm.ErrRecordNotFound)
+
+		if _, err := is.ImportCSV(1, 2, dto.ImportCSVDTO{MappingID: 3, CategoryID: 4}, strings.NewReader(statement)); !errors.Is(err, ErrImportMappingNotBelongedToUser) {
+			t.Error("exp ErrImportMappingNotBelongedToUser; got", err)
+		}
+	})
+	t.Run("should preview without creating expenses on a dry run", func(t *testing.T) {
+		expectOwnership()
+
+		got, err := is.ImportCSV(1, 2, dto.ImportCSVDTO{MappingID: 3, CategoryID: 4, DryRun: true}, strings.NewReader(statement))
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got.Rows) != 3 || got.Skipped != 1 || got.Created != 0 {
+			t.Error("exp 3 rows, 1 skipped, 0 created; got", len(got.Rows), got.Skipped, got.Created)
+		}
+	})
+	t.Run("should create nothing when a row cannot be read", func(t *testing.T) {
+		expectOwnership()
+
+		got, err := is.ImportCSV(1, 2, dto.ImportCSVDTO{MappingID: 3, CategoryID: 4}, strings.NewReader(statement+"yesterday,Snack,-5000\n"))
+		if !errors.Is(err, ErrImportHasInvalidRows) {
+			t.Error("exp ErrImportHasInvalidRows; got", err)
+		}
+		if got.Failed != 1 || got.Rows[3].Error == "" || got.Rows[3].Line != 5 {
+			t.Error("exp line 5 to fail; got", got.Rows)
+		}
+	})
+	t.Run("should create the expenses and skip credits", func(t *testing.T) {
+		expectOwnership()
+		mir.EXPECT().InsertStatement(gomock.Any(), gomock.Len(0)).DoAndReturn(func(expenses []model.Expense, incomes []model.Income) ([]model.Expense, []model.Income, error) {
+			if len(expenses) != 2 {
+				t.Fatal("exp 2; got", len(expenses))
+			}
+			for i := range expenses {
+				if expenses[i].UserID != 1 || expenses[i].AccountID != 2 || expenses[i].CategoryID != 4 {
+					t.Error("exp user 1, account 2, category 4; got", expenses[i])
+				}
+				expenses[i].ID = uint(10 + i)
+			}
+			return expenses, incomes, nil
+		})
+
+		got, err := is.ImportCSV(1, 2, dto.ImportCSVDTO{MappingID: 3, CategoryID: 4}, strings.NewReader(statement))
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Created != 2 || got.Skipped != 1 {
+			t.Error("exp 2 created, 1 skipped; got", got.Created, got.Skipped)
+		}
+		if got.Rows[0].TransactionID != 10 || got.Rows[1].TransactionID != 0 || got.Rows[2].TransactionID != 11 {
+			t.Error("exp expense IDs 10, 0, 11; got", got.Rows[0].TransactionID, got.Rows[1].TransactionID, got.Rows[2].TransactionID)
+		}
+	})
+}
+
+func TestImportService_ImportOFX(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mimr := mock_repository.NewMockImportMappingRepository(ctrl)
+	mir := mock_repository.NewMockImportRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	is := NewImportService(mimr, mir, mas, mcs)
+
+	statement := `<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20231014<TRNAMT>-25000<FITID>A1<NAME>Coffee</STMTTRN>
+<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20231015<TRNAMT>5000000<FITID>A2<NAME>Salary</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20231016<TRNAMT>-50000<FITID>A3<NAME>Lunch</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20231016<TRNAMT>-50000<FITID>A3<NAME>Lunch</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+	t.Run("should skip transactions imported before or repeated in the statement", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).Return(model.Account{}, nil)
+		mcs.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(4)).Return(model.Category{}, nil)
+		mir.EXPECT().GetImportedExternalIDs(gomock.Eq(uint(2)), gomock.Eq([]string{"A1", "A2", "A3", "A3"})).Return([]string{"A1"}, nil)
+		mir.EXPECT().InsertStatement(gomock.Len(1), gomock.Len(1)).DoAndReturn(func(expenses []model.Expense, incomes []model.Income) ([]model.Expense, []model.Income, error) {
+			if expenses[0].Name != "Lunch" || expenses[0].CategoryID != 4 || *expenses[0].ExternalID != "A3" {
+				t.Error("exp Lunch in category 4 as A3; got", expenses[0])
+			}
+			if incomes[0].Name != "Salary" || incomes[0].Amount != 5000000 {
+				t.Error("exp Salary of 5000000; got", incomes[0])
+			}
+			expenses[0].ID, incomes[0].ID = 20, 30
+			return expenses, incomes, nil
+		})
+
+		got, err := is.ImportOFX(1, 2, dto.ImportOFXDTO{CategoryID: 4}, strings.NewReader(statement))
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Created != 2 || got.Skipped != 2 {
+			t.Error("exp 2 created, 2 skipped; got", got.Created, got.Skipped)
+		}
+		if !got.Rows[0].Duplicate || got.Rows[1].TransactionID != 30 || got.Rows[2].TransactionID != 20 || !got.Rows[3].Duplicate {
+			t.Error("exp duplicate, 30, 20, duplicate; got", got.Rows)
+		}
+	})
+	t.Run("should return error when account belongs to another user", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).Return(model.Account{}, ErrNotFound)
+
+		if _, err := is.ImportOFX(1, 2, dto.ImportOFXDTO{CategoryID: 4}, strings.NewReader(statement)); !errors.Is(err, ErrNotFound) {
+			t.Error("exp ErrNotFound; got", err)
+		}
+	})
+}
+
+func TestImportService_CreateMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mimr := mock_repository.NewMockImportMappingRepository(ctrl)
+	mir := mock_repository.NewMockImportRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	is := NewImportService(mimr, mir, mas, mcs)
+
+	t.Run("should return error when payload is invalid", func(t *testing.T) {
+		if _, err := is.CreateMapping(1, dto.CreateImportMappingDTO{
+			Name:              "Bank",
+			DateColumn:        1,
+			DescriptionColumn: 2,
+			AmountColumn:      3,
+			SignConvention:    "negative",
+			DecimalSeparator:  ";",
+			DateFormat:        "YYYY-MM-DD",
+		}); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+	t.Run("should default delimiter, decimal separator and timezone", func(t *testing.T) {
+		mimr.EXPECT().Insert(gomock.Any()).DoAndReturn(func(mapping model.ImportMapping) (model.ImportMapping, error) {
+			return mapping, nil
+		})
+
+		got, err := is.CreateMapping(1, dto.CreateImportMappingDTO{
+			Name:              "Bank",
+			DateColumn:        1,
+			DescriptionColumn: 2,
+			AmountColumn:      3,
+			SignConvention:    "negative",
+			DateFormat:        "YYYY-MM-DD",
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Delimiter != "," || got.DecimalSeparator != "." || got.Timezone != "UTC" {
+			t.Error("exp , . UTC; got", got.Delimiter, got.DecimalSeparator, got.Timezone)
+		}
+	})
+}
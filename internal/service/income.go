@@ -0,0 +1,113 @@
+package service
+
+import (
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type IncomeService interface {
+	Create(userID int, accountID int, payload dto.CreateIncomeDTO) (model.Income, error)
+	GetOneByID(userID, id int) (model.Income, error)
+	GetManyBelongedToUser(userID int, period util.Period, itemPerPage, page int) ([]model.Income, error)
+	GetManyBelongedToAccount(userID, accountID int, period util.Period, itemPerPage, page int) ([]model.Income, error)
+	UpdateOneByID(userID, id int, payload dto.UpdateIncomeDTO) (model.Income, error)
+	DeleteOneByID(userID, id int) error
+}
+
+type incomeService struct {
+	ir repository.IncomeRepository
+	as AccountService
+}
+
+func NewIncomeService(ir repository.IncomeRepository, as AccountService) *incomeService {
+	return &incomeService{ir, as}
+}
+
+func (is *incomeService) Create(userID int, accountID int, payload dto.CreateIncomeDTO) (model.Income, error) {
+	account, err := is.as.GetOneByID(userID, accountID)
+	if err != nil {
+		return model.Income{}, err
+	}
+	amount, err := parsePositiveAmount(payload.Amount, account.Currency)
+	if err != nil {
+		return model.Income{}, err
+	}
+
+	occurredAt, timezone, err := resolveOccurredAt(payload.OccurredAt, payload.Timezone, time.Now())
+	if err != nil {
+		return model.Income{}, err
+	}
+
+	income, err := is.ir.Insert(uint(userID), uint(accountID), payload.Name, payload.Description, amount, occurredAt, timezone)
+	if err != nil {
+		return model.Income{}, err
+	}
+	income.Account = &account
+
+	return income, nil
+}
+
+func (is *incomeService) GetOneByID(userID, id int) (model.Income, error) {
+	income, err := is.ir.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return model.Income{}, notFound(err)
+	}
+
+	return income, nil
+}
+
+func (is *incomeService) GetManyBelongedToUser(userID int, period util.Period, itemPerPage, page int) ([]model.Income, error) {
+	incomes, err := is.ir.GetManyBelongedToUser(uint(userID), period, itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return incomes, nil
+}
+
+func (is *incomeService) GetManyBelongedToAccount(userID, accountID int, period util.Period, itemPerPage, page int) ([]model.Income, error) {
+	incomes, err := is.ir.GetManyBelongedToAccount(uint(userID), uint(accountID), period, itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return incomes, nil
+}
+
+func (is *incomeService) UpdateOneByID(userID, id int, payload dto.UpdateIncomeDTO) (model.Income, error) {
+	current, err := is.ir.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return model.Income{}, notFound(err)
+	}
+	amount, err := parsePositiveAmount(payload.Amount, accountCurrency(current.Account))
+	if err != nil {
+		return model.Income{}, err
+	}
+	occurredAt, timezone, err := resolveOccurredAt(payload.OccurredAt, payload.Timezone, current.OccurredAt)
+	if err != nil {
+		return model.Income{}, err
+	}
+	if payload.OccurredAt == nil && payload.Timezone == "" {
+		timezone = current.Timezone
+	}
+
+	income, err := is.ir.UpdateOneByID(uint(userID), uint(id), payload.Name, payload.Description, amount, occurredAt, timezone)
+	if err != nil {
+		return model.Income{}, notFound(err)
+	}
+	income.Account = current.Account
+
+	return income, nil
+}
+
+func (is *incomeService) DeleteOneByID(userID, id int) error {
+	if err := is.ir.DeleteOneByID(uint(userID), uint(id)); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"github.com/muhrizqiardi/spendtracker/tests/testutil"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestIncomeService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mir := mock_repository.NewMockIncomeRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	is := NewIncomeService(mir, mas)
+	usd := model.Currency{Code: "USD", MinorUnit: 2}
+
+	t.Run("should return error when account belongs to another user", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(func(userID, id int) (model.Account, error) {
+			return model.Account{}, ErrNotFound
+		})
+		if _, err := is.Create(1, 2, dto.CreateIncomeDTO{
+			Name:   "Salary",
+			Amount: "50000.00",
+		}); !errors.Is(err, ErrNotFound) {
+			t.Error("exp ErrNotFound; got", err)
+		}
+	})
+	t.Run("should return error when repository call returns error", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(func(userID, id int) (model.Account, error) {
+			return model.Account{
+				UserID:   uint(1),
+				Currency: &usd,
+			}, nil
+		})
+		mir.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq("Salary"), gomock.Eq("October"), gomock.Eq(5000000), gomock.Any(), gomock.Eq("UTC")).
+			DoAndReturn(func(userID uint, accountID uint, name string, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error) {
+				return model.Income{}, errors.New("")
+			})
+		if _, err := is.Create(1, 2, dto.CreateIncomeDTO{
+			Name:        "Salary",
+			Description: "October",
+			Amount:      "50000.00",
+		}); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+	t.Run("should return new income", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(func(userID, id int) (model.Account, error) {
+			return model.Account{
+				UserID:   uint(1),
+				Currency: &usd,
+			}, nil
+		})
+		mir.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq("Salary"), gomock.Eq("October"), gomock.Eq(5000000), gomock.Any(), gomock.Eq("UTC")).
+			DoAndReturn(func(userID uint, accountID uint, name string, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error) {
+				return model.Income{
+					UserID:      userID,
+					AccountID:   accountID,
+					Name:        name,
+					Description: description,
+					Amount:      amount,
+				}, nil
+			})
+
+		exp := model.Income{
+			UserID:      uint(1),
+			AccountID:   uint(2),
+			Name:        "Salary",
+			Description: "October",
+			Amount:      5000000,
+			Account:     &model.Account{UserID: 1, Currency: &usd},
+		}
+		got, err := is.Create(1, 2, dto.CreateIncomeDTO{
+			Name:        "Salary",
+			Description: "October",
+			Amount:      "50000.00",
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+
+		opts := []cmp.Option{
+			cmpopts.IgnoreFields(model.Income{}, "Model"),
+		}
+		testutil.CompareAndAssert(t, exp, got, opts...)
+	})
+}
+
+func TestIncomeService_GetOneByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mir := mock_repository.NewMockIncomeRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	is := NewIncomeService(mir, mas)
+
+	t.Run("should return income", func(t *testing.T) {
+		mir.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Income, error) {
+			return model.Income{
+				Model: gorm.Model{
+					ID: id,
+				},
+			}, nil
+		})
+
+		got, err := is.GetOneByID(1, 1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Model.ID != uint(1) {
+			t.Error("exp 1; got", got.Model.ID)
+		}
+	})
+}
+
+func TestIncomeService_GetManyBelongedToAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mir := mock_repository.NewMockIncomeRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	is := NewIncomeService(mir, mas)
+
+	t.Run("should return many incomes", func(t *testing.T) {
+		mir.EXPECT().GetManyBelongedToAccount(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(util.Period{}), gomock.Eq(10), gomock.Eq(20)).DoAndReturn(func(userID uint, accountID uint, period util.Period, limit, offset int) ([]model.Income, error) {
+			return []model.Income{
+				{
+					Name:   "Salary",
+					Amount: 5000000,
+				},
+				{
+					Name:   "Refund",
+					Amount: 25000,
+				},
+			}, nil
+		})
+
+		got, err := is.GetManyBelongedToAccount(1, 2, util.Period{}, 10, 3)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Error("exp 2; got", len(got))
+		}
+	})
+}
+
+func TestIncomeService_UpdateOneByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mir := mock_repository.NewMockIncomeRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	is := NewIncomeService(mir, mas)
+
+	occurredAt := time.Date(2023, time.October, 25, 2, 0, 0, 0, time.UTC)
+	account := &model.Account{UserID: 1, Currency: &model.Currency{Code: "IDR", MinorUnit: 2}}
+
+	t.Run("should keep occurrence time and timezone when omitted", func(t *testing.T) {
+		mir.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) (model.Income, error) {
+			return model.Income{
+				Model:      gorm.Model{ID: id},
+				OccurredAt: occurredAt,
+				Timezone:   "Asia/Jakarta",
+				Account:    account,
+			}, nil
+		})
+		mir.EXPECT().UpdateOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1)), gomock.Eq("Salary"), gomock.Eq("November"), gomock.Eq(5500000), gomock.Eq(occurredAt), gomock.Eq("Asia/Jakarta")).
+			DoAndReturn(func(userID, id uint, name string, description string, amount int, occurredAt time.Time, timezone string) (model.Income, error) {
+				return model.Income{
+					Model: gorm.Model{
+						ID: id,
+					},
+					Name:        name,
+					Description: description,
+					Amount:      amount,
+					OccurredAt:  occurredAt,
+					Timezone:    timezone,
+				}, nil
+			})
+
+		exp := model.Income{
+			Model: gorm.Model{
+				ID: uint(1),
+			},
+			Name:        "Salary",
+			Description: "November",
+			Amount:      5500000,
+			OccurredAt:  occurredAt,
+			Timezone:    "Asia/Jakarta",
+			Account:     account,
+		}
+		got, err := is.UpdateOneByID(1, 1, dto.UpdateIncomeDTO{
+			Name:        "Salary",
+			Description: "November",
+			Amount:      "55000.00",
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+
+		testutil.CompareAndAssert(t, exp, got)
+	})
+}
+
+func TestIncomeService_DeleteOneByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mir := mock_repository.NewMockIncomeRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	is := NewIncomeService(mir, mas)
+
+	t.Run("should return error when repository returns error", func(t *testing.T) {
+		mir.EXPECT().DeleteOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) error {
+			return errors.New("")
+		})
+
+		if err := is.DeleteOneByID(1, 1); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+	t.Run("should delete and return nil", func(t *testing.T) {
+		mir.EXPECT().DeleteOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).DoAndReturn(func(userID, id uint) error {
+			return nil
+		})
+
+		if err := is.DeleteOneByID(1, 1); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
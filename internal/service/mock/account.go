@@ -6,6 +6,7 @@ package mock_service
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
 	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
@@ -51,60 +52,90 @@ func (mr *MockAccountServiceMockRecorder) Create(userID, payload interface{}) *g
 }
 
 // DeleteOneByID mocks base method.
-func (m *MockAccountService) DeleteOneByID(id int) error {
+func (m *MockAccountService) DeleteOneByID(userID, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOneByID", id)
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteOneByID indicates an expected call of DeleteOneByID.
-func (mr *MockAccountServiceMockRecorder) DeleteOneByID(id interface{}) *gomock.Call {
+func (mr *MockAccountServiceMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockAccountService)(nil).DeleteOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockAccountService)(nil).DeleteOneByID), userID, id)
+}
+
+// GetBalance mocks base method.
+func (m *MockAccountService) GetBalance(userID, id int, at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", userID, id, at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockAccountServiceMockRecorder) GetBalance(userID, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockAccountService)(nil).GetBalance), userID, id, at)
+}
+
+// GetBalances mocks base method.
+func (m *MockAccountService) GetBalances(accounts []model.Account, at time.Time) (map[uint]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", accounts, at)
+	ret0, _ := ret[0].(map[uint]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockAccountServiceMockRecorder) GetBalances(accounts, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockAccountService)(nil).GetBalances), accounts, at)
 }
 
 // GetMany mocks base method.
-func (m *MockAccountService) GetMany(userID, itemPerPage, page int) ([]model.Account, error) {
+func (m *MockAccountService) GetMany(userID, itemPerPage int, cursor string) (model.Page[model.Account], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMany", userID, itemPerPage, page)
-	ret0, _ := ret[0].([]model.Account)
+	ret := m.ctrl.Call(m, "GetMany", userID, itemPerPage, cursor)
+	ret0, _ := ret[0].(model.Page[model.Account])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMany indicates an expected call of GetMany.
-func (mr *MockAccountServiceMockRecorder) GetMany(userID, itemPerPage, page interface{}) *gomock.Call {
+func (mr *MockAccountServiceMockRecorder) GetMany(userID, itemPerPage, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockAccountService)(nil).GetMany), userID, itemPerPage, page)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockAccountService)(nil).GetMany), userID, itemPerPage, cursor)
 }
 
 // GetOneByID mocks base method.
-func (m *MockAccountService) GetOneByID(id int) (model.Account, error) {
+func (m *MockAccountService) GetOneByID(userID, id int) (model.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOneByID", id)
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
 	ret0, _ := ret[0].(model.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOneByID indicates an expected call of GetOneByID.
-func (mr *MockAccountServiceMockRecorder) GetOneByID(id interface{}) *gomock.Call {
+func (mr *MockAccountServiceMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockAccountService)(nil).GetOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockAccountService)(nil).GetOneByID), userID, id)
 }
 
 // UpdateOneByID mocks base method.
-func (m *MockAccountService) UpdateOneByID(id int, payload dto.UpdateAccountDTO) (model.Account, error) {
+func (m *MockAccountService) UpdateOneByID(userID, id int, payload dto.UpdateAccountDTO) (model.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOneByID", id, payload)
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, payload)
 	ret0, _ := ret[0].(model.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateOneByID indicates an expected call of UpdateOneByID.
-func (mr *MockAccountServiceMockRecorder) UpdateOneByID(id, payload interface{}) *gomock.Call {
+func (mr *MockAccountServiceMockRecorder) UpdateOneByID(userID, id, payload interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockAccountService)(nil).UpdateOneByID), id, payload)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockAccountService)(nil).UpdateOneByID), userID, id, payload)
 }
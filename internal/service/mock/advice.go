@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/advice.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdviceService is a mock of AdviceService interface.
+type MockAdviceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdviceServiceMockRecorder
+}
+
+// MockAdviceServiceMockRecorder is the mock recorder for MockAdviceService.
+type MockAdviceServiceMockRecorder struct {
+	mock *MockAdviceService
+}
+
+// NewMockAdviceService creates a new mock instance.
+func NewMockAdviceService(ctrl *gomock.Controller) *MockAdviceService {
+	mock := &MockAdviceService{ctrl: ctrl}
+	mock.recorder = &MockAdviceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdviceService) EXPECT() *MockAdviceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAdvice mocks base method.
+func (m *MockAdviceService) GetAdvice(userID int, payload dto.AdviceDTO) (model.Advice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdvice", userID, payload)
+	ret0, _ := ret[0].(model.Advice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdvice indicates an expected call of GetAdvice.
+func (mr *MockAdviceServiceMockRecorder) GetAdvice(userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdvice", reflect.TypeOf((*MockAdviceService)(nil).GetAdvice), userID, payload)
+}
+
+// GetHistory mocks base method.
+func (m *MockAdviceService) GetHistory(userID, itemPerPage int, cursor string) (model.Page[model.Advice], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", userID, itemPerPage, cursor)
+	ret0, _ := ret[0].(model.Page[model.Advice])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockAdviceServiceMockRecorder) GetHistory(userID, itemPerPage, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockAdviceService)(nil).GetHistory), userID, itemPerPage, cursor)
+}
+
+// StreamAdvice mocks base method.
+func (m *MockAdviceService) StreamAdvice(ctx context.Context, userID int, payload dto.AdviceDTO, onToken func(string) error) (model.Advice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamAdvice", ctx, userID, payload, onToken)
+	ret0, _ := ret[0].(model.Advice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamAdvice indicates an expected call of StreamAdvice.
+func (mr *MockAdviceServiceMockRecorder) StreamAdvice(ctx, userID, payload, onToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamAdvice", reflect.TypeOf((*MockAdviceService)(nil).StreamAdvice), ctx, userID, payload, onToken)
+}
@@ -7,6 +7,7 @@ package mock_service
 import (
 	reflect "reflect"
 
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
 	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -34,11 +35,26 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// GetActiveSession mocks base method.
+func (m *MockAuthService) GetActiveSession(sessionID uint) (model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSession", sessionID)
+	ret0, _ := ret[0].(model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSession indicates an expected call of GetActiveSession.
+func (mr *MockAuthServiceMockRecorder) GetActiveSession(sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSession", reflect.TypeOf((*MockAuthService)(nil).GetActiveSession), sessionID)
+}
+
 // LogIn mocks base method.
-func (m *MockAuthService) LogIn(payload dto.LogInDTO) (string, error) {
+func (m *MockAuthService) LogIn(payload dto.LogInDTO) (model.AuthTokens, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "LogIn", payload)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(model.AuthTokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -48,3 +64,47 @@ func (mr *MockAuthServiceMockRecorder) LogIn(payload interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogIn", reflect.TypeOf((*MockAuthService)(nil).LogIn), payload)
 }
+
+// LogInTwoFactor mocks base method.
+func (m *MockAuthService) LogInTwoFactor(payload dto.LogInTwoFactorDTO) (model.AuthTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogInTwoFactor", payload)
+	ret0, _ := ret[0].(model.AuthTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogInTwoFactor indicates an expected call of LogInTwoFactor.
+func (mr *MockAuthServiceMockRecorder) LogInTwoFactor(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogInTwoFactor", reflect.TypeOf((*MockAuthService)(nil).LogInTwoFactor), payload)
+}
+
+// LogOut mocks base method.
+func (m *MockAuthService) LogOut(sessionID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogOut", sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogOut indicates an expected call of LogOut.
+func (mr *MockAuthServiceMockRecorder) LogOut(sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogOut", reflect.TypeOf((*MockAuthService)(nil).LogOut), sessionID)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthService) Refresh(payload dto.RefreshTokenDTO) (model.AuthTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", payload)
+	ret0, _ := ret[0].(model.AuthTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthServiceMockRecorder) Refresh(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthService)(nil).Refresh), payload)
+}
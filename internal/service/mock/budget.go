@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/budget.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
+	util "github.com/muhrizqiardi/spendtracker/internal/util"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBudgetService is a mock of BudgetService interface.
+type MockBudgetService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetServiceMockRecorder
+}
+
+// MockBudgetServiceMockRecorder is the mock recorder for MockBudgetService.
+type MockBudgetServiceMockRecorder struct {
+	mock *MockBudgetService
+}
+
+// NewMockBudgetService creates a new mock instance.
+func NewMockBudgetService(ctrl *gomock.Controller) *MockBudgetService {
+	mock := &MockBudgetService{ctrl: ctrl}
+	mock.recorder = &MockBudgetServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetService) EXPECT() *MockBudgetServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBudgetService) Create(userID int, payload dto.CreateBudgetDTO) (model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userID, payload)
+	ret0, _ := ret[0].(model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBudgetServiceMockRecorder) Create(userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBudgetService)(nil).Create), userID, payload)
+}
+
+// DeleteOneByID mocks base method.
+func (m *MockBudgetService) DeleteOneByID(userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneByID indicates an expected call of DeleteOneByID.
+func (mr *MockBudgetServiceMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockBudgetService)(nil).DeleteOneByID), userID, id)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockBudgetService) GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, itemPerPage, page)
+	ret0, _ := ret[0].([]model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockBudgetServiceMockRecorder) GetManyBelongedToUser(userID, itemPerPage, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockBudgetService)(nil).GetManyBelongedToUser), userID, itemPerPage, page)
+}
+
+// GetOneByID mocks base method.
+func (m *MockBudgetService) GetOneByID(userID, id int) (model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
+	ret0, _ := ret[0].(model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockBudgetServiceMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockBudgetService)(nil).GetOneByID), userID, id)
+}
+
+// GetStatus mocks base method.
+func (m *MockBudgetService) GetStatus(userID, id int, period util.Period) (model.BudgetStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", userID, id, period)
+	ret0, _ := ret[0].(model.BudgetStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockBudgetServiceMockRecorder) GetStatus(userID, id, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockBudgetService)(nil).GetStatus), userID, id, period)
+}
+
+// UpdateOneByID mocks base method.
+func (m *MockBudgetService) UpdateOneByID(userID, id int, payload dto.UpdateBudgetDTO) (model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, payload)
+	ret0, _ := ret[0].(model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOneByID indicates an expected call of UpdateOneByID.
+func (mr *MockBudgetServiceMockRecorder) UpdateOneByID(userID, id, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockBudgetService)(nil).UpdateOneByID), userID, id, payload)
+}
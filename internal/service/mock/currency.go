@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/currency.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	io "io"
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
+	util "github.com/muhrizqiardi/spendtracker/internal/util"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCurrencyService is a mock of CurrencyService interface.
+type MockCurrencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyServiceMockRecorder
+}
+
+// MockCurrencyServiceMockRecorder is the mock recorder for MockCurrencyService.
+type MockCurrencyServiceMockRecorder struct {
+	mock *MockCurrencyService
+}
+
+// NewMockCurrencyService creates a new mock instance.
+func NewMockCurrencyService(ctrl *gomock.Controller) *MockCurrencyService {
+	mock := &MockCurrencyService{ctrl: ctrl}
+	mock.recorder = &MockCurrencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyService) EXPECT() *MockCurrencyServiceMockRecorder {
+	return m.recorder
+}
+
+// Convert mocks base method.
+func (m *MockCurrencyService) Convert(userID int, rows []model.ExpenseStatsRow) (model.Currency, []model.ExpenseStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", userID, rows)
+	ret0, _ := ret[0].(model.Currency)
+	ret1, _ := ret[1].([]model.ExpenseStatsRow)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockCurrencyServiceMockRecorder) Convert(userID, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockCurrencyService)(nil).Convert), userID, rows)
+}
+
+// ConvertTo mocks base method.
+func (m *MockCurrencyService) ConvertTo(userID int, rows []model.ExpenseStatsRow, currency model.Currency) ([]model.ExpenseStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertTo", userID, rows, currency)
+	ret0, _ := ret[0].([]model.ExpenseStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertTo indicates an expected call of ConvertTo.
+func (mr *MockCurrencyServiceMockRecorder) ConvertTo(userID, rows, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertTo", reflect.TypeOf((*MockCurrencyService)(nil).ConvertTo), userID, rows, currency)
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockCurrencyService) GetExchangeRates(userID int, base, quote string, period util.Period, itemPerPage, page int) ([]model.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", userID, base, quote, period, itemPerPage, page)
+	ret0, _ := ret[0].([]model.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockCurrencyServiceMockRecorder) GetExchangeRates(userID, base, quote, period, itemPerPage, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockCurrencyService)(nil).GetExchangeRates), userID, base, quote, period, itemPerPage, page)
+}
+
+// GetOneByCode mocks base method.
+func (m *MockCurrencyService) GetOneByCode(code string) (model.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByCode", code)
+	ret0, _ := ret[0].(model.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByCode indicates an expected call of GetOneByCode.
+func (mr *MockCurrencyServiceMockRecorder) GetOneByCode(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByCode", reflect.TypeOf((*MockCurrencyService)(nil).GetOneByCode), code)
+}
+
+// GetOneByID mocks base method.
+func (m *MockCurrencyService) GetOneByID(id int) (model.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", id)
+	ret0, _ := ret[0].(model.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockCurrencyServiceMockRecorder) GetOneByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockCurrencyService)(nil).GetOneByID), id)
+}
+
+// GetReportingCurrency mocks base method.
+func (m *MockCurrencyService) GetReportingCurrency(userID int) (*model.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportingCurrency", userID)
+	ret0, _ := ret[0].(*model.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportingCurrency indicates an expected call of GetReportingCurrency.
+func (mr *MockCurrencyServiceMockRecorder) GetReportingCurrency(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportingCurrency", reflect.TypeOf((*MockCurrencyService)(nil).GetReportingCurrency), userID)
+}
+
+// ImportExchangeRates mocks base method.
+func (m *MockCurrencyService) ImportExchangeRates(userID int, r io.Reader, base string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportExchangeRates", userID, r, base)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportExchangeRates indicates an expected call of ImportExchangeRates.
+func (mr *MockCurrencyServiceMockRecorder) ImportExchangeRates(userID, r, base interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportExchangeRates", reflect.TypeOf((*MockCurrencyService)(nil).ImportExchangeRates), userID, r, base)
+}
+
+// SetReportingCurrency mocks base method.
+func (m *MockCurrencyService) SetReportingCurrency(userID int, payload dto.SetReportingCurrencyDTO) (*model.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReportingCurrency", userID, payload)
+	ret0, _ := ret[0].(*model.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReportingCurrency indicates an expected call of SetReportingCurrency.
+func (mr *MockCurrencyServiceMockRecorder) SetReportingCurrency(userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReportingCurrency", reflect.TypeOf((*MockCurrencyService)(nil).SetReportingCurrency), userID, payload)
+}
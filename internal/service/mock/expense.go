@@ -51,17 +51,17 @@ func (mr *MockExpenseServiceMockRecorder) Create(userID, accountID, payload inte
 }
 
 // DeleteOneByID mocks base method.
-func (m *MockExpenseService) DeleteOneByID(id int) error {
+func (m *MockExpenseService) DeleteOneByID(userID, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOneByID", id)
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteOneByID indicates an expected call of DeleteOneByID.
-func (mr *MockExpenseServiceMockRecorder) DeleteOneByID(id interface{}) *gomock.Call {
+func (mr *MockExpenseServiceMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockExpenseService)(nil).DeleteOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockExpenseService)(nil).DeleteOneByID), userID, id)
 }
 
 // GetMany mocks base method.
@@ -79,92 +79,47 @@ func (mr *MockExpenseServiceMockRecorder) GetMany(itemPerPage, page interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockExpenseService)(nil).GetMany), itemPerPage, page)
 }
 
-// GetManyBelongedToAccount mocks base method.
-func (m *MockExpenseService) GetManyBelongedToAccount(userID, accountID, itemPerPage, page int) ([]model.Expense, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManyBelongedToAccount", userID, accountID, itemPerPage, page)
-	ret0, _ := ret[0].([]model.Expense)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetManyBelongedToAccount indicates an expected call of GetManyBelongedToAccount.
-func (mr *MockExpenseServiceMockRecorder) GetManyBelongedToAccount(userID, accountID, itemPerPage, page interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToAccount", reflect.TypeOf((*MockExpenseService)(nil).GetManyBelongedToAccount), userID, accountID, itemPerPage, page)
-}
-
-// GetManyBelongedToCategory mocks base method.
-func (m *MockExpenseService) GetManyBelongedToCategory(userID, categoryID, itemPerPage, page int) ([]model.Expense, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManyBelongedToCategory", userID, categoryID, itemPerPage, page)
-	ret0, _ := ret[0].([]model.Expense)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetManyBelongedToCategory indicates an expected call of GetManyBelongedToCategory.
-func (mr *MockExpenseServiceMockRecorder) GetManyBelongedToCategory(userID, categoryID, itemPerPage, page interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToCategory", reflect.TypeOf((*MockExpenseService)(nil).GetManyBelongedToCategory), userID, categoryID, itemPerPage, page)
-}
-
-// GetManyBelongedToCategoryAccount mocks base method.
-func (m *MockExpenseService) GetManyBelongedToCategoryAccount(userID, categoryID, accountID, itemPerPage, page int) ([]model.Expense, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManyBelongedToCategoryAccount", userID, categoryID, accountID, itemPerPage, page)
-	ret0, _ := ret[0].([]model.Expense)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetManyBelongedToCategoryAccount indicates an expected call of GetManyBelongedToCategoryAccount.
-func (mr *MockExpenseServiceMockRecorder) GetManyBelongedToCategoryAccount(userID, categoryID, accountID, itemPerPage, page interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToCategoryAccount", reflect.TypeOf((*MockExpenseService)(nil).GetManyBelongedToCategoryAccount), userID, categoryID, accountID, itemPerPage, page)
-}
-
 // GetManyBelongedToUser mocks base method.
-func (m *MockExpenseService) GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.Expense, error) {
+func (m *MockExpenseService) GetManyBelongedToUser(userID int, filter dto.ExpenseFilterDTO, itemPerPage int, cursor string) (model.Page[model.Expense], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, itemPerPage, page)
-	ret0, _ := ret[0].([]model.Expense)
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, filter, itemPerPage, cursor)
+	ret0, _ := ret[0].(model.Page[model.Expense])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
-func (mr *MockExpenseServiceMockRecorder) GetManyBelongedToUser(userID, itemPerPage, page interface{}) *gomock.Call {
+func (mr *MockExpenseServiceMockRecorder) GetManyBelongedToUser(userID, filter, itemPerPage, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockExpenseService)(nil).GetManyBelongedToUser), userID, itemPerPage, page)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockExpenseService)(nil).GetManyBelongedToUser), userID, filter, itemPerPage, cursor)
 }
 
 // GetOneByID mocks base method.
-func (m *MockExpenseService) GetOneByID(id int) (model.Expense, error) {
+func (m *MockExpenseService) GetOneByID(userID, id int) (model.Expense, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOneByID", id)
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
 	ret0, _ := ret[0].(model.Expense)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOneByID indicates an expected call of GetOneByID.
-func (mr *MockExpenseServiceMockRecorder) GetOneByID(id interface{}) *gomock.Call {
+func (mr *MockExpenseServiceMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockExpenseService)(nil).GetOneByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockExpenseService)(nil).GetOneByID), userID, id)
 }
 
 // UpdateOneByID mocks base method.
-func (m *MockExpenseService) UpdateOneByID(id int, payload dto.UpdateExpenseDTO) (model.Expense, error) {
+func (m *MockExpenseService) UpdateOneByID(userID, id int, payload dto.UpdateExpenseDTO) (model.Expense, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOneByID", id, payload)
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, payload)
 	ret0, _ := ret[0].(model.Expense)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateOneByID indicates an expected call of UpdateOneByID.
-func (mr *MockExpenseServiceMockRecorder) UpdateOneByID(id, payload interface{}) *gomock.Call {
+func (mr *MockExpenseServiceMockRecorder) UpdateOneByID(userID, id, payload interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockExpenseService)(nil).UpdateOneByID), id, payload)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockExpenseService)(nil).UpdateOneByID), userID, id, payload)
 }
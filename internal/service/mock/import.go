@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/import.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	io "io"
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportService is a mock of ImportService interface.
+type MockImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportServiceMockRecorder
+}
+
+// MockImportServiceMockRecorder is the mock recorder for MockImportService.
+type MockImportServiceMockRecorder struct {
+	mock *MockImportService
+}
+
+// NewMockImportService creates a new mock instance.
+func NewMockImportService(ctrl *gomock.Controller) *MockImportService {
+	mock := &MockImportService{ctrl: ctrl}
+	mock.recorder = &MockImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportService) EXPECT() *MockImportServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateMapping mocks base method.
+func (m *MockImportService) CreateMapping(userID int, payload dto.CreateImportMappingDTO) (model.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMapping", userID, payload)
+	ret0, _ := ret[0].(model.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMapping indicates an expected call of CreateMapping.
+func (mr *MockImportServiceMockRecorder) CreateMapping(userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMapping", reflect.TypeOf((*MockImportService)(nil).CreateMapping), userID, payload)
+}
+
+// DeleteMapping mocks base method.
+func (m *MockImportService) DeleteMapping(userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMapping", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMapping indicates an expected call of DeleteMapping.
+func (mr *MockImportServiceMockRecorder) DeleteMapping(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMapping", reflect.TypeOf((*MockImportService)(nil).DeleteMapping), userID, id)
+}
+
+// GetMappings mocks base method.
+func (m *MockImportService) GetMappings(userID int) ([]model.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMappings", userID)
+	ret0, _ := ret[0].([]model.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMappings indicates an expected call of GetMappings.
+func (mr *MockImportServiceMockRecorder) GetMappings(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMappings", reflect.TypeOf((*MockImportService)(nil).GetMappings), userID)
+}
+
+// ImportCSV mocks base method.
+func (m *MockImportService) ImportCSV(userID, accountID int, payload dto.ImportCSVDTO, statement io.Reader) (model.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCSV", userID, accountID, payload, statement)
+	ret0, _ := ret[0].(model.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCSV indicates an expected call of ImportCSV.
+func (mr *MockImportServiceMockRecorder) ImportCSV(userID, accountID, payload, statement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCSV", reflect.TypeOf((*MockImportService)(nil).ImportCSV), userID, accountID, payload, statement)
+}
+
+// ImportOFX mocks base method.
+func (m *MockImportService) ImportOFX(userID, accountID int, payload dto.ImportOFXDTO, statement io.Reader) (model.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportOFX", userID, accountID, payload, statement)
+	ret0, _ := ret[0].(model.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportOFX indicates an expected call of ImportOFX.
+func (mr *MockImportServiceMockRecorder) ImportOFX(userID, accountID, payload, statement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportOFX", reflect.TypeOf((*MockImportService)(nil).ImportOFX), userID, accountID, payload, statement)
+}
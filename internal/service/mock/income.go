@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/income.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
+	util "github.com/muhrizqiardi/spendtracker/internal/util"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIncomeService is a mock of IncomeService interface.
+type MockIncomeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncomeServiceMockRecorder
+}
+
+// MockIncomeServiceMockRecorder is the mock recorder for MockIncomeService.
+type MockIncomeServiceMockRecorder struct {
+	mock *MockIncomeService
+}
+
+// NewMockIncomeService creates a new mock instance.
+func NewMockIncomeService(ctrl *gomock.Controller) *MockIncomeService {
+	mock := &MockIncomeService{ctrl: ctrl}
+	mock.recorder = &MockIncomeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncomeService) EXPECT() *MockIncomeServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIncomeService) Create(userID, accountID int, payload dto.CreateIncomeDTO) (model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userID, accountID, payload)
+	ret0, _ := ret[0].(model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIncomeServiceMockRecorder) Create(userID, accountID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIncomeService)(nil).Create), userID, accountID, payload)
+}
+
+// DeleteOneByID mocks base method.
+func (m *MockIncomeService) DeleteOneByID(userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneByID indicates an expected call of DeleteOneByID.
+func (mr *MockIncomeServiceMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockIncomeService)(nil).DeleteOneByID), userID, id)
+}
+
+// GetManyBelongedToAccount mocks base method.
+func (m *MockIncomeService) GetManyBelongedToAccount(userID, accountID int, period util.Period, itemPerPage, page int) ([]model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToAccount", userID, accountID, period, itemPerPage, page)
+	ret0, _ := ret[0].([]model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToAccount indicates an expected call of GetManyBelongedToAccount.
+func (mr *MockIncomeServiceMockRecorder) GetManyBelongedToAccount(userID, accountID, period, itemPerPage, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToAccount", reflect.TypeOf((*MockIncomeService)(nil).GetManyBelongedToAccount), userID, accountID, period, itemPerPage, page)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockIncomeService) GetManyBelongedToUser(userID int, period util.Period, itemPerPage, page int) ([]model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, period, itemPerPage, page)
+	ret0, _ := ret[0].([]model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockIncomeServiceMockRecorder) GetManyBelongedToUser(userID, period, itemPerPage, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockIncomeService)(nil).GetManyBelongedToUser), userID, period, itemPerPage, page)
+}
+
+// GetOneByID mocks base method.
+func (m *MockIncomeService) GetOneByID(userID, id int) (model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
+	ret0, _ := ret[0].(model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockIncomeServiceMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockIncomeService)(nil).GetOneByID), userID, id)
+}
+
+// UpdateOneByID mocks base method.
+func (m *MockIncomeService) UpdateOneByID(userID, id int, payload dto.UpdateIncomeDTO) (model.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, payload)
+	ret0, _ := ret[0].(model.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOneByID indicates an expected call of UpdateOneByID.
+func (mr *MockIncomeServiceMockRecorder) UpdateOneByID(userID, id, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockIncomeService)(nil).UpdateOneByID), userID, id, payload)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/recurring.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecurringService is a mock of RecurringService interface.
+type MockRecurringService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurringServiceMockRecorder
+}
+
+// MockRecurringServiceMockRecorder is the mock recorder for MockRecurringService.
+type MockRecurringServiceMockRecorder struct {
+	mock *MockRecurringService
+}
+
+// NewMockRecurringService creates a new mock instance.
+func NewMockRecurringService(ctrl *gomock.Controller) *MockRecurringService {
+	mock := &MockRecurringService{ctrl: ctrl}
+	mock.recorder = &MockRecurringServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurringService) EXPECT() *MockRecurringServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRecurringService) Create(userID int, payload dto.CreateRecurringTemplateDTO) (model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userID, payload)
+	ret0, _ := ret[0].(model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRecurringServiceMockRecorder) Create(userID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRecurringService)(nil).Create), userID, payload)
+}
+
+// DeleteOneByID mocks base method.
+func (m *MockRecurringService) DeleteOneByID(userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOneByID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOneByID indicates an expected call of DeleteOneByID.
+func (mr *MockRecurringServiceMockRecorder) DeleteOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOneByID", reflect.TypeOf((*MockRecurringService)(nil).DeleteOneByID), userID, id)
+}
+
+// GetManyBelongedToUser mocks base method.
+func (m *MockRecurringService) GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyBelongedToUser", userID, itemPerPage, page)
+	ret0, _ := ret[0].([]model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyBelongedToUser indicates an expected call of GetManyBelongedToUser.
+func (mr *MockRecurringServiceMockRecorder) GetManyBelongedToUser(userID, itemPerPage, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyBelongedToUser", reflect.TypeOf((*MockRecurringService)(nil).GetManyBelongedToUser), userID, itemPerPage, page)
+}
+
+// GetOneByID mocks base method.
+func (m *MockRecurringService) GetOneByID(userID, id int) (model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", userID, id)
+	ret0, _ := ret[0].(model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockRecurringServiceMockRecorder) GetOneByID(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockRecurringService)(nil).GetOneByID), userID, id)
+}
+
+// GetUpcoming mocks base method.
+func (m *MockRecurringService) GetUpcoming(userID, id, count int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcoming", userID, id, count)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcoming indicates an expected call of GetUpcoming.
+func (mr *MockRecurringServiceMockRecorder) GetUpcoming(userID, id, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcoming", reflect.TypeOf((*MockRecurringService)(nil).GetUpcoming), userID, id, count)
+}
+
+// MaterializeDue mocks base method.
+func (m *MockRecurringService) MaterializeDue(now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaterializeDue", now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaterializeDue indicates an expected call of MaterializeDue.
+func (mr *MockRecurringServiceMockRecorder) MaterializeDue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeDue", reflect.TypeOf((*MockRecurringService)(nil).MaterializeDue), now)
+}
+
+// UpdateOneByID mocks base method.
+func (m *MockRecurringService) UpdateOneByID(userID, id int, payload dto.UpdateRecurringTemplateDTO) (model.RecurringTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOneByID", userID, id, payload)
+	ret0, _ := ret[0].(model.RecurringTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOneByID indicates an expected call of UpdateOneByID.
+func (mr *MockRecurringServiceMockRecorder) UpdateOneByID(userID, id, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOneByID", reflect.TypeOf((*MockRecurringService)(nil).UpdateOneByID), userID, id, payload)
+}
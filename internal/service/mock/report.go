@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/report.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	model "github.com/muhrizqiardi/spendtracker/internal/database/model"
+	util "github.com/muhrizqiardi/spendtracker/internal/util"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportService is a mock of ReportService interface.
+type MockReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServiceMockRecorder
+}
+
+// MockReportServiceMockRecorder is the mock recorder for MockReportService.
+type MockReportServiceMockRecorder struct {
+	mock *MockReportService
+}
+
+// NewMockReportService creates a new mock instance.
+func NewMockReportService(ctrl *gomock.Controller) *MockReportService {
+	mock := &MockReportService{ctrl: ctrl}
+	mock.recorder = &MockReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportService) EXPECT() *MockReportServiceMockRecorder {
+	return m.recorder
+}
+
+// GetExpenseReport mocks base method.
+func (m *MockReportService) GetExpenseReport(userID int, groupBy string, period util.Period, timezone string) (model.ExpenseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseReport", userID, groupBy, period, timezone)
+	ret0, _ := ret[0].(model.ExpenseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseReport indicates an expected call of GetExpenseReport.
+func (mr *MockReportServiceMockRecorder) GetExpenseReport(userID, groupBy, period, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseReport", reflect.TypeOf((*MockReportService)(nil).GetExpenseReport), userID, groupBy, period, timezone)
+}
+
+// GetExpenseReportsByCurrency mocks base method.
+func (m *MockReportService) GetExpenseReportsByCurrency(userID int, groupBy string, period util.Period, timezone string) ([]model.ExpenseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseReportsByCurrency", userID, groupBy, period, timezone)
+	ret0, _ := ret[0].([]model.ExpenseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseReportsByCurrency indicates an expected call of GetExpenseReportsByCurrency.
+func (mr *MockReportServiceMockRecorder) GetExpenseReportsByCurrency(userID, groupBy, period, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseReportsByCurrency", reflect.TypeOf((*MockReportService)(nil).GetExpenseReportsByCurrency), userID, groupBy, period, timezone)
+}
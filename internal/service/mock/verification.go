@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/verification.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/muhrizqiardi/spendtracker/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVerificationService is a mock of VerificationService interface.
+type MockVerificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationServiceMockRecorder
+}
+
+// MockVerificationServiceMockRecorder is the mock recorder for MockVerificationService.
+type MockVerificationServiceMockRecorder struct {
+	mock *MockVerificationService
+}
+
+// NewMockVerificationService creates a new mock instance.
+func NewMockVerificationService(ctrl *gomock.Controller) *MockVerificationService {
+	mock := &MockVerificationService{ctrl: ctrl}
+	mock.recorder = &MockVerificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerificationService) EXPECT() *MockVerificationServiceMockRecorder {
+	return m.recorder
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockVerificationService) RequestPasswordReset(payload dto.RequestPasswordResetDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockVerificationServiceMockRecorder) RequestPasswordReset(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockVerificationService)(nil).RequestPasswordReset), payload)
+}
+
+// ResetPassword mocks base method.
+func (m *MockVerificationService) ResetPassword(payload dto.ResetPasswordDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockVerificationServiceMockRecorder) ResetPassword(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockVerificationService)(nil).ResetPassword), payload)
+}
+
+// RevokeTokens mocks base method.
+func (m *MockVerificationService) RevokeTokens(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokens", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTokens indicates an expected call of RevokeTokens.
+func (mr *MockVerificationServiceMockRecorder) RevokeTokens(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokens", reflect.TypeOf((*MockVerificationService)(nil).RevokeTokens), userID)
+}
+
+// SendEmailVerification mocks base method.
+func (m *MockVerificationService) SendEmailVerification(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailVerification", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailVerification indicates an expected call of SendEmailVerification.
+func (mr *MockVerificationServiceMockRecorder) SendEmailVerification(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailVerification", reflect.TypeOf((*MockVerificationService)(nil).SendEmailVerification), userID)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockVerificationService) VerifyEmail(payload dto.VerifyEmailDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockVerificationServiceMockRecorder) VerifyEmail(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockVerificationService)(nil).VerifyEmail), payload)
+}
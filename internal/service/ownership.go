@@ -0,0 +1,20 @@
+package service
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var ErrNotFound = errors.New("Not found")
+
+// notFound reports a missing record as ErrNotFound. Repository lookups are
+// scoped to the requesting user, so a record owned by someone else is
+// indistinguishable from one that doesn't exist.
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
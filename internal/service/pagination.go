@@ -0,0 +1,19 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+)
+
+var ErrInvalidCursor = errors.New("Invalid cursor; it may belong to a listing in another order")
+
+// invalidCursor reports a cursor the repository could not read as
+// ErrInvalidCursor.
+func invalidCursor(err error) error {
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return ErrInvalidCursor
+	}
+
+	return err
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+const (
+	maxUpcomingOccurrences = 100
+	materializeBatchSize   = 100
+)
+
+type RecurringService interface {
+	Create(userID int, payload dto.CreateRecurringTemplateDTO) (model.RecurringTemplate, error)
+	GetOneByID(userID, id int) (model.RecurringTemplate, error)
+	GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.RecurringTemplate, error)
+	GetUpcoming(userID, id, count int) ([]time.Time, error)
+	UpdateOneByID(userID, id int, payload dto.UpdateRecurringTemplateDTO) (model.RecurringTemplate, error)
+	DeleteOneByID(userID, id int) error
+	MaterializeDue(now time.Time) (int, error)
+}
+
+type recurringService struct {
+	rr repository.RecurringRepository
+	as AccountService
+	cs CategoryService
+	lg util.Logger
+}
+
+func NewRecurringService(rr repository.RecurringRepository, as AccountService, cs CategoryService, lg util.Logger) *recurringService {
+	return &recurringService{rr, as, cs, lg}
+}
+
+func (rs *recurringService) Create(userID int, payload dto.CreateRecurringTemplateDTO) (model.RecurringTemplate, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.RecurringTemplate{}, err
+	}
+	account, err := rs.checkOwnership(userID, payload.Type, payload.AccountID, payload.CategoryID)
+	if err != nil {
+		return model.RecurringTemplate{}, err
+	}
+	amount, err := parsePositiveAmount(payload.Amount, account.Currency)
+	if err != nil {
+		return model.RecurringTemplate{}, err
+	}
+
+	startAt, timezone, err := resolveOccurredAt(&payload.StartAt, payload.Timezone, time.Now())
+	if err != nil {
+		return model.RecurringTemplate{}, err
+	}
+	categoryID := payload.CategoryID
+	if payload.Type == model.TransactionTypeIncome {
+		categoryID = 0
+	}
+	template := model.RecurringTemplate{
+		UserID:      uint(userID),
+		Type:        payload.Type,
+		AccountID:   payload.AccountID,
+		CategoryID:  categoryID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Amount:      amount,
+		Frequency:   payload.Frequency,
+		Interval:    payload.Interval,
+		StartAt:     startAt,
+		Until:       payload.Until,
+		Count:       payload.Count,
+		Timezone:    timezone,
+	}
+	if template.Interval == 0 {
+		template.Interval = 1
+	}
+	if template.Until != nil {
+		until := template.Until.UTC()
+		template.Until = &until
+	}
+
+	template.NextAt, err = occurrenceOf(template, 0)
+	if err != nil {
+		return model.RecurringTemplate{}, err
+	}
+
+	template, err = rs.rr.Insert(template)
+	if err != nil {
+		return model.RecurringTemplate{}, err
+	}
+	template.Account = &account
+
+	return template, nil
+}
+
+func (rs *recurringService) GetOneByID(userID, id int) (model.RecurringTemplate, error) {
+	template, err := rs.rr.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return model.RecurringTemplate{}, notFound(err)
+	}
+
+	return template, nil
+}
+
+func (rs *recurringService) GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.RecurringTemplate, error) {
+	templates, err := rs.rr.GetManyBelongedToUser(uint(userID), itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// GetUpcoming lists up to count occurrences of the template that have not
+// been recorded yet, earliest first.
+func (rs *recurringService) GetUpcoming(userID, id, count int) ([]time.Time, error) {
+	template, err := rs.rr.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if count > maxUpcomingOccurrences {
+		count = maxUpcomingOccurrences
+	}
+
+	occurrences := make([]time.Time, 0, count)
+	for n := template.Materialized; len(occurrences) < count; n++ {
+		occurrence, err := occurrenceOf(template, n)
+		if err != nil {
+			return nil, err
+		}
+		if occurrence == nil {
+			break
+		}
+		occurrences = append(occurrences, *occurrence)
+	}
+
+	return occurrences, nil
+}
+
+func (rs *recurringService) UpdateOneByID(userID, id int, payload dto.UpdateRecurringTemplateDTO) (model.RecurringTemplate, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.RecurringTemplate{}, err
+	}
+	current, err := rs.rr.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return model.RecurringTemplate{}, notFound(err)
+	}
+	account, err := rs.checkOwnership(userID, current.Type, payload.AccountID, payload.CategoryID)
+	if err != nil {
+		return model.RecurringTemplate{}, err
+	}
+	amount, err := parsePositiveAmount(payload.Amount, account.Currency)
+	if err != nil {
+		return model.RecurringTemplate{}, err
+	}
+
+	categoryID := payload.CategoryID
+	if current.Type == model.TransactionTypeIncome {
+		categoryID = 0
+	}
+	template, err := rs.rr.UpdateOneByID(uint(userID), uint(id), payload.AccountID, categoryID, payload.Name, payload.Description, amount)
+	if err != nil {
+		return model.RecurringTemplate{}, notFound(err)
+	}
+	template.Account = &account
+
+	return template, nil
+}
+
+func (rs *recurringService) DeleteOneByID(userID, id int) error {
+	if err := rs.rr.DeleteOneByID(uint(userID), uint(id)); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// MaterializeDue records every occurrence due at now, catching up on any
+// that were missed while the server was down, and returns how many were
+// recorded. Occurrences another scheduler got to first are skipped. A
+// template that fails is logged and left until the next run so that it
+// doesn't hold up the others; the errors are returned together at the end.
+func (rs *recurringService) MaterializeDue(now time.Time) (int, error) {
+	materialized := 0
+	var failed []uint
+	var errs []error
+	for {
+		templates, err := rs.rr.GetManyDue(now, failed, materializeBatchSize)
+		if err != nil {
+			return materialized, errors.Join(append(errs, err)...)
+		}
+		if len(templates) == 0 {
+			return materialized, errors.Join(errs...)
+		}
+
+		for _, template := range templates {
+			if err := rs.materialize(template); err != nil {
+				if errors.Is(err, repository.ErrOccurrenceAlreadyMaterialized) {
+					continue
+				}
+				err = fmt.Errorf("recurring template %d: %w", template.ID, err)
+				rs.lg.Error("Failed to materialize recurring template", err)
+				failed = append(failed, template.ID)
+				errs = append(errs, err)
+				continue
+			}
+			materialized++
+		}
+	}
+}
+
+// materialize records the template's next occurrence.
+func (rs *recurringService) materialize(template model.RecurringTemplate) error {
+	next, err := occurrenceOf(template, template.Materialized+1)
+	if err != nil {
+		return err
+	}
+
+	return rs.rr.Materialize(template, next)
+}
+
+// checkOwnership returns the account, whose currency the amount is in.
+func (rs *recurringService) checkOwnership(userID int, transactionType string, accountID, categoryID uint) (model.Account, error) {
+	account, err := rs.as.GetOneByID(userID, int(accountID))
+	if err != nil {
+		return model.Account{}, ErrAccountNotBelongedToUser
+	}
+	if transactionType == model.TransactionTypeExpense {
+		if _, err := rs.cs.GetOneByID(userID, int(categoryID)); err != nil {
+			return model.Account{}, ErrCategoryNotBelongedToUser
+		}
+	}
+
+	return account, nil
+}
+
+// occurrenceOf returns the template's nth occurrence, or nil once its
+// schedule has ended.
+func occurrenceOf(template model.RecurringTemplate, n int) (*time.Time, error) {
+	loc, err := util.LoadTimezone(template.Timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	recurrence := util.Recurrence{
+		Frequency: template.Frequency,
+		Interval:  template.Interval,
+		Start:     template.StartAt,
+		Count:     template.Count,
+		Location:  loc,
+	}
+	if template.Until != nil {
+		recurrence.Until = *template.Until
+	}
+
+	occurrence, ok, err := recurrence.Occurrence(n)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &occurrence, nil
+}
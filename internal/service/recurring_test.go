@@ -0,0 +1,222 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestRecurringService_GetUpcoming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mrr := mock_repository.NewMockRecurringRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	rs := NewRecurringService(mrr, mas, mcs, util.NewLogger(zap.NewNop()))
+
+	t.Run("should clamp monthly occurrences to the end of shorter months", func(t *testing.T) {
+		mrr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).Return(model.RecurringTemplate{
+			Model:     gorm.Model{ID: 1},
+			Frequency: "monthly",
+			Interval:  1,
+			StartAt:   time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC),
+			Timezone:  "UTC",
+		}, nil)
+
+		got, err := rs.GetUpcoming(1, 1, 3)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		exp := []time.Time{
+			time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, time.March, 31, 9, 0, 0, 0, time.UTC),
+		}
+		if len(got) != len(exp) {
+			t.Fatal("exp 3; got", len(got))
+		}
+		for i := range exp {
+			if !got[i].Equal(exp[i]) {
+				t.Error("exp", exp[i], "; got", got[i])
+			}
+		}
+	})
+	t.Run("should keep local wall-clock time across DST changes", func(t *testing.T) {
+		mrr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).Return(model.RecurringTemplate{
+			Model:     gorm.Model{ID: 1},
+			Frequency: "weekly",
+			Interval:  1,
+			// 09:00 in New York, a week before DST starts on 10 March.
+			StartAt:  time.Date(2024, time.March, 3, 14, 0, 0, 0, time.UTC),
+			Timezone: "America/New_York",
+		}, nil)
+
+		got, err := rs.GetUpcoming(1, 1, 2)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		if exp := time.Date(2024, time.March, 10, 13, 0, 0, 0, time.UTC); !got[1].Equal(exp) {
+			t.Error("exp", exp, "; got", got[1])
+		}
+	})
+	t.Run("should stop at count and skip occurrences already recorded", func(t *testing.T) {
+		mrr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).Return(model.RecurringTemplate{
+			Model:        gorm.Model{ID: 1},
+			Frequency:    "daily",
+			Interval:     2,
+			StartAt:      time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Count:        4,
+			Timezone:     "UTC",
+			Materialized: 2,
+		}, nil)
+
+		got, err := rs.GetUpcoming(1, 1, 10)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		if exp := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC); !got[0].Equal(exp) {
+			t.Error("exp", exp, "; got", got[0])
+		}
+	})
+	t.Run("should stop at the end date", func(t *testing.T) {
+		until := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+		mrr.EXPECT().GetOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).Return(model.RecurringTemplate{
+			Model:     gorm.Model{ID: 1},
+			Frequency: "yearly",
+			Interval:  1,
+			StartAt:   time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			Until:     &until,
+			Timezone:  "UTC",
+		}, nil)
+
+		got, err := rs.GetUpcoming(1, 1, 10)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 3 {
+			t.Fatal("exp 3; got", len(got))
+		}
+		if exp := time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC); !got[1].Equal(exp) {
+			t.Error("exp", exp, "; got", got[1])
+		}
+	})
+	t.Run("should return ErrNotFound for another user's template", func(t *testing.T) {
+		mrr.EXPECT().GetOneByID(gomock.Eq(uint(2)), gomock.Eq(uint(1))).Return(model.RecurringTemplate{}, gorm.ErrRecordNotFound)
+
+		if _, err := rs.GetUpcoming(2, 1, 5); !errors.Is(err, ErrNotFound) {
+			t.Error("exp ErrNotFound; got", err)
+		}
+	})
+}
+
+func TestRecurringService_MaterializeDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mrr := mock_repository.NewMockRecurringRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	mcs := mock_service.NewMockCategoryService(ctrl)
+	rs := NewRecurringService(mrr, mas, mcs, util.NewLogger(zap.NewNop()))
+
+	now := time.Date(2024, time.January, 4, 12, 0, 0, 0, time.UTC)
+	template := func(materialized int) model.RecurringTemplate {
+		start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+		next := start.AddDate(0, 0, materialized)
+		return model.RecurringTemplate{
+			Model:        gorm.Model{ID: 1},
+			Type:         model.TransactionTypeExpense,
+			Frequency:    "daily",
+			Interval:     1,
+			StartAt:      start,
+			Timezone:     "UTC",
+			Materialized: materialized,
+			NextAt:       &next,
+		}
+	}
+
+	t.Run("should catch up on every missed occurrence", func(t *testing.T) {
+		gomock.InOrder(
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Any(), gomock.Any()).Return([]model.RecurringTemplate{template(0)}, nil),
+			mrr.EXPECT().Materialize(gomock.Any(), gomock.Any()).Return(nil),
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Any(), gomock.Any()).Return([]model.RecurringTemplate{template(1)}, nil),
+			mrr.EXPECT().Materialize(gomock.Any(), gomock.Any()).Return(nil),
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Any(), gomock.Any()).Return([]model.RecurringTemplate{template(2)}, nil),
+			mrr.EXPECT().Materialize(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(template model.RecurringTemplate, next *time.Time) error {
+					if exp := time.Date(2024, time.January, 4, 9, 0, 0, 0, time.UTC); next == nil || !next.Equal(exp) {
+						t.Error("exp", exp, "; got", next)
+					}
+					return nil
+				}),
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Any(), gomock.Any()).Return([]model.RecurringTemplate{template(3)}, nil),
+			mrr.EXPECT().Materialize(gomock.Any(), gomock.Any()).Return(nil),
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Any(), gomock.Any()).Return(nil, nil),
+		)
+
+		got, err := rs.MaterializeDue(now)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != 4 {
+			t.Error("exp 4; got", got)
+		}
+	})
+	t.Run("should skip occurrences already recorded elsewhere", func(t *testing.T) {
+		gomock.InOrder(
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Any(), gomock.Any()).Return([]model.RecurringTemplate{template(3)}, nil),
+			mrr.EXPECT().Materialize(gomock.Any(), gomock.Any()).Return(repository.ErrOccurrenceAlreadyMaterialized),
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Any(), gomock.Any()).Return(nil, nil),
+		)
+
+		got, err := rs.MaterializeDue(now)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != 0 {
+			t.Error("exp 0; got", got)
+		}
+	})
+	t.Run("should keep materializing other templates when one fails", func(t *testing.T) {
+		broken := template(0)
+		broken.ID = 2
+		broken.Timezone = "Nowhere/Invalid"
+		gomock.InOrder(
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Len(0), gomock.Any()).Return([]model.RecurringTemplate{broken, template(3)}, nil),
+			mrr.EXPECT().Materialize(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(template model.RecurringTemplate, next *time.Time) error {
+					if template.ID != 1 {
+						t.Error("exp template 1; got", template.ID)
+					}
+					return nil
+				}),
+			mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Eq([]uint{2}), gomock.Any()).Return(nil, nil),
+		)
+
+		got, err := rs.MaterializeDue(now)
+		if !errors.Is(err, ErrInvalidTimezone) {
+			t.Error("exp ErrInvalidTimezone; got", err)
+		}
+		if got != 1 {
+			t.Error("exp 1; got", got)
+		}
+	})
+	t.Run("should return error when repository returns error", func(t *testing.T) {
+		mrr.EXPECT().GetManyDue(gomock.Eq(now), gomock.Any(), gomock.Any()).Return(nil, errors.New(""))
+
+		if _, err := rs.MaterializeDue(now); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/gorm"
+)
+
+const (
+	GroupByDay   = "day"
+	GroupByWeek  = "week"
+	GroupByMonth = "month"
+	GroupByYear  = "year"
+)
+
+// maxReportPeriods bounds how many periods one report may be split into,
+// which is a year of days.
+const maxReportPeriods = 366
+
+var (
+	ErrInvalidReportPeriod = errors.New("Report period needs both from and to, with from before to")
+	ErrInvalidGroupBy      = errors.New("Group by must be one of day, week, month or year")
+	ErrReportTooLarge      = errors.New("Report would have too many periods; use a shorter range or a longer group by")
+)
+
+type ReportService interface {
+	GetExpenseReport(userID int, groupBy string, period util.Period, timezone string) (model.ExpenseReport, error)
+	GetExpenseReportsByCurrency(userID int, groupBy string, period util.Period, timezone string) ([]model.ExpenseReport, error)
+}
+
+type reportService struct {
+	rr   repository.ReportRepository
+	curs CurrencyService
+}
+
+func NewReportService(rr repository.ReportRepository, curs CurrencyService) *reportService {
+	return &reportService{rr, curs}
+}
+
+// GetExpenseReport summarizes the user's expenses over period, converted to
+// their reporting currency. Periods are calendar days, ISO weeks, months or
+// years in timezone; the first and last are cut to period. Without a
+// period it covers the current year.
+func (rs *reportService) GetExpenseReport(userID int, groupBy string, period util.Period, timezone string) (model.ExpenseReport, error) {
+	stats, err := rs.getExpenseStats(userID, groupBy, period, timezone)
+	if err != nil {
+		return model.ExpenseReport{}, err
+	}
+
+	// Everything is converted in one go so that rates are only loaded once.
+	currency, converted, err := rs.curs.Convert(userID, stats.rows())
+	if err != nil {
+		return model.ExpenseReport{}, err
+	}
+
+	return rs.expenseReport(userID, stats, stats.withRows(converted), currency)
+}
+
+// GetExpenseReportsByCurrency is GetExpenseReport without conversion: one
+// report for each currency the user spent in over period, by currency code.
+func (rs *reportService) GetExpenseReportsByCurrency(userID int, groupBy string, period util.Period, timezone string) ([]model.ExpenseReport, error) {
+	stats, err := rs.getExpenseStats(userID, groupBy, period, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := map[string]model.Currency{}
+	for _, r := range stats.summary {
+		currencies[r.Currency] = model.Currency{Code: r.Currency, MinorUnit: r.MinorUnit}
+	}
+	codes := make([]string, 0, len(currencies))
+	for code := range currencies {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	reports := make([]model.ExpenseReport, 0, len(codes))
+	for _, code := range codes {
+		inCurrency := stats.inCurrency(code)
+		report, err := rs.expenseReport(userID, inCurrency, inCurrency, currencies[code])
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// expenseStats is what an expense report is made of, with rows in whichever
+// currency the expenses were in until they are converted.
+type expenseStats struct {
+	period     util.Period
+	periods    []util.Period
+	timezone   string
+	groupBy    string
+	summary    []model.ExpenseStatsRow
+	byPeriod   []model.ExpenseStatsRow
+	byCategory []model.ExpenseStatsRow
+	byAccount  []model.ExpenseStatsRow
+}
+
+func (rs *reportService) getExpenseStats(userID int, groupBy string, period util.Period, timezone string) (expenseStats, error) {
+	loc, err := util.LoadTimezone(timezone)
+	if err != nil {
+		return expenseStats{}, ErrInvalidTimezone
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if groupBy == "" {
+		groupBy = GroupByMonth
+	}
+	if period.From.IsZero() && period.To.IsZero() {
+		period = util.YearOf(time.Now().In(loc))
+	}
+	if period.From.IsZero() || period.To.IsZero() || !period.From.Before(period.To) {
+		return expenseStats{}, ErrInvalidReportPeriod
+	}
+
+	stats := expenseStats{period: period, timezone: timezone, groupBy: groupBy}
+	if stats.periods, err = splitPeriod(period, groupBy, loc); err != nil {
+		return expenseStats{}, err
+	}
+	if stats.summary, err = rs.rr.GetExpenseStats(uint(userID), period); err != nil {
+		return expenseStats{}, err
+	}
+	if stats.byPeriod, err = rs.rr.GetExpenseStatsByPeriods(uint(userID), stats.periods); err != nil {
+		return expenseStats{}, err
+	}
+	if stats.byCategory, err = rs.rr.GetExpenseStatsByCategory(uint(userID), period); err != nil {
+		return expenseStats{}, err
+	}
+	if stats.byAccount, err = rs.rr.GetExpenseStatsByAccount(uint(userID), period); err != nil {
+		return expenseStats{}, err
+	}
+
+	return stats, nil
+}
+
+// rows returns every row of s, which withRows splits up again.
+func (s expenseStats) rows() []model.ExpenseStatsRow {
+	all := make([]model.ExpenseStatsRow, 0, len(s.summary)+len(s.byPeriod)+len(s.byCategory)+len(s.byAccount))
+	return append(append(append(append(all, s.summary...), s.byPeriod...), s.byCategory...), s.byAccount...)
+}
+
+// withRows returns s with rows in place of its own, such as once they are
+// converted.
+func (s expenseStats) withRows(rows []model.ExpenseStatsRow) expenseStats {
+	s.summary, rows = rows[:len(s.summary)], rows[len(s.summary):]
+	s.byPeriod, rows = rows[:len(s.byPeriod)], rows[len(s.byPeriod):]
+	s.byCategory, s.byAccount = rows[:len(s.byCategory)], rows[len(s.byCategory):]
+
+	return s
+}
+
+// inCurrency returns s with only the rows in currency.
+func (s expenseStats) inCurrency(currency string) expenseStats {
+	filter := func(rows []model.ExpenseStatsRow) []model.ExpenseStatsRow {
+		var in []model.ExpenseStatsRow
+		for _, r := range rows {
+			if r.Currency == currency {
+				in = append(in, r)
+			}
+		}
+		return in
+	}
+	s.summary = filter(s.summary)
+	s.byPeriod = filter(s.byPeriod)
+	s.byCategory = filter(s.byCategory)
+	s.byAccount = filter(s.byAccount)
+
+	return s
+}
+
+// expenseReport adds up converted, which is stats once in currency.
+func (rs *reportService) expenseReport(userID int, stats, converted expenseStats, currency model.Currency) (model.ExpenseReport, error) {
+	report := model.ExpenseReport{
+		From:       stats.period.From,
+		To:         stats.period.To,
+		Timezone:   stats.timezone,
+		GroupBy:    stats.groupBy,
+		Currency:   currency,
+		Summary:    sumExpenseStats(converted.summary),
+		Periods:    make([]model.PeriodExpenseStats, len(stats.periods)),
+		Categories: []model.CategoryExpenseStats{},
+		Accounts:   []model.AccountExpenseStats{},
+	}
+
+	if report.Summary.Count > 0 {
+		// The largest expense overall is the largest in whichever currency
+		// and day has the largest one once converted.
+		top := 0
+		for i, r := range converted.summary {
+			if r.Largest > converted.summary[top].Largest {
+				top = i
+			}
+		}
+		day, err := time.Parse(util.DateLayout, stats.summary[top].Date)
+		if err != nil {
+			return model.ExpenseReport{}, err
+		}
+		within := util.Period{From: day, To: day.AddDate(0, 0, 1)}
+		if within.From.Before(stats.period.From) {
+			within.From = stats.period.From
+		}
+		if within.To.After(stats.period.To) {
+			within.To = stats.period.To
+		}
+		largest, err := rs.rr.GetLargestExpense(uint(userID), stats.summary[top].Currency, within)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ExpenseReport{}, err
+		}
+		if err == nil {
+			report.LargestExpense = &largest
+		}
+	}
+
+	for i, p := range stats.periods {
+		report.Periods[i] = model.PeriodExpenseStats{From: p.From, To: p.To}
+	}
+	for _, g := range groupExpenseStats(converted.byPeriod) {
+		if int(g.Key) < len(report.Periods) {
+			report.Periods[g.Key].ExpenseStats = g.ExpenseStats
+		}
+	}
+	for _, g := range groupExpenseStats(converted.byCategory) {
+		report.Categories = append(report.Categories, model.CategoryExpenseStats{
+			CategoryID:   g.Key,
+			CategoryName: g.Name,
+			ExpenseStats: g.ExpenseStats,
+		})
+	}
+	for _, g := range groupExpenseStats(converted.byAccount) {
+		report.Accounts = append(report.Accounts, model.AccountExpenseStats{
+			AccountID:    g.Key,
+			AccountName:  g.Name,
+			ExpenseStats: g.ExpenseStats,
+		})
+	}
+
+	return report, nil
+}
+
+// sumExpenseStats adds up rows that are all in one currency.
+func sumExpenseStats(rows []model.ExpenseStatsRow) model.ExpenseStats {
+	var stats model.ExpenseStats
+	for _, r := range rows {
+		stats.Count += r.Count
+		stats.Total += r.Total
+		if r.Largest > stats.Largest {
+			stats.Largest = r.Largest
+		}
+	}
+	if stats.Count > 0 {
+		stats.Average = float64(stats.Total) / float64(stats.Count)
+	}
+
+	return stats
+}
+
+// groupExpenseStats adds up rows per Key, biggest total first.
+func groupExpenseStats(rows []model.ExpenseStatsRow) []model.ExpenseStatsRow {
+	byKey := map[uint][]model.ExpenseStatsRow{}
+	for _, r := range rows {
+		byKey[r.Key] = append(byKey[r.Key], r)
+	}
+
+	groups := make([]model.ExpenseStatsRow, 0, len(byKey))
+	for key, rs := range byKey {
+		groups = append(groups, model.ExpenseStatsRow{
+			Key:          key,
+			Name:         rs[0].Name,
+			ExpenseStats: sumExpenseStats(rs),
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Total != groups[j].Total {
+			return groups[i].Total > groups[j].Total
+		}
+		return groups[i].Key < groups[j].Key
+	})
+
+	return groups
+}
+
+// splitPeriod cuts period at every start of a groupBy in loc.
+func splitPeriod(period util.Period, groupBy string, loc *time.Location) ([]util.Period, error) {
+	var start func(time.Time) time.Time
+	var next func(time.Time) time.Time
+	switch groupBy {
+	case GroupByDay:
+		start = func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case GroupByWeek:
+		start = func(t time.Time) time.Time {
+			// Weeks start on Monday, as ISO 8601 has it.
+			back := (int(t.Weekday()) + 6) % 7
+			return time.Date(t.Year(), t.Month(), t.Day()-back, 0, 0, 0, 0, loc)
+		}
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case GroupByMonth:
+		start = func(t time.Time) time.Time { return util.MonthOf(t).From }
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	case GroupByYear:
+		start = func(t time.Time) time.Time { return util.YearOf(t).From }
+		next = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+	default:
+		return nil, ErrInvalidGroupBy
+	}
+
+	var periods []util.Period
+	from := period.From.In(loc)
+	for boundary := start(from); boundary.Before(period.To); boundary = next(boundary) {
+		if len(periods) == maxReportPeriods {
+			return nil, ErrReportTooLarge
+		}
+		p := util.Period{From: boundary, To: next(boundary)}
+		if p.From.Before(period.From) {
+			p.From = period.From
+		}
+		if p.To.After(period.To) {
+			p.To = period.To
+		}
+		periods = append(periods, p)
+	}
+
+	return periods, nil
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestReportService_GetExpenseReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mrr := mock_repository.NewMockReportRepository(ctrl)
+	mcur := mock_service.NewMockCurrencyService(ctrl)
+	rs := NewReportService(mrr, mcur)
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	october := util.Period{
+		From: time.Date(2023, time.October, 1, 0, 0, 0, 0, jakarta),
+		To:   time.Date(2023, time.November, 1, 0, 0, 0, 0, jakarta),
+	}
+
+	t.Run("should return error when period is half open", func(t *testing.T) {
+		if _, err := rs.GetExpenseReport(1, GroupByMonth, util.Period{From: october.From}, ""); !errors.Is(err, ErrInvalidReportPeriod) {
+			t.Error("exp ErrInvalidReportPeriod; got", err)
+		}
+	})
+	t.Run("should return error when group by is unknown", func(t *testing.T) {
+		if _, err := rs.GetExpenseReport(1, "quarter", october, ""); !errors.Is(err, ErrInvalidGroupBy) {
+			t.Error("exp ErrInvalidGroupBy; got", err)
+		}
+	})
+	t.Run("should return error when timezone is unknown", func(t *testing.T) {
+		if _, err := rs.GetExpenseReport(1, GroupByMonth, october, "Mars/Olympus"); !errors.Is(err, ErrInvalidTimezone) {
+			t.Error("exp ErrInvalidTimezone; got", err)
+		}
+	})
+	t.Run("should return error when there would be too many periods", func(t *testing.T) {
+		twoYears := util.Period{From: october.From, To: october.From.AddDate(2, 0, 0)}
+		if _, err := rs.GetExpenseReport(1, GroupByDay, twoYears, ""); !errors.Is(err, ErrReportTooLarge) {
+			t.Error("exp ErrReportTooLarge; got", err)
+		}
+	})
+	t.Run("should split into local days and leave out the largest expense when empty", func(t *testing.T) {
+		mrr.EXPECT().GetExpenseStats(gomock.Eq(uint(1)), gomock.Eq(october)).Return(nil, nil)
+		mrr.EXPECT().GetExpenseStatsByPeriods(gomock.Eq(uint(1)), gomock.Any()).DoAndReturn(func(userID uint, periods []util.Period) ([]model.ExpenseStatsRow, error) {
+			if len(periods) != 31 {
+				t.Fatal("exp 31; got", len(periods))
+			}
+			if !periods[0].From.Equal(october.From) || !periods[0].To.Equal(october.From.AddDate(0, 0, 1)) {
+				t.Error("exp first period to be October 1 in Jakarta; got", periods[0])
+			}
+			return nil, nil
+		})
+		mrr.EXPECT().GetExpenseStatsByCategory(gomock.Eq(uint(1)), gomock.Eq(october)).Return(nil, nil)
+		mrr.EXPECT().GetExpenseStatsByAccount(gomock.Eq(uint(1)), gomock.Eq(october)).Return(nil, nil)
+		mcur.EXPECT().Convert(gomock.Eq(1), gomock.Any()).Return(model.Currency{}, []model.ExpenseStatsRow{}, nil)
+
+		got, err := rs.GetExpenseReport(1, GroupByDay, october, "Asia/Jakarta")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.LargestExpense != nil {
+			t.Error("exp nil; got", got.LargestExpense)
+		}
+		if got.Timezone != "Asia/Jakarta" || got.GroupBy != GroupByDay || len(got.Periods) != 31 {
+			t.Error("exp 31 days in Asia/Jakarta; got", got.Timezone, got.GroupBy, len(got.Periods))
+		}
+	})
+	t.Run("should convert before adding up and find the largest expense once converted", func(t *testing.T) {
+		summary := []model.ExpenseStatsRow{
+			{Currency: "USD", Date: "2023-10-02", ExpenseStats: model.ExpenseStats{Count: 1, Total: 100, Average: 100, Largest: 100}},
+			{Currency: "IDR", Date: "2023-10-03", ExpenseStats: model.ExpenseStats{Count: 2, Total: 300000, Average: 150000, Largest: 200000}},
+		}
+		byCategory := []model.ExpenseStatsRow{
+			{Key: 4, Name: "Food", Currency: "USD", Date: "2023-10-02", ExpenseStats: model.ExpenseStats{Count: 1, Total: 100, Largest: 100}},
+			{Key: 4, Name: "Food", Currency: "IDR", Date: "2023-10-03", ExpenseStats: model.ExpenseStats{Count: 1, Total: 100000, Largest: 100000}},
+			{Key: 5, Name: "Bills", Currency: "IDR", Date: "2023-10-03", ExpenseStats: model.ExpenseStats{Count: 1, Total: 200000, Largest: 200000}},
+		}
+		mrr.EXPECT().GetExpenseStats(gomock.Any(), gomock.Any()).Return(summary, nil)
+		mrr.EXPECT().GetExpenseStatsByPeriods(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mrr.EXPECT().GetExpenseStatsByCategory(gomock.Any(), gomock.Any()).Return(byCategory, nil)
+		mrr.EXPECT().GetExpenseStatsByAccount(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mcur.EXPECT().Convert(gomock.Eq(1), gomock.Any()).DoAndReturn(func(userID int, rows []model.ExpenseStatsRow) (model.Currency, []model.ExpenseStatsRow, error) {
+			if len(rows) != 5 {
+				t.Fatal("exp every row to be converted at once; got", len(rows))
+			}
+			converted := make([]model.ExpenseStatsRow, len(rows))
+			for i, r := range rows {
+				converted[i] = r
+				if r.Currency == "USD" {
+					converted[i].Currency = "IDR"
+					converted[i].Total *= 15000
+					converted[i].Largest *= 15000
+				}
+			}
+			return model.Currency{Code: "IDR"}, converted, nil
+		})
+		mrr.EXPECT().GetLargestExpense(gomock.Eq(uint(1)), gomock.Eq("USD"), gomock.Eq(util.Period{
+			From: time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2023, time.October, 3, 0, 0, 0, 0, time.UTC),
+		})).Return(model.Expense{Model: gorm.Model{ID: 7}, Amount: 100}, nil)
+
+		got, err := rs.GetExpenseReport(1, "", october, "Asia/Jakarta")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Currency.Code != "IDR" || got.GroupBy != GroupByMonth {
+			t.Error("exp IDR by month; got", got.Currency, got.GroupBy)
+		}
+		if got.Summary.Count != 3 || got.Summary.Total != 1800000 || got.Summary.Largest != 1500000 || got.Summary.Average != 600000 {
+			t.Error("exp 3 expenses totalling 1800000; got", got.Summary)
+		}
+		if got.LargestExpense == nil || got.LargestExpense.ID != 7 {
+			t.Error("exp expense 7; got", got.LargestExpense)
+		}
+		if len(got.Categories) != 2 || got.Categories[0].CategoryName != "Food" || got.Categories[0].Total != 1600000 {
+			t.Error("exp Food first with 1600000; got", got.Categories)
+		}
+	})
+	t.Run("should return error when amounts cannot be converted", func(t *testing.T) {
+		mrr.EXPECT().GetExpenseStats(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mrr.EXPECT().GetExpenseStatsByPeriods(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mrr.EXPECT().GetExpenseStatsByCategory(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mrr.EXPECT().GetExpenseStatsByAccount(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mcur.EXPECT().Convert(gomock.Any(), gomock.Any()).Return(model.Currency{}, nil, ErrExchangeRateNotFound)
+
+		if _, err := rs.GetExpenseReport(1, "", october, ""); !errors.Is(err, ErrExchangeRateNotFound) {
+			t.Error("exp ErrExchangeRateNotFound; got", err)
+		}
+	})
+}
+
+func TestReportService_GetExpenseReportsByCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mrr := mock_repository.NewMockReportRepository(ctrl)
+	mcur := mock_service.NewMockCurrencyService(ctrl)
+	rs := NewReportService(mrr, mcur)
+
+	october := util.Period{
+		From: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("should add up each currency on its own without converting", func(t *testing.T) {
+		summary := []model.ExpenseStatsRow{
+			{Currency: "USD", MinorUnit: 2, Date: "2023-10-02", ExpenseStats: model.ExpenseStats{Count: 1, Total: 100, Largest: 100}},
+			{Currency: "IDR", Date: "2023-10-03", ExpenseStats: model.ExpenseStats{Count: 2, Total: 300000, Largest: 200000}},
+			{Currency: "USD", MinorUnit: 2, Date: "2023-10-04", ExpenseStats: model.ExpenseStats{Count: 1, Total: 50, Largest: 50}},
+		}
+		byCategory := []model.ExpenseStatsRow{
+			{Key: 4, Name: "Food", Currency: "USD", Date: "2023-10-02", ExpenseStats: model.ExpenseStats{Count: 2, Total: 150, Largest: 100}},
+			{Key: 5, Name: "Bills", Currency: "IDR", Date: "2023-10-03", ExpenseStats: model.ExpenseStats{Count: 2, Total: 300000, Largest: 200000}},
+		}
+		mrr.EXPECT().GetExpenseStats(gomock.Any(), gomock.Any()).Return(summary, nil)
+		mrr.EXPECT().GetExpenseStatsByPeriods(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mrr.EXPECT().GetExpenseStatsByCategory(gomock.Any(), gomock.Any()).Return(byCategory, nil)
+		mrr.EXPECT().GetExpenseStatsByAccount(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mrr.EXPECT().GetLargestExpense(gomock.Eq(uint(1)), gomock.Eq("IDR"), gomock.Any()).Return(model.Expense{Model: gorm.Model{ID: 8}}, nil)
+		mrr.EXPECT().GetLargestExpense(gomock.Eq(uint(1)), gomock.Eq("USD"), gomock.Eq(util.Period{
+			From: time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2023, time.October, 3, 0, 0, 0, 0, time.UTC),
+		})).Return(model.Expense{Model: gorm.Model{ID: 7}}, nil)
+
+		got, err := rs.GetExpenseReportsByCurrency(1, "", october, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		idr, usd := got[0], got[1]
+		if idr.Currency.Code != "IDR" || idr.Summary.Total != 300000 || len(idr.Categories) != 1 || idr.Categories[0].CategoryName != "Bills" {
+			t.Error("exp IDR with 300000 in Bills; got", idr.Currency, idr.Summary, idr.Categories)
+		}
+		if usd.Currency.Code != "USD" || usd.Currency.MinorUnit != 2 || usd.Summary.Count != 2 || usd.Summary.Total != 150 {
+			t.Error("exp 2 USD expenses totalling 150; got", usd.Currency, usd.Summary)
+		}
+		if usd.LargestExpense == nil || usd.LargestExpense.ID != 7 {
+			t.Error("exp expense 7; got", usd.LargestExpense)
+		}
+	})
+}
+
+func TestSplitPeriod(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	t.Run("should keep local midnights across daylight saving changes", func(t *testing.T) {
+		period := util.Period{
+			From: time.Date(2023, time.November, 4, 0, 0, 0, 0, newYork),
+			To:   time.Date(2023, time.November, 7, 0, 0, 0, 0, newYork),
+		}
+		got, err := splitPeriod(period, GroupByDay, newYork)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 3 {
+			t.Fatal("exp 3; got", len(got))
+		}
+		if d := got[1].To.Sub(got[1].From); d != 25*time.Hour {
+			t.Error("exp 25h on the day clocks go back; got", d)
+		}
+	})
+	t.Run("should start weeks on Monday and cut the ends to the period", func(t *testing.T) {
+		period := util.Period{
+			// A Wednesday to the Sunday after next.
+			From: time.Date(2023, time.October, 11, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2023, time.October, 23, 0, 0, 0, 0, time.UTC),
+		}
+		got, err := splitPeriod(period, GroupByWeek, time.UTC)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		if !got[0].From.Equal(period.From) || got[0].To.Weekday() != time.Monday {
+			t.Error("exp first week from Wednesday to Monday; got", got[0])
+		}
+		if !got[1].To.Equal(period.To) {
+			t.Error("exp last week to end with the period; got", got[1])
+		}
+	})
+	t.Run("should split a range into years", func(t *testing.T) {
+		period := util.Period{
+			From: time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}
+		got, err := splitPeriod(period, GroupByYear, time.UTC)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 || got[1].From.Year() != 2023 {
+			t.Error("exp 2022 and 2023; got", got)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+var ErrInvalidTimezone = errors.New("Invalid timezone")
+
+// resolveOccurredAt returns the UTC instant a transaction happened and the
+// timezone it should be displayed in. A missing occurredAt falls back to
+// fallback; a missing timezone is taken from occurredAt's UTC offset.
+func resolveOccurredAt(occurredAt *time.Time, timezone string, fallback time.Time) (time.Time, string, error) {
+	if _, err := util.LoadTimezone(timezone); err != nil {
+		return time.Time{}, "", ErrInvalidTimezone
+	}
+
+	t := fallback
+	if occurredAt != nil {
+		t = *occurredAt
+		if timezone == "" {
+			timezone = t.Format("-07:00")
+		}
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	return t.UTC(), timezone, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResolveOccurredAt(t *testing.T) {
+	fallback := time.Date(2023, time.October, 16, 8, 0, 0, 0, time.UTC)
+	saturday := time.Date(2023, time.October, 14, 19, 30, 0, 0, time.FixedZone("", 7*60*60))
+
+	t.Run("should fall back when occurred at is missing", func(t *testing.T) {
+		got, tz, err := resolveOccurredAt(nil, "", fallback)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if !got.Equal(fallback) || tz != "UTC" {
+			t.Error("exp fallback in UTC; got", got, tz)
+		}
+	})
+	t.Run("should take timezone from the UTC offset", func(t *testing.T) {
+		got, tz, err := resolveOccurredAt(&saturday, "", fallback)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if !got.Equal(saturday) || got.Location() != time.UTC || tz != "+07:00" {
+			t.Error("exp saturday stored in UTC with +07:00; got", got, tz)
+		}
+	})
+	t.Run("should keep the given timezone", func(t *testing.T) {
+		_, tz, err := resolveOccurredAt(&saturday, "Asia/Jakarta", fallback)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if tz != "Asia/Jakarta" {
+			t.Error("exp Asia/Jakarta; got", tz)
+		}
+	})
+	t.Run("should reject unknown timezone", func(t *testing.T) {
+		if _, _, err := resolveOccurredAt(&saturday, "Mars/Olympus", fallback); !errors.Is(err, ErrInvalidTimezone) {
+			t.Error("exp ErrInvalidTimezone; got", err)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+)
+
+type TransactionService interface {
+	GetManyBelongedToUser(userID int, period util.Period, itemPerPage, page int) ([]model.Transaction, error)
+	GetManyBelongedToAccount(userID, accountID int, period util.Period, itemPerPage, page int) ([]model.Transaction, error)
+}
+
+type transactionService struct {
+	tr repository.TransactionRepository
+}
+
+func NewTransactionService(tr repository.TransactionRepository) *transactionService {
+	return &transactionService{tr}
+}
+
+func (ts *transactionService) GetManyBelongedToUser(userID int, period util.Period, itemPerPage, page int) ([]model.Transaction, error) {
+	transactions, err := ts.tr.GetManyBelongedToUser(uint(userID), period, itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (ts *transactionService) GetManyBelongedToAccount(userID, accountID int, period util.Period, itemPerPage, page int) ([]model.Transaction, error) {
+	transactions, err := ts.tr.GetManyBelongedToAccount(uint(userID), uint(accountID), period, itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
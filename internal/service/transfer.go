@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/money"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+)
+
+var (
+	ErrSameAccountTransfer  = errors.New("Cannot transfer to the same account")
+	ErrExchangeRateRequired = errors.New("Exchange rate is required when account currencies differ")
+	ErrExchangeRateMismatch = errors.New("Exchange rate must be 1 when account currencies are the same")
+	ErrNegativeFee          = errors.New("Fee cannot be negative")
+)
+
+type TransferService interface {
+	Create(userID int, payload dto.CreateTransferDTO) (model.Transfer, error)
+	GetOneByID(userID, id int) (model.Transfer, error)
+	GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.Transfer, error)
+	GetManyBelongedToAccount(userID, accountID, itemPerPage, page int) ([]model.Transfer, error)
+	DeleteOneByID(userID, id int) error
+}
+
+type transferService struct {
+	tr repository.TransferRepository
+	as AccountService
+}
+
+func NewTransferService(tr repository.TransferRepository, as AccountService) *transferService {
+	return &transferService{tr, as}
+}
+
+func (ts *transferService) Create(userID int, payload dto.CreateTransferDTO) (model.Transfer, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return model.Transfer{}, err
+	}
+	if payload.FromAccountID == payload.ToAccountID {
+		return model.Transfer{}, ErrSameAccountTransfer
+	}
+
+	from, err := ts.as.GetOneByID(userID, int(payload.FromAccountID))
+	if err != nil {
+		return model.Transfer{}, ErrAccountNotBelongedToUser
+	}
+	to, err := ts.as.GetOneByID(userID, int(payload.ToAccountID))
+	if err != nil {
+		return model.Transfer{}, ErrAccountNotBelongedToUser
+	}
+
+	amount, err := parsePositiveAmount(payload.Amount, from.Currency)
+	if err != nil {
+		return model.Transfer{}, err
+	}
+	fee := 0
+	if payload.Fee != "" {
+		if fee, err = parseAmount(payload.Fee, from.Currency); err != nil {
+			return model.Transfer{}, err
+		}
+		if fee < 0 {
+			return model.Transfer{}, ErrNegativeFee
+		}
+	}
+
+	rate := payload.ExchangeRate
+	if from.CurrencyID == to.CurrencyID {
+		if rate != 0 && rate != 1 {
+			return model.Transfer{}, ErrExchangeRateMismatch
+		}
+		rate = 1
+	} else if rate == 0 {
+		return model.Transfer{}, ErrExchangeRateRequired
+	}
+	receivedAmount := money.Convert(amount, rate, minorUnitOf(from.Currency), minorUnitOf(to.Currency))
+
+	occurredAt, timezone, err := resolveOccurredAt(payload.OccurredAt, payload.Timezone, time.Now())
+	if err != nil {
+		return model.Transfer{}, err
+	}
+
+	transfer, err := ts.tr.Insert(
+		uint(userID),
+		payload.FromAccountID,
+		payload.ToAccountID,
+		payload.Description,
+		amount,
+		fee,
+		rate,
+		receivedAmount,
+		occurredAt,
+		timezone,
+	)
+	if err != nil {
+		return model.Transfer{}, err
+	}
+	transfer.FromAccount = &from
+	transfer.ToAccount = &to
+
+	return transfer, nil
+}
+
+func (ts *transferService) GetOneByID(userID, id int) (model.Transfer, error) {
+	transfer, err := ts.tr.GetOneByID(uint(userID), uint(id))
+	if err != nil {
+		return model.Transfer{}, notFound(err)
+	}
+
+	return transfer, nil
+}
+
+func (ts *transferService) GetManyBelongedToUser(userID, itemPerPage, page int) ([]model.Transfer, error) {
+	transfers, err := ts.tr.GetManyBelongedToUser(uint(userID), itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (ts *transferService) GetManyBelongedToAccount(userID, accountID, itemPerPage, page int) ([]model.Transfer, error) {
+	transfers, err := ts.tr.GetManyBelongedToAccount(uint(userID), uint(accountID), itemPerPage, (page-1)*itemPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (ts *transferService) DeleteOneByID(userID, id int) error {
+	if err := ts.tr.DeleteOneByID(uint(userID), uint(id)); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
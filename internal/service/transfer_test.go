@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestTransferService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mtr := mock_repository.NewMockTransferRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	ts := NewTransferService(mtr, mas)
+
+	account := func(id, userID, currencyID uint) func(int, int) (model.Account, error) {
+		return func(int, int) (model.Account, error) {
+			return model.Account{
+				Model:      gorm.Model{ID: id},
+				UserID:     userID,
+				CurrencyID: currencyID,
+			}, nil
+		}
+	}
+
+	t.Run("should return error when payload is invalid", func(t *testing.T) {
+		if _, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   3,
+		}); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+	t.Run("should return error when both accounts are the same", func(t *testing.T) {
+		if _, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   2,
+			Amount:        "1000",
+		}); !errors.Is(err, ErrSameAccountTransfer) {
+			t.Error("exp ErrSameAccountTransfer; got", err)
+		}
+	})
+	t.Run("should return error when an account belongs to another user", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(account(2, 1, 1))
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).Return(model.Account{}, ErrNotFound)
+
+		if _, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   3,
+			Amount:        "1000",
+		}); !errors.Is(err, ErrAccountNotBelongedToUser) {
+			t.Error("exp ErrAccountNotBelongedToUser; got", err)
+		}
+	})
+	t.Run("should require exchange rate when currencies differ", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(account(2, 1, 1))
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(account(3, 1, 2))
+
+		if _, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   3,
+			Amount:        "1000",
+		}); !errors.Is(err, ErrExchangeRateRequired) {
+			t.Error("exp ErrExchangeRateRequired; got", err)
+		}
+	})
+	t.Run("should reject exchange rate when currencies are the same", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(account(2, 1, 1))
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(account(3, 1, 1))
+
+		if _, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   3,
+			Amount:        "1000",
+			ExchangeRate:  1.5,
+		}); !errors.Is(err, ErrExchangeRateMismatch) {
+			t.Error("exp ErrExchangeRateMismatch; got", err)
+		}
+	})
+	t.Run("should convert received amount with exchange rate", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(account(2, 1, 1))
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(account(3, 1, 2))
+		mtr.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(uint(3)), gomock.Eq("Savings"), gomock.Eq(1000), gomock.Eq(25), gomock.Eq(1.2345), gomock.Eq(1235), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(userID, fromAccountID, toAccountID uint, description string, amount, fee int, exchangeRate float64, receivedAmount int, occurredAt time.Time, timezone string) (model.Transfer, error) {
+				return model.Transfer{
+					UserID:         userID,
+					FromAccountID:  fromAccountID,
+					ToAccountID:    toAccountID,
+					Description:    description,
+					Amount:         amount,
+					Fee:            fee,
+					ExchangeRate:   exchangeRate,
+					ReceivedAmount: receivedAmount,
+				}, nil
+			})
+
+		got, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   3,
+			Description:   "Savings",
+			Amount:        "1000",
+			Fee:           "25",
+			ExchangeRate:  1.2345,
+		})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.ReceivedAmount != 1235 {
+			t.Error("exp 1235; got", got.ReceivedAmount)
+		}
+	})
+	t.Run("should receive the amount in the minor units of the other currency", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).
+			Return(model.Account{Model: gorm.Model{ID: 2}, UserID: 1, CurrencyID: 1, Currency: &model.Currency{Code: "USD", MinorUnit: 2}}, nil)
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).
+			Return(model.Account{Model: gorm.Model{ID: 3}, UserID: 1, CurrencyID: 2, Currency: &model.Currency{Code: "JPY"}}, nil)
+		mtr.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(uint(3)), gomock.Eq(""), gomock.Eq(1050), gomock.Eq(0), gomock.Eq(150.0), gomock.Eq(1575), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(userID, fromAccountID, toAccountID uint, description string, amount, fee int, exchangeRate float64, receivedAmount int, occurredAt time.Time, timezone string) (model.Transfer, error) {
+				return model.Transfer{Amount: amount, ReceivedAmount: receivedAmount}, nil
+			})
+
+		if _, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   3,
+			Amount:        "10.50",
+			ExchangeRate:  150,
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should return error when fee is negative", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(account(2, 1, 1))
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(account(3, 1, 1))
+
+		if _, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   3,
+			Amount:        "1000",
+			Fee:           "-25",
+		}); !errors.Is(err, ErrNegativeFee) {
+			t.Error("exp ErrNegativeFee; got", err)
+		}
+	})
+	t.Run("should default exchange rate to 1 for the same currency", func(t *testing.T) {
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(2)).DoAndReturn(account(2, 1, 1))
+		mas.EXPECT().GetOneByID(gomock.Eq(1), gomock.Eq(3)).DoAndReturn(account(3, 1, 1))
+		mtr.EXPECT().Insert(gomock.Eq(uint(1)), gomock.Eq(uint(2)), gomock.Eq(uint(3)), gomock.Eq(""), gomock.Eq(1000), gomock.Eq(0), gomock.Eq(1.0), gomock.Eq(1000), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(userID, fromAccountID, toAccountID uint, description string, amount, fee int, exchangeRate float64, receivedAmount int, occurredAt time.Time, timezone string) (model.Transfer, error) {
+				return model.Transfer{ReceivedAmount: receivedAmount}, nil
+			})
+
+		if _, err := ts.Create(1, dto.CreateTransferDTO{
+			FromAccountID: 2,
+			ToAccountID:   3,
+			Amount:        "1000",
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
+
+func TestTransferService_DeleteOneByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mtr := mock_repository.NewMockTransferRepository(ctrl)
+	mas := mock_service.NewMockAccountService(ctrl)
+	ts := NewTransferService(mtr, mas)
+
+	t.Run("should return error when repository returns error", func(t *testing.T) {
+		mtr.EXPECT().DeleteOneByID(gomock.Eq(uint(1)), gomock.Eq(uint(1))).Return(errors.New(""))
+
+		if err := ts.DeleteOneByID(1, 1); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/totp"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	totpIssuer        = "Spendtracker"
+	recoveryCodeCount = 10
+	twoFactorTokenTTL = 5 * time.Minute
+	// maxTwoFactorAttempts is how many codes can be tried with one
+	// two-factor token, and maxTwoFactorFailures how many wrong codes a
+	// user can try within twoFactorLockout before logging in is locked.
+	maxTwoFactorAttempts = 5
+	maxTwoFactorFailures = 10
+	twoFactorLockout     = 15 * time.Minute
+)
+
+var (
+	ErrTOTPAlreadyEnabled = errors.New("Two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled    = errors.New("Two-factor authentication has not been set up")
+	ErrTOTPNotEnabled     = errors.New("Two-factor authentication is not enabled")
+	ErrInvalidTOTPCode    = errors.New("Invalid two-factor code")
+	ErrWrongPassword      = errors.New("Wrong password")
+
+	ErrTooManyTwoFactorAttempts = errors.New("Too many wrong two-factor codes; try again later")
+)
+
+type TwoFactorService interface {
+	Enroll(userID int) (model.TOTPEnrollment, error)
+	Confirm(userID int, payload dto.ConfirmTOTPDTO) ([]string, error)
+	Disable(userID int, payload dto.DisableTOTPDTO) error
+	Verify(user model.User, code string) error
+	Challenge(user model.User) (string, error)
+	Redeem(token, code string) (model.User, error)
+}
+
+type twoFactorService struct {
+	tfr repository.TwoFactorRepository
+	us  UserService
+}
+
+func NewTwoFactorService(tfr repository.TwoFactorRepository, us UserService) *twoFactorService {
+	return &twoFactorService{tfr, us}
+}
+
+// Enroll generates a new secret for the user to add to their authenticator.
+// Two-factor authentication stays off until Confirm.
+func (tfs *twoFactorService) Enroll(userID int) (model.TOTPEnrollment, error) {
+	user, err := tfs.us.GetOneByID(userID)
+	if err != nil {
+		return model.TOTPEnrollment{}, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return model.TOTPEnrollment{}, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return model.TOTPEnrollment{}, err
+	}
+	if err := tfs.tfr.SetSecret(user.ID, secret); err != nil {
+		return model.TOTPEnrollment{}, err
+	}
+
+	return model.TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm turns two-factor authentication on once the user proves their
+// authenticator has the secret, and returns their recovery codes. These are
+// only ever available here, since only their hashes are stored.
+func (tfs *twoFactorService) Confirm(userID int, payload dto.ConfirmTOTPDTO) ([]string, error) {
+	if err := validator.New().Struct(payload); err != nil {
+		return nil, err
+	}
+	user, err := tfs.us.GetOneByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTOTPNotEnrolled
+	}
+	step, ok := totp.Validate(user.TOTPSecret, payload.Code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+	if err := tfs.tfr.Enable(user.ID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off. It asks for the password and
+// a code again, so that someone at an unattended session can't do it.
+func (tfs *twoFactorService) Disable(userID int, payload dto.DisableTOTPDTO) error {
+	if err := validator.New().Struct(payload); err != nil {
+		return err
+	}
+	user, err := tfs.us.GetOneByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.TOTPEnabledAt == nil {
+		return ErrTOTPNotEnabled
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
+		return ErrWrongPassword
+	}
+	if err := tfs.Verify(user, payload.Code); err != nil {
+		return err
+	}
+
+	return tfs.tfr.Disable(user.ID)
+}
+
+// Verify checks a TOTP code or one of the user's recovery codes, using it
+// up.
+func (tfs *twoFactorService) Verify(user model.User, code string) error {
+	if user.TOTPEnabledAt == nil {
+		return ErrTOTPNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		step, ok := totp.Validate(user.TOTPSecret, code, time.Now())
+		if !ok {
+			return ErrInvalidTOTPCode
+		}
+		if err := tfs.tfr.UseStep(user.ID, step); err != nil {
+			if errors.Is(err, repository.ErrTOTPStepUsed) {
+				return ErrInvalidTOTPCode
+			}
+			return err
+		}
+		return nil
+	}
+
+	err := tfs.tfr.UseRecoveryCode(user.ID, hashToken(normalizeRecoveryCode(code)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidTOTPCode
+	}
+
+	return err
+}
+
+// Challenge starts logging in a user whose password was right, returning
+// the token to redeem along with a code. Users who recently tried too many
+// wrong codes have to wait.
+func (tfs *twoFactorService) Challenge(user model.User) (string, error) {
+	now := time.Now()
+	failures, err := tfs.tfr.CountFailedAttempts(user.ID, now.Add(-twoFactorLockout))
+	if err != nil {
+		return "", err
+	}
+	if failures >= maxTwoFactorFailures {
+		return "", ErrTooManyTwoFactorAttempts
+	}
+
+	token, tokenHash, err := newToken("")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tfs.tfr.InsertChallenge(user.ID, tokenHash, now.Add(twoFactorTokenTTL)); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// Redeem checks a code against the token from Challenge and returns the user
+// logging in. A token is used up once a code is right, and after
+// maxTwoFactorAttempts codes either way.
+func (tfs *twoFactorService) Redeem(token, code string) (model.User, error) {
+	challenge, err := tfs.tfr.GetChallenge(hashToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.User{}, ErrInvalidTwoFactorToken
+	}
+	if err != nil {
+		return model.User{}, err
+	}
+	if challenge.UsedAt != nil || time.Now().After(challenge.ExpiresAt) {
+		return model.User{}, ErrInvalidTwoFactorToken
+	}
+	if challenge.Attempts >= maxTwoFactorAttempts {
+		return model.User{}, ErrTooManyTwoFactorAttempts
+	}
+	if err := tfs.tfr.AttemptChallenge(challenge.ID, maxTwoFactorAttempts); err != nil {
+		if errors.Is(err, repository.ErrTwoFactorChallengeClosed) {
+			return model.User{}, ErrInvalidTwoFactorToken
+		}
+		return model.User{}, err
+	}
+
+	user, err := tfs.us.GetOneByID(int(challenge.UserID))
+	if err != nil {
+		return model.User{}, err
+	}
+	if err := tfs.Verify(user, code); err != nil {
+		return model.User{}, err
+	}
+	if err := tfs.tfr.UseChallenge(challenge.ID); err != nil {
+		if errors.Is(err, repository.ErrTwoFactorChallengeClosed) {
+			return model.User{}, ErrInvalidTwoFactorToken
+		}
+		return model.User{}, err
+	}
+
+	return user, nil
+}
+
+// newRecoveryCode returns a random code like "k3j9d-x2mq7".
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))[:10]
+
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode lets recovery codes be typed in either case and
+// with or without the dash.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
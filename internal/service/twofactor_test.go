@@ -0,0 +1,286 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
+	"github.com/muhrizqiardi/spendtracker/internal/totp"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestTwoFactorService_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mtfr := mock_repository.NewMockTwoFactorRepository(ctrl)
+	mus := mock_service.NewMockUserService(ctrl)
+	tfs := NewTwoFactorService(mtfr, mus)
+
+	t.Run("should return ErrTOTPAlreadyEnabled if it is enabled", func(t *testing.T) {
+		enabledAt := time.Now()
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{TOTPEnabledAt: &enabledAt}, nil)
+
+		if _, err := tfs.Enroll(1); !errors.Is(err, ErrTOTPAlreadyEnabled) {
+			t.Error("exp ErrTOTPAlreadyEnabled; got", err)
+		}
+	})
+	t.Run("should store a new secret and return its otpauth URI", func(t *testing.T) {
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Model: gorm.Model{ID: 1}, Email: "email@example.com"}, nil)
+		var stored string
+		mtfr.EXPECT().SetSecret(gomock.Eq(uint(1)), gomock.Any()).DoAndReturn(func(userID uint, secret string) error {
+			stored = secret
+			return nil
+		})
+
+		got, err := tfs.Enroll(1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Secret == "" || got.Secret != stored {
+			t.Error("exp the stored secret; got", got.Secret, stored)
+		}
+		if got.URI != totp.URI(totpIssuer, "email@example.com", stored) {
+			t.Error("exp the otpauth URI; got", got.URI)
+		}
+	})
+}
+
+func TestTwoFactorService_Confirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mtfr := mock_repository.NewMockTwoFactorRepository(ctrl)
+	mus := mock_service.NewMockUserService(ctrl)
+	tfs := NewTwoFactorService(mtfr, mus)
+
+	secret, _ := totp.NewSecret()
+	pending := model.User{Model: gorm.Model{ID: 1}, TOTPSecret: secret}
+
+	t.Run("should return ErrTOTPNotEnrolled without a secret", func(t *testing.T) {
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{}, nil)
+
+		if _, err := tfs.Confirm(1, dto.ConfirmTOTPDTO{Code: "123456"}); !errors.Is(err, ErrTOTPNotEnrolled) {
+			t.Error("exp ErrTOTPNotEnrolled; got", err)
+		}
+	})
+	t.Run("should return ErrInvalidTOTPCode for a wrong code", func(t *testing.T) {
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(pending, nil)
+
+		if _, err := tfs.Confirm(1, dto.ConfirmTOTPDTO{Code: "abcdef"}); !errors.Is(err, ErrInvalidTOTPCode) {
+			t.Error("exp ErrInvalidTOTPCode; got", err)
+		}
+	})
+	t.Run("should enable it and return recovery codes, storing only their hashes", func(t *testing.T) {
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(pending, nil)
+		step := totp.Step(time.Now())
+		code, _ := totp.Code(secret, step)
+		var hashes []string
+		mtfr.EXPECT().Enable(gomock.Eq(uint(1)), gomock.Any(), gomock.Any()).DoAndReturn(func(userID uint, s int64, h []string) error {
+			hashes = h
+			return nil
+		})
+
+		got, err := tfs.Confirm(1, dto.ConfirmTOTPDTO{Code: code})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+			t.Fatal("exp", recoveryCodeCount, "codes; got", len(got), len(hashes))
+		}
+		if hashes[0] != hashToken(normalizeRecoveryCode(got[0])) {
+			t.Error("exp the codes to be stored hashed; got", hashes[0])
+		}
+	})
+}
+
+func TestTwoFactorService_Verify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mtfr := mock_repository.NewMockTwoFactorRepository(ctrl)
+	mus := mock_service.NewMockUserService(ctrl)
+	tfs := NewTwoFactorService(mtfr, mus)
+
+	secret, _ := totp.NewSecret()
+	enabledAt := time.Now()
+	user := model.User{Model: gorm.Model{ID: 1}, TOTPSecret: secret, TOTPEnabledAt: &enabledAt}
+
+	t.Run("should accept a current code once", func(t *testing.T) {
+		step := totp.Step(time.Now())
+		code, _ := totp.Code(secret, step)
+		mtfr.EXPECT().UseStep(gomock.Eq(uint(1)), gomock.Any()).Return(nil)
+		mtfr.EXPECT().UseStep(gomock.Eq(uint(1)), gomock.Any()).Return(repository.ErrTOTPStepUsed)
+
+		if err := tfs.Verify(user, code); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if err := tfs.Verify(user, code); !errors.Is(err, ErrInvalidTOTPCode) {
+			t.Error("exp ErrInvalidTOTPCode; got", err)
+		}
+	})
+	t.Run("should accept a recovery code however it is typed", func(t *testing.T) {
+		mtfr.EXPECT().UseRecoveryCode(gomock.Eq(uint(1)), gomock.Eq(hashToken("abcdefghij"))).Return(nil)
+
+		if err := tfs.Verify(user, " ABCDE-fghij "); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should return ErrInvalidTOTPCode for an unknown or used recovery code", func(t *testing.T) {
+		mtfr.EXPECT().UseRecoveryCode(gomock.Eq(uint(1)), gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		if err := tfs.Verify(user, "abcde-fghij"); !errors.Is(err, ErrInvalidTOTPCode) {
+			t.Error("exp ErrInvalidTOTPCode; got", err)
+		}
+	})
+}
+
+func TestTwoFactorService_Challenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mtfr := mock_repository.NewMockTwoFactorRepository(ctrl)
+	mus := mock_service.NewMockUserService(ctrl)
+	tfs := NewTwoFactorService(mtfr, mus)
+
+	user := model.User{Model: gorm.Model{ID: 1}}
+
+	t.Run("should lock out a user who tried too many wrong codes", func(t *testing.T) {
+		mtfr.EXPECT().CountFailedAttempts(gomock.Eq(uint(1)), gomock.Any()).Return(maxTwoFactorFailures, nil)
+
+		if _, err := tfs.Challenge(user); !errors.Is(err, ErrTooManyTwoFactorAttempts) {
+			t.Error("exp ErrTooManyTwoFactorAttempts; got", err)
+		}
+	})
+	t.Run("should store only the token's hash", func(t *testing.T) {
+		mtfr.EXPECT().CountFailedAttempts(gomock.Eq(uint(1)), gomock.Any()).Return(0, nil)
+		var stored string
+		mtfr.EXPECT().InsertChallenge(gomock.Eq(uint(1)), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(userID uint, tokenHash string, expiresAt time.Time) (model.TwoFactorChallenge, error) {
+				stored = tokenHash
+				return model.TwoFactorChallenge{}, nil
+			})
+
+		got, err := tfs.Challenge(user)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got == "" || hashToken(got) != stored {
+			t.Error("exp the hash of", got, "; got", stored)
+		}
+	})
+}
+
+func TestTwoFactorService_Redeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mtfr := mock_repository.NewMockTwoFactorRepository(ctrl)
+	mus := mock_service.NewMockUserService(ctrl)
+	tfs := NewTwoFactorService(mtfr, mus)
+
+	secret, _ := totp.NewSecret()
+	enabledAt := time.Now()
+	user := model.User{Model: gorm.Model{ID: 1}, TOTPSecret: secret, TOTPEnabledAt: &enabledAt}
+	challenge := func() model.TwoFactorChallenge {
+		return model.TwoFactorChallenge{Model: gorm.Model{ID: 3}, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)}
+	}
+
+	t.Run("should return ErrInvalidTwoFactorToken for an unknown token", func(t *testing.T) {
+		mtfr.EXPECT().GetChallenge(gomock.Eq(hashToken("token"))).Return(model.TwoFactorChallenge{}, gorm.ErrRecordNotFound)
+
+		if _, err := tfs.Redeem("token", "123456"); !errors.Is(err, ErrInvalidTwoFactorToken) {
+			t.Error("exp ErrInvalidTwoFactorToken; got", err)
+		}
+	})
+	t.Run("should return ErrInvalidTwoFactorToken for a used token", func(t *testing.T) {
+		used := challenge()
+		usedAt := time.Now()
+		used.UsedAt = &usedAt
+		mtfr.EXPECT().GetChallenge(gomock.Any()).Return(used, nil)
+
+		if _, err := tfs.Redeem("token", "123456"); !errors.Is(err, ErrInvalidTwoFactorToken) {
+			t.Error("exp ErrInvalidTwoFactorToken; got", err)
+		}
+	})
+	t.Run("should return ErrTooManyTwoFactorAttempts once the token's attempts are used up", func(t *testing.T) {
+		exhausted := challenge()
+		exhausted.Attempts = maxTwoFactorAttempts
+		mtfr.EXPECT().GetChallenge(gomock.Any()).Return(exhausted, nil)
+
+		if _, err := tfs.Redeem("token", "123456"); !errors.Is(err, ErrTooManyTwoFactorAttempts) {
+			t.Error("exp ErrTooManyTwoFactorAttempts; got", err)
+		}
+	})
+	t.Run("should count a wrong code as an attempt", func(t *testing.T) {
+		mtfr.EXPECT().GetChallenge(gomock.Any()).Return(challenge(), nil)
+		mtfr.EXPECT().AttemptChallenge(gomock.Eq(uint(3)), gomock.Eq(maxTwoFactorAttempts)).Return(nil)
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(user, nil)
+		mtfr.EXPECT().UseRecoveryCode(gomock.Eq(uint(1)), gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		if _, err := tfs.Redeem("token", "abcde-fghij"); !errors.Is(err, ErrInvalidTOTPCode) {
+			t.Error("exp ErrInvalidTOTPCode; got", err)
+		}
+	})
+	t.Run("should not check a code once another request used the last attempt", func(t *testing.T) {
+		mtfr.EXPECT().GetChallenge(gomock.Any()).Return(challenge(), nil)
+		mtfr.EXPECT().AttemptChallenge(gomock.Eq(uint(3)), gomock.Any()).Return(repository.ErrTwoFactorChallengeClosed)
+
+		if _, err := tfs.Redeem("token", "abcde-fghij"); !errors.Is(err, ErrInvalidTwoFactorToken) {
+			t.Error("exp ErrInvalidTwoFactorToken; got", err)
+		}
+	})
+	t.Run("should use the token up and return the user", func(t *testing.T) {
+		mtfr.EXPECT().GetChallenge(gomock.Any()).Return(challenge(), nil)
+		mtfr.EXPECT().AttemptChallenge(gomock.Eq(uint(3)), gomock.Any()).Return(nil)
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(user, nil)
+		mtfr.EXPECT().UseRecoveryCode(gomock.Eq(uint(1)), gomock.Any()).Return(nil)
+		mtfr.EXPECT().UseChallenge(gomock.Eq(uint(3))).Return(nil)
+
+		got, err := tfs.Redeem("token", "abcde-fghij")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.ID != 1 {
+			t.Error("exp user 1; got", got.ID)
+		}
+	})
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mtfr := mock_repository.NewMockTwoFactorRepository(ctrl)
+	mus := mock_service.NewMockUserService(ctrl)
+	tfs := NewTwoFactorService(mtfr, mus)
+
+	secret, _ := totp.NewSecret()
+	enabledAt := time.Now()
+	user := model.User{
+		Model:         gorm.Model{ID: 1},
+		Password:      "$2a$12$htC6KUeMQ10/mBdUoVeRp.UW47NYED2gMG.mF/7oJ39p02XPJvuI2",
+		TOTPSecret:    secret,
+		TOTPEnabledAt: &enabledAt,
+	}
+
+	t.Run("should return ErrWrongPassword without the right password", func(t *testing.T) {
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(user, nil)
+
+		if err := tfs.Disable(1, dto.DisableTOTPDTO{Password: "wrongpassword", Code: "123456"}); !errors.Is(err, ErrWrongPassword) {
+			t.Error("exp ErrWrongPassword; got", err)
+		}
+	})
+	t.Run("should return ErrInvalidTOTPCode without a valid code", func(t *testing.T) {
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(user, nil)
+		mtfr.EXPECT().UseRecoveryCode(gomock.Eq(uint(1)), gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		if err := tfs.Disable(1, dto.DisableTOTPDTO{Password: "topsecret", Code: "notacode"}); !errors.Is(err, ErrInvalidTOTPCode) {
+			t.Error("exp ErrInvalidTOTPCode; got", err)
+		}
+	})
+	t.Run("should disable it with the password and a code", func(t *testing.T) {
+		mus.EXPECT().GetOneByID(gomock.Eq(1)).Return(user, nil)
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+		mtfr.EXPECT().UseStep(gomock.Eq(uint(1)), gomock.Any()).Return(nil)
+		mtfr.EXPECT().Disable(gomock.Eq(uint(1))).Return(nil)
+
+		if err := tfs.Disable(1, dto.DisableTOTPDTO{Password: "topsecret", Code: code}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
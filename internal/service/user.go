@@ -1,6 +1,9 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
@@ -8,6 +11,10 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrVerificationEmailNotSent comes with the user when their changes were
+// saved but the email asking them to verify their address couldn't be sent.
+var ErrVerificationEmailNotSent = errors.New("Verification email could not be sent; request another")
+
 type UserService interface {
 	Register(payload dto.RegisterUserDTO) (model.User, error)
 	GetOneByID(id int) (model.User, error)
@@ -18,10 +25,12 @@ type UserService interface {
 
 type userService struct {
 	ur repository.UserRepository
+	sr repository.SessionRepository
+	vs VerificationService
 }
 
-func NewUserService(ur repository.UserRepository) *userService {
-	return &userService{ur}
+func NewUserService(ur repository.UserRepository, sr repository.SessionRepository, vs VerificationService) *userService {
+	return &userService{ur, sr, vs}
 }
 
 func (us *userService) Register(payload dto.RegisterUserDTO) (model.User, error) {
@@ -37,6 +46,9 @@ func (us *userService) Register(payload dto.RegisterUserDTO) (model.User, error)
 	if err != nil {
 		return model.User{}, err
 	}
+	if err := us.vs.SendEmailVerification(int(user.ID)); err != nil {
+		return user, fmt.Errorf("%w: %v", ErrVerificationEmailNotSent, err)
+	}
 
 	return user, nil
 }
@@ -62,11 +74,19 @@ func (us *userService) GetOneByEmail(email string) (model.User, error) {
 	return user, nil
 }
 
+// UpdateOneByID logs the user out of every session if the password changes,
+// so that whoever might have learned the old one loses access too. A new
+// email address has to be verified again.
 func (us *userService) UpdateOneByID(id int, payload dto.UpdateUserDTO) (model.User, error) {
 	validate := validator.New()
 	if err := validate.Struct(payload); err != nil {
 		return model.User{}, err
 	}
+	current, err := us.ur.GetOneByID(id)
+	if err != nil {
+		return model.User{}, err
+	}
+	passwordChanged := bcrypt.CompareHashAndPassword([]byte(current.Password), []byte(payload.Password)) != nil
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return model.User{}, err
@@ -75,6 +95,22 @@ func (us *userService) UpdateOneByID(id int, payload dto.UpdateUserDTO) (model.U
 	if err != nil {
 		return model.User{}, err
 	}
+	if passwordChanged {
+		if err := us.sr.RevokeManyBelongedToUser(uint(id)); err != nil {
+			return model.User{}, err
+		}
+	}
+	if payload.Email != current.Email {
+		if err := us.ur.SetEmailVerifiedAt(id, nil); err != nil {
+			return model.User{}, err
+		}
+		if err := us.vs.RevokeTokens(id); err != nil {
+			return model.User{}, err
+		}
+		if err := us.vs.SendEmailVerification(id); err != nil {
+			return user, fmt.Errorf("%w: %v", ErrVerificationEmailNotSent, err)
+		}
+	}
 
 	return user, nil
 }
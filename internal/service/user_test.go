@@ -9,6 +9,7 @@ import (
 	"github.com/muhrizqiardi/spendtracker/internal/database/model"
 	"github.com/muhrizqiardi/spendtracker/internal/dto"
 	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	mock_service "github.com/muhrizqiardi/spendtracker/internal/service/mock"
 	"github.com/muhrizqiardi/spendtracker/tests/testutil"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
@@ -17,7 +18,9 @@ import (
 func TestUserService_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mur := mock_repository.NewMockUserRepository(ctrl)
-	us := NewUserService(mur)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mvs := mock_service.NewMockVerificationService(ctrl)
+	us := NewUserService(mur, msr, mvs)
 	t.Run("should return error if payload is invalid", func(t *testing.T) {
 		if _, err := us.Register(dto.RegisterUserDTO{
 			Email:    "invalid.email.example.com",
@@ -54,6 +57,7 @@ func TestUserService_Register(t *testing.T) {
 					Password: password,
 				}, nil
 			})
+		mvs.EXPECT().SendEmailVerification(gomock.Any()).Return(nil)
 
 		exp := model.User{
 			Email:    "test@example.com",
@@ -82,7 +86,9 @@ func TestUserService_Register(t *testing.T) {
 func TestUserService_GetOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mur := mock_repository.NewMockUserRepository(ctrl)
-	us := NewUserService(mur)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mvs := mock_service.NewMockVerificationService(ctrl)
+	us := NewUserService(mur, msr, mvs)
 	opts := []cmp.Option{
 		cmpopts.IgnoreFields(
 			model.User{},
@@ -137,7 +143,9 @@ func TestUserService_GetOneByID(t *testing.T) {
 func TestUserService_GetOneByEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mur := mock_repository.NewMockUserRepository(ctrl)
-	us := NewUserService(mur)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mvs := mock_service.NewMockVerificationService(ctrl)
+	us := NewUserService(mur, msr, mvs)
 	opts := []cmp.Option{
 		cmpopts.IgnoreFields(model.User{}, "Model"),
 	}
@@ -188,7 +196,9 @@ func TestUserService_GetOneByEmail(t *testing.T) {
 func TestUserService_UpdateOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mur := mock_repository.NewMockUserRepository(ctrl)
-	us := NewUserService(mur)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mvs := mock_service.NewMockVerificationService(ctrl)
+	us := NewUserService(mur, msr, mvs)
 
 	t.Run("should return error if payload is invalid", func(t *testing.T) {
 		if _, err := us.UpdateOneByID(1, dto.UpdateUserDTO{
@@ -205,6 +215,7 @@ func TestUserService_UpdateOneByID(t *testing.T) {
 		}
 	})
 	t.Run("should return error when repository returns error", func(t *testing.T) {
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Email: "test@example.com", Password: "$2a$12$htC6KUeMQ10/mBdUoVeRp.UW47NYED2gMG.mF/7oJ39p02XPJvuI2"}, nil)
 		mur.
 			EXPECT().
 			UpdateOneByID(gomock.Eq(1), gomock.Eq("test@example.com"), gomock.Eq("Fulan"), gomock.Any()).
@@ -221,6 +232,7 @@ func TestUserService_UpdateOneByID(t *testing.T) {
 		}
 	})
 	t.Run("should update user with hashed password and return the updated user", func(t *testing.T) {
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Email: "test@example.com", Password: "$2a$12$htC6KUeMQ10/mBdUoVeRp.UW47NYED2gMG.mF/7oJ39p02XPJvuI2"}, nil)
 		mur.
 			EXPECT().
 			UpdateOneByID(gomock.Eq(1), gomock.Eq("test@example.com"), gomock.Eq("Fulan"), gomock.Any()).
@@ -256,10 +268,89 @@ func TestUserService_UpdateOneByID(t *testing.T) {
 	})
 }
 
+func TestUserService_UpdateOneByID_Password(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mur := mock_repository.NewMockUserRepository(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mvs := mock_service.NewMockVerificationService(ctrl)
+	us := NewUserService(mur, msr, mvs)
+
+	t.Run("should log the user out of every session when the password changes", func(t *testing.T) {
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Email: "test@example.com", Password: "$2a$12$htC6KUeMQ10/mBdUoVeRp.UW47NYED2gMG.mF/7oJ39p02XPJvuI2"}, nil)
+		mur.EXPECT().UpdateOneByID(gomock.Eq(1), gomock.Eq("test@example.com"), gomock.Eq("Fulan"), gomock.Any()).Return(model.User{}, nil)
+		msr.EXPECT().RevokeManyBelongedToUser(gomock.Eq(uint(1))).Return(nil)
+
+		if _, err := us.UpdateOneByID(1, dto.UpdateUserDTO{
+			Email:    "test@example.com",
+			FullName: "Fulan",
+			Password: "newsecret",
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should return error if revoking the sessions fails", func(t *testing.T) {
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Email: "test@example.com", Password: "$2a$12$htC6KUeMQ10/mBdUoVeRp.UW47NYED2gMG.mF/7oJ39p02XPJvuI2"}, nil)
+		mur.EXPECT().UpdateOneByID(gomock.Eq(1), gomock.Eq("test@example.com"), gomock.Eq("Fulan"), gomock.Any()).Return(model.User{}, nil)
+		msr.EXPECT().RevokeManyBelongedToUser(gomock.Eq(uint(1))).Return(errors.New(""))
+
+		if _, err := us.UpdateOneByID(1, dto.UpdateUserDTO{
+			Email:    "test@example.com",
+			FullName: "Fulan",
+			Password: "newsecret",
+		}); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+}
+
+func TestUserService_EmailVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mur := mock_repository.NewMockUserRepository(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mvs := mock_service.NewMockVerificationService(ctrl)
+	us := NewUserService(mur, msr, mvs)
+
+	t.Run("should return the registered user along with ErrVerificationEmailNotSent", func(t *testing.T) {
+		mur.EXPECT().Insert(gomock.Eq("test@example.com"), gomock.Eq("Fulan"), gomock.Any()).Return(model.User{Model: gorm.Model{ID: 1}}, nil)
+		mvs.EXPECT().SendEmailVerification(gomock.Eq(1)).Return(errors.New("smtp down"))
+
+		got, err := us.Register(dto.RegisterUserDTO{
+			Email:    "test@example.com",
+			FullName: "Fulan",
+			Password: "topsecret",
+		})
+		if !errors.Is(err, ErrVerificationEmailNotSent) {
+			t.Error("exp ErrVerificationEmailNotSent; got", err)
+		}
+		if got.ID != 1 {
+			t.Error("exp the registered user; got", got.ID)
+		}
+	})
+	t.Run("should revoke earlier links and ask to verify a new email address", func(t *testing.T) {
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Email: "old@example.com", Password: "$2a$12$htC6KUeMQ10/mBdUoVeRp.UW47NYED2gMG.mF/7oJ39p02XPJvuI2"}, nil)
+		mur.EXPECT().UpdateOneByID(gomock.Eq(1), gomock.Eq("new@example.com"), gomock.Eq("Fulan"), gomock.Any()).Return(model.User{}, nil)
+		mur.EXPECT().SetEmailVerifiedAt(gomock.Eq(1), gomock.Nil()).Return(nil)
+		gomock.InOrder(
+			mvs.EXPECT().RevokeTokens(gomock.Eq(1)).Return(nil),
+			mvs.EXPECT().SendEmailVerification(gomock.Eq(1)).Return(nil),
+		)
+
+		if _, err := us.UpdateOneByID(1, dto.UpdateUserDTO{
+			Email:    "new@example.com",
+			FullName: "Fulan",
+			Password: "topsecret",
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
+
 func TestUserService_DeleteOneByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mur := mock_repository.NewMockUserRepository(ctrl)
-	us := NewUserService(mur)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mvs := mock_service.NewMockVerificationService(ctrl)
+	us := NewUserService(mur, msr, mvs)
 
 	t.Run("should return error when repository returns error", func(t *testing.T) {
 		mur.EXPECT().DeleteOneByID(gomock.Eq(1)).DoAndReturn(func(id int) error {
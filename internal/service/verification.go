@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/mailer"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	purposeVerifyEmail   = "verify_email"
+	purposeResetPassword = "reset_password"
+
+	verifyEmailTTL   = 48 * time.Hour
+	resetPasswordTTL = time.Hour
+)
+
+var (
+	ErrInvalidUserToken     = errors.New("Invalid, expired or already used link; request a new one")
+	ErrEmailAlreadyVerified = errors.New("Email is already verified")
+)
+
+type VerificationService interface {
+	SendEmailVerification(userID int) error
+	VerifyEmail(payload dto.VerifyEmailDTO) error
+	RequestPasswordReset(payload dto.RequestPasswordResetDTO) error
+	ResetPassword(payload dto.ResetPasswordDTO) error
+	// RevokeTokens uses up every link mailed to the user so far, such as
+	// when they change their email address.
+	RevokeTokens(userID int) error
+}
+
+type verificationService struct {
+	ur     repository.UserRepository
+	utr    repository.UserTokenRepository
+	sr     repository.SessionRepository
+	m      mailer.Mailer
+	appURL string
+}
+
+func NewVerificationService(ur repository.UserRepository, utr repository.UserTokenRepository, sr repository.SessionRepository, m mailer.Mailer, appURL string) *verificationService {
+	return &verificationService{ur, utr, sr, m, appURL}
+}
+
+func (vs *verificationService) SendEmailVerification(userID int) error {
+	user, err := vs.ur.GetOneByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, err := vs.issue(user, purposeVerifyEmail, verifyEmailTTL)
+	if err != nil {
+		return err
+	}
+
+	return vs.m.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: "Hi " + user.FullName + ",\n\n" +
+			"Open this link within 48 hours to verify your email address:\n\n" +
+			vs.link("/verify-email", token) + "\n\n" +
+			"If you didn't sign up for Spendtracker, ignore this email.\n",
+	})
+}
+
+func (vs *verificationService) VerifyEmail(payload dto.VerifyEmailDTO) error {
+	if err := validator.New().Struct(payload); err != nil {
+		return err
+	}
+	token, err := vs.utr.Use(purposeVerifyEmail, hashToken(payload.Token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidUserToken
+	}
+	if err != nil {
+		return err
+	}
+
+	// The user may have changed their email since the link was mailed.
+	err = vs.ur.VerifyEmail(int(token.UserID), token.Email, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidUserToken
+	}
+
+	return err
+}
+
+// RequestPasswordReset mails a reset link if a user has the email address.
+// It succeeds either way, so that it doesn't tell who has an account.
+func (vs *verificationService) RequestPasswordReset(payload dto.RequestPasswordResetDTO) error {
+	if err := validator.New().Struct(payload); err != nil {
+		return err
+	}
+	user, err := vs.ur.GetOneByEmail(payload.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := vs.issue(user, purposeResetPassword, resetPasswordTTL)
+	if err != nil {
+		return err
+	}
+
+	return vs.m.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: "Hi " + user.FullName + ",\n\n" +
+			"Open this link within an hour to choose a new password:\n\n" +
+			vs.link("/reset-password", token) + "\n\n" +
+			"If you didn't ask to reset your password, ignore this email; your password stays the same.\n",
+	})
+}
+
+// ResetPassword sets a new password and logs the user out of every session.
+// Following the link also proves the user owns the email address it was
+// mailed to, which is only good while it is still theirs.
+func (vs *verificationService) ResetPassword(payload dto.ResetPasswordDTO) error {
+	if err := validator.New().Struct(payload); err != nil {
+		return err
+	}
+	token, err := vs.utr.Use(purposeResetPassword, hashToken(payload.Token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidUserToken
+	}
+	if err != nil {
+		return err
+	}
+	user, err := vs.ur.GetOneByID(int(token.UserID))
+	if err != nil {
+		return err
+	}
+	if user.Email != token.Email {
+		return ErrInvalidUserToken
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := vs.ur.UpdatePassword(int(token.UserID), string(hashedPassword)); err != nil {
+		return err
+	}
+	if err := vs.sr.RevokeManyBelongedToUser(token.UserID); err != nil {
+		return err
+	}
+
+	if user.EmailVerifiedAt == nil {
+		// Should the email change meanwhile, the new one stays unverified.
+		err := vs.ur.VerifyEmail(int(token.UserID), token.Email, time.Now())
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (vs *verificationService) RevokeTokens(userID int) error {
+	return vs.utr.RevokeManyBelongedToUser(uint(userID))
+}
+
+func (vs *verificationService) issue(user model.User, purpose string, ttl time.Duration) (string, error) {
+	token, tokenHash, err := newToken("")
+	if err != nil {
+		return "", err
+	}
+	if _, err := vs.utr.Insert(model.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		Email:     user.Email,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// link is the page of the app at path that takes token.
+func (vs *verificationService) link(path, token string) string {
+	return strings.TrimRight(vs.appURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/dto"
+	"github.com/muhrizqiardi/spendtracker/internal/mailer"
+	mock_mailer "github.com/muhrizqiardi/spendtracker/internal/mailer/mock"
+	mock_repository "github.com/muhrizqiardi/spendtracker/internal/repository/mock"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestVerificationService_SendEmailVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mur := mock_repository.NewMockUserRepository(ctrl)
+	mutr := mock_repository.NewMockUserTokenRepository(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mm := mock_mailer.NewMockMailer(ctrl)
+	vs := NewVerificationService(mur, mutr, msr, mm, "https://app.example.com/")
+
+	t.Run("should return ErrEmailAlreadyVerified for verified users", func(t *testing.T) {
+		verifiedAt := time.Now()
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{EmailVerifiedAt: &verifiedAt}, nil)
+
+		if err := vs.SendEmailVerification(1); !errors.Is(err, ErrEmailAlreadyVerified) {
+			t.Error("exp ErrEmailAlreadyVerified; got", err)
+		}
+	})
+	t.Run("should mail a link with a token that is only stored hashed", func(t *testing.T) {
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Model: gorm.Model{ID: 1}, Email: "test@example.com"}, nil)
+		var stored model.UserToken
+		mutr.EXPECT().Insert(gomock.Any()).DoAndReturn(func(token model.UserToken) (model.UserToken, error) {
+			stored = token
+			return token, nil
+		})
+		var sent mailer.Message
+		mm.EXPECT().Send(gomock.Any()).DoAndReturn(func(msg mailer.Message) error {
+			sent = msg
+			return nil
+		})
+
+		if err := vs.SendEmailVerification(1); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if sent.To != "test@example.com" || !strings.Contains(sent.Body, "https://app.example.com/verify-email?token=") {
+			t.Error("exp a verification link to test@example.com; got", sent)
+		}
+		token := sent.Body[strings.Index(sent.Body, "token=")+len("token="):]
+		token = token[:strings.Index(token, "\n")]
+		if stored.TokenHash != hashToken(token) || stored.Purpose != purposeVerifyEmail || stored.UserID != 1 || stored.Email != "test@example.com" {
+			t.Error("exp the token to be stored hashed for the user and address; got", stored)
+		}
+	})
+}
+
+func TestVerificationService_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mur := mock_repository.NewMockUserRepository(ctrl)
+	mutr := mock_repository.NewMockUserTokenRepository(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mm := mock_mailer.NewMockMailer(ctrl)
+	vs := NewVerificationService(mur, mutr, msr, mm, "https://app.example.com")
+
+	t.Run("should return ErrInvalidUserToken for an unknown, used or expired token", func(t *testing.T) {
+		mutr.EXPECT().Use(gomock.Eq(purposeVerifyEmail), gomock.Eq(hashToken("token"))).Return(model.UserToken{}, gorm.ErrRecordNotFound)
+
+		if err := vs.VerifyEmail(dto.VerifyEmailDTO{Token: "token"}); !errors.Is(err, ErrInvalidUserToken) {
+			t.Error("exp ErrInvalidUserToken; got", err)
+		}
+	})
+	t.Run("should mark the token's user verified", func(t *testing.T) {
+		mutr.EXPECT().Use(gomock.Eq(purposeVerifyEmail), gomock.Eq(hashToken("token"))).Return(model.UserToken{UserID: 1, Email: "test@example.com"}, nil)
+		mur.EXPECT().VerifyEmail(gomock.Eq(1), gomock.Eq("test@example.com"), gomock.Any()).Return(nil)
+
+		if err := vs.VerifyEmail(dto.VerifyEmailDTO{Token: "token"}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should return ErrInvalidUserToken when the email changed since", func(t *testing.T) {
+		mutr.EXPECT().Use(gomock.Eq(purposeVerifyEmail), gomock.Eq(hashToken("token"))).Return(model.UserToken{UserID: 1, Email: "old@example.com"}, nil)
+		mur.EXPECT().VerifyEmail(gomock.Eq(1), gomock.Eq("old@example.com"), gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		if err := vs.VerifyEmail(dto.VerifyEmailDTO{Token: "token"}); !errors.Is(err, ErrInvalidUserToken) {
+			t.Error("exp ErrInvalidUserToken; got", err)
+		}
+	})
+}
+
+func TestVerificationService_PasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mur := mock_repository.NewMockUserRepository(ctrl)
+	mutr := mock_repository.NewMockUserTokenRepository(ctrl)
+	msr := mock_repository.NewMockSessionRepository(ctrl)
+	mm := mock_mailer.NewMockMailer(ctrl)
+	vs := NewVerificationService(mur, mutr, msr, mm, "https://app.example.com")
+
+	t.Run("should succeed without mailing anyone for an unknown address", func(t *testing.T) {
+		mur.EXPECT().GetOneByEmail(gomock.Eq("nobody@example.com")).Return(model.User{}, gorm.ErrRecordNotFound)
+
+		if err := vs.RequestPasswordReset(dto.RequestPasswordResetDTO{Email: "nobody@example.com"}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should mail a reset link", func(t *testing.T) {
+		mur.EXPECT().GetOneByEmail(gomock.Eq("test@example.com")).Return(model.User{Model: gorm.Model{ID: 1}, Email: "test@example.com"}, nil)
+		mutr.EXPECT().Insert(gomock.Any()).DoAndReturn(func(token model.UserToken) (model.UserToken, error) {
+			if token.Purpose != purposeResetPassword || token.ExpiresAt.After(time.Now().Add(resetPasswordTTL)) {
+				t.Error("exp a reset token expiring within", resetPasswordTTL, "; got", token)
+			}
+			return token, nil
+		})
+		mm.EXPECT().Send(gomock.Any()).DoAndReturn(func(msg mailer.Message) error {
+			if !strings.Contains(msg.Body, "https://app.example.com/reset-password?token=") {
+				t.Error("exp a reset link; got", msg.Body)
+			}
+			return nil
+		})
+
+		if err := vs.RequestPasswordReset(dto.RequestPasswordResetDTO{Email: "test@example.com"}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should return ErrInvalidUserToken for an unknown, used or expired token", func(t *testing.T) {
+		mutr.EXPECT().Use(gomock.Eq(purposeResetPassword), gomock.Any()).Return(model.UserToken{}, gorm.ErrRecordNotFound)
+
+		if err := vs.ResetPassword(dto.ResetPasswordDTO{Token: "token", Password: "newsecret"}); !errors.Is(err, ErrInvalidUserToken) {
+			t.Error("exp ErrInvalidUserToken; got", err)
+		}
+	})
+	t.Run("should set the password and log the user out everywhere", func(t *testing.T) {
+		verifiedAt := time.Now()
+		mutr.EXPECT().Use(gomock.Eq(purposeResetPassword), gomock.Eq(hashToken("token"))).Return(model.UserToken{UserID: 1, Email: "test@example.com"}, nil)
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Email: "test@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+		mur.EXPECT().UpdatePassword(gomock.Eq(1), gomock.Not(gomock.Eq("newsecret"))).Return(nil)
+		msr.EXPECT().RevokeManyBelongedToUser(gomock.Eq(uint(1))).Return(nil)
+
+		if err := vs.ResetPassword(dto.ResetPasswordDTO{Token: "token", Password: "newsecret"}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should verify the address the link was mailed to", func(t *testing.T) {
+		mutr.EXPECT().Use(gomock.Eq(purposeResetPassword), gomock.Eq(hashToken("token"))).Return(model.UserToken{UserID: 1, Email: "test@example.com"}, nil)
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Email: "test@example.com"}, nil)
+		mur.EXPECT().UpdatePassword(gomock.Eq(1), gomock.Any()).Return(nil)
+		msr.EXPECT().RevokeManyBelongedToUser(gomock.Eq(uint(1))).Return(nil)
+		mur.EXPECT().VerifyEmail(gomock.Eq(1), gomock.Eq("test@example.com"), gomock.Any()).Return(nil)
+
+		if err := vs.ResetPassword(dto.ResetPasswordDTO{Token: "token", Password: "newsecret"}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should not reset the password with a link mailed to an earlier address", func(t *testing.T) {
+		mutr.EXPECT().Use(gomock.Eq(purposeResetPassword), gomock.Eq(hashToken("token"))).Return(model.UserToken{UserID: 1, Email: "old@example.com"}, nil)
+		mur.EXPECT().GetOneByID(gomock.Eq(1)).Return(model.User{Email: "new@example.com"}, nil)
+
+		if err := vs.ResetPassword(dto.ResetPasswordDTO{Token: "token", Password: "newsecret"}); !errors.Is(err, ErrInvalidUserToken) {
+			t.Error("exp ErrInvalidUserToken; got", err)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key of RFC 6238's test vectors.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	t.Run("should match the RFC 6238 test vectors", func(t *testing.T) {
+		for _, tc := range []struct {
+			unix int64
+			exp  string
+		}{
+			// The RFC lists 8 digit codes; these are their last 6.
+			{59, "287082"},
+			{1111111109, "081804"},
+			{1111111111, "050471"},
+			{1234567890, "005924"},
+			{2000000000, "279037"},
+			{20000000000, "353130"},
+		} {
+			got, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+			if err != nil {
+				t.Error("exp nil; got error:", err)
+			}
+			if got != tc.exp {
+				t.Errorf("exp %s at %d; got %s", tc.exp, tc.unix, got)
+			}
+		}
+	})
+	t.Run("should return error for a secret that isn't base32", func(t *testing.T) {
+		if _, err := Code("not base32!", 1); err == nil {
+			t.Error("exp error; got nil")
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	t.Run("should accept the current code and its neighbours", func(t *testing.T) {
+		for _, offset := range []time.Duration{-Period, 0, Period} {
+			code, _ := Code(rfcSecret, Step(now.Add(offset)))
+			step, ok := Validate(rfcSecret, code, now)
+			if !ok || step != Step(now.Add(offset)) {
+				t.Error("exp the code to be valid for its step; got", step, ok)
+			}
+		}
+	})
+	t.Run("should refuse codes from further away and malformed codes", func(t *testing.T) {
+		code, _ := Code(rfcSecret, Step(now.Add(-2*Period)))
+		for _, c := range []string{code, "", "12345", "1234567"} {
+			if _, ok := Validate(rfcSecret, c, now); ok {
+				t.Error("exp invalid; got valid for", c)
+			}
+		}
+	})
+}
+
+func TestURI(t *testing.T) {
+	t.Run("should build an otpauth URI", func(t *testing.T) {
+		got := URI("Spendtracker", "user@example.com", "SECRET")
+		if !strings.HasPrefix(got, "otpauth://totp/Spendtracker:user@example.com?") || !strings.Contains(got, "secret=SECRET") || !strings.Contains(got, "issuer=Spendtracker") {
+			t.Error("exp an otpauth URI; got", got)
+		}
+	})
+}
+
+func TestNewSecret(t *testing.T) {
+	t.Run("should return distinct secrets that codes can be made from", func(t *testing.T) {
+		a, _ := NewSecret()
+		b, _ := NewSecret()
+		if a == b {
+			t.Error("exp distinct secrets; got", a, b)
+		}
+		if _, err := Code(a, 1); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
@@ -1,9 +1,19 @@
 package util
 
 type BaseResponse[T any] struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Data    T      `json:"data,omitempty"`
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       T           `json:"data,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination places a page of a listing within it. A cursor is passed back
+// as the cursor query parameter to get the page it points at, and is
+// omitted when there is no such page.
+type Pagination struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+	Total      int64  `json:"total"`
 }
 
 func CreateBaseResponse[T any](success bool, message string, data T) BaseResponse[T] {
@@ -13,3 +23,12 @@ func CreateBaseResponse[T any](success bool, message string, data T) BaseRespons
 		Data:    data,
 	}
 }
+
+func CreatePaginatedResponse[T any](message string, data T, pagination Pagination) BaseResponse[T] {
+	return BaseResponse[T]{
+		Success:    true,
+		Message:    message,
+		Data:       data,
+		Pagination: &pagination,
+	}
+}
@@ -3,6 +3,8 @@ package util
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,6 +18,32 @@ type Config struct {
 	DB_Host      string
 	DB_Name      string
 	Secret       string
+
+	// AppURL is where the app is served, which links in emails point to.
+	AppURL string
+	// Mailer is how emails are sent: "smtp", "file" to write them into
+	// MailDir, or "log", the default, to log them.
+	Mailer       string
+	MailFrom     string
+	MailDir      string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// LLMProvider is the model advice comes from: "openai", the default,
+	// for any OpenAI-compatible API, "ollama" for a local Ollama server or
+	// "offline" for canned advice that needs no model. Empty options use
+	// the provider's defaults.
+	LLMProvider  string
+	LLMBaseURL   string
+	LLMModel     string
+	LLMMaxTokens int
+	LLMTimeout   time.Duration
+
+	// SchedulerInterval is how often due recurring transactions are
+	// recorded.
+	SchedulerInterval time.Duration
 }
 
 func LoadConfig() Config {
@@ -32,6 +60,30 @@ func LoadConfig() Config {
 		DB_Host:      os.Getenv("DB_HOST"),
 		Secret:       os.Getenv("SECRET"),
 		OpenAIAPIKey: os.Getenv("OPENAI_API_KEY"),
+
+		AppURL:       os.Getenv("APP_URL"),
+		Mailer:       os.Getenv("MAILER"),
+		MailFrom:     os.Getenv("MAIL_FROM"),
+		MailDir:      os.Getenv("MAIL_DIR"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     os.Getenv("SMTP_PORT"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+
+		LLMProvider: os.Getenv("LLM_PROVIDER"),
+		LLMBaseURL:  os.Getenv("LLM_BASE_URL"),
+		LLMModel:    os.Getenv("LLM_MODEL"),
+
+		SchedulerInterval: time.Minute,
+	}
+	if maxTokens, err := strconv.Atoi(os.Getenv("LLM_MAX_TOKENS")); err == nil && maxTokens > 0 {
+		cfg.LLMMaxTokens = maxTokens
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("LLM_TIMEOUT")); err == nil && timeout > 0 {
+		cfg.LLMTimeout = timeout
+	}
+	if interval, err := time.ParseDuration(os.Getenv("SCHEDULER_INTERVAL")); err == nil && interval > 0 {
+		cfg.SchedulerInterval = interval
 	}
 
 	return cfg
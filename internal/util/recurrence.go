@@ -0,0 +1,78 @@
+package util
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+var ErrInvalidFrequency = errors.New("Frequency must be one of daily, weekly, monthly or yearly")
+
+// Recurrence is a subset of an iCalendar RRULE: FREQ, INTERVAL, UNTIL and
+// COUNT anchored at Start. Occurrences are computed in Location so that a
+// schedule keeps its wall-clock time across DST changes. Monthly and yearly
+// occurrences falling on a day the month doesn't have (the 31st, or 29
+// February) are moved to the last day of that month instead of skipped.
+type Recurrence struct {
+	Frequency string
+	Interval  int
+	Start     time.Time
+	Until     time.Time
+	Count     int
+	Location  *time.Location
+}
+
+// Occurrence returns the nth occurrence, counting from zero at Start. ok is
+// false once the rule has ended by Count or Until.
+func (r Recurrence) Occurrence(n int) (t time.Time, ok bool, err error) {
+	if n < 0 || (r.Count > 0 && n >= r.Count) {
+		return time.Time{}, false, nil
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	loc := r.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	start := r.Start.In(loc)
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		t = start.AddDate(0, 0, n*interval)
+	case FrequencyWeekly:
+		t = start.AddDate(0, 0, 7*n*interval)
+	case FrequencyMonthly:
+		t = addMonthsClamped(start, n*interval)
+	case FrequencyYearly:
+		t = addMonthsClamped(start, 12*n*interval)
+	default:
+		return time.Time{}, false, ErrInvalidFrequency
+	}
+
+	if !r.Until.IsZero() && t.After(r.Until) {
+		return time.Time{}, false, nil
+	}
+
+	return t.UTC(), true, nil
+}
+
+// addMonthsClamped adds months to t, keeping its day of month unless the
+// target month is shorter.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	day := t.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
@@ -0,0 +1,102 @@
+package util
+
+import (
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// Period bounds a query by time. A zero From or To leaves that end open; To
+// is exclusive.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// ParseDateOrTime accepts either an RFC 3339 timestamp or a plain
+// YYYY-MM-DD date, which is read as midnight UTC. isDate reports whether the
+// value was a plain date, so callers can treat it as a whole day.
+func ParseDateOrTime(value string) (t time.Time, isDate bool, err error) {
+	return ParseDateOrTimeIn(value, time.UTC)
+}
+
+// ParseDateOrTimeIn is ParseDateOrTime with plain dates read as midnight in
+// loc.
+func ParseDateOrTimeIn(value string, loc *time.Location) (t time.Time, isDate bool, err error) {
+	if t, err := time.ParseInLocation(DateLayout, value, loc); err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return t, false, nil
+}
+
+// ParsePeriod builds a Period from optional from/to query values. A plain
+// date in to includes that whole day.
+func ParsePeriod(from, to string) (Period, error) {
+	return ParsePeriodIn(from, to, time.UTC)
+}
+
+// ParsePeriodIn is ParsePeriod with plain dates read as days in loc.
+func ParsePeriodIn(from, to string, loc *time.Location) (Period, error) {
+	var period Period
+	if from != "" {
+		t, _, err := ParseDateOrTimeIn(from, loc)
+		if err != nil {
+			return Period{}, err
+		}
+		period.From = t
+	}
+	if to != "" {
+		t, isDate, err := ParseDateOrTimeIn(to, loc)
+		if err != nil {
+			return Period{}, err
+		}
+		if isDate {
+			t = t.AddDate(0, 0, 1)
+		}
+		period.To = t
+	}
+
+	return period, nil
+}
+
+// MonthOf returns the calendar month containing t, in t's location.
+func MonthOf(t time.Time) Period {
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return Period{From: from, To: from.AddDate(0, 1, 0)}
+}
+
+// YearOf returns the calendar year containing t, in t's location.
+func YearOf(t time.Time) Period {
+	from := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	return Period{From: from, To: from.AddDate(1, 0, 0)}
+}
+
+// LoadTimezone resolves an IANA zone name such as "Asia/Jakarta" or a fixed
+// UTC offset such as "+07:00". An empty name is UTC.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if offset, err := time.Parse("-07:00", name); err == nil {
+		_, seconds := offset.Zone()
+		return time.FixedZone(name, seconds), nil
+	}
+
+	return time.LoadLocation(name)
+}
+
+// InTimezone renders t in the named zone, falling back to UTC when the zone
+// cannot be resolved.
+func InTimezone(t time.Time, name string) time.Time {
+	loc, err := LoadTimezone(name)
+	if err != nil {
+		return t.UTC()
+	}
+
+	return t.In(loc)
+}
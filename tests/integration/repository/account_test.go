@@ -0,0 +1,186 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForAccountTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Account{},
+		&model.Expense{},
+		&model.Income{},
+		&model.Transfer{},
+		&model.RecurringTemplate{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestAccountRepository_GetTransactionTotals(t *testing.T) {
+	db, err := setupDBForAccountTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ar := repository.NewAccountRepository(db)
+
+	past := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Now().Add(24 * time.Hour)
+	if err := db.Create(&[]model.Expense{
+		{UserID: 1, AccountID: 1, Amount: 300, OccurredAt: past},
+		{UserID: 1, AccountID: 1, Amount: 200, OccurredAt: time.Now()},
+		{UserID: 1, AccountID: 2, Amount: 50, OccurredAt: time.Now()},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Income{
+		{UserID: 1, AccountID: 1, Amount: 1000, OccurredAt: past},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should net incomes and expenses per account", func(t *testing.T) {
+		got, err := ar.GetTransactionTotals([]uint{1, 2}, future)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got[1] != 500 {
+			t.Error("exp 500; got", got[1])
+		}
+		if got[2] != -50 {
+			t.Error("exp -50; got", got[2])
+		}
+	})
+	t.Run("should only include transactions before the given time", func(t *testing.T) {
+		got, err := ar.GetTransactionTotals([]uint{1}, past.Add(time.Hour))
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got[1] != 700 {
+			t.Error("exp 700; got", got[1])
+		}
+	})
+}
+
+func TestAccountRepository_GetTransactionTotals_Transfers(t *testing.T) {
+	db, err := setupDBForAccountTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ar := repository.NewAccountRepository(db)
+	tr := repository.NewTransferRepository(db)
+
+	if err := db.Create(&[]model.Account{
+		{UserID: 1, CurrencyID: 1},
+		{UserID: 1, CurrencyID: 2},
+		{UserID: 2, CurrencyID: 1},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should debit amount and fee from source and credit received amount to destination", func(t *testing.T) {
+		if _, err := tr.Insert(1, 1, 2, "", 1000, 10, 0.5, 500, time.Now(), "UTC"); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+
+		got, err := ar.GetTransactionTotals([]uint{1, 2}, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got[1] != -1010 {
+			t.Error("exp -1010; got", got[1])
+		}
+		if got[2] != 500 {
+			t.Error("exp 500; got", got[2])
+		}
+	})
+	t.Run("should not record transfer to an account of another user", func(t *testing.T) {
+		if _, err := tr.Insert(1, 1, 3, "", 1000, 0, 1, 1000, time.Now(), "UTC"); !errors.Is(err, repository.ErrTransferAccountNotFound) {
+			t.Error("exp ErrTransferAccountNotFound; got", err)
+		}
+
+		var count int64
+		db.Model(&model.Transfer{}).Count(&count)
+		if count != 1 {
+			t.Error("exp 1; got", count)
+		}
+	})
+}
+
+func TestAccountRepository_UpdateOneByID(t *testing.T) {
+	db, err := setupDBForAccountTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ar := repository.NewAccountRepository(db)
+
+	account, err := ar.Insert(1, 1, "Acme Bank", 1000)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should not update an account that belongs to another user", func(t *testing.T) {
+		if _, err := ar.UpdateOneByID(2, account.ID, 1, "Stolen", 0); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should keep the owner when updating", func(t *testing.T) {
+		got, err := ar.UpdateOneByID(1, account.ID, 2, "Lorem Bank", 500)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.UserID != 1 || got.Name != "Lorem Bank" || got.CurrencyID != 2 {
+			t.Error("exp Lorem Bank in currency 2 owned by user 1; got", got.Name, got.CurrencyID, got.UserID)
+		}
+	})
+	t.Run("should not change the currency once the account has transactions", func(t *testing.T) {
+		if err := db.Create(&model.Transfer{UserID: 1, FromAccountID: 99, ToAccountID: account.ID, Amount: 100, ReceivedAmount: 100, OccurredAt: time.Now()}).Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if _, err := ar.UpdateOneByID(1, account.ID, 3, "Lorem Bank", 500); !errors.Is(err, repository.ErrAccountHasTransactions) {
+			t.Error("exp repository.ErrAccountHasTransactions; got", err)
+		}
+		got, err := ar.UpdateOneByID(1, account.ID, 2, "Ipsum Bank", 500)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Name != "Ipsum Bank" || got.CurrencyID != 2 {
+			t.Error("exp Ipsum Bank in currency 2; got", got.Name, got.CurrencyID)
+		}
+	})
+}
+
+func TestAccountRepository_DeleteOneByID(t *testing.T) {
+	db, err := setupDBForAccountTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ar := repository.NewAccountRepository(db)
+
+	account, err := ar.Insert(1, 1, "Acme Bank", 1000)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should not delete an account that belongs to another user", func(t *testing.T) {
+		if err := ar.DeleteOneByID(2, account.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+		if _, err := ar.GetOneByID(1, account.ID); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
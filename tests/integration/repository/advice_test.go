@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForAdviceTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.User{},
+		&model.Currency{},
+		&model.Account{},
+		&model.Category{},
+		&model.ExchangeRate{},
+		&model.Expense{},
+		&model.Income{},
+		&model.Budget{},
+		&model.RecurringTemplate{},
+		&model.Advice{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestAdviceRepository_GetDataVersion(t *testing.T) {
+	db, err := setupDBForAdviceTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ar := repository.NewAdviceRepository(db)
+	user := model.User{Email: "test@example.com"}
+	other := model.User{Email: "other@example.com"}
+	db.Create(&user)
+	db.Create(&other)
+	expense := model.Expense{UserID: user.ID, Name: "Coffee", Amount: 500}
+	db.Create(&expense)
+
+	version, err := ar.GetDataVersion(user.ID)
+	if err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+	assertVersion := func(t *testing.T, changed bool) {
+		got, err := ar.GetDataVersion(user.ID)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if changed == (got == version) {
+			t.Errorf("exp changed to be %v; got %q, was %q", changed, got, version)
+		}
+		version = got
+	}
+
+	t.Run("should stay the same while nothing changes", func(t *testing.T) {
+		assertVersion(t, false)
+	})
+	t.Run("should ignore other users' data", func(t *testing.T) {
+		db.Create(&model.Expense{UserID: other.ID, Name: "Tea", Amount: 300})
+
+		assertVersion(t, false)
+	})
+	t.Run("should change when an expense is created", func(t *testing.T) {
+		db.Create(&model.Expense{UserID: user.ID, Name: "Lunch", Amount: 1500})
+
+		assertVersion(t, true)
+	})
+	t.Run("should change when an expense is deleted", func(t *testing.T) {
+		db.Delete(&expense)
+
+		assertVersion(t, true)
+	})
+	t.Run("should change when an income is created", func(t *testing.T) {
+		db.Create(&model.Income{UserID: user.ID, Name: "Salary", Amount: 500000})
+
+		assertVersion(t, true)
+	})
+	t.Run("should change when an income is updated", func(t *testing.T) {
+		var income model.Income
+		db.First(&income, "user_id = ?", user.ID)
+		db.Model(&income).Update("amount", 600000)
+
+		assertVersion(t, true)
+	})
+	t.Run("should change when a budget is created", func(t *testing.T) {
+		db.Create(&model.Budget{UserID: user.ID, CategoryID: 1, MonthlyLimit: 10000})
+
+		assertVersion(t, true)
+	})
+	t.Run("should change with the reporting currency", func(t *testing.T) {
+		currency := model.Currency{Code: "USD", MinorUnit: 2}
+		db.Create(&currency)
+		db.Model(&user).Update("reporting_currency_id", currency.ID)
+
+		assertVersion(t, true)
+	})
+}
+
+func TestAdviceRepository_GetLatestByInputHash(t *testing.T) {
+	db, err := setupDBForAdviceTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ar := repository.NewAdviceRepository(db)
+
+	for _, a := range []model.Advice{
+		{UserID: 1, InputHash: "input", Text: "first"},
+		{UserID: 1, InputHash: "input", Text: "second"},
+		{UserID: 1, InputHash: "other", Text: "third"},
+	} {
+		if _, err := ar.Insert(a); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	}
+
+	t.Run("should return the latest advice for the input", func(t *testing.T) {
+		got, err := ar.GetLatestByInputHash(1, "input")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Text != "second" {
+			t.Errorf("exp %q; got %q", "second", got.Text)
+		}
+	})
+	t.Run("should not return another user's advice", func(t *testing.T) {
+		if _, err := ar.GetLatestByInputHash(2, "input"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should list advice newest first", func(t *testing.T) {
+		page, err := ar.GetManyBelongedToUser(1, 2, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(page.Items) != 2 || page.Items[0].Text != "third" || page.Total != 3 || page.NextCursor == "" {
+			t.Error("exp the 2 newest of 3; got", page)
+		}
+
+		next, err := ar.GetManyBelongedToUser(1, 2, page.NextCursor)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(next.Items) != 1 || next.Items[0].Text != "first" {
+			t.Error("exp the oldest; got", next.Items)
+		}
+	})
+}
@@ -0,0 +1,304 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForBackupTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.User{},
+		&model.Currency{},
+		&model.Account{},
+		&model.Category{},
+		&model.Tag{},
+		&model.Expense{},
+		&model.Income{},
+		&model.Transfer{},
+		&model.Budget{},
+		&model.RecurringTemplate{},
+		&model.RecurringOccurrence{},
+		&model.ImportMapping{},
+		&model.ExchangeRate{},
+		&model.Advice{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestBackupRepository_ExportAndRestore(t *testing.T) {
+	db, err := setupDBForBackupTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	br := repository.NewBackupRepository(db)
+
+	if err := db.Create(&[]model.Currency{{Code: "IDR"}, {Code: "USD"}}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	reportingCurrencyID := uint(2)
+	if err := db.Create(&[]model.User{
+		{Email: "one@example.com", ReportingCurrencyID: &reportingCurrencyID},
+		{Email: "two@example.com"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Account{
+		{UserID: 1, CurrencyID: 1, Name: "Wallet"},
+		{UserID: 1, CurrencyID: 2, Name: "Card"},
+		{UserID: 2, CurrencyID: 1, Name: "Other"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Category{
+		{UserID: 1, Name: "Food"},
+		{UserID: 1, Name: "Travel"},
+		{UserID: 2, Name: "Food"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	occurredAt := time.Date(2023, time.October, 14, 12, 0, 0, 0, time.UTC)
+	if err := db.Create(&[]model.Expense{
+		{UserID: 1, AccountID: 2, CategoryID: 2, Name: "Flight", Amount: 300, OccurredAt: occurredAt, Timezone: "UTC", Tags: []model.Tag{{UserID: 1, Name: "trip"}}},
+		{UserID: 2, AccountID: 3, CategoryID: 3, Name: "Lunch", Amount: 5, OccurredAt: occurredAt, Timezone: "UTC"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Expense{
+		{UserID: 1, AccountID: 1, Name: "Before categories", Amount: 10, OccurredAt: occurredAt, Timezone: "UTC"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Income{
+		{UserID: 1, AccountID: 1, Name: "Salary", Amount: 1000, OccurredAt: occurredAt, Timezone: "UTC"},
+		{UserID: 2, AccountID: 3, Name: "Salary", Amount: 500, OccurredAt: occurredAt, Timezone: "UTC"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Transfer{
+		{UserID: 1, FromAccountID: 1, ToAccountID: 2, Amount: 150, Fee: 1, ExchangeRate: 0.5, ReceivedAmount: 75, OccurredAt: occurredAt, Timezone: "UTC"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	accountID := uint(2)
+	if err := db.Create(&[]model.Budget{
+		{UserID: 1, CategoryID: 2, AccountID: &accountID, CurrencyID: 2, MonthlyLimit: 500},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	nextAt := occurredAt.AddDate(0, 1, 0)
+	if err := db.Create(&[]model.RecurringTemplate{
+		{UserID: 1, Type: model.TransactionTypeIncome, AccountID: 1, Name: "Salary", Amount: 1000, Frequency: "monthly", Interval: 1, StartAt: occurredAt, Timezone: "UTC", Materialized: 1, NextAt: &nextAt},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.RecurringOccurrence{
+		{TemplateID: 1, Sequence: 1, OccurredAt: occurredAt, TransactionID: 1},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.ImportMapping{
+		{UserID: 1, Name: "Bank", DateColumn: 1, DescriptionColumn: 2, AmountColumn: 3, SignConvention: "negative", DateFormat: "YYYY-MM-DD"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.ExchangeRate{
+		{UserID: 1, Date: occurredAt, BaseCurrency: "USD", QuoteCurrency: "IDR", Rate: 15000},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	var backup model.Backup
+	t.Run("should export only the user's data with currencies", func(t *testing.T) {
+		backup, err = br.Export(1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(backup.Accounts) != 2 || len(backup.Categories) != 2 || len(backup.Expenses) != 2 {
+			t.Fatal("exp 2 accounts, 2 categories, 2 expenses; got", len(backup.Accounts), len(backup.Categories), len(backup.Expenses))
+		}
+		if len(backup.Incomes) != 1 || len(backup.Transfers) != 1 || len(backup.Budgets) != 1 {
+			t.Fatal("exp 1 income, 1 transfer, 1 budget; got", len(backup.Incomes), len(backup.Transfers), len(backup.Budgets))
+		}
+		if len(backup.RecurringTemplates) != 1 || len(backup.RecurringOccurrences) != 1 || len(backup.ImportMappings) != 1 || len(backup.ExchangeRates) != 1 {
+			t.Fatal("exp 1 template, occurrence, mapping and rate; got", len(backup.RecurringTemplates), len(backup.RecurringOccurrences), len(backup.ImportMappings), len(backup.ExchangeRates))
+		}
+		if backup.Accounts[1].Currency == nil || backup.Accounts[1].Currency.Code != "USD" {
+			t.Error("exp USD; got", backup.Accounts[1].Currency)
+		}
+		if backup.ReportingCurrency == nil || backup.ReportingCurrency.Code != "USD" {
+			t.Error("exp USD; got", backup.ReportingCurrency)
+		}
+		if len(backup.Expenses[0].Tags) != 1 || backup.Expenses[0].Tags[0].Name != "trip" {
+			t.Error("exp Flight tagged trip; got", backup.Expenses[0].Tags)
+		}
+	})
+	t.Run("should restore into another user with new IDs", func(t *testing.T) {
+		restored, err := br.Restore(2, backup)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(restored.Accounts) != 2 || len(restored.Categories) != 3 || len(restored.Expenses) != 2 {
+			t.Fatal("exp 2 accounts, 3 categories, 2 expenses; got", len(restored.Accounts), len(restored.Categories), len(restored.Expenses))
+		}
+		if restored.Categories[0].ID != 3 {
+			t.Error("exp user 2's existing Food category 3; got", restored.Categories[0].ID)
+		}
+
+		wallet, card, travel, flight := restored.Accounts[0], restored.Accounts[1], restored.Categories[1], restored.Expenses[0]
+		if card.ID == 2 || card.UserID != 2 || card.CurrencyID != 2 {
+			t.Error("exp a new USD account of user 2; got", card.ID, card.UserID, card.CurrencyID)
+		}
+		if flight.AccountID != card.ID || flight.CategoryID != travel.ID || flight.UserID != 2 {
+			t.Error("exp flight remapped to", card.ID, travel.ID, "; got", flight.AccountID, flight.CategoryID, flight.UserID)
+		}
+		if !flight.OccurredAt.Equal(occurredAt) {
+			t.Error("exp", occurredAt, "; got", flight.OccurredAt)
+		}
+		if uncategorized := restored.Categories[2]; uncategorized.Name != "Uncategorized" || restored.Expenses[1].CategoryID != uncategorized.ID {
+			t.Error("exp the expense without a category in Uncategorized; got", restored.Expenses[1].CategoryID, uncategorized)
+		}
+
+		salary := restored.Incomes[0]
+		if salary.ID == 1 || salary.UserID != 2 || salary.AccountID != wallet.ID || salary.Amount != 1000 {
+			t.Error("exp a new income of user 2 into", wallet.ID, "; got", salary.ID, salary.UserID, salary.AccountID, salary.Amount)
+		}
+		transfer := restored.Transfers[0]
+		if transfer.FromAccountID != wallet.ID || transfer.ToAccountID != card.ID || transfer.UserID != 2 || transfer.ReceivedAmount != 75 {
+			t.Error("exp transfer remapped from", wallet.ID, "to", card.ID, "; got", transfer.FromAccountID, transfer.ToAccountID, transfer.UserID, transfer.ReceivedAmount)
+		}
+		budget := restored.Budgets[0]
+		if budget.CategoryID != travel.ID || budget.AccountID == nil || *budget.AccountID != card.ID {
+			t.Error("exp budget remapped to", travel.ID, card.ID, "; got", budget.CategoryID, budget.AccountID)
+		}
+		template, occurrence := restored.RecurringTemplates[0], restored.RecurringOccurrences[0]
+		if template.AccountID != wallet.ID || template.Materialized != 1 || template.NextAt == nil || !template.NextAt.Equal(nextAt) {
+			t.Error("exp template of", wallet.ID, "with its progress; got", template.AccountID, template.Materialized, template.NextAt)
+		}
+		if occurrence.TemplateID != template.ID || occurrence.TransactionID != salary.ID {
+			t.Error("exp occurrence linked to", template.ID, salary.ID, "; got", occurrence.TemplateID, occurrence.TransactionID)
+		}
+
+		var user model.User
+		if err := db.First(&user, 2).Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if user.ReportingCurrencyID == nil || *user.ReportingCurrencyID != 2 {
+			t.Error("exp reporting currency 2; got", user.ReportingCurrencyID)
+		}
+		got, err := br.Export(2)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got.Incomes) != 2 || len(got.Transfers) != 1 || len(got.ImportMappings) != 1 || len(got.ExchangeRates) != 1 {
+			t.Error("exp 2 incomes, 1 transfer, 1 mapping, 1 rate; got", len(got.Incomes), len(got.Transfers), len(got.ImportMappings), len(got.ExchangeRates))
+		}
+		for _, e := range got.Expenses {
+			if e.ID == flight.ID && (len(e.Tags) != 1 || e.Tags[0].Name != "trip" || e.Tags[0].UserID != 2) {
+				t.Error("exp flight tagged with user 2's trip; got", e.Tags)
+			}
+		}
+	})
+	t.Run("should not duplicate exchange rates the user already has", func(t *testing.T) {
+		if _, err := br.Restore(2, model.Backup{ExchangeRates: backup.ExchangeRates}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+
+		var count int64
+		if err := db.Model(&model.ExchangeRate{}).Where("user_id = ?", 2).Count(&count).Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if count != 1 {
+			t.Error("exp 1; got", count)
+		}
+	})
+	t.Run("should leave the source user's data alone", func(t *testing.T) {
+		got, err := br.Export(1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got.Accounts) != 2 || len(got.Expenses) != 2 || len(got.Incomes) != 1 {
+			t.Error("exp 2 accounts, 2 expenses, 1 income; got", len(got.Accounts), len(got.Expenses), len(got.Incomes))
+		}
+	})
+}
+
+func TestBackupRepository_ExportAndRestore_Deleted(t *testing.T) {
+	db, err := setupDBForBackupTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	br := repository.NewBackupRepository(db)
+
+	if err := db.Create(&[]model.User{{Email: "one@example.com"}, {Email: "two@example.com"}}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&model.Currency{Code: "IDR"}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&model.Account{UserID: 1, CurrencyID: 1, Name: "Old card"}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Category{{UserID: 1, Name: "Hobbies"}, {UserID: 2, Name: "Food"}}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&model.Expense{UserID: 1, AccountID: 1, CategoryID: 1, Name: "Paint", Amount: 50, OccurredAt: time.Now(), Timezone: "UTC"}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	for _, record := range []interface{}{&model.Account{}, &model.Category{}} {
+		if err := db.Delete(record, 1).Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	}
+	if err := db.Delete(&model.Category{}, 2).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	var backup model.Backup
+	t.Run("should export deleted accounts and categories", func(t *testing.T) {
+		backup, err = br.Export(1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(backup.Accounts) != 1 || !backup.Accounts[0].DeletedAt.Valid || len(backup.Categories) != 1 || !backup.Categories[0].DeletedAt.Valid {
+			t.Fatal("exp the deleted account and category; got", backup.Accounts, backup.Categories)
+		}
+	})
+	t.Run("should restore them deleted", func(t *testing.T) {
+		restored, err := br.Restore(2, backup)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if !restored.Accounts[0].DeletedAt.Valid || !restored.Categories[0].DeletedAt.Valid {
+			t.Error("exp both deleted; got", restored.Accounts[0].DeletedAt, restored.Categories[0].DeletedAt)
+		}
+		if restored.Expenses[0].AccountID != restored.Accounts[0].ID || restored.Expenses[0].CategoryID != restored.Categories[0].ID {
+			t.Error("exp the expense in them; got", restored.Expenses[0].AccountID, restored.Expenses[0].CategoryID)
+		}
+	})
+	t.Run("should bring back a deleted category the backup has in use", func(t *testing.T) {
+		restored, err := br.Restore(2, model.Backup{Categories: []model.Category{{Model: gorm.Model{ID: 8}, Name: "Food"}}})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		var food model.Category
+		if err := db.First(&food, "user_id = ? AND name = ?", 2, "Food").Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if restored.Categories[0].ID != food.ID {
+			t.Error("exp user 2's Food", food.ID, "; got", restored.Categories[0].ID)
+		}
+	})
+}
@@ -1 +1,65 @@
 package integration
+
+import (
+	"testing"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForCategoryTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(&model.Category{}); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestCategoryRepository_GetManyByIDs(t *testing.T) {
+	db, err := setupDBForCategoryTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	cr := repository.NewCategoryRepository(db)
+
+	var ids []uint
+	for _, c := range []struct {
+		userID uint
+		name   string
+	}{{1, "Food"}, {1, "Pets"}, {2, "Travel"}} {
+		category, err := cr.Insert(c.userID, c.name)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		ids = append(ids, category.ID)
+	}
+	if err := cr.Delete(1, ids[1]); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should return the user's categories, deleted ones included", func(t *testing.T) {
+		got, err := cr.GetManyByIDs(1, ids)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 || got[0].Name != "Food" || got[1].Name != "Pets" {
+			t.Error("exp Food and Pets; got", got)
+		}
+	})
+	t.Run("should return none without ids", func(t *testing.T) {
+		got, err := cr.GetManyByIDs(1, nil)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 0 {
+			t.Error("exp none; got", got)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForCurrencyTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Currency{},
+		&model.User{},
+		&model.ExchangeRate{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestCurrencyRepository_ReportingCurrency(t *testing.T) {
+	db, err := setupDBForCurrencyTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	cr := repository.NewCurrencyRepository(db)
+
+	if err := db.Create(&[]model.Currency{{Code: "IDR"}, {Code: "USD"}}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	user := model.User{Email: "user@example.com"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should return nil before one is set", func(t *testing.T) {
+		got, err := cr.GetReportingCurrency(user.ID)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != nil {
+			t.Error("exp nil; got", got)
+		}
+	})
+	t.Run("should set and return the reporting currency", func(t *testing.T) {
+		usd, err := cr.GetOneByCode("USD")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if err := cr.SetReportingCurrency(user.ID, &usd.ID); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, err := cr.GetReportingCurrency(user.ID)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got == nil || got.Code != "USD" {
+			t.Error("exp USD; got", got)
+		}
+	})
+	t.Run("should clear the reporting currency", func(t *testing.T) {
+		if err := cr.SetReportingCurrency(user.ID, nil); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got, err := cr.GetReportingCurrency(user.ID); err != nil || got != nil {
+			t.Error("exp nil, nil; got", got, err)
+		}
+	})
+}
+
+func TestCurrencyRepository_ExchangeRates(t *testing.T) {
+	db, err := setupDBForCurrencyTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	cr := repository.NewCurrencyRepository(db)
+
+	day := func(d int) time.Time { return time.Date(2023, time.October, d, 0, 0, 0, 0, time.UTC) }
+	if err := cr.UpsertExchangeRates(1, []model.ExchangeRate{
+		{Date: day(12), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1.06},
+		{Date: day(13), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1.04},
+		{Date: day(13), BaseCurrency: "EUR", QuoteCurrency: "IDR", Rate: 16500},
+	}); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := cr.UpsertExchangeRates(2, []model.ExchangeRate{
+		{Date: day(13), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 9},
+	}); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should replace a rate for the same day and currencies", func(t *testing.T) {
+		if err := cr.UpsertExchangeRates(1, []model.ExchangeRate{
+			{Date: day(13), BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: 1.05},
+		}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, err := cr.GetExchangeRates(1, "EUR", "USD", util.Period{}, 10, 0)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		if got[0].Rate != 1.05 || !got[0].Date.Equal(day(13)) {
+			t.Error("exp 1.05 on 2023-10-13 first; got", got[0].Rate, got[0].Date)
+		}
+	})
+	t.Run("should only return the user's rates in the period, oldest first", func(t *testing.T) {
+		got, err := cr.GetExchangeRatesInPeriod(1, util.Period{From: day(13), To: day(14)})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		for _, r := range got {
+			if r.UserID != 1 || !r.Date.Equal(day(13)) {
+				t.Error("exp user 1's rates on 2023-10-13; got", r)
+			}
+		}
+	})
+}
@@ -1,14 +1,72 @@
 package integration
 
-import "testing"
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"github.com/muhrizqiardi/spendtracker/tests/testutil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForExpenseTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Currency{},
+		&model.Account{},
+		&model.Category{},
+		&model.Tag{},
+		&model.Expense{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
 
 func TestExpenseRepository_Insert(t *testing.T) {
 	t.Run("should insert expense", func(t *testing.T) {})
 }
 
 func TestExpenseRepository_GetOneByID(t *testing.T) {
-	t.Run("should return error if the expense does not exist", func(t *testing.T) {})
-	t.Run("should return one expense by ID", func(t *testing.T) {})
+	db, err := setupDBForExpenseTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	er := repository.NewExpenseRepository(db)
+
+	expense, err := er.Insert(1, 1, 1, "Dinner", "", 120000, time.Now(), "UTC", nil)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should return error if the expense does not exist", func(t *testing.T) {
+		if _, err := er.GetOneByID(1, 1001); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should return error if the expense belongs to another user", func(t *testing.T) {
+		if _, err := er.GetOneByID(2, expense.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should return one expense by ID", func(t *testing.T) {
+		got, err := er.GetOneByID(1, expense.ID)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.ID != expense.ID || got.Name != "Dinner" {
+			t.Error("exp Dinner; got", got.Name)
+		}
+	})
 }
 
 func TestExpenseRepository_GetMany(t *testing.T) {
@@ -16,11 +74,414 @@ func TestExpenseRepository_GetMany(t *testing.T) {
 }
 
 func TestExpenseRepository_UpdateOneByID(t *testing.T) {
-	t.Run("should return error if the expense does not exist", func(t *testing.T) {})
-	t.Run("should update expense and return expense", func(t *testing.T) {})
+	db, err := setupDBForExpenseTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	er := repository.NewExpenseRepository(db)
+
+	expense, err := er.Insert(1, 1, 1, "Dinner", "", 120000, time.Now(), "UTC", nil)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should return error if the expense does not exist", func(t *testing.T) {
+		if _, err := er.UpdateOneByID(1, 1001, 1, "Lunch", "", 50000, time.Now(), "UTC", nil); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should not update an expense that belongs to another user", func(t *testing.T) {
+		if _, err := er.UpdateOneByID(2, expense.ID, 1, "Lunch", "", 50000, time.Now(), "UTC", nil); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+		got, err := er.GetOneByID(1, expense.ID)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Name != "Dinner" {
+			t.Error("exp Dinner; got", got.Name)
+		}
+	})
+	t.Run("should update expense and return expense", func(t *testing.T) {
+		got, err := er.UpdateOneByID(1, expense.ID, 1, "Lunch", "", 50000, time.Now(), "UTC", nil)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Name != "Lunch" || got.UserID != 1 || got.AccountID != 1 {
+			t.Error("exp Lunch on user 1, account 1; got", got.Name, got.UserID, got.AccountID)
+		}
+	})
 }
 
 func TestExpenseRepository_DeleteOneByID(t *testing.T) {
-	t.Run("should return error if the expense does not exist", func(t *testing.T) {})
-	t.Run("should delete expense and return the deleted expense", func(t *testing.T) {})
+	db, err := setupDBForExpenseTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	er := repository.NewExpenseRepository(db)
+
+	expense, err := er.Insert(1, 1, 1, "Dinner", "", 120000, time.Now(), "UTC", nil)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should return error if the expense does not exist", func(t *testing.T) {
+		if err := er.DeleteOneByID(1, 1001); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should not delete an expense that belongs to another user", func(t *testing.T) {
+		if err := er.DeleteOneByID(2, expense.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+		if _, err := er.GetOneByID(1, expense.ID); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+	t.Run("should delete expense", func(t *testing.T) {
+		if err := er.DeleteOneByID(1, expense.ID); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if _, err := er.GetOneByID(1, expense.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+}
+
+func TestExpenseRepository_GetManyBelongedToUser_Filter(t *testing.T) {
+	db, err := setupDBForExpenseTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	er := repository.NewExpenseRepository(db)
+	ar := repository.NewAccountRepository(db)
+
+	currencies := []model.Currency{{Code: "USD", MinorUnit: 2}, {Code: "JPY"}}
+	if err := db.Create(&currencies).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	usd, err := ar.Insert(1, currencies[0].ID, "Checking", 0)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	jpy, err := ar.Insert(1, currencies[1].ID, "Travel", 0)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	saturday := time.Date(2023, time.October, 14, 12, 30, 0, 0, time.UTC)
+	monday := time.Date(2023, time.October, 16, 8, 0, 0, 0, time.UTC)
+	if _, err := er.Insert(1, usd.ID, 1, "Dinner", "", 120000, saturday, "Asia/Jakarta", []string{"food", "date"}); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if _, err := er.Insert(1, usd.ID, 2, "Electricity", "", 300000, monday, "UTC", nil); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if _, err := er.Insert(1, usd.ID, 2, "Water", "", 100000, saturday, "UTC", nil); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ramen, err := er.Insert(1, jpy.ID, 1, "Ramen", "Lunch_special, 100% pork", 1500, monday, "Asia/Tokyo", []string{"travel"})
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if _, err := er.Insert(2, jpy.ID, 1, "Sushi", "", 3000, monday, "Asia/Tokyo", []string{"food"}); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	names := func(expenses []model.Expense) []string {
+		names := make([]string, 0, len(expenses))
+		for _, e := range expenses {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+
+	t.Run("should persist category and filter by it", func(t *testing.T) {
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{CategoryIDs: []uint{2}}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got.Items) != 2 {
+			t.Fatal("exp 2; got", len(got.Items))
+		}
+		for _, e := range got.Items {
+			if e.CategoryID != 2 {
+				t.Error("exp category 2; got", e.CategoryID)
+			}
+		}
+	})
+	t.Run("should filter by several accounts and categories at once", func(t *testing.T) {
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{
+			AccountIDs:  []uint{usd.ID, jpy.ID},
+			CategoryIDs: []uint{1},
+		}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []string{"Ramen", "Dinner"}, names(got.Items))
+	})
+	t.Run("should compare amounts in each expense's own currency", func(t *testing.T) {
+		// From 1,000 to 2,000: 1,200.00 and 1,000.00 USD, and 1,500 JPY.
+		minAmount, maxAmount := 10000000, 20000000
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{
+			MinAmount: &minAmount,
+			MaxAmount: &maxAmount,
+			SortBy:    "name",
+			Ascending: true,
+		}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []string{"Dinner", "Ramen", "Water"}, names(got.Items))
+	})
+	t.Run("should search name and description for every word", func(t *testing.T) {
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{Search: "ramen PORK"}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []string{"Ramen"}, names(got.Items))
+	})
+	t.Run("should match wildcards in the search literally", func(t *testing.T) {
+		for _, search := range []string{"_", "100%"} {
+			got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{Search: search}, 10, "")
+			if err != nil {
+				t.Error("exp nil; got error:", err)
+			}
+			testutil.CompareAndAssert(t, []string{"Ramen"}, names(got.Items))
+		}
+	})
+	t.Run("should filter by any of the tags", func(t *testing.T) {
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{Tags: []string{"food", "travel"}}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []string{"Ramen", "Dinner"}, names(got.Items))
+		if len(got.Items) == 2 && (len(got.Items[1].Tags) != 2 || got.Items[1].Tags[0].Name != "date") {
+			t.Error("exp Dinner tagged date and food; got", got.Items[1].Tags)
+		}
+	})
+	t.Run("should replace tags on update", func(t *testing.T) {
+		if _, err := er.UpdateOneByID(1, ramen.ID, 1, "Ramen", ramen.Description, 1500, monday, "Asia/Tokyo", []string{"food"}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{Tags: []string{"travel"}}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []string{}, names(got.Items))
+	})
+	t.Run("should sort by the field asked for", func(t *testing.T) {
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{SortBy: "amount"}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []string{"Electricity", "Dinner", "Water", "Ramen"}, names(got.Items))
+	})
+}
+
+func TestExpenseRepository_GetManyBelongedToUser(t *testing.T) {
+	db, err := setupDBForExpenseTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	er := repository.NewExpenseRepository(db)
+
+	saturday := time.Date(2023, time.October, 14, 12, 30, 0, 0, time.UTC)
+	monday := time.Date(2023, time.October, 16, 8, 0, 0, 0, time.UTC)
+	// Typed in on Monday for a purchase made on Saturday.
+	if _, err := er.Insert(1, 1, 1, "Groceries", "", 250000, saturday, "Asia/Jakarta", nil); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if _, err := er.Insert(1, 1, 1, "Lunch", "", 50000, monday, "Asia/Jakarta", nil); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should sort by occurrence time, newest first", func(t *testing.T) {
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got.Items) != 2 {
+			t.Fatal("exp 2; got", len(got.Items))
+		}
+		if got.Items[0].Name != "Lunch" || got.Items[1].Name != "Groceries" {
+			t.Error("exp Lunch, Groceries; got", got.Items[0].Name, got.Items[1].Name)
+		}
+	})
+	t.Run("should filter by occurrence time", func(t *testing.T) {
+		period := util.Period{
+			From: time.Date(2023, time.October, 14, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2023, time.October, 15, 0, 0, 0, 0, time.UTC),
+		}
+		got, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{Period: period}, 10, "")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got.Items) != 1 {
+			t.Fatal("exp 1; got", len(got.Items))
+		}
+		if got.Items[0].Name != "Groceries" || got.Items[0].Timezone != "Asia/Jakarta" {
+			t.Error("exp Groceries in Asia/Jakarta; got", got.Items[0].Name, got.Items[0].Timezone)
+		}
+	})
+}
+
+func TestExpenseRepository_GetManyBelongedToUser_Pagination(t *testing.T) {
+	db, err := setupDBForExpenseTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	er := repository.NewExpenseRepository(db)
+
+	day := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	for i, amount := range []int{300, 100, 200, 200, 400} {
+		name := string(rune('A' + i))
+		if _, err := er.Insert(1, 1, 1, name, "", amount, day.AddDate(0, 0, i), "UTC", nil); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	}
+	if _, err := er.Insert(2, 1, 1, "F", "", 100, day, "UTC", nil); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	names := func(expenses []model.Expense) []string {
+		names := make([]string, 0, len(expenses))
+		for _, e := range expenses {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+	byAmount := repository.ExpenseFilter{SortBy: "amount", Ascending: true}
+
+	t.Run("should walk forward through pages, ties broken by ID", func(t *testing.T) {
+		got := []string{}
+		page, err := er.GetManyBelongedToUser(1, byAmount, 2, "")
+		if err != nil {
+			t.Fatal("exp nil; got error:", err)
+		}
+		if page.Total != 5 || page.PrevCursor != "" {
+			t.Error("exp total 5 and no previous page; got", page.Total, page.PrevCursor)
+		}
+		got = append(got, names(page.Items)...)
+		for page.NextCursor != "" {
+			if page, err = er.GetManyBelongedToUser(1, byAmount, 2, page.NextCursor); err != nil {
+				t.Fatal("exp nil; got error:", err)
+			}
+			got = append(got, names(page.Items)...)
+		}
+		testutil.CompareAndAssert(t, []string{"B", "C", "D", "A", "E"}, got)
+	})
+	t.Run("should walk back to the previous page", func(t *testing.T) {
+		first, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{}, 2, "")
+		if err != nil {
+			t.Fatal("exp nil; got error:", err)
+		}
+		second, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{}, 2, first.NextCursor)
+		if err != nil {
+			t.Fatal("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, []string{"C", "B"}, names(second.Items))
+
+		back, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{}, 2, second.PrevCursor)
+		if err != nil {
+			t.Fatal("exp nil; got error:", err)
+		}
+		testutil.CompareAndAssert(t, names(first.Items), names(back.Items))
+		if back.PrevCursor != "" || back.NextCursor == "" {
+			t.Error("exp only a next page; got", back.PrevCursor, back.NextCursor)
+		}
+	})
+	t.Run("should reject a cursor made for another order", func(t *testing.T) {
+		page, err := er.GetManyBelongedToUser(1, byAmount, 2, "")
+		if err != nil {
+			t.Fatal("exp nil; got error:", err)
+		}
+		if _, err := er.GetManyBelongedToUser(1, repository.ExpenseFilter{}, 2, page.NextCursor); !errors.Is(err, repository.ErrInvalidCursor) {
+			t.Error("exp repository.ErrInvalidCursor; got", err)
+		}
+		if _, err := er.GetManyBelongedToUser(1, byAmount, 2, "not a cursor"); !errors.Is(err, repository.ErrInvalidCursor) {
+			t.Error("exp repository.ErrInvalidCursor; got", err)
+		}
+	})
+}
+
+func TestExpenseRepository_GetTotalsBelongedToCategory(t *testing.T) {
+	db, err := setupDBForExpenseTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	er := repository.NewExpenseRepository(db)
+
+	currencies := []model.Currency{{Code: "IDR"}, {Code: "USD"}}
+	if err := db.Create(&currencies).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Account{
+		{UserID: 1, CurrencyID: currencies[0].ID},
+		{UserID: 1, CurrencyID: currencies[1].ID},
+		{UserID: 2, CurrencyID: currencies[0].ID},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	october := util.Period{
+		From: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+	}
+	inOctober := time.Date(2023, time.October, 14, 12, 0, 0, 0, time.UTC)
+	for _, e := range []struct {
+		userID, accountID, categoryID uint
+		amount                        int
+		occurredAt                    time.Time
+	}{
+		{1, 1, 1, 100000, inOctober},
+		{1, 1, 1, 20000, inOctober},
+		{1, 2, 1, 50, inOctober},
+		{1, 1, 2, 70000, inOctober},
+		{1, 1, 1, 30000, time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		{2, 3, 1, 90000, inOctober},
+	} {
+		if _, err := er.Insert(e.userID, e.accountID, e.categoryID, "", "", e.amount, e.occurredAt, "UTC", nil); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	}
+
+	t.Run("should total the user's expenses in the category and period per currency and day", func(t *testing.T) {
+		got, err := er.GetTotalsBelongedToCategory(1, 1, nil, october)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+		totals := map[string]model.ExpenseStatsRow{}
+		for _, r := range got {
+			totals[r.Currency] = r
+		}
+		if idr := totals["IDR"]; idr.Total != 120000 || idr.Count != 2 || idr.Date != "2023-10-14" {
+			t.Error("exp 2 IDR expenses totalling 120000 on 2023-10-14; got", idr)
+		}
+		if usd := totals["USD"]; usd.Total != 50 {
+			t.Error("exp 50 USD; got", usd)
+		}
+	})
+	t.Run("should only count the given account", func(t *testing.T) {
+		accountID := uint(2)
+		got, err := er.GetTotalsBelongedToCategory(1, 1, &accountID, october)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 1 || got[0].Currency != "USD" || got[0].Total != 50 {
+			t.Error("exp 50 USD; got", got)
+		}
+	})
+	t.Run("should return nothing without expenses", func(t *testing.T) {
+		got, err := er.GetTotalsBelongedToCategory(1, 9, nil, october)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 0 {
+			t.Error("exp 0; got", len(got))
+		}
+	})
 }
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForImportTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Expense{},
+		&model.Income{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestImportRepository_InsertStatement(t *testing.T) {
+	db, err := setupDBForImportTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ir := repository.NewImportRepository(db)
+
+	externalID := func(id string) *string { return &id }
+	statement := func() ([]model.Expense, []model.Income) {
+		return []model.Expense{
+				{UserID: 1, AccountID: 1, CategoryID: 1, Name: "Coffee", Amount: 25000, OccurredAt: time.Now(), Timezone: "UTC", ExternalID: externalID("A1")},
+				{UserID: 1, AccountID: 1, CategoryID: 1, Name: "Lunch", Amount: 50000, OccurredAt: time.Now(), Timezone: "UTC", ExternalID: externalID("A3")},
+			}, []model.Income{
+				{UserID: 1, AccountID: 1, Name: "Salary", Amount: 5000000, OccurredAt: time.Now(), Timezone: "UTC", ExternalID: externalID("A2")},
+			}
+	}
+
+	t.Run("should insert expenses and incomes and return their IDs", func(t *testing.T) {
+		expenses, incomes, err := ir.InsertStatement(statement())
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(expenses) != 2 || expenses[0].ID == 0 || len(incomes) != 1 || incomes[0].ID == 0 {
+			t.Error("exp 2 expenses and 1 income with IDs; got", expenses, incomes)
+		}
+	})
+	t.Run("should find external IDs imported into the account", func(t *testing.T) {
+		got, err := ir.GetImportedExternalIDs(1, []string{"A1", "A2", "A4"})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		sort.Strings(got)
+		if len(got) != 2 || got[0] != "A1" || got[1] != "A2" {
+			t.Error("exp A1, A2; got", got)
+		}
+		got, err = ir.GetImportedExternalIDs(2, []string{"A1", "A2"})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 0 {
+			t.Error("exp none for another account; got", got)
+		}
+	})
+	t.Run("should still find a deleted transaction", func(t *testing.T) {
+		if err := db.Where("external_id = ?", "A3").Delete(&model.Expense{}).Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, err := ir.GetImportedExternalIDs(1, []string{"A3"})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 1 {
+			t.Error("exp A3; got", got)
+		}
+	})
+	t.Run("should insert nothing when part of the statement was imported before", func(t *testing.T) {
+		expenses := []model.Expense{
+			{UserID: 1, AccountID: 1, CategoryID: 1, Name: "Tea", Amount: 1000, OccurredAt: time.Now(), Timezone: "UTC", ExternalID: externalID("A5")},
+			{UserID: 1, AccountID: 1, CategoryID: 1, Name: "Coffee", Amount: 25000, OccurredAt: time.Now(), Timezone: "UTC", ExternalID: externalID("A1")},
+		}
+		if _, _, err := ir.InsertStatement(expenses, nil); err == nil {
+			t.Error("exp error; got nil")
+		}
+
+		var count int64
+		if err := db.Model(&model.Expense{}).Where("name = ?", "Tea").Count(&count).Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if count != 0 {
+			t.Error("exp 0; got", count)
+		}
+	})
+}
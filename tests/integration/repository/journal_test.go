@@ -0,0 +1,86 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForJournalTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Currency{},
+		&model.Account{},
+		&model.Category{},
+		&model.Expense{},
+		&model.Income{},
+		&model.Transfer{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestJournalRepository_Get(t *testing.T) {
+	db, err := setupDBForJournalTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	jr := repository.NewJournalRepository(db)
+
+	currency := model.Currency{Code: "IDR"}
+	if err := db.Create(&currency).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	accounts := []model.Account{
+		{UserID: 1, CurrencyID: currency.ID, Name: "Cash"},
+		{UserID: 1, CurrencyID: currency.ID, Name: "Closed"},
+		{UserID: 2, CurrencyID: currency.ID, Name: "Other"},
+	}
+	if err := db.Create(&accounts).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Delete(&accounts[1]).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	later := time.Date(2023, time.October, 16, 0, 0, 0, 0, time.UTC)
+	earlier := time.Date(2023, time.October, 14, 0, 0, 0, 0, time.UTC)
+	if err := db.Create(&[]model.Expense{
+		{UserID: 1, AccountID: accounts[1].ID, Name: "Lunch", Amount: 50000, OccurredAt: later, Timezone: "UTC"},
+		{UserID: 1, AccountID: accounts[0].ID, Name: "Dinner", Amount: 120000, OccurredAt: earlier, Timezone: "UTC"},
+		{UserID: 2, AccountID: accounts[2].ID, Name: "Other", Amount: 1000, OccurredAt: earlier, Timezone: "UTC"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	got, err := jr.Get(1)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should keep deleted accounts with their currency", func(t *testing.T) {
+		if len(got.Accounts) != 2 {
+			t.Fatal("exp 2; got", len(got.Accounts))
+		}
+		if got.Accounts[1].Name != "Closed" || got.Accounts[1].Currency == nil || got.Accounts[1].Currency.Code != "IDR" {
+			t.Error("exp Closed in IDR; got", got.Accounts[1])
+		}
+	})
+	t.Run("should only return the user's expenses, oldest first", func(t *testing.T) {
+		if len(got.Expenses) != 2 {
+			t.Fatal("exp 2; got", len(got.Expenses))
+		}
+		if got.Expenses[0].Name != "Dinner" || got.Expenses[1].Name != "Lunch" {
+			t.Error("exp Dinner, Lunch; got", got.Expenses[0].Name, got.Expenses[1].Name)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForRecurringTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Expense{},
+		&model.Income{},
+		&model.RecurringTemplate{},
+		&model.RecurringOccurrence{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestRecurringRepository_Materialize(t *testing.T) {
+	db, err := setupDBForRecurringTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	rr := repository.NewRecurringRepository(db)
+
+	first := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 1, 0)
+	template, err := rr.Insert(model.RecurringTemplate{
+		UserID:     1,
+		Type:       model.TransactionTypeExpense,
+		AccountID:  1,
+		CategoryID: 2,
+		Name:       "Rent",
+		Amount:     3000000,
+		Frequency:  "monthly",
+		Interval:   1,
+		StartAt:    first,
+		Timezone:   "UTC",
+		NextAt:     &first,
+	})
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should list the template as due", func(t *testing.T) {
+		got, err := rr.GetManyDue(first, nil, 10)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 1 {
+			t.Error("exp 1; got", len(got))
+		}
+	})
+	t.Run("should leave out templates to skip", func(t *testing.T) {
+		got, err := rr.GetManyDue(first, []uint{template.ID}, 10)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 0 {
+			t.Error("exp 0; got", len(got))
+		}
+	})
+	t.Run("should record the occurrence once", func(t *testing.T) {
+		if err := rr.Materialize(template, &second); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		// A second scheduler still holding the old snapshot.
+		if err := rr.Materialize(template, &second); !errors.Is(err, repository.ErrOccurrenceAlreadyMaterialized) {
+			t.Error("exp ErrOccurrenceAlreadyMaterialized; got", err)
+		}
+
+		var expenses []model.Expense
+		if err := db.Find(&expenses).Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(expenses) != 1 {
+			t.Fatal("exp 1; got", len(expenses))
+		}
+		if expenses[0].Name != "Rent" || expenses[0].CategoryID != 2 || !expenses[0].OccurredAt.Equal(first) {
+			t.Error("exp Rent in category 2 on", first, "; got", expenses[0].Name, expenses[0].CategoryID, expenses[0].OccurredAt)
+		}
+
+		var occurrences []model.RecurringOccurrence
+		if err := db.Find(&occurrences).Error; err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(occurrences) != 1 || occurrences[0].TransactionID != expenses[0].ID {
+			t.Error("exp 1 occurrence linked to the expense; got", occurrences)
+		}
+	})
+	t.Run("should advance the template", func(t *testing.T) {
+		got, err := rr.GetOneByID(1, template.ID)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Materialized != 1 || got.NextAt == nil || !got.NextAt.Equal(second) {
+			t.Error("exp 1 materialized, next at", second, "; got", got.Materialized, got.NextAt)
+		}
+		due, err := rr.GetManyDue(first, nil, 10)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(due) != 0 {
+			t.Error("exp 0; got", len(due))
+		}
+	})
+	t.Run("should keep progress when the template is edited", func(t *testing.T) {
+		got, err := rr.UpdateOneByID(1, template.ID, 1, 2, "Rent", "Flat", 3200000)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Amount != 3200000 || got.Materialized != 1 {
+			t.Error("exp 3200000 with 1 materialized; got", got.Amount, got.Materialized)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForReportTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Currency{},
+		&model.Account{},
+		&model.Category{},
+		&model.Expense{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+// sumRows adds up rows by key, as if they were all in one currency.
+func sumRows(rows []model.ExpenseStatsRow) map[uint]model.ExpenseStatsRow {
+	sums := map[uint]model.ExpenseStatsRow{}
+	for _, r := range rows {
+		sum := sums[r.Key]
+		sum.Key, sum.Name = r.Key, r.Name
+		sum.Count += r.Count
+		sum.Total += r.Total
+		if r.Largest > sum.Largest {
+			sum.Largest = r.Largest
+		}
+		sums[r.Key] = sum
+	}
+
+	return sums
+}
+
+func TestReportRepository(t *testing.T) {
+	db, err := setupDBForReportTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	rr := repository.NewReportRepository(db)
+	er := repository.NewExpenseRepository(db)
+
+	currencies := []model.Currency{{Code: "IDR"}, {Code: "USD"}}
+	if err := db.Create(&currencies).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Account{
+		{UserID: 1, CurrencyID: currencies[0].ID, Name: "Cash"},
+		{UserID: 1, CurrencyID: currencies[0].ID, Name: "Bank"},
+		{UserID: 1, CurrencyID: currencies[1].ID, Name: "Wise"},
+	}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := db.Create(&[]model.Category{{UserID: 1, Name: "Food"}, {UserID: 1, Name: "Bills"}}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	for _, e := range []struct {
+		userID, accountID, categoryID uint
+		name                          string
+		amount                        int
+		occurredAt                    time.Time
+	}{
+		{1, 1, 1, "Lunch", 50000, time.Date(2023, time.October, 2, 5, 0, 0, 0, time.UTC)},
+		{1, 1, 1, "Dinner", 120000, time.Date(2023, time.October, 2, 12, 0, 0, 0, time.UTC)},
+		{1, 2, 2, "Electricity", 300000, time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC)},
+		{1, 3, 2, "Hosting", 20, time.Date(2023, time.October, 20, 0, 0, 0, 0, time.UTC)},
+		{1, 2, 2, "Water", 100000, time.Date(2023, time.November, 3, 0, 0, 0, 0, time.UTC)},
+		{2, 4, 3, "Other", 999999, time.Date(2023, time.October, 2, 0, 0, 0, 0, time.UTC)},
+	} {
+		if _, err := er.Insert(e.userID, e.accountID, e.categoryID, e.name, "", e.amount, e.occurredAt, "UTC", nil); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	}
+	deleted, err := er.Insert(1, 1, 1, "Deleted", "", 1000000, time.Date(2023, time.October, 3, 0, 0, 0, 0, time.UTC), "UTC", nil)
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if err := er.DeleteOneByID(1, deleted.ID); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	october := util.Period{
+		From: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("should aggregate the user's expenses per currency and day", func(t *testing.T) {
+		got, err := rr.GetExpenseStats(1, october)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 3 {
+			t.Fatal("exp 3; got", got)
+		}
+		rows := map[string]model.ExpenseStatsRow{}
+		for _, r := range got {
+			rows[r.Currency+" "+r.Date] = r
+		}
+		if r := rows["IDR 2023-10-02"]; r.Count != 2 || r.Total != 170000 || r.Average != 85000 || r.Largest != 120000 {
+			t.Error("exp 2 IDR expenses totalling 170000 on 2023-10-02; got", r)
+		}
+		if r := rows["IDR 2023-10-20"]; r.Total != 300000 {
+			t.Error("exp 300000 IDR on 2023-10-20; got", r)
+		}
+		if r := rows["USD 2023-10-20"]; r.Total != 20 {
+			t.Error("exp 20 USD on 2023-10-20; got", r)
+		}
+	})
+	t.Run("should return the largest expense in a currency", func(t *testing.T) {
+		got, err := rr.GetLargestExpense(1, "USD", october)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.Name != "Hosting" {
+			t.Error("exp Hosting; got", got.Name)
+		}
+	})
+	t.Run("should return not found without expenses", func(t *testing.T) {
+		if _, err := rr.GetLargestExpense(3, "IDR", october); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should aggregate into each period", func(t *testing.T) {
+		periods := []util.Period{
+			{From: october.From, To: october.From.AddDate(0, 0, 14)},
+			{From: october.From.AddDate(0, 0, 14), To: october.From.AddDate(0, 0, 28)},
+			{From: october.From.AddDate(0, 0, 28), To: october.To},
+		}
+		got, err := rr.GetExpenseStatsByPeriods(1, periods)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		sums := sumRows(got)
+		if len(sums) != 2 {
+			t.Fatal("exp 2 periods with expenses; got", sums)
+		}
+		if sums[0].Count != 2 || sums[0].Total != 170000 || sums[0].Largest != 120000 {
+			t.Error("exp 2 expenses totalling 170000; got", sums[0])
+		}
+		if sums[1].Count != 2 {
+			t.Error("exp 2 expenses; got", sums[1])
+		}
+	})
+	t.Run("should aggregate by category with its name", func(t *testing.T) {
+		got, err := rr.GetExpenseStatsByCategory(1, october)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		sums := sumRows(got)
+		if sums[1].Name != "Food" || sums[1].Count != 2 || sums[2].Name != "Bills" || sums[2].Count != 2 {
+			t.Error("exp Food and Bills; got", sums)
+		}
+	})
+	t.Run("should aggregate by account with its name", func(t *testing.T) {
+		got, err := rr.GetExpenseStatsByAccount(1, util.Period{})
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		sums := sumRows(got)
+		if sums[2].Name != "Bank" || sums[2].Total != 400000 || sums[1].Name != "Cash" || sums[1].Total != 170000 || sums[3].Name != "Wise" {
+			t.Error("exp Bank, Cash and Wise; got", sums)
+		}
+	})
+}
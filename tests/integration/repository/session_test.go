@@ -0,0 +1,119 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForSessionTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Session{},
+		&model.RefreshToken{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestSessionRepository_RotateRefreshToken(t *testing.T) {
+	db, err := setupDBForSessionTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	sr := repository.NewSessionRepository(db)
+
+	expiresAt := time.Now().Add(time.Hour)
+	session, err := sr.Insert(1, "first", expiresAt)
+	if err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+	first, err := sr.GetRefreshToken("first")
+	if err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+
+	t.Run("should return the refresh token with its session", func(t *testing.T) {
+		if first.Session == nil || first.Session.ID != session.ID || first.UsedAt != nil {
+			t.Error("exp an unused token of the session; got", first)
+		}
+	})
+	t.Run("should mark the token used and store its replacement", func(t *testing.T) {
+		if err := sr.RotateRefreshToken(first.ID, session.ID, "second", expiresAt); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		used, err := sr.GetRefreshToken("first")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if used.UsedAt == nil {
+			t.Error("exp the token to be marked used")
+		}
+		second, err := sr.GetRefreshToken("second")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if second.SessionID != session.ID {
+			t.Error("exp the replacement in the same session; got", second.SessionID)
+		}
+	})
+	t.Run("should return ErrRefreshTokenUsed when rotating a used token", func(t *testing.T) {
+		if err := sr.RotateRefreshToken(first.ID, session.ID, "third", expiresAt); !errors.Is(err, repository.ErrRefreshTokenUsed) {
+			t.Error("exp repository.ErrRefreshTokenUsed; got", err)
+		}
+		if _, err := sr.GetRefreshToken("third"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+}
+
+func TestSessionRepository_Revoke(t *testing.T) {
+	db, err := setupDBForSessionTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	sr := repository.NewSessionRepository(db)
+
+	expiresAt := time.Now().Add(time.Hour)
+	a, _ := sr.Insert(1, "a", expiresAt)
+	b, _ := sr.Insert(1, "b", expiresAt)
+	other, _ := sr.Insert(2, "c", expiresAt)
+
+	t.Run("should revoke one session", func(t *testing.T) {
+		if err := sr.RevokeOneByID(a.ID); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, err := sr.GetOneByID(a.ID)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.RevokedAt == nil {
+			t.Error("exp the session to be revoked")
+		}
+		if got, _ := sr.GetOneByID(b.ID); got.RevokedAt != nil {
+			t.Error("exp the other session to stay active")
+		}
+	})
+	t.Run("should revoke every session of the user and only theirs", func(t *testing.T) {
+		if err := sr.RevokeManyBelongedToUser(1); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got, _ := sr.GetOneByID(b.ID); got.RevokedAt == nil {
+			t.Error("exp the session to be revoked")
+		}
+		if got, _ := sr.GetOneByID(other.ID); got.RevokedAt != nil {
+			t.Error("exp another user's session to stay active")
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"github.com/muhrizqiardi/spendtracker/internal/util"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForTransactionTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.Currency{},
+		&model.Account{},
+		&model.Expense{},
+		&model.Income{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestTransactionRepository_GetManyBelongedToUser(t *testing.T) {
+	db, err := setupDBForTransactionTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	er := repository.NewExpenseRepository(db)
+	ir := repository.NewIncomeRepository(db)
+	tr := repository.NewTransactionRepository(db)
+
+	if err := db.Create(&model.Currency{Code: "USD", MinorUnit: 2}).Error; err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if _, err := repository.NewAccountRepository(db).Insert(1, 1, "Wallet", 0); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if _, err := er.Insert(1, 1, 1, "Dinner", "", 120000, time.Now(), "UTC", nil); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if _, err := ir.Insert(1, 1, "Salary", "", 5000000, time.Now(), "UTC"); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	if _, err := er.Insert(2, 3, 2, "Coffee", "", 30000, time.Now(), "UTC", nil); err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should return expenses and incomes of the user with signed amounts", func(t *testing.T) {
+		got, err := tr.GetManyBelongedToUser(1, util.Period{}, 10, 0)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 2 {
+			t.Fatal("exp 2; got", len(got))
+		}
+
+		amounts := map[string]int{}
+		for _, tx := range got {
+			amounts[tx.Type] = tx.Amount
+			if tx.Currency != "USD" || tx.MinorUnit != 2 {
+				t.Error("exp USD with 2 decimal places; got", tx.Currency, tx.MinorUnit)
+			}
+		}
+		if amounts[model.TransactionTypeExpense] != -120000 {
+			t.Error("exp -120000; got", amounts[model.TransactionTypeExpense])
+		}
+		if amounts[model.TransactionTypeIncome] != 5000000 {
+			t.Error("exp 5000000; got", amounts[model.TransactionTypeIncome])
+		}
+	})
+	t.Run("should paginate over both kinds", func(t *testing.T) {
+		got, err := tr.GetManyBelongedToUser(1, util.Period{}, 1, 1)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if len(got) != 1 {
+			t.Error("exp 1; got", len(got))
+		}
+	})
+}
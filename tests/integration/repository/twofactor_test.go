@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForTwoFactorTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.User{},
+		&model.RecoveryCode{},
+		&model.TwoFactorChallenge{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestTwoFactorRepository(t *testing.T) {
+	db, err := setupDBForTwoFactorTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	tfr := repository.NewTwoFactorRepository(db)
+	ur := repository.NewUserRepository(db)
+
+	user, err := ur.Insert("totp@example.com", "Fulan", "hashedpass")
+	if err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+
+	t.Run("should keep the secret pending until enabled", func(t *testing.T) {
+		if err := tfr.SetSecret(user.ID, "SECRET"); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, _ := ur.GetOneByID(int(user.ID))
+		if got.TOTPSecret != "SECRET" || got.TOTPEnabledAt != nil {
+			t.Error("exp a pending secret; got", got.TOTPSecret, got.TOTPEnabledAt)
+		}
+	})
+	t.Run("should enable it with recovery codes", func(t *testing.T) {
+		if err := tfr.Enable(user.ID, 100, []string{"hash1", "hash2"}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, _ := ur.GetOneByID(int(user.ID))
+		if got.TOTPEnabledAt == nil || got.TOTPLastStep != 100 {
+			t.Error("exp enabled at step 100; got", got.TOTPEnabledAt, got.TOTPLastStep)
+		}
+	})
+	t.Run("should refuse a step that was already used", func(t *testing.T) {
+		if err := tfr.UseStep(user.ID, 100); !errors.Is(err, repository.ErrTOTPStepUsed) {
+			t.Error("exp repository.ErrTOTPStepUsed; got", err)
+		}
+		if err := tfr.UseStep(user.ID, 101); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if err := tfr.UseStep(user.ID, 101); !errors.Is(err, repository.ErrTOTPStepUsed) {
+			t.Error("exp repository.ErrTOTPStepUsed; got", err)
+		}
+	})
+	t.Run("should use each recovery code once", func(t *testing.T) {
+		if err := tfr.UseRecoveryCode(user.ID, "hash1"); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if err := tfr.UseRecoveryCode(user.ID, "hash1"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+		if err := tfr.UseRecoveryCode(user.ID+1, "hash2"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound for another user; got", err)
+		}
+	})
+	t.Run("should keep it enabled when the user is updated", func(t *testing.T) {
+		if _, err := ur.UpdateOneByID(int(user.ID), "totp@example.com", "Fulan", "newhash"); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, _ := ur.GetOneByID(int(user.ID))
+		if got.TOTPSecret != "SECRET" || got.TOTPEnabledAt == nil {
+			t.Error("exp two-factor authentication to stay enabled; got", got.TOTPSecret, got.TOTPEnabledAt)
+		}
+	})
+	t.Run("should disable it and drop the recovery codes", func(t *testing.T) {
+		if err := tfr.Disable(user.ID); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, _ := ur.GetOneByID(int(user.ID))
+		if got.TOTPSecret != "" || got.TOTPEnabledAt != nil {
+			t.Error("exp it disabled; got", got.TOTPSecret, got.TOTPEnabledAt)
+		}
+		if err := tfr.UseRecoveryCode(user.ID, "hash2"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+}
+
+func TestTwoFactorRepository_Challenge(t *testing.T) {
+	db, err := setupDBForTwoFactorTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	tfr := repository.NewTwoFactorRepository(db)
+
+	since := time.Now().Add(-time.Minute)
+	challenge, err := tfr.InsertChallenge(1, "hash", time.Now().Add(5*time.Minute))
+	if err != nil {
+		t.Fatal("exp nil; got error:", err)
+	}
+
+	t.Run("should find the challenge by its token's hash", func(t *testing.T) {
+		got, err := tfr.GetChallenge("hash")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.ID != challenge.ID || got.UserID != 1 {
+			t.Error("exp challenge", challenge.ID, "of user 1; got", got.ID, got.UserID)
+		}
+	})
+	t.Run("should allow only so many attempts", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if err := tfr.AttemptChallenge(challenge.ID, 2); err != nil {
+				t.Error("exp nil; got error:", err)
+			}
+		}
+		if err := tfr.AttemptChallenge(challenge.ID, 2); !errors.Is(err, repository.ErrTwoFactorChallengeClosed) {
+			t.Error("exp ErrTwoFactorChallengeClosed; got", err)
+		}
+	})
+	t.Run("should count the attempts of unused challenges as failed", func(t *testing.T) {
+		got, err := tfr.CountFailedAttempts(1, since)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != 2 {
+			t.Error("exp 2; got", got)
+		}
+	})
+	t.Run("should use a challenge once", func(t *testing.T) {
+		if err := tfr.UseChallenge(challenge.ID); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if err := tfr.UseChallenge(challenge.ID); !errors.Is(err, repository.ErrTwoFactorChallengeClosed) {
+			t.Error("exp ErrTwoFactorChallengeClosed; got", err)
+		}
+		if err := tfr.AttemptChallenge(challenge.ID, 5); !errors.Is(err, repository.ErrTwoFactorChallengeClosed) {
+			t.Error("exp ErrTwoFactorChallengeClosed; got", err)
+		}
+
+		got, err := tfr.CountFailedAttempts(1, since)
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got != 0 {
+			t.Error("exp 0; got", got)
+		}
+	})
+}
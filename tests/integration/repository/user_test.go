@@ -1,7 +1,9 @@
 package integration
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -164,6 +166,44 @@ func TestUserRepository_UpdateOneByID(t *testing.T) {
 	})
 }
 
+func TestUserRepository_VerifyEmail(t *testing.T) {
+	db, err := setupDBForUserTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	ur := repository.NewUserRepository(db)
+
+	user, err := ur.Insert("current@example.com", "Fulan", "hashedpass")
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+
+	t.Run("should not verify an address the user no longer has", func(t *testing.T) {
+		if err := ur.VerifyEmail(int(user.ID), "earlier@example.com", time.Now()); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+		got, err := ur.GetOneByID(int(user.ID))
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.EmailVerifiedAt != nil {
+			t.Error("exp nil; got", got.EmailVerifiedAt)
+		}
+	})
+	t.Run("should verify the user's current address", func(t *testing.T) {
+		if err := ur.VerifyEmail(int(user.ID), "current@example.com", time.Now()); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		got, err := ur.GetOneByID(int(user.ID))
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.EmailVerifiedAt == nil {
+			t.Error("exp verified; got nil")
+		}
+	})
+}
+
 func TestUserRepository_DeleteOneByID(t *testing.T) {
 	db, err := setupDBForUserTest()
 	if err != nil {
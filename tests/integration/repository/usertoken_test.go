@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muhrizqiardi/spendtracker/internal/database/model"
+	"github.com/muhrizqiardi/spendtracker/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBForUserTokenTest() (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	if err != nil {
+		return &gorm.DB{}, err
+	}
+
+	if err := db.AutoMigrate(
+		&model.UserToken{},
+	); err != nil {
+		return &gorm.DB{}, err
+	}
+
+	return db, nil
+}
+
+func TestUserTokenRepository_Use(t *testing.T) {
+	db, err := setupDBForUserTokenTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	utr := repository.NewUserTokenRepository(db)
+
+	insert := func(hash, purpose string, expiresAt time.Time) {
+		if _, err := utr.Insert(model.UserToken{UserID: 1, Purpose: purpose, TokenHash: hash, ExpiresAt: expiresAt}); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	}
+	later := time.Now().Add(time.Hour)
+
+	t.Run("should use a token once", func(t *testing.T) {
+		insert("once", "verify_email", later)
+
+		got, err := utr.Use("verify_email", "once")
+		if err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		if got.UserID != 1 || got.UsedAt == nil {
+			t.Error("exp the used token of user 1; got", got)
+		}
+		if _, err := utr.Use("verify_email", "once"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should not use an expired token", func(t *testing.T) {
+		insert("expired", "verify_email", time.Now().Add(-time.Minute))
+
+		if _, err := utr.Use("verify_email", "expired"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should not use a token for another purpose", func(t *testing.T) {
+		insert("reset", "reset_password", later)
+
+		if _, err := utr.Use("verify_email", "reset"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+	})
+	t.Run("should use up earlier tokens for the same purpose", func(t *testing.T) {
+		insert("first", "reset_password", later)
+		insert("second", "reset_password", later)
+
+		if _, err := utr.Use("reset_password", "first"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Error("exp gorm.ErrRecordNotFound; got", err)
+		}
+		if _, err := utr.Use("reset_password", "second"); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}
+
+func TestUserTokenRepository_RevokeManyBelongedToUser(t *testing.T) {
+	db, err := setupDBForUserTokenTest()
+	if err != nil {
+		t.Error("exp nil; got error:", err)
+	}
+	utr := repository.NewUserTokenRepository(db)
+
+	later := time.Now().Add(time.Hour)
+	for _, token := range []model.UserToken{
+		{UserID: 1, Purpose: "verify_email", TokenHash: "verify", ExpiresAt: later},
+		{UserID: 1, Purpose: "reset_password", TokenHash: "reset", ExpiresAt: later},
+		{UserID: 2, Purpose: "reset_password", TokenHash: "other", ExpiresAt: later},
+	} {
+		if _, err := utr.Insert(token); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	}
+
+	t.Run("should use up every token of the user only", func(t *testing.T) {
+		if err := utr.RevokeManyBelongedToUser(1); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+		for _, tc := range []struct{ purpose, hash string }{{"verify_email", "verify"}, {"reset_password", "reset"}} {
+			if _, err := utr.Use(tc.purpose, tc.hash); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Error("exp gorm.ErrRecordNotFound; got", err)
+			}
+		}
+		if _, err := utr.Use("reset_password", "other"); err != nil {
+			t.Error("exp nil; got error:", err)
+		}
+	})
+}